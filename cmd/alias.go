@@ -0,0 +1,46 @@
+package cmd
+
+import (
+	"strings"
+
+	"github.com/sotarok/gw/internal/config"
+	"github.com/spf13/cobra"
+)
+
+// expandAlias replaces the command name in args with the command line of the
+// matching alias.<name> key, so `alias.s = start` makes `gw s 123` run
+// `gw start 123`. The command name is the first argument that is not a flag.
+// Real commands always win over an alias of the same name, and an alias's
+// expansion is not expanded again. args is returned unchanged when no alias
+// applies.
+func expandAlias(root *cobra.Command, cfg *config.Config, args []string) []string {
+	for i, arg := range args {
+		if strings.HasPrefix(arg, "-") {
+			continue
+		}
+		if isCommandName(root, arg) {
+			return args
+		}
+		expansion := cfg.ExpandAlias(arg)
+		if len(expansion) == 0 {
+			return args
+		}
+		expanded := make([]string, 0, len(args)+len(expansion)-1)
+		expanded = append(expanded, args[:i]...)
+		expanded = append(expanded, expansion...)
+		return append(expanded, args[i+1:]...)
+	}
+	return args
+}
+
+// isCommandName reports whether name selects one of root's subcommands,
+// including cobra's help command and command aliases.
+func isCommandName(root *cobra.Command, name string) bool {
+	root.InitDefaultHelpCmd()
+	for _, c := range root.Commands() {
+		if c.Name() == name || c.HasAlias(name) {
+			return true
+		}
+	}
+	return false
+}
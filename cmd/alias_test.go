@@ -0,0 +1,61 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/sotarok/gw/internal/config"
+	"github.com/spf13/cobra"
+)
+
+func TestExpandAlias(t *testing.T) {
+	root := &cobra.Command{Use: "gw"}
+	root.AddCommand(
+		&cobra.Command{Use: "start", Run: func(*cobra.Command, []string) {}},
+		&cobra.Command{Use: "clean", Run: func(*cobra.Command, []string) {}},
+	)
+	cfg := config.New()
+	cfg.Aliases = map[string]string{
+		"s":         "start",
+		"clean-dry": "clean --dry-run",
+		"start":     "clean",
+		"help":      "clean",
+		"loop":      "s",
+	}
+
+	tests := []struct {
+		name string
+		args []string
+		want []string
+	}{
+		{name: "simple alias", args: []string{"s", "123"}, want: []string{"start", "123"}},
+		{name: "alias with flags", args: []string{"clean-dry", "--force"}, want: []string{"clean", "--dry-run", "--force"}},
+		{name: "global flags before the alias", args: []string{"--ascii", "s", "123"}, want: []string{"--ascii", "start", "123"}},
+		{name: "real command wins", args: []string{"start", "123"}, want: []string{"start", "123"}},
+		{name: "help command wins", args: []string{"help"}, want: []string{"help"}},
+		{name: "expansion is not expanded again", args: []string{"loop"}, want: []string{"s"}},
+		{name: "only the command name is expanded", args: []string{"start", "s"}, want: []string{"start", "s"}},
+		{name: "unknown name is left to cobra", args: []string{"nope"}, want: []string{"nope"}},
+		{name: "no arguments", args: []string{}, want: []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := expandAlias(root, cfg, tt.args); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("expandAlias(%q) = %q, want %q", tt.args, got, tt.want)
+			}
+		})
+	}
+}
+
+// TestExpandAlias_RealCommands checks precedence against gw's own command tree.
+func TestExpandAlias_RealCommands(t *testing.T) {
+	cfg := config.New()
+	cfg.Aliases = map[string]string{"end": "start", "s": "start"}
+
+	if got := expandAlias(rootCmd, cfg, []string{"end", "123"}); !reflect.DeepEqual(got, []string{"end", "123"}) {
+		t.Errorf("Expected the end command to win over its alias, got %q", got)
+	}
+	if got := expandAlias(rootCmd, cfg, []string{"s", "123"}); !reflect.DeepEqual(got, []string{"start", "123"}) {
+		t.Errorf("Expected s to expand to start, got %q", got)
+	}
+}
@@ -8,21 +8,94 @@ var (
 	checkoutCopyEnvs       bool
 	checkoutNoFetch        bool
 	checkoutNoProjectHooks bool
+	checkoutOverwriteEnvs  bool
+	checkoutOpen           bool
+	checkoutPrintPath      bool
+	checkoutPR             string
+	checkoutNoTrack        bool
+	checkoutFromMergeBase  bool
+	checkoutNewBranch      string
+	checkoutBase           string
+	checkoutRemoteDefault  bool
+	checkoutDetach         bool
 )
 
 var checkoutCmd = &cobra.Command{
-	Use:   "checkout [branch]",
+	Use:   "checkout [branch | -]",
 	Short: "Checkout an existing branch as a new worktree",
 	Long: `Checkout an existing branch as a new worktree.
-If no branch is specified, an interactive selector will be shown.`,
+If no branch is specified, an interactive selector will be shown.
+
+"gw checkout -" goes back to the worktree you last switched away from with
+gw start or gw checkout, like "cd -" (requires shell integration).
+
+With --print-path, only the new worktree's absolute path is printed on stdout
+(everything else goes to stderr) and nothing is asked, for scripts:
+  cd "$(gw checkout feature/foo --print-path)"
+
+With --pr, the head of a GitHub pull request (or GitLab merge request) is
+fetched from the remote into the local branch pr-<number>, which is then
+checked out:
+  gw checkout --pr 42
+
+With --from-merge-base, the worktree starts at the merge-base of the branch
+and main instead, detached (or on a new branch with --new-branch), to review
+the branch's own changes in isolation:
+  gw checkout feature/foo --from-merge-base
+
+With --detach, the worktree starts at the branch's tip with a detached HEAD,
+in a directory ending in -detached, so the branch itself stays free to be
+checked out elsewhere:
+  gw checkout feature/foo --detach
+
+With --base, a branch that does not exist yet is created from the given ref
+instead of failing; an existing branch is checked out as is:
+  gw checkout new-feature --base release/2.0
+
+With --track-remote-default, gw fetches first and uses the remote's default
+branch (e.g. origin/main) as the base, without having to know its name:
+  gw checkout new-feature --track-remote-default`,
+	Example: `  # Check out an existing branch in its own worktree
+  gw checkout feature/login
+
+  # Pick a branch from a list
+  gw checkout
+
+  # Go back to the previous worktree
+  gw checkout -
+
+  # Review a pull request
+  gw checkout --pr 42
+
+  # Create a new branch from release/2.0 in its own worktree
+  gw checkout new-feature --base release/2.0`,
 	Args: cobra.MaximumNArgs(1),
 	RunE: runCheckout,
 }
 
 func init() {
 	checkoutCmd.Flags().BoolVar(&checkoutCopyEnvs, "copy-envs", false, "Copy untracked .env files to the new worktree")
+	checkoutCmd.Flags().BoolVar(&checkoutOverwriteEnvs, "overwrite-envs", false, "Overwrite env files that already exist in the worktree with different content")
 	checkoutCmd.Flags().BoolVar(&checkoutNoFetch, "no-fetch", false, "Skip git fetch before running the command")
+	checkoutCmd.Flags().BoolVar(&checkoutOpen, "open", false, "Open the new worktree in the editor (editor key or $EDITOR)")
 	checkoutCmd.Flags().BoolVar(&checkoutNoProjectHooks, "no-project-hooks", false, "Skip project-local .gwrc hook overrides for this run")
+	checkoutCmd.Flags().BoolVar(&checkoutPrintPath, "print-path", false, "Print only the worktree path on stdout (status goes to stderr) and never prompt")
+	checkoutCmd.Flags().StringVar(&checkoutPR, "pr", "", "Check out the head of this pull request (or merge request) as pr-<number>")
+	checkoutCmd.Flags().BoolVar(&checkoutNoTrack, "no-track", false, "Do not set the remote branch as the upstream of the new local branch")
+	checkoutCmd.Flags().BoolVar(&checkoutFromMergeBase, "from-merge-base", false, "Start the worktree at the merge-base of the branch and main, with a detached HEAD")
+	checkoutCmd.Flags().StringVar(&checkoutNewBranch, "new-branch", "", "With --from-merge-base, create this branch at the merge-base instead of detaching")
+	checkoutCmd.Flags().StringVar(&checkoutBase, "base", "", "Create the branch from this ref if it does not exist yet")
+	checkoutCmd.Flags().BoolVar(&checkoutRemoteDefault, "track-remote-default", false, "Fetch, then create the branch from the remote's default branch (e.g. origin/main) if it does not exist yet")
+	checkoutCmd.MarkFlagsMutuallyExclusive("open", "print-path")
+	checkoutCmd.Flags().BoolVar(&checkoutDetach, "detach", false, "Start the worktree at the branch's tip with a detached HEAD, leaving the branch free")
+	checkoutCmd.MarkFlagsMutuallyExclusive("detach", "from-merge-base")
+	checkoutCmd.MarkFlagsMutuallyExclusive("detach", "base")
+	checkoutCmd.MarkFlagsMutuallyExclusive("base", "pr")
+	checkoutCmd.MarkFlagsMutuallyExclusive("base", "from-merge-base")
+	checkoutCmd.MarkFlagsMutuallyExclusive("track-remote-default", "base")
+	checkoutCmd.MarkFlagsMutuallyExclusive("track-remote-default", "pr")
+	checkoutCmd.MarkFlagsMutuallyExclusive("track-remote-default", "from-merge-base")
+	checkoutCmd.MarkFlagsMutuallyExclusive("track-remote-default", "detach")
 	rootCmd.AddCommand(checkoutCmd)
 }
 
@@ -34,6 +107,19 @@ func runCheckout(cmd *cobra.Command, args []string) error {
 
 	// Use the new command structure
 	deps := DefaultDependencies()
+	if checkoutPrintPath {
+		defer redirectOSStdout(deps)()
+	}
 	checkoutCmd := NewCheckoutCommand(deps, checkoutCopyEnvs, checkoutNoFetch, checkoutNoProjectHooks)
+	checkoutCmd.overwriteEnvs = checkoutOverwriteEnvs
+	checkoutCmd.openEditor = checkoutOpen
+	checkoutCmd.printPath = checkoutPrintPath
+	checkoutCmd.pr = checkoutPR
+	checkoutCmd.noTrack = checkoutNoTrack
+	checkoutCmd.fromMergeBase = checkoutFromMergeBase
+	checkoutCmd.newBranch = checkoutNewBranch
+	checkoutCmd.base = checkoutBase
+	checkoutCmd.baseFromRemoteDefault = checkoutRemoteDefault
+	checkoutCmd.detach = checkoutDetach
 	return checkoutCmd.Execute(branch)
 }
@@ -1,14 +1,23 @@
 package cmd
 
 import (
+	"github.com/sotarok/gw/internal/stats"
 	"github.com/spf13/cobra"
 )
 
 var (
 	forceClean          bool
-	dryRunClean         bool
 	cleanNoFetch        bool
 	cleanNoProjectHooks bool
+	cleanInteractive    bool
+	cleanDeleteRemote   bool
+	cleanQuiet          bool
+	cleanKeep           int
+	cleanExclude        []string
+	cleanForceDelete    bool
+	cleanBranchOnly     bool
+	cleanExplain        bool
+	cleanJSON           bool
 )
 
 var cleanCmd = &cobra.Command{
@@ -21,7 +30,46 @@ A worktree is considered safe to delete if it meets all of the following conditi
   3. Merged to origin/main
 
 The command will show which worktrees can be removed and which cannot (with reasons),
-then ask for confirmation before removing them.`,
+then ask for confirmation before removing them.
+
+Use --interactive to pick exactly which worktrees to remove. Worktrees that failed
+the safety checks can be picked too, but require an extra confirmation.
+
+Use --keep N to always keep the N worktrees with the most recent last commit,
+and --exclude <pattern> (repeatable) to always keep those whose branch matches
+a glob such as 'spike/*'.
+Use --dry-run to only show what would be removed, and --explain to also show,
+for each worktree that is kept, what to do about every reason (e.g. run
+git push, or merge it to main). --explain implies --dry-run.
+
+Use --remove-branch-only to leave worktrees alone and instead delete the local
+branches that are merged to main but no longer have a worktree, such as those
+left behind while auto_remove_branch was off. They are deleted with
+git branch -d, after the same confirmation.
+
+Use --json with --force or --dry-run to get a summary of the removed, skipped
+and failed worktrees as JSON on stdout, e.g. in CI; the usual output goes to
+stderr.`,
+	Example: `  # Preview which worktrees would be removed
+  gw clean --dry-run
+
+  # Also show what to do about each worktree that is kept
+  gw clean --explain
+
+  # Remove every merged, clean worktree
+  gw clean
+
+  # Clean everything except spike/* and experiment/* branches
+  gw clean --exclude 'spike/*' --exclude 'experiment/*'
+
+  # Choose which worktrees to remove
+  gw clean --interactive
+
+  # Delete merged branches whose worktrees are already gone
+  gw clean --remove-branch-only
+
+  # Remove without prompting and report the result as JSON
+  gw clean --force --json`,
 	Args: cobra.NoArgs,
 	RunE: runClean,
 }
@@ -29,13 +77,45 @@ then ask for confirmation before removing them.`,
 func init() {
 	rootCmd.AddCommand(cleanCmd)
 	cleanCmd.Flags().BoolVarP(&forceClean, "force", "f", false, "Force removal without confirmation prompt")
-	cleanCmd.Flags().BoolVar(&dryRunClean, "dry-run", false, "Show what would be removed without actually removing")
 	cleanCmd.Flags().BoolVar(&cleanNoFetch, "no-fetch", false, "Skip git fetch before running the command")
+	cleanCmd.Flags().BoolVarP(&cleanInteractive, "interactive", "i", false, "Select which worktrees to remove from a list")
+	cleanCmd.Flags().BoolVar(&cleanDeleteRemote, "delete-remote", false, "Also delete each removed worktree's branch on origin")
+	cleanCmd.Flags().BoolVar(&cleanForceDelete, "force-delete-branch", false, "Delete each removed worktree's branch even if it is not merged (git branch -D)")
+	cleanCmd.Flags().BoolVar(&cleanExplain, "explain", false, "Show what to do about each reason a worktree is kept (implies --dry-run)")
+	cleanCmd.Flags().BoolVar(&cleanJSON, "json", false, "Print a summary of removed, skipped and failed worktrees as JSON (needs --force or --dry-run)")
+	cleanCmd.Flags().BoolVar(&cleanBranchOnly, "remove-branch-only", false, "Delete merged local branches that have no worktree, instead of removing worktrees")
+	cleanCmd.Flags().StringArrayVar(&cleanExclude, "exclude", nil, "Keep worktrees whose branch matches this glob, e.g. 'spike/*' (repeatable)")
+	cleanCmd.Flags().IntVar(&cleanKeep, "keep", 0, "Keep the N worktrees with the most recent last commit, even if they are removable")
+	cleanCmd.Flags().BoolVarP(&cleanQuiet, "quiet", "q", false, "Hide the progress spinner while checking worktrees")
 	cleanCmd.Flags().BoolVar(&cleanNoProjectHooks, "no-project-hooks", false, "Skip project-local .gwrc hook overrides for this run")
+	cleanCmd.MarkFlagsMutuallyExclusive("remove-branch-only", "interactive")
+	cleanCmd.MarkFlagsMutuallyExclusive("remove-branch-only", "keep")
+	cleanCmd.MarkFlagsMutuallyExclusive("remove-branch-only", "exclude")
+	cleanCmd.MarkFlagsMutuallyExclusive("remove-branch-only", "force-delete-branch")
+	cleanCmd.MarkFlagsMutuallyExclusive("remove-branch-only", "explain")
+	cleanCmd.MarkFlagsMutuallyExclusive("json", "interactive")
+	cleanCmd.MarkFlagsMutuallyExclusive("json", "remove-branch-only")
+	cleanCmd.MarkFlagsMutuallyExclusive("json", "explain")
 }
 
 func runClean(cmd *cobra.Command, args []string) error {
 	deps := DefaultDependencies()
-	cleanCmd := NewCleanCommand(deps, forceClean, dryRunClean, cleanNoFetch, cleanNoProjectHooks)
-	return cleanCmd.Execute()
+	if cleanJSON {
+		defer redirectOSStdout(deps)()
+	}
+	cleanCmd := NewCleanCommand(deps, forceClean, dryRun || cleanExplain, cleanNoFetch, cleanNoProjectHooks)
+	cleanCmd.interactive = cleanInteractive
+	cleanCmd.deleteRemote = cleanDeleteRemote
+	cleanCmd.quiet = cleanQuiet
+	cleanCmd.keep = cleanKeep
+	cleanCmd.exclude = cleanExclude
+	cleanCmd.forceDelete = cleanForceDelete
+	cleanCmd.branchOnly = cleanBranchOnly
+	cleanCmd.explain = cleanExplain
+	cleanCmd.jsonOutput = cleanJSON
+	if err := cleanCmd.Execute(); err != nil {
+		return err
+	}
+	recordUsage(deps, stats.CommandClean)
+	return nil
 }
@@ -5,12 +5,14 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"strings"
 
-	"github.com/charmbracelet/lipgloss"
 	"github.com/sotarok/gw/internal/config"
 	"github.com/sotarok/gw/internal/detect"
 	"github.com/sotarok/gw/internal/git"
 	"github.com/sotarok/gw/internal/hook"
+	"github.com/sotarok/gw/internal/i18n"
+	"github.com/sotarok/gw/internal/recent"
 	"github.com/sotarok/gw/internal/spinner"
 	"github.com/sotarok/gw/internal/ui"
 )
@@ -20,28 +22,6 @@ import (
 // clean command's protection list.
 const defaultBaseBranch = "main"
 
-// Symbol constants for consistent output formatting across commands
-const (
-	symbolSuccess = "✓"
-	symbolError   = "✗"
-	symbolWarning = "⚠"
-	symbolArrow   = "→"
-)
-
-// Symbol styles for colored output (lipgloss handles NO_COLOR automatically)
-var (
-	styleSuccess = lipgloss.NewStyle().Foreground(lipgloss.Color("2")) // Green
-	styleError   = lipgloss.NewStyle().Foreground(lipgloss.Color("1")) // Red
-	styleWarning = lipgloss.NewStyle().Foreground(lipgloss.Color("3")) // Yellow
-	styleArrow   = lipgloss.NewStyle().Foreground(lipgloss.Color("4")) // Blue
-)
-
-// Colored symbol functions return symbols with appropriate colors
-func coloredSuccess() string { return styleSuccess.Render(symbolSuccess) }
-func coloredError() string   { return styleError.Render(symbolError) }
-func coloredWarning() string { return styleWarning.Render(symbolWarning) }
-func coloredArrow() string   { return styleArrow.Render(symbolArrow) }
-
 // Dependencies holds all the dependencies for commands
 type Dependencies struct {
 	Git    git.Interface
@@ -50,6 +30,10 @@ type Dependencies struct {
 	Config *config.Config // always non-nil
 	Stdout io.Writer
 	Stderr io.Writer
+
+	// NoPrompt makes commands take the answer they would give in a
+	// non-interactive session instead of asking (set by --print-path).
+	NoPrompt bool
 }
 
 // DefaultDependencies returns the default dependencies.
@@ -58,13 +42,24 @@ type Dependencies struct {
 // warning on stderr (previously the failure was swallowed silently), so
 // Config is guaranteed to be non-nil.
 func DefaultDependencies() *Dependencies {
-	cfg, err := config.Load(config.GetConfigPath())
+	configPath := config.GetConfigPath()
+	cfg, err := config.Load(configPath)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "%s Could not load ~/.gwrc, using defaults: %v\n", symbolWarning, err)
+		fmt.Fprintf(os.Stderr, "%s Could not load %s, using defaults: %v\n", coloredWarning(), configPath, err)
 		cfg = config.New()
 	}
+	if err := applyTheme(cfg.Theme); err != nil {
+		fmt.Fprintf(os.Stderr, "%s %v\n", coloredWarning(), err)
+	}
+	i18n.SetLanguage(i18n.DetectLanguage(cfg.Language))
+	gitClient := git.NewClient()
+	gitClient.SetRemote(cfg.DefaultRemote)
+	gitClient.SetWorktreeRoot(cfg.WorktreeRoot)
+	if dryRun {
+		gitClient.SetDryRun(os.Stdout)
+	}
 	return &Dependencies{
-		Git:    git.NewClient(),
+		Git:    gitClient,
 		UI:     ui.NewDefaultUI(),
 		Detect: detect.NewDefaultDetector(),
 		Config: cfg,
@@ -76,8 +71,12 @@ func DefaultDependencies() *Dependencies {
 // runPreEndHook runs pre_end_hook with cwd set to worktreePath, then restores
 // the original directory regardless of hook outcome. Hook failures are
 // reported as warnings on stderr; commandLabel ("end" or "clean") flows into
-// GW_COMMAND for the hook process.
+// GW_COMMAND for the hook process. A dry run only prints the hook.
 func runPreEndHook(deps *Dependencies, hookCmd, worktreePath, branchName, repoName, commandLabel string) {
+	if dryRun {
+		fmt.Fprintf(deps.Stdout, "[dry-run] run pre_end_hook in %s: %s\n", worktreePath, hookCmd)
+		return
+	}
 	originalDir, err := os.Getwd()
 	if err != nil {
 		fmt.Fprintf(deps.Stderr, "%s Could not capture cwd for pre-end hook: %v\n", coloredWarning(), err)
@@ -101,11 +100,62 @@ func runPreEndHook(deps *Dependencies, hookCmd, worktreePath, branchName, repoNa
 	}
 }
 
+// dryRunSetupSteps lists what gw start and gw checkout do in a new worktree
+// once git has created it, in the order they do it, for --dry-run. hookName
+// and hookCmd name the post-create hook; changeDir is false when the shell is
+// to stay put.
+func dryRunSetupSteps(deps *Dependencies, copyEnvs bool, hookName, hookCmd string, changeDir, openEditor bool) []string {
+	var steps []string
+	if len(deps.Config.AlwaysCopy) > 0 {
+		steps = append(steps, "copy the always_copy files: "+strings.Join(deps.Config.AlwaysCopy, ", "))
+	}
+	switch {
+	case copyEnvs || (deps.Config.CopyEnvs != nil && *deps.Config.CopyEnvs):
+		steps = append(steps, "copy untracked .env files")
+	case deps.Config.CopyEnvs == nil && !deps.NoPrompt:
+		steps = append(steps, "offer to copy untracked .env files")
+	}
+	steps = append(steps, "run package-manager setup if a package manager is detected")
+	if hookCmd != "" {
+		steps = append(steps, fmt.Sprintf("run %s: %s", hookName, hookCmd))
+	}
+	switch {
+	case opensNewWindow(deps):
+		steps = append(steps, "open it with new_window_cmd")
+	case changeDir && deps.Config.AutoCD:
+		steps = append(steps, "change the shell to it")
+	}
+	if openEditor {
+		steps = append(steps, "open it in the editor")
+	}
+	return steps
+}
+
+// printDryRunSetup prints the steps that would follow the creation of the
+// worktree at worktreePath instead of running them: a dry run never creates
+// the directory they act on.
+func printDryRunSetup(deps *Dependencies, worktreePath string, steps []string) {
+	absPath, err := filepath.Abs(worktreePath)
+	if err != nil {
+		absPath = worktreePath
+	}
+	fmt.Fprintf(deps.Stdout, "[dry-run] then, in %s:\n", absPath)
+	for _, step := range steps {
+		fmt.Fprintf(deps.Stdout, "[dry-run]   %s\n", step)
+	}
+}
+
 // fetchIfConfigured runs git fetch --all --prune if configured and not skipped
 func fetchIfConfigured(deps *Dependencies, noFetch bool) {
 	if noFetch || !deps.Config.FetchBeforeCommand {
 		return
 	}
+	fetchRemotes(deps)
+}
+
+// fetchRemotes runs git fetch --all --prune behind a spinner. A failed fetch
+// is reported as a warning and the command goes on with the refs it has.
+func fetchRemotes(deps *Dependencies) {
 	sp := spinner.New("Fetching from remotes...", deps.Stdout)
 	sp.Start()
 	err := deps.Git.FetchAll()
@@ -115,12 +165,298 @@ func fetchIfConfigured(deps *Dependencies, noFetch bool) {
 	}
 }
 
+// remoteChecker is what noteMissingRemote needs: the selected remote and
+// whether it is configured.
+type remoteChecker interface {
+	Remote() string
+	HasRemote() (bool, error)
+}
+
+// missingRemote reports whether the selected remote is known not to exist. A
+// failed lookup counts as present, so the usual checks and errors apply.
+func missingRemote(g remoteChecker) bool {
+	hasRemote, err := g.HasRemote()
+	return err == nil && !hasRemote
+}
+
+// noteMissingRemote tells the user, before end or clean runs its safety
+// checks, that the repository has no remote to validate against: merge status
+// is compared with the local base branch only, and nothing counts as
+// unpushed.
+func noteMissingRemote(deps *Dependencies, g remoteChecker) {
+	if missingRemote(g) {
+		fmt.Fprintf(deps.Stderr, "%s No remote named %s: merge status is checked against the local %s only and nothing counts as unpushed, so worktrees can be removed without remote validation\n",
+			coloredWarning(), g.Remote(), defaultBaseBranch)
+	}
+}
+
+// remoteBranchDeleter is what deleteRemoteBranchIfConfigured needs: the
+// remote to report and the push that deletes the branch there.
+type remoteBranchDeleter interface {
+	remoteChecker
+	DeleteRemoteBranch(branch string) error
+}
+
+// deleteRemoteBranchIfConfigured runs git push <remote> --delete for branch
+// when delete_remote_branch is set or the caller's --delete-remote flag is
+// given. Failures are reported as warnings: the worktree is already gone by now.
+func deleteRemoteBranchIfConfigured(deps *Dependencies, g remoteBranchDeleter, branch string, deleteRemoteFlag bool) {
+	if !deleteRemoteFlag && !deps.Config.DeleteRemoteBranch {
+		return
+	}
+	if missingRemote(g) {
+		fmt.Fprintf(deps.Stderr, "%s No remote named %s; there is no remote branch %s to delete\n", coloredWarning(), g.Remote(), branch)
+		return
+	}
+	fmt.Fprintf(deps.Stdout, "Deleting remote branch %s/%s...\n", g.Remote(), branch)
+	if err := g.DeleteRemoteBranch(branch); err != nil {
+		fmt.Fprintf(deps.Stderr, "%s Failed to delete remote branch %s: %v\n", coloredWarning(), branch, err)
+		return
+	}
+	fmt.Fprintf(deps.Stdout, "%s Deleted remote branch %s/%s\n", coloredSuccess(), g.Remote(), branch)
+}
+
+// refDescriber is what detachedLabel needs: naming a commit after a tag.
+type refDescriber interface {
+	ResolveRefDescription(commit string) (string, error)
+}
+
+// detachedLabel is how a worktree on a detached HEAD at commit is shown in
+// place of its branch: the nearest tag, e.g. "(v1.2.3)", or "(detached HEAD)"
+// when no tag is reachable. A failed lookup is reported on stderr.
+func detachedLabel(deps *Dependencies, g refDescriber, commit string) string {
+	if commit == "" {
+		return "(detached HEAD)"
+	}
+	description, err := g.ResolveRefDescription(commit)
+	if err != nil {
+		fmt.Fprintf(deps.Stderr, "%s Could not describe %s: %v\n", coloredWarning(), commit, err)
+	}
+	if description == "" {
+		return "(detached HEAD)"
+	}
+	return "(" + description + ")"
+}
+
+// warnBranchNotDeleted reports a local branch that could not be deleted. git
+// refuses to delete an unmerged branch with -d, so that case points at
+// --force-delete-branch.
+func warnBranchNotDeleted(deps *Dependencies, branch string, err error) {
+	hint := ""
+	if strings.Contains(err.Error(), "not fully merged") {
+		hint = " (use --force-delete-branch to delete it anyway)"
+	}
+	fmt.Fprintf(deps.Stderr, "%s Failed to delete branch %s: %v%s\n", coloredWarning(), branch, err, hint)
+}
+
+// printPathDeps prepares deps for --print-path and similar machine-readable
+// output: status output moves to stderr and prompts are skipped, leaving the
+// returned writer, the original stdout, for the worktree path (or report)
+// alone.
+func printPathDeps(deps *Dependencies) (*Dependencies, io.Writer) {
+	status := *deps
+	status.Stdout = deps.Stderr
+	status.NoPrompt = true
+	return &status, deps.Stdout
+}
+
+// redirectOSStdout sends what is printed straight to os.Stdout rather than
+// through Dependencies (the env file list, package-manager setup) to stderr
+// for --print-path and --json. deps.Stdout still holds the real stdout. The
+// returned func restores os.Stdout.
+func redirectOSStdout(deps *Dependencies) (restore func()) {
+	stdout := os.Stdout
+	os.Stdout = os.Stderr
+	// Rebuilt so that the setup command's output follows the redirect too.
+	deps.Detect = detect.NewDefaultDetector()
+	return func() { os.Stdout = stdout }
+}
+
+// printWorktreePath writes the absolute worktreePath, and nothing else, to w.
+func printWorktreePath(w io.Writer, worktreePath string) {
+	absPath, err := filepath.Abs(worktreePath)
+	if err != nil {
+		absPath = worktreePath
+	}
+	fmt.Fprintln(w, absPath)
+}
+
+// envCDFile names the file the shell integration creates and passes to gw so
+// that start and checkout can report the new worktree's path without the
+// shell function having to parse stdout.
+const envCDFile = "GW_CD_FILE"
+
+// writeCDFile writes the absolute worktreePath, and nothing else, to the file
+// named by $GW_CD_FILE, and records the switch from fromWorktree (the worktree
+// gw was run in) for gw checkout -. It does nothing when the variable is
+// unset; a failed write is only a warning since the worktree itself was
+// created.
+func writeCDFile(deps *Dependencies, fromWorktree, worktreePath string) {
+	cdFile := os.Getenv(envCDFile)
+	if cdFile == "" {
+		return
+	}
+	absPath, err := filepath.Abs(worktreePath)
+	if err != nil {
+		absPath = worktreePath
+	}
+	if err := os.WriteFile(cdFile, []byte(absPath), 0o600); err != nil {
+		fmt.Fprintf(deps.Stderr, "%s Could not write the worktree path to $%s: %v\n", coloredWarning(), envCDFile, err)
+		return
+	}
+	recordSwitch(deps, fromWorktree, absPath)
+}
+
+// recordSwitch remembers that the shell left fromWorktree for toWorktree, so
+// gw checkout - can go back. Dry runs are not recorded, and a recents file
+// that cannot be written only produces a warning.
+func recordSwitch(deps *Dependencies, fromWorktree, toWorktree string) {
+	if dryRun {
+		return
+	}
+	path, err := recent.DefaultPath()
+	if err == nil {
+		err = recent.Record(path, fromWorktree, toWorktree)
+	}
+	if err != nil {
+		fmt.Fprintf(deps.Stderr, "%s Could not record the worktree switch: %v\n", coloredWarning(), err)
+	}
+}
+
+// newWindowPathPlaceholder is replaced with the worktree's path in
+// new_window_cmd.
+const newWindowPathPlaceholder = "{path}"
+
+// newWindowArgs splits new_window_cmd into a command and its arguments and
+// substitutes worktreePath for {path}. Splitting happens first, so a path
+// with spaces stays a single argument.
+func newWindowArgs(template, worktreePath string) (string, []string) {
+	fields := strings.Fields(template)
+	if len(fields) == 0 {
+		return "", nil
+	}
+	for i, field := range fields {
+		fields[i] = strings.ReplaceAll(field, newWindowPathPlaceholder, worktreePath)
+	}
+	return fields[0], fields[1:]
+}
+
+// newTerminalExecutor returns the executor new_window_cmd is run with. The
+// command only launches the window, so it gets no input.
+func newTerminalExecutor(deps *Dependencies) detect.CommandExecutor {
+	return &detect.DefaultExecutor{Stdout: deps.Stdout, Stderr: deps.Stderr}
+}
+
+// opensNewWindow reports whether new_window_cmd is set, in which case gw
+// start and gw checkout open the worktree in a new window instead of
+// changing the shell's directory.
+func opensNewWindow(deps *Dependencies) bool {
+	return strings.TrimSpace(deps.Config.NewWindowCmd) != ""
+}
+
+// openNewWindow runs new_window_cmd for worktreePath, from worktreePath, so
+// the new worktree opens in a new terminal window or tab. A failing command
+// is only a warning since the worktree itself was created.
+func openNewWindow(deps *Dependencies, executor detect.CommandExecutor, worktreePath string) {
+	absPath, err := filepath.Abs(worktreePath)
+	if err != nil {
+		absPath = worktreePath
+	}
+	command, args := newWindowArgs(deps.Config.NewWindowCmd, absPath)
+	if command == "" {
+		return
+	}
+	fmt.Fprintf(deps.Stdout, "Opening %s in a new window...\n", absPath)
+	if err := executor.Execute(absPath, command, args); err != nil {
+		fmt.Fprintf(deps.Stderr, "%s Could not open a new window: %v\n", coloredWarning(), err)
+	}
+}
+
+// envEditor is consulted for the editor command when the editor key is unset.
+const envEditor = "EDITOR"
+
+// newEditorExecutor returns the executor --open launches the editor with. It
+// shares the terminal so that terminal editors work as well as GUI ones.
+func newEditorExecutor(deps *Dependencies) detect.CommandExecutor {
+	return &detect.DefaultExecutor{Stdin: os.Stdin, Stdout: deps.Stdout, Stderr: deps.Stderr}
+}
+
+// openInEditor launches the editor key's command, or $EDITOR, on worktreePath
+// with worktreePath as the working directory. The command may carry its own
+// arguments ("code --new-window"). A missing or failing editor is only a
+// warning since the worktree itself was created.
+func openInEditor(deps *Dependencies, executor detect.CommandExecutor, worktreePath string) {
+	editor := deps.Config.Editor
+	if editor == "" {
+		editor = os.Getenv(envEditor)
+	}
+	fields := strings.Fields(editor)
+	if len(fields) == 0 {
+		fmt.Fprintf(deps.Stderr, "%s Cannot open the worktree: set the editor key in ~/.gwrc or $%s\n", coloredWarning(), envEditor)
+		return
+	}
+
+	absPath, err := filepath.Abs(worktreePath)
+	if err != nil {
+		absPath = worktreePath
+	}
+	fmt.Fprintf(deps.Stdout, "Opening %s in %s...\n", absPath, fields[0])
+	args := append(fields[1:], absPath)
+	if err := executor.Execute(absPath, fields[0], args); err != nil {
+		fmt.Fprintf(deps.Stderr, "%s Could not open the editor: %v\n", coloredWarning(), err)
+	}
+}
+
+// copyAlwaysCopyFiles copies the always_copy files from sourceRoot into a new
+// worktree. Listed paths that are missing, directories, absolute, or outside
+// the repository are reported as warnings and skipped; files already present in the worktree
+// are kept.
+func copyAlwaysCopyFiles(deps *Dependencies, g git.EnvFileHandler, sourceRoot, worktreePath string) {
+	if len(deps.Config.AlwaysCopy) == 0 {
+		return
+	}
+
+	var files []git.EnvFile
+	for _, p := range deps.Config.AlwaysCopy {
+		rel := filepath.Clean(p)
+		if filepath.IsAbs(rel) || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			fmt.Fprintf(deps.Stderr, "%s always_copy: %s is not a repository-relative path\n", coloredWarning(), p)
+			continue
+		}
+		absPath := filepath.Join(sourceRoot, rel)
+		info, err := os.Lstat(absPath)
+		if err != nil {
+			fmt.Fprintf(deps.Stderr, "%s always_copy: %s not found in %s\n", coloredWarning(), p, sourceRoot)
+			continue
+		}
+		if info.IsDir() {
+			fmt.Fprintf(deps.Stderr, "%s always_copy: %s is a directory, only files are copied\n", coloredWarning(), p)
+			continue
+		}
+		files = append(files, git.EnvFile{Path: rel, AbsolutePath: absPath})
+	}
+	if len(files) == 0 {
+		return
+	}
+
+	copied, err := g.CopyFiles(files, sourceRoot, worktreePath)
+	if err != nil {
+		fmt.Fprintf(deps.Stderr, "%s Could not copy always_copy files: %v\n", coloredWarning(), err)
+		return
+	}
+	fmt.Fprintf(deps.Stdout, "%s Copied %d always_copy %s\n", coloredSuccess(), copied, plural(copied, "file", "files"))
+}
+
 // handleEnvFiles is a common function for handling environment files
 // Priority order:
 // 1. If --copy-envs flag is set, always copy
 // 2. If config.CopyEnvs is set (true/false), use that value (unless flag overrides)
 // 3. If neither is set, prompt user (interactive mode)
-func handleEnvFiles(deps *Dependencies, copyEnvsFlag bool, originalDir, worktreePath string) error {
+//
+// Files that already exist in the worktree with different content are not
+// overwritten unless overwriteEnvs is set: in interactive mode the user is asked
+// about each one, otherwise they are skipped with a warning.
+func handleEnvFiles(deps *Dependencies, copyEnvsFlag, overwriteEnvs bool, originalDir, worktreePath string) error {
 	envFiles, err := deps.Git.FindUntrackedEnvFiles(originalDir)
 	if err != nil {
 		return fmt.Errorf("failed to find env files: %w", err)
@@ -153,6 +489,11 @@ func handleEnvFiles(deps *Dependencies, copyEnvsFlag bool, originalDir, worktree
 		needsPrompt = true
 	}
 
+	if needsPrompt && deps.NoPrompt {
+		fmt.Fprintf(deps.Stderr, "%s Not copying %d untracked environment file(s) without asking (use --copy-envs or set copy_envs)\n", coloredWarning(), len(envFiles))
+		return nil
+	}
+
 	if needsPrompt {
 		fmt.Fprintf(deps.Stdout, "\nFound %d untracked environment file(s):\n", len(envFiles))
 		deps.UI.ShowEnvFilesList(filePaths)
@@ -169,6 +510,14 @@ func handleEnvFiles(deps *Dependencies, copyEnvsFlag bool, originalDir, worktree
 		deps.UI.ShowEnvFilesList(filePaths)
 	}
 
+	if shouldCopy && !overwriteEnvs {
+		envFiles, err = skipEnvFileConflicts(deps, envFiles, originalDir, worktreePath, needsPrompt)
+		if err != nil {
+			return err
+		}
+		shouldCopy = len(envFiles) > 0
+	}
+
 	if shouldCopy {
 		// Copy files
 		if err := deps.Git.CopyEnvFiles(envFiles, originalDir, worktreePath); err != nil {
@@ -179,3 +528,39 @@ func handleEnvFiles(deps *Dependencies, copyEnvsFlag bool, originalDir, worktree
 
 	return nil
 }
+
+// skipEnvFileConflicts removes from envFiles the files that would overwrite a
+// different file already in the worktree. When interactive, the user decides
+// for each conflicting file; otherwise every conflict is skipped with a warning
+// pointing at --overwrite-envs.
+func skipEnvFileConflicts(deps *Dependencies, envFiles []git.EnvFile, originalDir, worktreePath string, interactive bool) ([]git.EnvFile, error) {
+	conflicts := git.FindEnvFileConflicts(envFiles, originalDir, worktreePath)
+	if len(conflicts) == 0 {
+		return envFiles, nil
+	}
+
+	skip := make(map[string]bool, len(conflicts))
+	for _, f := range conflicts {
+		if interactive {
+			fmt.Fprintf(deps.Stdout, "\n%s already exists in the worktree with different content. Overwrite it?", f.Path)
+			overwrite, err := deps.UI.ConfirmPrompt("")
+			if err != nil {
+				return nil, fmt.Errorf("failed to get user input: %w", err)
+			}
+			if overwrite {
+				continue
+			}
+		} else {
+			fmt.Fprintf(deps.Stderr, "%s Skipped %s: it already exists in the worktree with different content (use --overwrite-envs to replace it)\n", coloredWarning(), f.Path)
+		}
+		skip[f.Path] = true
+	}
+
+	kept := envFiles[:0:0]
+	for _, f := range envFiles {
+		if !skip[f.Path] {
+			kept = append(kept, f)
+		}
+	}
+	return kept, nil
+}
@@ -2,31 +2,48 @@ package cmd
 
 import (
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 
+	"github.com/sotarok/gw/internal/detect"
 	"github.com/sotarok/gw/internal/git"
 	"github.com/sotarok/gw/internal/hook"
+	"github.com/sotarok/gw/internal/i18n"
 	"github.com/sotarok/gw/internal/iterm2"
+	"github.com/sotarok/gw/internal/recent"
 	"github.com/sotarok/gw/internal/spinner"
 	"github.com/sotarok/gw/internal/ui"
 )
 
 // checkoutGit is the subset of git operations CheckoutCommand actually uses.
 type checkoutGit interface {
-	git.RepositoryReader // GetOriginalRepositoryName, GetRepositoryRoot, GetCurrentBranch, FetchAll
-	git.WorktreeManager  // CreateWorktreeFromBranch
-	git.BranchManager    // BranchExists, ListAllBranches
-	git.EnvFileHandler   // FindUntrackedEnvFiles, CopyEnvFiles (via handleEnvFiles)
+	git.RepositoryReader // GetOriginalRepositoryName, GetRepositoryRoot, GetCurrentBranch, FetchAll, Remote
+	git.WorktreeManager  // CreateWorktreeFromBranch, CreateUntrackedWorktreeFromBranch, CreateWorktreeAt, ListWorktrees, WorktreeRoot
+	git.BranchManager    // BranchExists, ListAllBranches, FetchPullRequest, ResolveBaseBranch, MergeBase, RemoteDefaultBranch, RemoteBranchCandidates, CutRemoteBranch
+	git.EnvFileHandler   // FindUntrackedEnvFiles, CopyEnvFiles (via handleEnvFiles), CopyFiles
 }
 
 // CheckoutCommand handles the checkout command logic
 type CheckoutCommand struct {
-	deps           *Dependencies
-	copyEnvs       bool
-	noFetch        bool
-	noProjectHooks bool
+	deps                  *Dependencies
+	copyEnvs              bool
+	noFetch               bool
+	noProjectHooks        bool
+	overwriteEnvs         bool   // --overwrite-envs: replace env files that differ in the worktree
+	openEditor            bool   // --open: launch the editor in the new worktree
+	printPath             bool   // --print-path: print only the worktree path on stdout and never prompt
+	pr                    string // --pr: check out this pull request's head as pr-<number>
+	noTrack               bool   // --no-track: do not set an upstream for a remote branch
+	fromMergeBase         bool   // --from-merge-base: start the worktree at the branch's merge-base with main
+	newBranch             string // --new-branch: with --from-merge-base, create this branch instead of detaching
+	base                  string // --base: create the branch from this ref when it does not exist yet
+	baseFromRemoteDefault bool   // --track-remote-default: fetch, then use the remote's default branch as --base
+	detach                bool   // --detach: start the worktree at the branch's tip with a detached HEAD
+	editor                detect.CommandExecutor
+	terminal              detect.CommandExecutor // runs new_window_cmd
 }
 
 // NewCheckoutCommand creates a new checkout command handler
@@ -36,34 +53,125 @@ func NewCheckoutCommand(deps *Dependencies, copyEnvs, noFetch, noProjectHooks bo
 		copyEnvs:       copyEnvs,
 		noFetch:        noFetch,
 		noProjectHooks: noProjectHooks,
+		editor:         newEditorExecutor(deps),
+		terminal:       newTerminalExecutor(deps),
 	}
 }
 
 // git returns the command's git dependency narrowed to the operations it uses.
 func (c *CheckoutCommand) git() checkoutGit { return c.deps.Git }
 
+// previousWorktreeArg makes checkout return to the previous worktree, like cd -.
+const previousWorktreeArg = "-"
+
 // Execute runs the checkout command
 func (c *CheckoutCommand) Execute(branch string) error {
+	var pathOut io.Writer
+	if c.printPath {
+		if branch == "" && c.pr == "" {
+			return fmt.Errorf("--print-path requires a branch: the interactive selector cannot be used")
+		}
+		c.deps, pathOut = printPathDeps(c.deps)
+	}
+
+	if c.pr != "" && branch != "" {
+		return fmt.Errorf("--pr cannot be combined with a branch")
+	}
+	if c.newBranch != "" {
+		if !c.fromMergeBase {
+			return fmt.Errorf("--new-branch can only be used with --from-merge-base")
+		}
+		if err := git.ValidateRef(c.newBranch); err != nil {
+			return err
+		}
+	}
+	if c.detach && (c.fromMergeBase || c.base != "") {
+		return fmt.Errorf("--detach cannot be combined with --from-merge-base or --base")
+	}
+	if c.base != "" {
+		if branch == "" || branch == previousWorktreeArg || c.pr != "" || c.fromMergeBase {
+			return fmt.Errorf("--base needs a branch name and cannot be combined with --pr or --from-merge-base")
+		}
+		if err := git.ValidateRef(c.base); err != nil {
+			return err
+		}
+	}
+	if c.baseFromRemoteDefault {
+		if c.base != "" {
+			return fmt.Errorf("--track-remote-default cannot be combined with --base")
+		}
+		if branch == "" || branch == previousWorktreeArg || c.pr != "" || c.fromMergeBase || c.detach {
+			return fmt.Errorf("--track-remote-default needs a branch name and cannot be combined with --pr, --from-merge-base or --detach")
+		}
+	}
+	if branch == previousWorktreeArg {
+		return c.switchToPrevious(pathOut)
+	}
+	// An empty branch means "pick interactively"; anything typed must be a valid ref.
+	if branch != "" {
+		if err := git.ValidateRef(branch); err != nil {
+			return err
+		}
+	}
 	if err := ResolveProjectConfig(c.deps, c.noProjectHooks); err != nil {
 		return err
 	}
 
+	if c.pr != "" {
+		prBranch, err := c.fetchPullRequest()
+		if err != nil {
+			return err
+		}
+		branch = prBranch
+	}
+
 	branch, err := c.resolveBranch(branch)
 	if err != nil {
 		return err
 	}
+	if c.baseFromRemoteDefault {
+		if c.base, err = c.git().RemoteDefaultBranch(); err != nil {
+			return err
+		}
+	}
+
+	// A detached worktree leaves the branch free, so it may be checked out
+	// elsewhere already.
+	if !c.fromMergeBase && !c.detach {
+		existing, err := c.existingWorktree(branch)
+		if err != nil {
+			return err
+		}
+		if existing != nil {
+			return c.switchToExisting(existing, pathOut)
+		}
+	}
 
 	repoName, branchName, worktreePath, repoRoot, err := c.prepareWorktree(branch)
 	if err != nil {
 		return err
 	}
 
-	absolutePath, err := c.createWorktree(branch, branchName, worktreePath)
+	var absolutePath string
+	switch {
+	case c.fromMergeBase:
+		branchName, worktreePath = c.mergeBaseTarget(repoName, repoRoot, branchName)
+		absolutePath, err = c.createMergeBaseWorktree(branch, worktreePath)
+	case c.detach:
+		worktreePath = git.ResolveWorktreePath(c.git().WorktreeRoot(), repoRoot, repoName,
+			git.SanitizeBranchNameForDirectory(branchName)+detachedSuffix)
+		absolutePath, err = c.createDetachedWorktree(branch, worktreePath)
+	default:
+		absolutePath, err = c.createWorktree(branch, branchName, worktreePath)
+	}
 	if err != nil {
 		return err
 	}
 
 	c.postCreate(repoName, branchName, worktreePath, absolutePath, repoRoot)
+	if pathOut != nil {
+		printWorktreePath(pathOut, absolutePath)
+	}
 	return nil
 }
 
@@ -78,8 +186,133 @@ func (c *CheckoutCommand) resolveBranch(branch string) (string, error) {
 		branch = selectedBranch
 	}
 
-	// Fetch from remotes if configured
-	fetchIfConfigured(c.deps, c.noFetch)
+	// Fetch from remotes if configured. Branching from the remote's default
+	// branch is only worth it with fresh refs, so that fetches regardless.
+	if c.baseFromRemoteDefault && !c.noFetch {
+		fetchRemotes(c.deps)
+	} else {
+		fetchIfConfigured(c.deps, c.noFetch)
+	}
+	return c.pickRemote(branch)
+}
+
+// pickRemote narrows a branch name that several remotes have, e.g. feature on
+// both origin and upstream, to one of their remote-tracking branches: the
+// user picks it when prompting is possible, otherwise it has to be named in
+// full. Any other branch is returned as is.
+func (c *CheckoutCommand) pickRemote(branch string) (string, error) {
+	candidates, err := c.git().RemoteBranchCandidates(branch)
+	if err != nil {
+		return "", err
+	}
+	if len(candidates) < 2 {
+		return branch, nil
+	}
+	if !isTerminalStdin() || c.deps.NoPrompt {
+		return "", fmt.Errorf("%s exists on several remotes (%s); name the one to check out, e.g. gw checkout %s",
+			branch, strings.Join(candidates, ", "), candidates[0])
+	}
+
+	items := make([]ui.SelectorItem, len(candidates))
+	for i, candidate := range candidates {
+		items[i] = ui.SelectorItem{ID: candidate, Name: candidate}
+	}
+	selected, err := c.deps.UI.ShowSelector(fmt.Sprintf("%s exists on several remotes. Select one to check out:", branch), items)
+	if err != nil {
+		return "", err
+	}
+	return selected.ID, nil
+}
+
+// existingWorktree returns the worktree that already has branch (or, for a
+// remote branch, its local counterpart) checked out, or nil when there is
+// none.
+func (c *CheckoutCommand) existingWorktree(branch string) (*git.WorktreeInfo, error) {
+	branchName, _ := c.git().CutRemoteBranch(branch)
+	worktrees, err := c.git().ListWorktrees()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list worktrees: %w", err)
+	}
+	for i := range worktrees {
+		if worktrees[i].Branch == branchName && !worktrees[i].IsPrunable {
+			return &worktrees[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// switchToExisting hands the shell the worktree that already has the branch
+// checked out, since git cannot check it out a second time. When prompting is
+// possible the user is asked first, and declining fails with
+// ErrWorktreeExists. A non-nil pathOut also receives the worktree's path
+// (--print-path).
+func (c *CheckoutCommand) switchToExisting(wt *git.WorktreeInfo, pathOut io.Writer) error {
+	fmt.Fprintf(c.deps.Stdout, "%s %s is already checked out at %s\n", coloredWarning(), wt.Branch, wt.Path)
+	if isTerminalStdin() && !c.deps.NoPrompt {
+		fmt.Fprint(c.deps.Stdout, "Switch to that worktree instead?")
+		confirmed, err := c.deps.UI.ConfirmPrompt(" (y/N): ")
+		if err != nil {
+			return fmt.Errorf("failed to get user input: %w", err)
+		}
+		if !confirmed {
+			return fmt.Errorf("%w: %s is checked out at %s", git.ErrWorktreeExists, wt.Branch, wt.Path)
+		}
+	}
+
+	if opensNewWindow(c.deps) {
+		openNewWindow(c.deps, c.terminal, wt.Path)
+	} else {
+		current, _ := c.git().GetRepositoryRoot()
+		writeCDFile(c.deps, current, wt.Path)
+		fmt.Fprintf(c.deps.Stdout, "%s Existing worktree: %s\n", activeTheme.ready, wt.Path)
+		if c.deps.Config.AutoCD {
+			fmt.Fprintf(c.deps.Stdout, "\n%s %s\n", activeTheme.hint, i18n.T(i18n.MsgShellIntegCD))
+		}
+	}
+	if pathOut != nil {
+		printWorktreePath(pathOut, wt.Path)
+	}
+	return nil
+}
+
+// pullRequestBranchPrefix names the local branch a pull request is fetched
+// into, e.g. pr-42.
+const pullRequestBranchPrefix = "pr-"
+
+// fetchPullRequest fetches the head of pull request c.pr into the local branch
+// pr-<number> and returns that branch. A pull request already checked out in
+// a worktree is refused, since git will not update a checked-out branch.
+func (c *CheckoutCommand) fetchPullRequest() (string, error) {
+	g := c.git()
+
+	number := strings.TrimPrefix(c.pr, "#")
+	if n, err := strconv.Atoi(number); err != nil || n <= 0 {
+		return "", fmt.Errorf("invalid pull request number %q", c.pr)
+	}
+	branch := pullRequestBranchPrefix + number
+
+	worktrees, err := g.ListWorktrees()
+	if err != nil {
+		return "", fmt.Errorf("failed to list worktrees: %w", err)
+	}
+	for _, wt := range worktrees {
+		if wt.Branch == branch {
+			return "", fmt.Errorf("%w: pull request #%s is checked out at %s", git.ErrWorktreeExists, number, wt.Path)
+		}
+	}
+
+	sp := spinner.New(fmt.Sprintf("Fetching pull request #%s...", number), c.deps.Stdout)
+	sp.Start()
+	repo, err := g.FetchPullRequest(number, branch)
+	sp.Stop()
+	if err != nil {
+		return "", err
+	}
+	source := "#" + number
+	if repo.Owner != "" {
+		source = repo.String() + source
+	}
+	fmt.Fprintf(c.deps.Stdout, "%s Fetched %s into %s\n", coloredSuccess(), source, branch)
 	return branch, nil
 }
 
@@ -103,10 +336,7 @@ func (c *CheckoutCommand) prepareWorktree(branch string) (repoName, branchName,
 	}
 
 	// Extract branch name without remote prefix
-	branchName = branch
-	if strings.HasPrefix(branch, "origin/") {
-		branchName = strings.TrimPrefix(branch, "origin/")
-	}
+	branchName, _ = g.CutRemoteBranch(branch)
 
 	// Create worktree directory name
 	sanitizedBranchName := git.SanitizeBranchNameForDirectory(branchName)
@@ -119,13 +349,14 @@ func (c *CheckoutCommand) prepareWorktree(branch string) (repoName, branchName,
 	if err != nil {
 		return "", "", "", "", fmt.Errorf("failed to get repository root: %w", err)
 	}
-	worktreePath = git.ResolveWorktreePath(repoRoot, repoName, sanitizedBranchName)
+	worktreePath = git.ResolveWorktreePath(g.WorktreeRoot(), repoRoot, repoName, sanitizedBranchName)
 
 	return repoName, branchName, worktreePath, repoRoot, nil
 }
 
 // createWorktree verifies the branch exists, creates the worktree, and returns
-// the absolute path to it.
+// the absolute path to it. A branch that does not exist yet is created from
+// --base when one was given.
 func (c *CheckoutCommand) createWorktree(branch, branchName, worktreePath string) (string, error) {
 	g := c.git()
 
@@ -135,16 +366,46 @@ func (c *CheckoutCommand) createWorktree(branch, branchName, worktreePath string
 		return "", fmt.Errorf("failed to check branch existence: %w", err)
 	}
 	if !exists {
-		return "", fmt.Errorf("branch '%s' does not exist in the repository\nUse 'git branch -a' to see all available branches", branch)
+		if c.base != "" {
+			return c.createFromBase(branchName, worktreePath)
+		}
+		return "", fmt.Errorf("%w\nUse 'git branch -a' to see all available branches", &git.BranchNotFoundError{Branch: branch})
+	}
+	if c.base != "" {
+		fmt.Fprintf(c.deps.Stdout, "%s %s already exists; checking it out as is and ignoring --base %s\n", coloredWarning(), branch, c.base)
+	}
+
+	create := g.CreateWorktreeFromBranch
+	if c.noTrack {
+		create = g.CreateUntrackedWorktreeFromBranch
+	}
+	return c.createWithSpinner(branch, worktreePath, func() error {
+		return create(worktreePath, branch, branchName)
+	})
+}
+
+// requireBranch fails with a BranchNotFoundError naming name when ref does not
+// exist locally or on a remote.
+func (c *CheckoutCommand) requireBranch(ref, name string) error {
+	exists, err := c.git().BranchExists(ref)
+	if err != nil {
+		return fmt.Errorf("failed to check branch existence: %w", err)
+	}
+	if !exists {
+		return fmt.Errorf("%w\nUse 'git branch -a' to see all available branches", &git.BranchNotFoundError{Branch: name})
 	}
+	return nil
+}
 
-	// Create worktree with spinner
-	sp := spinner.New(fmt.Sprintf("Creating worktree for branch '%s'...", branch), c.deps.Stdout)
+// createWithSpinner runs create behind a "creating <label>" spinner and
+// returns the absolute path to the new worktree at worktreePath.
+func (c *CheckoutCommand) createWithSpinner(label, worktreePath string, create func() error) (string, error) {
+	sp := spinner.New(i18n.T(i18n.MsgCheckoutCreating, label), c.deps.Stdout)
 	sp.Start()
-	createErr := g.CreateWorktreeFromBranch(worktreePath, branch, branchName)
+	err := create()
 	sp.Stop()
-	if createErr != nil {
-		return "", fmt.Errorf("failed to create worktree: %w", createErr)
+	if err != nil {
+		return "", fmt.Errorf("failed to create worktree: %w", err)
 	}
 
 	absolutePath, err := filepath.Abs(worktreePath)
@@ -154,9 +415,99 @@ func (c *CheckoutCommand) createWorktree(branch, branchName, worktreePath string
 	return absolutePath, nil
 }
 
+// createFromBase creates branch at --base, preferring a local branch of that
+// name over the remote one like gw start does, checks it out in a new
+// worktree, and returns the absolute path to it.
+func (c *CheckoutCommand) createFromBase(branch, worktreePath string) (string, error) {
+	g := c.git()
+
+	base, _ := g.ResolveBaseBranch(c.base)
+	if err := c.requireBranch(base, c.base); err != nil {
+		return "", err
+	}
+	fmt.Fprintf(c.deps.Stdout, "%s Creating %s from %s\n", coloredArrow(), branch, base)
+
+	return c.createWithSpinner(branch, worktreePath, func() error {
+		return g.CreateWorktreeAt(worktreePath, base, branch)
+	})
+}
+
+// mergeBaseSuffix ends the directory of a detached --from-merge-base
+// worktree, so it does not take the place of the branch's own worktree.
+const mergeBaseSuffix = "-merge-base"
+
+// mergeBaseTarget returns the branch name and worktree path of a
+// --from-merge-base worktree: those of --new-branch when given, otherwise the
+// checked out branch's name and a path ending in -merge-base.
+func (c *CheckoutCommand) mergeBaseTarget(repoName, repoRoot, branchName string) (string, string) {
+	suffix := git.SanitizeBranchNameForDirectory(branchName) + mergeBaseSuffix
+	if c.newBranch != "" {
+		branchName = c.newBranch
+		suffix = git.SanitizeBranchNameForDirectory(c.newBranch)
+	}
+	return branchName, git.ResolveWorktreePath(c.git().WorktreeRoot(), repoRoot, repoName, suffix)
+}
+
+// createMergeBaseWorktree creates the worktree at the merge-base of branch and
+// main, detached or on --new-branch, and returns the absolute path to it.
+// Diffing the branch against that worktree shows only the branch's own
+// changes.
+func (c *CheckoutCommand) createMergeBaseWorktree(branch, worktreePath string) (string, error) {
+	g := c.git()
+
+	if err := c.requireBranch(branch, branch); err != nil {
+		return "", err
+	}
+	if c.newBranch != "" {
+		exists, err := g.BranchExists(c.newBranch)
+		if err != nil {
+			return "", fmt.Errorf("failed to check branch existence: %w", err)
+		}
+		if exists {
+			return "", fmt.Errorf("branch %s already exists; choose another --new-branch", c.newBranch)
+		}
+	}
+
+	base, _ := g.ResolveBaseBranch(defaultBaseBranch)
+	commit, err := g.MergeBase(base, branch)
+	if err != nil {
+		return "", err
+	}
+	fmt.Fprintf(c.deps.Stdout, "%s Merge-base of %s and %s: %s\n", coloredArrow(), branch, base, shortHash(commit))
+
+	return c.createWithSpinner(shortHash(commit), worktreePath, func() error {
+		return g.CreateWorktreeAt(worktreePath, commit, c.newBranch)
+	})
+}
+
+// detachedSuffix ends the directory of a --detach worktree, so it does not
+// take the place of the branch's own worktree.
+const detachedSuffix = "-detached"
+
+// createDetachedWorktree creates the worktree at the tip of branch with a
+// detached HEAD and returns the absolute path to it. The branch itself is not
+// checked out, so it stays free for another worktree.
+func (c *CheckoutCommand) createDetachedWorktree(branch, worktreePath string) (string, error) {
+	g := c.git()
+
+	if err := c.requireBranch(branch, branch); err != nil {
+		return "", err
+	}
+
+	return c.createWithSpinner(branch, worktreePath, func() error {
+		return g.CreateWorktreeAt(worktreePath, branch, "")
+	})
+}
+
 // postCreate performs the post-creation steps: optional auto-cd, env file copy,
 // package manager setup, the post-checkout hook, and the completion message.
 func (c *CheckoutCommand) postCreate(repoName, branchName, worktreePath, absolutePath, repoRoot string) {
+	if dryRun {
+		printDryRunSetup(c.deps, absolutePath, dryRunSetupSteps(c.deps, c.copyEnvs, "post_checkout_hook",
+			c.deps.Config.PostCheckoutHook, true, c.openEditor))
+		return
+	}
+
 	// Change to the new worktree directory for setup operations
 	// Note: This only affects the current process, not the parent shell
 	if c.deps.Config.AutoCD {
@@ -168,6 +519,8 @@ func (c *CheckoutCommand) postCreate(repoName, branchName, worktreePath, absolut
 		}
 	}
 
+	copyAlwaysCopyFiles(c.deps, c.git(), repoRoot, absolutePath)
+
 	// Handle environment files
 	if err := c.handleEnvFiles(repoRoot, absolutePath); err != nil {
 		// Don't fail the command, just warn
@@ -189,21 +542,79 @@ func (c *CheckoutCommand) postCreate(repoName, branchName, worktreePath, absolut
 			Command:      "checkout",
 		}
 		if err := hook.Execute(c.deps.Config.PostCheckoutHook, hookEnv, c.deps.Stdout, c.deps.Stderr); err != nil {
-			fmt.Fprintf(c.deps.Stderr, "%s Post-checkout hook failed: %v\n", coloredWarning(), err)
+			fmt.Fprintf(c.deps.Stderr, "%s %s\n", coloredWarning(), i18n.T(i18n.MsgCheckoutHookFailed, err))
 		}
 	}
 
+	newWindow := opensNewWindow(c.deps)
+	if !newWindow {
+		writeCDFile(c.deps, repoRoot, absolutePath)
+	}
+
 	// Show completion message
 	if c.deps.Stdout != nil {
-		fmt.Fprintf(c.deps.Stdout, "\n✨ Worktree ready at:\n   %s\n", absolutePath)
+		fmt.Fprintf(c.deps.Stdout, "\n%s %s\n", activeTheme.ready, i18n.T(i18n.MsgWorktreeReady, absolutePath))
+		if c.deps.Config.AutoCD && !newWindow {
+			fmt.Fprintf(c.deps.Stdout, "\n%s %s\n", activeTheme.hint, i18n.T(i18n.MsgShellIntegCD))
+		}
+	}
+
+	if newWindow {
+		openNewWindow(c.deps, c.terminal, absolutePath)
+	}
+
+	if c.openEditor {
+		openInEditor(c.deps, c.editor, absolutePath)
+	}
+}
+
+// switchToPrevious hands the shell the worktree it most recently switched
+// away from with gw start or gw checkout. Recorded worktrees that have been
+// removed since, or that belong to another repository, are skipped. A non-nil
+// pathOut also receives the worktree's path (--print-path).
+func (c *CheckoutCommand) switchToPrevious(pathOut io.Writer) error {
+	g := c.git()
+
+	current, err := g.GetRepositoryRoot()
+	if err != nil {
+		return fmt.Errorf("failed to get repository root: %w", err)
+	}
+	worktrees, err := g.ListWorktrees()
+	if err != nil {
+		return fmt.Errorf("failed to list worktrees: %w", err)
+	}
+	path, err := recent.DefaultPath()
+	if err != nil {
+		return err
+	}
+	visited, err := recent.Load(path)
+	if err != nil {
+		return err
+	}
+
+	existing := make(map[string]bool, len(worktrees))
+	for _, wt := range worktrees {
+		existing[wt.Path] = true
+	}
+	for _, previous := range visited {
+		if previous == current || !existing[previous] {
+			continue
+		}
+		writeCDFile(c.deps, current, previous)
+		fmt.Fprintf(c.deps.Stdout, "%s Previous worktree: %s\n", activeTheme.ready, previous)
 		if c.deps.Config.AutoCD {
-			fmt.Fprintf(c.deps.Stdout, "\n💡 Shell integration will change to this directory after the command completes.\n")
+			fmt.Fprintf(c.deps.Stdout, "\n%s %s\n", activeTheme.hint, i18n.T(i18n.MsgShellIntegCD))
+		}
+		if pathOut != nil {
+			printWorktreePath(pathOut, previous)
 		}
+		return nil
 	}
+	return fmt.Errorf("no previous worktree to switch to: switch worktrees with gw start or gw checkout first")
 }
 
 func (c *CheckoutCommand) handleEnvFiles(originalDir, worktreePath string) error {
-	return handleEnvFiles(c.deps, c.copyEnvs, originalDir, worktreePath)
+	return handleEnvFiles(c.deps, c.copyEnvs, c.overwriteEnvs, originalDir, worktreePath)
 }
 
 func (c *CheckoutCommand) selectBranch() (string, error) {
@@ -231,15 +642,15 @@ func (c *CheckoutCommand) selectBranch() (string, error) {
 	const (
 		mainBranch   = "main"
 		masterBranch = "master"
-		originMain   = "origin/main"
-		originMaster = "origin/master"
 	)
+	remoteMain := g.Remote() + "/" + mainBranch
+	remoteMaster := g.Remote() + "/" + masterBranch
 
 	var filteredBranches []string
 	for _, branch := range branches {
 		// Skip current branch and main branches
 		if branch != currentBranch && branch != mainBranch && branch != masterBranch &&
-			branch != originMain && branch != originMaster {
+			branch != remoteMain && branch != remoteMaster {
 			filteredBranches = append(filteredBranches, branch)
 		}
 	}
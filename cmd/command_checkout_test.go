@@ -2,14 +2,17 @@ package cmd
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"os"
+	"os/exec"
 	"path/filepath"
 	"strings"
 	"testing"
 
 	"github.com/sotarok/gw/internal/config"
 	"github.com/sotarok/gw/internal/git"
+	"github.com/sotarok/gw/internal/recent"
 	"github.com/sotarok/gw/internal/ui"
 )
 
@@ -1066,3 +1069,752 @@ func TestCheckoutCommand_Execute_PostHookFailure(t *testing.T) {
 		t.Error("Expected success message even when hook fails")
 	}
 }
+
+func TestCheckoutCommand_Execute_InvalidBranchName(t *testing.T) {
+	for _, branch := range []string{" feature", "--oops", "foo..bar"} {
+		t.Run(branch, func(t *testing.T) {
+			mockGitInstance := &mockGit{isGitRepo: true}
+			mockGitInstance.GetOriginalRepositoryNameFn = func() (string, error) {
+				t.Error("git should not be reached for an invalid branch name")
+				return testRepoName, nil
+			}
+
+			deps := &Dependencies{
+				Git:    mockGitInstance,
+				UI:     &mockUI{},
+				Detect: &mockDetect{},
+				Config: config.New(),
+				Stdout: &bytes.Buffer{},
+				Stderr: &bytes.Buffer{},
+			}
+
+			err := NewCheckoutCommand(deps, false, true, false).Execute(branch)
+			if err == nil || !strings.Contains(err.Error(), "invalid branch name") {
+				t.Errorf("Expected 'invalid branch name' error, got: %v", err)
+			}
+		})
+	}
+}
+
+func TestCheckoutCommand_Execute_PrintPath(t *testing.T) {
+	t.Run("prints only the worktree path", func(t *testing.T) {
+		repoRoot := filepath.Join(t.TempDir(), "repo")
+
+		mg := &mockGit{
+			isGitRepo:                   true,
+			BranchExistsFn:              func(string) (bool, error) { return true, nil },
+			GetOriginalRepositoryNameFn: func() (string, error) { return "repo", nil },
+			GetRepositoryRootFn:         func() (string, error) { return repoRoot, nil },
+		}
+		stdout := &bytes.Buffer{}
+		stderr := &bytes.Buffer{}
+		deps := &Dependencies{
+			Git:    mg,
+			UI:     &mockUI{},
+			Detect: &mockDetect{},
+			Config: &config.Config{},
+			Stdout: stdout,
+			Stderr: stderr,
+		}
+		cmd := NewCheckoutCommand(deps, false, true, false)
+		cmd.printPath = true
+
+		if err := cmd.Execute(testBranchFeature); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		want := git.ResolveWorktreePath("", repoRoot, "repo", "feature-test") + "\n"
+		if stdout.String() != want {
+			t.Errorf("stdout = %q, want only the worktree path %q", stdout.String(), want)
+		}
+		if !strings.Contains(stderr.String(), "Creating worktree for branch 'feature/test'") {
+			t.Errorf("Expected status messages on stderr, got:\n%s", stderr.String())
+		}
+	})
+
+	t.Run("requires a branch", func(t *testing.T) {
+		deps := &Dependencies{
+			Git:    &mockGit{isGitRepo: true},
+			UI:     &mockUI{},
+			Detect: &mockDetect{},
+			Config: config.New(),
+			Stdout: &bytes.Buffer{},
+			Stderr: &bytes.Buffer{},
+		}
+		cmd := NewCheckoutCommand(deps, false, true, false)
+		cmd.printPath = true
+
+		err := cmd.Execute("")
+		if err == nil || !strings.Contains(err.Error(), "--print-path requires a branch") {
+			t.Errorf("Expected a missing branch error, got: %v", err)
+		}
+	})
+}
+
+func TestCheckoutCommand_Execute_Previous(t *testing.T) {
+	newPreviousDeps := func(t *testing.T, visited ...string) (*Dependencies, string, *bytes.Buffer) {
+		t.Helper()
+		home := t.TempDir()
+		t.Setenv("HOME", home)
+		cdFile := filepath.Join(t.TempDir(), "cd")
+		t.Setenv(envCDFile, cdFile)
+		if len(visited) > 0 {
+			if err := recent.Record(filepath.Join(home, ".gw", "recent.json"), visited...); err != nil {
+				t.Fatalf("failed to seed recents: %v", err)
+			}
+		}
+
+		mg := &mockGit{
+			GetRepositoryRootFn: func() (string, error) { return "/repo-2", nil },
+			ListWorktreesFn: func() ([]git.WorktreeInfo, error) {
+				return []git.WorktreeInfo{{Path: "/repo"}, {Path: "/repo-1"}, {Path: "/repo-2"}}, nil
+			},
+		}
+		stdout := &bytes.Buffer{}
+		return &Dependencies{
+			Git:    mg,
+			UI:     &mockUI{},
+			Detect: &mockDetect{},
+			Config: config.New(),
+			Stdout: stdout,
+			Stderr: &bytes.Buffer{},
+		}, cdFile, stdout
+	}
+
+	t.Run("switches to the prior worktree", func(t *testing.T) {
+		deps, cdFile, stdout := newPreviousDeps(t, "/repo-1", "/repo-2")
+
+		if err := NewCheckoutCommand(deps, false, true, false).Execute("-"); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if got, _ := os.ReadFile(cdFile); string(got) != "/repo-1" {
+			t.Errorf("%s content = %q, want /repo-1", envCDFile, got)
+		}
+		if !strings.Contains(stdout.String(), "Previous worktree: /repo-1") {
+			t.Errorf("Expected the previous worktree in the output, got:\n%s", stdout.String())
+		}
+
+		// Going back again returns to where we came from, like cd -.
+		deps.Git.(*mockGit).GetRepositoryRootFn = func() (string, error) { return "/repo-1", nil }
+		if err := NewCheckoutCommand(deps, false, true, false).Execute("-"); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if got, _ := os.ReadFile(cdFile); string(got) != "/repo-2" {
+			t.Errorf("%s content = %q, want /repo-2", envCDFile, got)
+		}
+	})
+
+	t.Run("skips removed worktrees", func(t *testing.T) {
+		deps, cdFile, _ := newPreviousDeps(t, "/repo", "/repo-gone", "/repo-2")
+
+		if err := NewCheckoutCommand(deps, false, true, false).Execute("-"); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if got, _ := os.ReadFile(cdFile); string(got) != "/repo" {
+			t.Errorf("%s content = %q, want /repo", envCDFile, got)
+		}
+	})
+
+	t.Run("fewer than two recents", func(t *testing.T) {
+		deps, _, _ := newPreviousDeps(t, "/repo-2")
+
+		err := NewCheckoutCommand(deps, false, true, false).Execute("-")
+		if err == nil || !strings.Contains(err.Error(), "no previous worktree") {
+			t.Errorf("Expected a no previous worktree error, got: %v", err)
+		}
+	})
+}
+
+func TestCheckoutCommand_Execute_ExistingWorktree(t *testing.T) {
+	newExistingDeps := func(ui *mockUI) (*Dependencies, *bytes.Buffer) {
+		mg := &mockGit{
+			isGitRepo: true,
+			ListWorktreesFn: func() ([]git.WorktreeInfo, error) {
+				return []git.WorktreeInfo{
+					{Path: "/repo", Branch: "main"},
+					{Path: "/repo-feature-foo", Branch: testBranchFeature},
+				}, nil
+			},
+			CreateWorktreeFromBranchFn: func(string, string, string) error {
+				t.Error("Expected no new worktree for a branch that is already checked out")
+				return nil
+			},
+		}
+		stdout := &bytes.Buffer{}
+		return &Dependencies{Git: mg, UI: ui, Detect: &mockDetect{}, Config: config.New(), Stdout: stdout, Stderr: &bytes.Buffer{}}, stdout
+	}
+
+	for _, branch := range []string{testBranchFeature, "origin/" + testBranchFeature} {
+		t.Run("switches to the worktree of "+branch, func(t *testing.T) {
+			cdFile := filepath.Join(t.TempDir(), "cd")
+			t.Setenv(envCDFile, cdFile)
+			t.Setenv("HOME", t.TempDir())
+			deps, stdout := newExistingDeps(&mockUI{})
+
+			if err := NewCheckoutCommand(deps, false, true, true).Execute(branch); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if got, _ := os.ReadFile(cdFile); string(got) != "/repo-feature-foo" {
+				t.Errorf("%s content = %q, want /repo-feature-foo", envCDFile, got)
+			}
+			if !strings.Contains(stdout.String(), "Existing worktree: /repo-feature-foo") {
+				t.Errorf("Expected the existing worktree to be reported, got:\n%s", stdout.String())
+			}
+		})
+	}
+
+	t.Run("asks before switching and fails when declined", func(t *testing.T) {
+		original := isTerminalStdin
+		isTerminalStdin = func() bool { return true }
+		defer func() { isTerminalStdin = original }()
+		ui := &mockUI{confirmResult: false}
+		deps, _ := newExistingDeps(ui)
+
+		err := NewCheckoutCommand(deps, false, true, true).Execute(testBranchFeature)
+		if !ui.confirmCalled {
+			t.Error("Expected a confirmation prompt")
+		}
+		if !errors.Is(err, git.ErrWorktreeExists) {
+			t.Errorf("Expected ErrWorktreeExists, got: %v", err)
+		}
+	})
+}
+
+func TestCheckoutCommand_Execute_PR(t *testing.T) {
+	newPRDeps := func(mg *mockGit) *Dependencies {
+		return &Dependencies{
+			Git:    mg,
+			UI:     &mockUI{},
+			Detect: &mockDetect{},
+			Config: config.New(),
+			Stdout: &bytes.Buffer{},
+			Stderr: &bytes.Buffer{},
+		}
+	}
+
+	t.Run("rejects a branch argument", func(t *testing.T) {
+		cmd := NewCheckoutCommand(newPRDeps(&mockGit{isGitRepo: true}), false, true, true)
+		cmd.pr = "42"
+
+		err := cmd.Execute(testBranchFeature)
+		if err == nil || !strings.Contains(err.Error(), "--pr cannot be combined with a branch") {
+			t.Errorf("Expected a conflicting arguments error, got: %v", err)
+		}
+	})
+
+	t.Run("rejects an invalid number", func(t *testing.T) {
+		cmd := NewCheckoutCommand(newPRDeps(&mockGit{isGitRepo: true}), false, true, true)
+		cmd.pr = "abc"
+
+		err := cmd.Execute("")
+		if err == nil || !strings.Contains(err.Error(), `invalid pull request number "abc"`) {
+			t.Errorf("Expected an invalid number error, got: %v", err)
+		}
+	})
+
+	t.Run("refuses a pull request already checked out", func(t *testing.T) {
+		fetched := false
+		mg := &mockGit{
+			isGitRepo: true,
+			ListWorktreesFn: func() ([]git.WorktreeInfo, error) {
+				return []git.WorktreeInfo{{Path: "/repo-pr-42", Branch: "pr-42"}}, nil
+			},
+			FetchPullRequestFn: func(string, string) (git.RemoteRepo, error) {
+				fetched = true
+				return git.RemoteRepo{}, nil
+			},
+		}
+		cmd := NewCheckoutCommand(newPRDeps(mg), false, true, true)
+		cmd.pr = "#42"
+
+		err := cmd.Execute("")
+		if !errors.Is(err, git.ErrWorktreeExists) {
+			t.Errorf("expected git.ErrWorktreeExists, got: %v", err)
+		}
+		if fetched {
+			t.Error("Expected no fetch into a checked-out branch")
+		}
+	})
+}
+
+func TestCheckoutCommand_Execute_PR_Integration(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	originalDir, _ := os.Getwd()
+	defer os.Chdir(originalDir)
+
+	root := t.TempDir()
+	remote := filepath.Join(root, "remote.git")
+	repo := filepath.Join(root, "repo")
+	contributor := filepath.Join(root, "contributor")
+	runGit := func(dir string, args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v failed: %v\n%s", args, err, out)
+		}
+	}
+	pushPRCommit := func(file string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(contributor, file), []byte(file+"\n"), 0644); err != nil {
+			t.Fatalf("write file: %v", err)
+		}
+		runGit(contributor, "add", file)
+		runGit(contributor, "commit", "-m", "add "+file)
+		// What GitHub does when a pull request is opened or updated.
+		runGit(contributor, "push", "origin", "HEAD:refs/pull/42/head")
+	}
+
+	runGit(root, "init", "--bare", remote)
+	runGit(root, "clone", remote, contributor)
+	runGit(contributor, "config", "user.email", "test@example.com")
+	runGit(contributor, "config", "user.name", "Test User")
+	runGit(contributor, "commit", "--allow-empty", "-m", "initial")
+	runGit(contributor, "push", "origin", "HEAD")
+	pushPRCommit("first.txt")
+	runGit(root, "clone", remote, repo)
+
+	if err := os.Chdir(repo); err != nil {
+		t.Fatalf("chdir: %v", err)
+	}
+	checkoutPR := func() error {
+		deps := &Dependencies{
+			Git:    git.NewClient(),
+			UI:     &mockUI{},
+			Detect: &mockDetect{},
+			Config: &config.Config{},
+			Stdout: &bytes.Buffer{},
+			Stderr: &bytes.Buffer{},
+		}
+		cmd := NewCheckoutCommand(deps, false, true, true)
+		cmd.pr = "42"
+		return cmd.Execute("")
+	}
+
+	if err := checkoutPR(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	worktree := filepath.Join(root, "repo-pr-42")
+	if _, err := os.Stat(filepath.Join(worktree, "first.txt")); err != nil {
+		t.Errorf("Expected the worktree to be on the pull request's head: %v", err)
+	}
+
+	if err := checkoutPR(); !errors.Is(err, git.ErrWorktreeExists) {
+		t.Errorf("expected git.ErrWorktreeExists for a checked-out pull request, got: %v", err)
+	}
+
+	// With the worktree gone but pr-42 left behind, a new push to the pull
+	// request fast-forwards the existing branch.
+	runGit(repo, "worktree", "remove", worktree)
+	pushPRCommit("second.txt")
+	if err := checkoutPR(); err != nil {
+		t.Fatalf("Unexpected error re-checking out an existing pr-42: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(worktree, "second.txt")); err != nil {
+		t.Errorf("Expected pr-42 to be updated to the pull request's new head: %v", err)
+	}
+}
+
+func TestCheckoutCommand_Execute_NoTrack_Integration(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	originalDir, _ := os.Getwd()
+	defer os.Chdir(originalDir)
+
+	root := t.TempDir()
+	remote := filepath.Join(root, "remote.git")
+	repo := filepath.Join(root, "repo")
+	runGit := func(dir string, args ...string) (string, error) {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		out, err := cmd.CombinedOutput()
+		return strings.TrimSpace(string(out)), err
+	}
+	mustGit := func(dir string, args ...string) {
+		t.Helper()
+		if out, err := runGit(dir, args...); err != nil {
+			t.Fatalf("git %v failed: %v\n%s", args, err, out)
+		}
+	}
+
+	mustGit(root, "init", "--bare", remote)
+	mustGit(root, "clone", remote, repo)
+	mustGit(repo, "config", "user.email", "test@example.com")
+	mustGit(repo, "config", "user.name", "Test User")
+	mustGit(repo, "commit", "--allow-empty", "-m", "initial")
+	mustGit(repo, "push", "origin", "HEAD")
+	for _, branch := range []string{"tracked", "untracked"} {
+		mustGit(repo, "push", "origin", "HEAD:refs/heads/"+branch)
+	}
+	mustGit(repo, "fetch", "origin")
+
+	if err := os.Chdir(repo); err != nil {
+		t.Fatalf("chdir: %v", err)
+	}
+	checkout := func(branch string, noTrack bool) {
+		t.Helper()
+		deps := &Dependencies{
+			Git:    git.NewClient(),
+			UI:     &mockUI{},
+			Detect: &mockDetect{},
+			Config: &config.Config{},
+			Stdout: &bytes.Buffer{},
+			Stderr: &bytes.Buffer{},
+		}
+		cmd := NewCheckoutCommand(deps, false, true, true)
+		cmd.noTrack = noTrack
+		if err := cmd.Execute(branch); err != nil {
+			t.Fatalf("checkout %s: unexpected error: %v", branch, err)
+		}
+	}
+
+	checkout("origin/tracked", false)
+	if upstream, err := runGit(repo, "rev-parse", "--abbrev-ref", "tracked@{upstream}"); err != nil || upstream != "origin/tracked" {
+		t.Errorf("Expected tracked to have origin/tracked as upstream, got %q (%v)", upstream, err)
+	}
+
+	checkout("origin/untracked", true)
+	if upstream, err := runGit(repo, "rev-parse", "--abbrev-ref", "untracked@{upstream}"); err == nil {
+		t.Errorf("Expected untracked to have no upstream with --no-track, got %q", upstream)
+	}
+	if _, err := os.Stat(filepath.Join(root, "repo-untracked")); err != nil {
+		t.Errorf("Expected the worktree to be created: %v", err)
+	}
+}
+
+func TestCheckoutCommand_Execute_FromMergeBase_Integration(t *testing.T) {
+	repo, runGit := newDryRunTestRepo(t)
+	parent := filepath.Dir(repo)
+
+	// main and feature/foo both move on after feature/foo branched off.
+	runGit("checkout", "-b", "feature/foo")
+	runGit("commit", "--allow-empty", "-m", "feature work")
+	runGit("checkout", "main")
+	runGit("commit", "--allow-empty", "-m", "main work")
+	mergeBase := runGit("merge-base", "main", "feature/foo")
+
+	checkout := func(newBranch string) *bytes.Buffer {
+		t.Helper()
+		stdout := &bytes.Buffer{}
+		deps := &Dependencies{
+			Git:    git.NewClient(),
+			UI:     &mockUI{},
+			Detect: &mockDetect{},
+			Config: &config.Config{},
+			Stdout: stdout,
+			Stderr: &bytes.Buffer{},
+		}
+		cmd := NewCheckoutCommand(deps, false, true, true)
+		cmd.fromMergeBase = true
+		cmd.newBranch = newBranch
+		if err := cmd.Execute("feature/foo"); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		return stdout
+	}
+	headOf := func(dir string) string {
+		t.Helper()
+		return runGit("-C", dir, "rev-parse", "HEAD")
+	}
+
+	t.Run("detached at the merge-base", func(t *testing.T) {
+		stdout := checkout("")
+		worktree := filepath.Join(parent, "repo-feature-foo-merge-base")
+		if head := headOf(worktree); head != mergeBase {
+			t.Errorf("HEAD = %s, want the merge-base %s", head, mergeBase)
+		}
+		c := exec.Command("git", "symbolic-ref", "-q", "HEAD")
+		c.Dir = worktree
+		if err := c.Run(); err == nil {
+			t.Error("Expected a detached HEAD")
+		}
+		if !strings.Contains(stdout.String(), "Merge-base of feature/foo and main: "+mergeBase[:shortHashLen]) {
+			t.Errorf("Expected the merge-base to be reported, got:\n%s", stdout.String())
+		}
+	})
+
+	t.Run("new branch at the merge-base", func(t *testing.T) {
+		checkout("review/foo")
+		worktree := filepath.Join(parent, "repo-review-foo")
+		if head := headOf(worktree); head != mergeBase {
+			t.Errorf("HEAD = %s, want the merge-base %s", head, mergeBase)
+		}
+		if branch := runGit("-C", worktree, "branch", "--show-current"); branch != "review/foo" {
+			t.Errorf("Expected review/foo to be checked out, got %q", branch)
+		}
+	})
+}
+
+func TestCheckoutCommand_Execute_Base_Integration(t *testing.T) {
+	repo, runGit := newDryRunTestRepo(t)
+	parent := filepath.Dir(repo)
+
+	runGit("branch", "release/2.0")
+	runGit("commit", "--allow-empty", "-m", "main work")
+	runGit("branch", "existing")
+	release := runGit("rev-parse", "release/2.0")
+	mainHead := runGit("rev-parse", "main")
+
+	checkout := func(branch string) *bytes.Buffer {
+		t.Helper()
+		stdout := &bytes.Buffer{}
+		deps := &Dependencies{
+			Git:    git.NewClient(),
+			UI:     &mockUI{},
+			Detect: &mockDetect{},
+			Config: &config.Config{},
+			Stdout: stdout,
+			Stderr: &bytes.Buffer{},
+		}
+		cmd := NewCheckoutCommand(deps, false, true, true)
+		cmd.base = "release/2.0"
+		if err := cmd.Execute(branch); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		return stdout
+	}
+
+	t.Run("creates a missing branch from the base", func(t *testing.T) {
+		stdout := checkout("new-feature")
+		worktree := filepath.Join(parent, "repo-new-feature")
+		if head := runGit("-C", worktree, "rev-parse", "HEAD"); head != release {
+			t.Errorf("HEAD = %s, want release/2.0 at %s", head, release)
+		}
+		if branch := runGit("-C", worktree, "branch", "--show-current"); branch != "new-feature" {
+			t.Errorf("Expected new-feature to be checked out, got %q", branch)
+		}
+		if !strings.Contains(stdout.String(), "Creating new-feature from release/2.0") {
+			t.Errorf("Expected the base to be reported, got:\n%s", stdout.String())
+		}
+	})
+
+	t.Run("checks out an existing branch as is", func(t *testing.T) {
+		stdout := checkout("existing")
+		worktree := filepath.Join(parent, "repo-existing")
+		if head := runGit("-C", worktree, "rev-parse", "HEAD"); head != mainHead {
+			t.Errorf("HEAD = %s, want existing's own commit %s", head, mainHead)
+		}
+		if !strings.Contains(stdout.String(), "existing already exists; checking it out as is and ignoring --base release/2.0") {
+			t.Errorf("Expected a note that --base was ignored, got:\n%s", stdout.String())
+		}
+	})
+}
+
+func TestCheckoutCommand_Execute_BaseNotFound(t *testing.T) {
+	mg := &mockGit{
+		isGitRepo:       true,
+		BranchExistsFn:  func(string) (bool, error) { return false, nil },
+		ListWorktreesFn: func() ([]git.WorktreeInfo, error) { return nil, nil },
+		CreateWorktreeAtFn: func(string, string, string) error {
+			t.Error("Expected no worktree to be created")
+			return nil
+		},
+	}
+	deps, _, _ := newListTestDeps(mg)
+
+	cmd := NewCheckoutCommand(deps, false, true, true)
+	cmd.base = "release/9.9"
+	err := cmd.Execute("new-feature")
+	var notFound *git.BranchNotFoundError
+	if !errors.As(err, &notFound) || notFound.Branch != "release/9.9" {
+		t.Errorf("Expected release/9.9 to be reported missing, got: %v", err)
+	}
+}
+
+func TestCheckoutCommand_Execute_TrackRemoteDefault_Integration(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	originalDir, _ := os.Getwd()
+	defer os.Chdir(originalDir)
+
+	root := t.TempDir()
+	remote := filepath.Join(root, "remote.git")
+	repo := filepath.Join(root, "repo")
+	other := filepath.Join(root, "other")
+	runGit := func(dir string, args ...string) string {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		out, err := cmd.CombinedOutput()
+		if err != nil {
+			t.Fatalf("git %v failed: %v\n%s", args, err, out)
+		}
+		return strings.TrimSpace(string(out))
+	}
+
+	// repo is cloned from the bare remote, so origin/HEAD is set, and then
+	// falls behind when another clone pushes to the default branch.
+	runGit(root, "init", "--bare", remote)
+	runGit(root, "clone", remote, other)
+	runGit(other, "config", "user.email", "test@example.com")
+	runGit(other, "config", "user.name", "Test User")
+	runGit(other, "commit", "--allow-empty", "-m", "initial")
+	defaultBranch := runGit(other, "symbolic-ref", "--short", "HEAD")
+	runGit(other, "push", "origin", defaultBranch)
+	runGit(root, "clone", remote, repo)
+	runGit(other, "commit", "--allow-empty", "-m", "pushed after the clone")
+	runGit(other, "push", "origin", defaultBranch)
+	pushed := runGit(other, "rev-parse", "HEAD")
+
+	if err := os.Chdir(repo); err != nil {
+		t.Fatalf("chdir: %v", err)
+	}
+	stdout := &bytes.Buffer{}
+	deps := &Dependencies{
+		Git:    git.NewClient(),
+		UI:     &mockUI{},
+		Detect: &mockDetect{},
+		Config: &config.Config{},
+		Stdout: stdout,
+		Stderr: &bytes.Buffer{},
+	}
+	cmd := NewCheckoutCommand(deps, false, false, true)
+	cmd.baseFromRemoteDefault = true
+	if err := cmd.Execute("new-feature"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	worktree := filepath.Join(root, "repo-new-feature")
+	if head := runGit(worktree, "rev-parse", "HEAD"); head != pushed {
+		t.Errorf("HEAD = %s, want the commit pushed after the clone %s", head, pushed)
+	}
+	if want := "Creating new-feature from origin/" + defaultBranch; !strings.Contains(stdout.String(), want) {
+		t.Errorf("Expected %q, got:\n%s", want, stdout.String())
+	}
+	if local := runGit(repo, "rev-parse", defaultBranch); local == pushed {
+		t.Error("Expected the local default branch to be left behind")
+	}
+}
+
+func TestCheckoutCommand_Execute_TrackRemoteDefaultConflicts(t *testing.T) {
+	deps, _, _ := newListTestDeps(&mockGit{isGitRepo: true})
+	cmd := NewCheckoutCommand(deps, false, true, true)
+	cmd.baseFromRemoteDefault = true
+	cmd.base = "develop"
+	if err := cmd.Execute("new-feature"); err == nil || !strings.Contains(err.Error(), "cannot be combined with --base") {
+		t.Errorf("Expected a --base conflict error, got: %v", err)
+	}
+
+	cmd = NewCheckoutCommand(deps, false, true, true)
+	cmd.baseFromRemoteDefault = true
+	if err := cmd.Execute(""); err == nil || !strings.Contains(err.Error(), "needs a branch name") {
+		t.Errorf("Expected a missing branch error, got: %v", err)
+	}
+}
+
+func TestCheckoutCommand_Execute_Detach_Integration(t *testing.T) {
+	repo, runGit := newDryRunTestRepo(t)
+	parent := filepath.Dir(repo)
+
+	runGit("checkout", "-b", "feature/foo")
+	runGit("commit", "--allow-empty", "-m", "feature work")
+	runGit("checkout", "main")
+	tip := runGit("rev-parse", "feature/foo")
+
+	deps := &Dependencies{
+		Git:    git.NewClient(),
+		UI:     &mockUI{},
+		Detect: &mockDetect{},
+		Config: &config.Config{},
+		Stdout: &bytes.Buffer{},
+		Stderr: &bytes.Buffer{},
+	}
+	cmd := NewCheckoutCommand(deps, false, true, true)
+	cmd.detach = true
+	if err := cmd.Execute("feature/foo"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	worktree := filepath.Join(parent, "repo-feature-foo-detached")
+	if head := runGit("-C", worktree, "rev-parse", "HEAD"); head != tip {
+		t.Errorf("HEAD = %s, want the tip of feature/foo %s", head, tip)
+	}
+	c := exec.Command("git", "symbolic-ref", "-q", "HEAD")
+	c.Dir = worktree
+	if err := c.Run(); err == nil {
+		t.Error("Expected a detached HEAD")
+	}
+	if list := runGit("worktree", "list", "--porcelain"); strings.Contains(list, "branch refs/heads/feature/foo") {
+		t.Errorf("Expected feature/foo not to be checked out in any worktree, got:\n%s", list)
+	}
+
+	// The branch is still free for a worktree of its own.
+	cmd = NewCheckoutCommand(deps, false, true, true)
+	if err := cmd.Execute("feature/foo"); err != nil {
+		t.Fatalf("Unexpected error checking out the branch itself: %v", err)
+	}
+	if branch := runGit("-C", filepath.Join(parent, "repo-feature-foo"), "branch", "--show-current"); branch != "feature/foo" {
+		t.Errorf("Expected feature/foo to be checked out, got %q", branch)
+	}
+}
+
+func TestCheckoutCommand_Execute_AmbiguousRemote_Integration(t *testing.T) {
+	repo, runGit := newDryRunTestRepo(t)
+	parent := filepath.Dir(repo)
+	t.Setenv("HOME", t.TempDir())
+
+	// feature exists on both origin and upstream, but not locally.
+	for _, remote := range []string{"origin", "upstream"} {
+		remoteDir := filepath.Join(parent, remote+".git")
+		runGit("init", "--bare", remoteDir)
+		runGit("remote", "add", remote, remoteDir)
+		runGit("push", remote, "main:refs/heads/feature")
+	}
+	runGit("fetch", "--all")
+
+	checkout := func(tty bool, selector func(string, []ui.SelectorItem) (*ui.SelectorItem, error)) error {
+		t.Helper()
+		orig := isTerminalStdin
+		isTerminalStdin = func() bool { return tty }
+		defer func() { isTerminalStdin = orig }()
+		deps := &Dependencies{
+			Git:    git.NewClient(),
+			UI:     &mockUI{ShowSelectorFn: selector},
+			Detect: &mockDetect{},
+			Config: &config.Config{},
+			Stdout: &bytes.Buffer{},
+			Stderr: &bytes.Buffer{},
+		}
+		return NewCheckoutCommand(deps, false, true, true).Execute("feature")
+	}
+
+	t.Run("requires a remote without a terminal", func(t *testing.T) {
+		err := checkout(false, nil)
+		if err == nil {
+			t.Fatal("Expected an error for a branch on two remotes")
+		}
+		if !strings.Contains(err.Error(), "origin/feature, upstream/feature") {
+			t.Errorf("Expected the error to name both remotes, got: %v", err)
+		}
+		if _, statErr := os.Stat(filepath.Join(parent, "repo-feature")); !os.IsNotExist(statErr) {
+			t.Errorf("Expected no worktree to be created, stat error: %v", statErr)
+		}
+	})
+
+	t.Run("asks which remote", func(t *testing.T) {
+		var offered []string
+		err := checkout(true, func(_ string, items []ui.SelectorItem) (*ui.SelectorItem, error) {
+			for _, item := range items {
+				offered = append(offered, item.ID)
+			}
+			return &items[1], nil
+		})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if strings.Join(offered, " ") != "origin/feature upstream/feature" {
+			t.Errorf("Expected both remote branches to be offered, got %v", offered)
+		}
+		if upstream := runGit("rev-parse", "--abbrev-ref", "feature@{upstream}"); upstream != "upstream/feature" {
+			t.Errorf("Expected feature to track upstream/feature, got %q", upstream)
+		}
+		if branch := runGit("-C", filepath.Join(parent, "repo-feature"), "branch", "--show-current"); branch != "feature" {
+			t.Errorf("Expected feature to be checked out in repo-feature, got %q", branch)
+		}
+	})
+}
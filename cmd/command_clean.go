@@ -1,19 +1,36 @@
 package cmd
 
 import (
+	"encoding/json"
 	"fmt"
+	"io"
+	"os"
+	"path"
 	"path/filepath"
+	"runtime"
+	"slices"
+	"sort"
 	"strings"
 	"sync"
+	"time"
 
+	"github.com/sotarok/gw/internal/config"
 	"github.com/sotarok/gw/internal/git"
+	"github.com/sotarok/gw/internal/i18n"
 	"github.com/sotarok/gw/internal/spinner"
+	"github.com/sotarok/gw/internal/ui"
 )
 
-// cleanCheckConcurrency caps the number of worktrees whose safety checks may
-// run in parallel during `gw clean`. Each check forks three `git` subprocesses,
-// so the effective fd ceiling is ~3× this value.
-const cleanCheckConcurrency = 8
+// checkConcurrency returns how many worktrees' safety checks may run in
+// parallel during `gw clean`: max_parallel_checks when set, otherwise one per
+// CPU. Each check forks four `git` subprocesses, so the effective fd ceiling
+// is ~4× this value.
+func checkConcurrency(cfg *config.Config) int {
+	if cfg.MaxParallelChecks > 0 {
+		return cfg.MaxParallelChecks
+	}
+	return runtime.NumCPU()
+}
 
 // protectedBranches are the integration branches that `gw clean` never treats
 // as removable candidates.
@@ -32,9 +49,9 @@ func isProtectedBranch(branch string) bool {
 
 // cleanGit is the subset of git operations CleanCommand actually uses.
 type cleanGit interface {
-	git.RepositoryReader // GetRepositoryName, FetchAll
+	git.RepositoryReader // GetRepositoryName, GetMainRepositoryRoot, FetchAll
 	git.WorktreeManager  // ListWorktrees, RemoveWorktreeByPath
-	git.BranchManager    // DeleteBranch
+	git.BranchManager    // DeleteBranch, DeleteRemoteBranch, ListLocalBranches
 	git.StatusChecker
 }
 
@@ -52,6 +69,47 @@ type CleanCommand struct {
 	dryRun         bool
 	noFetch        bool
 	noProjectHooks bool
+	interactive    bool     // --interactive: pick the worktrees to remove from a multi-select list
+	deleteRemote   bool     // --delete-remote: also delete the branch on origin
+	quiet          bool     // --quiet: no progress spinner while checking worktrees
+	keep           int      // --keep: never remove the N worktrees with the newest last commit
+	forceDelete    bool     // --force-delete-branch: delete branches even if they are not merged
+	branchOnly     bool     // --remove-branch-only: delete merged branches that have no worktree instead
+	explain        bool     // --explain: show the next step for each reason a worktree is kept
+	jsonOutput     bool     // --json: print a summary of the run as JSON on stdout
+	exclude        []string // --exclude: never remove worktrees whose branch matches one of these globs
+
+	summary *cleanSummary // what happened to each worktree; set once they are checked
+}
+
+// cleanSummary is the --json report of a gw clean run.
+type cleanSummary struct {
+	DryRun    bool          `json:"dry_run"`
+	Counts    cleanCounts   `json:"counts"`
+	Removed   []cleanResult `json:"removed"`
+	Removable []cleanResult `json:"removable,omitempty"` // dry run only: what would have been removed
+	Skipped   []cleanResult `json:"skipped"`
+	Failed    []cleanResult `json:"failed"`
+}
+
+// cleanCounts holds the lengths of the cleanSummary lists.
+type cleanCounts struct {
+	Removed   int `json:"removed"`
+	Removable int `json:"removable"`
+	Skipped   int `json:"skipped"`
+	Failed    int `json:"failed"`
+}
+
+// cleanResult is one worktree in a cleanSummary.
+type cleanResult struct {
+	Path    string   `json:"path"`
+	Branch  string   `json:"branch"`
+	Reasons []string `json:"reasons,omitempty"` // why it was skipped
+	Error   string   `json:"error,omitempty"`   // why removing it failed
+}
+
+func newCleanResult(status *WorktreeStatus) cleanResult {
+	return cleanResult{Path: status.Info.Path, Branch: status.Info.Branch}
 }
 
 // NewCleanCommand creates a new clean command handler
@@ -70,65 +128,321 @@ func (c *CleanCommand) git() cleanGit { return c.deps.Git }
 
 // Execute runs the clean command
 func (c *CleanCommand) Execute() error {
+	if c.jsonOutput {
+		return c.executeJSON()
+	}
+	return c.execute()
+}
+
+// executeJSON runs the clean command for --json: the usual output goes to
+// stderr, and stdout gets only the summary of what happened to each worktree.
+// It never prompts, so it needs --force or --dry-run.
+func (c *CleanCommand) executeJSON() error {
+	if !c.force && !c.dryRun {
+		return fmt.Errorf("--json needs --force or --dry-run, since it cannot ask for confirmation")
+	}
+	var out io.Writer
+	c.deps, out = printPathDeps(c.deps)
+
+	err := c.execute()
+	if c.summary == nil {
+		return err
+	}
+	c.summary.Counts = cleanCounts{
+		Removed:   len(c.summary.Removed),
+		Removable: len(c.summary.Removable),
+		Skipped:   len(c.summary.Skipped),
+		Failed:    len(c.summary.Failed),
+	}
+	enc := json.NewEncoder(out)
+	enc.SetIndent("", "  ")
+	if encErr := enc.Encode(c.summary); encErr != nil && err == nil {
+		err = encErr
+	}
+	return err
+}
+
+func (c *CleanCommand) execute() error {
 	// --force and --dry-run also skip project hooks: --force signals a
 	// non-interactive removal, and --dry-run must never mutate trust state or
 	// prompt for a run that won't actually happen.
+	if c.keep < 0 {
+		return fmt.Errorf("--keep must not be negative, got %d", c.keep)
+	}
+	for _, pattern := range c.exclude {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("invalid --exclude pattern %q: %w", pattern, err)
+		}
+	}
+	if c.branchOnly {
+		return c.executeBranchOnly()
+	}
 	if err := ResolveProjectConfig(c.deps, c.noProjectHooks || c.force || c.dryRun); err != nil {
 		return err
 	}
 
 	// Fetch from remotes if configured
 	fetchIfConfigured(c.deps, c.noFetch)
+	noteMissingRemote(c.deps, c.git())
 
 	statuses, err := c.checkWorktrees()
 	if err != nil {
 		return err
 	}
+	c.keepNewest(statuses)
+	c.summary = &cleanSummary{DryRun: c.dryRun, Removed: []cleanResult{}, Skipped: []cleanResult{}, Failed: []cleanResult{}}
+	for _, status := range filterStatuses(statuses, false) {
+		result := newCleanResult(status)
+		result.Reasons = status.Warnings
+		c.summary.Skipped = append(c.summary.Skipped, result)
+	}
 
 	// Display results
 	c.displayResults(statuses)
 
-	// Count removable worktrees
-	removableCount := 0
-	for _, status := range statuses {
-		if status.CanRemove {
-			removableCount++
-		}
+	if c.interactive && !c.dryRun {
+		return c.executeInteractive(statuses)
 	}
 
-	if removableCount == 0 {
-		fmt.Fprintf(c.deps.Stdout, "\nNo worktrees to remove.\n")
+	removable := filterStatuses(statuses, true)
+	if len(removable) == 0 {
+		fmt.Fprintf(c.deps.Stdout, "\n%s\n", i18n.T(i18n.MsgCleanNothing))
 		return nil
 	}
 
 	// If dry-run, stop here
 	if c.dryRun {
-		fmt.Fprintf(c.deps.Stdout, "\nDry-run mode: no changes made.\n")
+		for _, status := range removable {
+			c.summary.Removable = append(c.summary.Removable, newCleanResult(status))
+		}
+		fmt.Fprintf(c.deps.Stdout, "\n%s\n", i18n.T(i18n.MsgCleanDryRun))
 		return nil
 	}
 
 	// Ask for confirmation unless forced
 	if !c.force {
-		var prompt string
-		if removableCount == 1 {
-			prompt = "\nRemove 1 worktree? (y/N): "
-		} else {
-			prompt = fmt.Sprintf("\nRemove %d worktrees? (y/N): ", removableCount)
+		confirmed, err := c.deps.UI.ConfirmPrompt(c.confirmationPrompt(statuses))
+		if err != nil {
+			return fmt.Errorf("failed to read response: %w", err)
+		}
+
+		if !confirmed {
+			fmt.Fprintln(c.deps.Stdout, i18n.T(i18n.MsgAborted))
+			return errAborted
 		}
+	}
+
+	// Remove worktrees
+	return c.removeWorktrees(removable)
+}
+
+// executeBranchOnly handles --remove-branch-only: it offers to delete the
+// local branches that are merged to the default branch but no longer have a
+// worktree, typically left behind by gw end or gw clean while
+// auto_remove_branch was off. Branches are deleted with git branch -d, so one
+// git does not consider merged is kept.
+func (c *CleanCommand) executeBranchOnly() error {
+	fetchIfConfigured(c.deps, c.noFetch)
+
+	orphans, err := c.orphanedBranches()
+	if err != nil {
+		return err
+	}
+	if len(orphans) == 0 {
+		fmt.Fprintln(c.deps.Stdout, "No merged branches without a worktree.")
+		return nil
+	}
 
+	fmt.Fprintf(c.deps.Stdout, "\n%s Merged branches without a worktree (%d)\n", coloredSuccess(), len(orphans))
+	for _, branch := range orphans {
+		fmt.Fprintf(c.deps.Stdout, "  %s\n", branch)
+	}
+
+	if c.dryRun {
+		fmt.Fprintf(c.deps.Stdout, "\n%s\n", i18n.T(i18n.MsgCleanDryRun))
+		return nil
+	}
+	if !c.force {
+		prompt := fmt.Sprintf("\nWill delete %d %s. Continue? (y/N): ", len(orphans), plural(len(orphans), "branch", "branches"))
 		confirmed, err := c.deps.UI.ConfirmPrompt(prompt)
 		if err != nil {
 			return fmt.Errorf("failed to read response: %w", err)
 		}
-
 		if !confirmed {
-			fmt.Fprintf(c.deps.Stdout, "Aborted.\n")
-			return nil
+			fmt.Fprintln(c.deps.Stdout, i18n.T(i18n.MsgAborted))
+			return errAborted
 		}
 	}
 
-	// Remove worktrees
-	return c.removeWorktrees(statuses)
+	failed := 0
+	for _, branch := range orphans {
+		if err := c.git().DeleteBranch(branch, false); err != nil {
+			fmt.Fprintf(c.deps.Stderr, "%s Could not delete branch %s: %v\n", coloredWarning(), branch, err)
+			failed++
+			continue
+		}
+		fmt.Fprintf(c.deps.Stdout, "%s Deleted branch %s\n", coloredSuccess(), branch)
+		deleteRemoteBranchIfConfigured(c.deps, c.git(), branch, c.deleteRemote)
+	}
+	if failed > 0 {
+		return fmt.Errorf("failed to delete %d branch(es)", failed)
+	}
+	return nil
+}
+
+// orphanedBranches returns the local branches, other than the protected
+// integration branches, that no worktree has checked out and that are merged
+// to the default branch.
+func (c *CleanCommand) orphanedBranches() ([]string, error) {
+	worktrees, err := c.git().ListWorktrees()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list worktrees: %w", err)
+	}
+	checkedOut := make(map[string]bool, len(worktrees))
+	for _, wt := range worktrees {
+		if wt.Branch != "" {
+			checkedOut[wt.Branch] = true
+		}
+	}
+
+	branches, err := c.git().ListLocalBranches()
+	if err != nil {
+		return nil, err
+	}
+	var orphans []string
+	for _, branch := range branches {
+		if checkedOut[branch] || isProtectedBranch(branch) {
+			continue
+		}
+		merged, err := c.git().IsMergedToBaseBranch("", branch, defaultBaseBranch)
+		if err != nil {
+			fmt.Fprintf(c.deps.Stderr, "%s Could not check whether %s is merged: %v\n", coloredWarning(), branch, err)
+			continue
+		}
+		if merged {
+			orphans = append(orphans, branch)
+		}
+	}
+	return orphans, nil
+}
+
+// executeInteractive lets the user pick exactly which worktrees to remove from
+// a multi-select list. Worktrees that failed the safety checks are offered
+// too (listed after the removable ones, with their reasons), but removing any
+// of them requires a second explicit confirmation unless --force is set.
+func (c *CleanCommand) executeInteractive(statuses []*WorktreeStatus) error {
+	if len(statuses) == 0 {
+		fmt.Fprintf(c.deps.Stdout, "\n%s\n", i18n.T(i18n.MsgCleanNothing))
+		return nil
+	}
+
+	ordered := filterStatuses(statuses, true)
+	ordered = append(ordered, filterStatuses(statuses, false)...)
+	byPath := make(map[string]*WorktreeStatus, len(ordered))
+	items := make([]ui.SelectorItem, len(ordered))
+	for i, status := range ordered {
+		name := fmt.Sprintf("%s (%s)", filepath.Base(status.Info.Path), status.Info.Branch)
+		if !status.CanRemove {
+			name += " " + activeTheme.dash + " " + strings.Join(status.Warnings, ", ")
+		}
+		items[i] = ui.SelectorItem{ID: status.Info.Path, Name: name}
+		byPath[status.Info.Path] = status
+	}
+
+	selected, err := c.deps.UI.ShowMultiSelector("Select worktrees to remove:", items)
+	if err != nil {
+		return err
+	}
+
+	var safe, unsafe []*WorktreeStatus
+	for _, item := range selected {
+		status := byPath[item.ID]
+		if status.CanRemove {
+			safe = append(safe, status)
+		} else {
+			unsafe = append(unsafe, status)
+		}
+	}
+
+	if len(unsafe) > 0 && !c.force {
+		forced, err := c.confirmUnsafeRemoval(unsafe)
+		if err != nil {
+			return err
+		}
+		if !forced {
+			fmt.Fprintf(c.deps.Stdout, "Skipping worktrees that failed safety checks.\n")
+			unsafe = nil
+		}
+	}
+
+	safe = append(safe, unsafe...)
+	if len(safe) == 0 {
+		fmt.Fprintf(c.deps.Stdout, "\n%s\n", i18n.T(i18n.MsgCleanNoneSelected))
+		return nil
+	}
+
+	return c.removeWorktrees(safe)
+}
+
+// confirmUnsafeRemoval lists the selected worktrees that failed the safety
+// checks and asks whether to remove them anyway.
+func (c *CleanCommand) confirmUnsafeRemoval(unsafe []*WorktreeStatus) (bool, error) {
+	fmt.Fprintf(c.deps.Stderr, "\n%s The following selected worktrees failed safety checks:\n", coloredWarning())
+	for _, status := range unsafe {
+		fmt.Fprintf(c.deps.Stderr, "  %s %s (%s): %s\n", activeTheme.bullet,
+			filepath.Base(status.Info.Path), status.Info.Branch, strings.Join(status.Warnings, ", "))
+	}
+
+	confirmed, err := c.deps.UI.ConfirmPrompt("\nForce removal of these worktrees? (y/N): ")
+	if err != nil {
+		return false, fmt.Errorf("failed to read response: %w", err)
+	}
+	return confirmed, nil
+}
+
+// filterStatuses returns the statuses whose CanRemove equals canRemove,
+// preserving order.
+func filterStatuses(statuses []*WorktreeStatus, canRemove bool) []*WorktreeStatus {
+	filtered := []*WorktreeStatus{}
+	for _, status := range statuses {
+		if status.CanRemove == canRemove {
+			filtered = append(filtered, status)
+		}
+	}
+	return filtered
+}
+
+// confirmationPrompt builds the final confirmation prompt. It restates how
+// many worktrees will be removed and, when auto_remove_branch is enabled, which
+// branches will be deleted along with them, so the user sees the full extent
+// of the operation right where they answer.
+func (c *CleanCommand) confirmationPrompt(statuses []*WorktreeStatus) string {
+	worktreeCount := 0
+	var branches []string
+	for _, status := range statuses {
+		if !status.CanRemove {
+			continue
+		}
+		worktreeCount++
+		if (c.deps.Config.AutoRemoveBranch || c.forceDelete) && status.Info.Branch != "" {
+			branches = append(branches, status.Info.Branch)
+		}
+	}
+
+	recap := fmt.Sprintf("Will remove %d %s", worktreeCount, plural(worktreeCount, "worktree", "worktrees"))
+	if len(branches) > 0 {
+		recap += fmt.Sprintf(" and delete %d %s (%s)",
+			len(branches), plural(len(branches), "branch", "branches"), strings.Join(branches, ", "))
+	}
+	return fmt.Sprintf("\n%s. Continue? (y/N): ", recap)
+}
+
+// plural returns singular when n is 1 and pluralForm otherwise.
+func plural(n int, singular, pluralForm string) string {
+	if n == 1 {
+		return singular
+	}
+	return pluralForm
 }
 
 // checkWorktrees lists worktrees, filters out protected/branchless ones, and
@@ -148,72 +462,134 @@ func (c *CleanCommand) checkWorktrees() ([]*WorktreeStatus, error) {
 		candidates = append(candidates, wt)
 	}
 
+	// Locked and excluded worktrees are kept whatever their state, so they
+	// are not checked.
 	statuses := make([]*WorktreeStatus, len(candidates))
-	sp := spinner.New("Checking worktrees...", c.deps.Stdout)
-	sp.Start()
-	// Bound concurrency: each check forks three `git` subprocesses, so
+	toCheck := make([]int, 0, len(candidates))
+	for i := range candidates {
+		if candidates[i].IsLocked {
+			statuses[i] = &WorktreeStatus{Info: &candidates[i], Warnings: []string{"locked" + lockReasonSuffix(candidates[i].LockReason)}}
+			continue
+		}
+		if pattern, excluded := c.excludedBy(candidates[i].Branch); excluded {
+			statuses[i] = &WorktreeStatus{Info: &candidates[i], Warnings: []string{excludedReasonPrefix + pattern}}
+			continue
+		}
+		toCheck = append(toCheck, i)
+	}
+
+	progress := c.newCheckProgress(len(toCheck))
+	// Bound concurrency: each check forks four `git` subprocesses, so
 	// unbounded fan-out over a large worktree count could exhaust file
 	// descriptors and saturate the disk.
-	sem := make(chan struct{}, cleanCheckConcurrency)
+	sem := make(chan struct{}, checkConcurrency(c.deps.Config))
 	var wg sync.WaitGroup
-	wg.Add(len(candidates))
-	for i := range candidates {
+	wg.Add(len(toCheck))
+	for _, i := range toCheck {
 		go func(idx int) {
 			defer wg.Done()
 			sem <- struct{}{}
 			defer func() { <-sem }()
+			progress(&candidates[idx])
 			statuses[idx] = c.checkWorktree(&candidates[idx])
 		}(i)
 	}
 	wg.Wait()
-	sp.Stop()
+	progress(nil)
 
 	return statuses, nil
 }
 
-// checkWorktree checks if a worktree can be safely removed.
-func (c *CleanCommand) checkWorktree(info *git.WorktreeInfo) *WorktreeStatus {
-	status := &WorktreeStatus{
-		Info:      info,
-		CanRemove: true,
-		Warnings:  []string{},
+// excludedReasonPrefix starts the reason given for a worktree matched by
+// --exclude; the matching pattern follows.
+const excludedReasonPrefix = "excluded by pattern "
+
+// excludedBy returns the first --exclude pattern that branch matches, if any.
+// Patterns use path.Match syntax, so "spike/*" matches spike/foo but not
+// spike/foo/bar.
+func (c *CleanCommand) excludedBy(branch string) (string, bool) {
+	for _, pattern := range c.exclude {
+		if matched, _ := path.Match(pattern, branch); matched {
+			return pattern, true
+		}
 	}
+	return "", false
+}
 
-	res := runSafetyChecks(c.git(), info.Path, info.Branch, defaultBaseBranch)
+// keepNewest marks the c.keep worktrees with the most recent last commit as
+// not removable, whatever their safety check said. A worktree whose last
+// commit cannot be read counts as the oldest. Worktrees excluded by --exclude
+// are kept anyway and do not use up any of the N.
+func (c *CleanCommand) keepNewest(statuses []*WorktreeStatus) {
+	if c.keep == 0 {
+		return
+	}
+	statuses = slices.DeleteFunc(slices.Clone(statuses), func(status *WorktreeStatus) bool {
+		_, excluded := c.excludedBy(status.Info.Branch)
+		return excluded
+	})
 
-	// A broken or missing worktree (git exit 128) — surface a single clear
-	// reason instead of three meaningless ones.
-	if res.InvalidRepo {
-		status.Warnings = append(status.Warnings, "invalid git repository")
-		status.CanRemove = false
-		return status
+	lastCommit := make(map[*WorktreeStatus]time.Time, len(statuses))
+	for _, status := range statuses {
+		if details, err := c.git().GetWorktreeDetails(status.Info.Path); err == nil {
+			lastCommit[status] = details.LastCommit.Date
+		}
 	}
 
-	if res.Uncommitted.Err != nil {
-		status.Warnings = append(status.Warnings, fmt.Sprintf("Could not check uncommitted changes: %v", res.Uncommitted.Err))
-		status.CanRemove = false
-	} else if res.Uncommitted.Tripped {
-		status.Warnings = append(status.Warnings, "uncommitted changes")
+	newest := append([]*WorktreeStatus(nil), statuses...)
+	sort.SliceStable(newest, func(i, j int) bool {
+		return lastCommit[newest[i]].After(lastCommit[newest[j]])
+	})
+	for _, status := range newest[:min(c.keep, len(newest))] {
 		status.CanRemove = false
+		status.Warnings = append(status.Warnings, fmt.Sprintf("kept by --keep %d (last commit %s)",
+			c.keep, formatLastCommitDate(lastCommit[status])))
 	}
+}
 
-	if res.Unpushed.Err != nil {
-		status.Warnings = append(status.Warnings, fmt.Sprintf("Could not check unpushed commits: %v", res.Unpushed.Err))
-		status.CanRemove = false
-	} else if res.Unpushed.Tripped {
-		status.Warnings = append(status.Warnings, "unpushed commits")
-		status.CanRemove = false
+// formatLastCommitDate renders a last commit date for the clean output, or
+// "unknown" when it could not be read.
+func formatLastCommitDate(date time.Time) string {
+	if date.IsZero() {
+		return "unknown"
 	}
+	return date.Format("2006-01-02")
+}
 
-	if res.Merged.Err != nil {
-		status.Warnings = append(status.Warnings, fmt.Sprintf("Could not check merge status: %v", res.Merged.Err))
-		status.CanRemove = false
-	} else if res.Merged.Tripped {
-		status.Warnings = append(status.Warnings, "not merged")
-		status.CanRemove = false
+// newCheckProgress starts a "Checking worktree 3/15: <dir>" spinner for the
+// safety checks and returns a callback to report each worktree as its check
+// begins; calling it with nil stops the spinner. The spinner is only shown on
+// a terminal and not with --quiet, so piped output stays clean.
+func (c *CleanCommand) newCheckProgress(total int) func(info *git.WorktreeInfo) {
+	if c.quiet || total == 0 || !spinner.IsTerminal(c.deps.Stdout) {
+		return func(*git.WorktreeInfo) {}
+	}
+
+	sp := spinner.New(fmt.Sprintf("Checking %d worktrees...", total), c.deps.Stdout)
+	sp.Start()
+
+	var mu sync.Mutex
+	started := 0
+	return func(info *git.WorktreeInfo) {
+		mu.Lock()
+		defer mu.Unlock()
+		if info == nil {
+			sp.Stop()
+			return
+		}
+		started++
+		sp.UpdateMessage(fmt.Sprintf("Checking worktree %d/%d: %s", started, total, filepath.Base(info.Path)))
 	}
+}
 
-	return status
+// checkWorktree checks if a worktree can be safely removed.
+func (c *CleanCommand) checkWorktree(info *git.WorktreeInfo) *WorktreeStatus {
+	canRemove, warnings := EvaluateWorktreeSafety(c.git(), info.Path, info.Branch, defaultBaseBranch)
+	return &WorktreeStatus{
+		Info:      info,
+		CanRemove: canRemove,
+		Warnings:  warnings,
+	}
 }
 
 // displayResults displays the status of all worktrees
@@ -247,7 +623,11 @@ func (c *CleanCommand) displayResults(statuses []*WorktreeStatus) {
 			}
 			dirName := filepath.Base(status.Info.Path)
 			fmt.Fprintf(c.deps.Stdout, "  %s (%s)\n", dirName, status.Info.Branch)
-			if len(status.Warnings) > 0 {
+			if c.explain {
+				for _, warning := range status.Warnings {
+					fmt.Fprintf(c.deps.Stdout, "    %s %s: %s\n", coloredArrow(), warning, cleanRemediation(warning))
+				}
+			} else if len(status.Warnings) > 0 {
 				reasons := strings.Join(status.Warnings, ", ")
 				fmt.Fprintf(c.deps.Stdout, "    %s %s\n", coloredArrow(), reasons)
 			}
@@ -255,10 +635,58 @@ func (c *CleanCommand) displayResults(statuses []*WorktreeStatus) {
 	}
 }
 
-// removeWorktrees removes all removable worktrees
+// cleanRemediation returns the next step that would make a worktree kept for
+// the given reason removable, for --explain. The reasons are the ones
+// safetyWarnings, keepNewest, --exclude and locked worktrees produce.
+func cleanRemediation(warning string) string {
+	switch {
+	case warning == "uncommitted changes":
+		return "commit or stash them (git status shows what changed)"
+	case warning == "unpushed commits":
+		return "run `git push` in the worktree"
+	case strings.HasPrefix(warning, "not merged to "):
+		return fmt.Sprintf("merge it to %s, e.g. through its pull request, or remove it with gw end --merge",
+			strings.TrimPrefix(warning, "not merged to "))
+	case strings.HasSuffix(warning, " in progress"):
+		operation := strings.TrimSuffix(warning, " in progress")
+		return fmt.Sprintf("finish it with `git %[1]s --continue` or give it up with `git %[1]s --abort`", operation)
+	case warning == "invalid git repository":
+		return "run `git worktree prune` if the directory was deleted, or gw reattach if it was moved"
+	case strings.HasPrefix(warning, "kept by --keep"):
+		return "lower --keep to let it go"
+	case strings.HasPrefix(warning, excludedReasonPrefix):
+		return "drop that --exclude pattern to let it go"
+	case warning == "locked" || strings.HasPrefix(warning, "locked ("):
+		return "unlock it with `git worktree unlock` once it is no longer needed"
+	case strings.HasPrefix(warning, "Could not check"):
+		return "fix the git error and run gw clean again"
+	default:
+		return "resolve it and run gw clean again"
+	}
+}
+
+// removeWorktrees removes every worktree in statuses; callers pass only the
+// worktrees that were chosen for removal. Removing the worktree the current
+// directory is in needs a confirmation of its own (see guardCurrentDir).
 func (c *CleanCommand) removeWorktrees(statuses []*WorktreeStatus) error {
+	statuses, current, mainRoot, err := c.guardCurrentDir(statuses)
+	if err != nil {
+		return err
+	}
+	if len(statuses) == 0 {
+		fmt.Fprintln(c.deps.Stdout, i18n.T(i18n.MsgAborted))
+		return errAborted
+	}
+	if current != nil {
+		// git cannot run from inside a directory that is being removed.
+		if err := os.Chdir(mainRoot); err != nil {
+			return fmt.Errorf("failed to change to the main worktree: %w", err)
+		}
+	}
+
 	successCount := 0
 	failCount := 0
+	removedCurrent := false
 
 	var repoName string
 	if c.deps.Config.PreEndHook != "" {
@@ -266,10 +694,6 @@ func (c *CleanCommand) removeWorktrees(statuses []*WorktreeStatus) error {
 	}
 
 	for _, status := range statuses {
-		if !status.CanRemove {
-			continue
-		}
-
 		dirName := filepath.Base(status.Info.Path)
 
 		// Run pre-end hook from inside the worktree before it gets removed.
@@ -285,33 +709,143 @@ func (c *CleanCommand) removeWorktrees(statuses []*WorktreeStatus) error {
 		if removeErr != nil {
 			fmt.Fprintf(c.deps.Stderr, "%s Failed to remove %s: %v\n", coloredError(), dirName, removeErr)
 			failCount++
+			c.recordFailed(status, removeErr)
 			continue
 		}
+		c.recordRemoved(status)
 
-		fmt.Fprintf(c.deps.Stdout, "%s Removed %s\n", coloredSuccess(), dirName)
+		fmt.Fprintf(c.deps.Stdout, "%s %s\n", coloredSuccess(), i18n.T(i18n.MsgCleanRemoved, dirName))
 		successCount++
+		removedCurrent = removedCurrent || status == current
 
-		// Delete the branch if auto-remove is enabled
-		if c.deps.Config.AutoRemoveBranch && status.Info.Branch != "" {
-			fmt.Fprintf(c.deps.Stdout, "Deleting branch %s...\n", status.Info.Branch)
-			if err := c.git().DeleteBranch(status.Info.Branch); err != nil {
-				// Don't fail the command, just warn
-				fmt.Fprintf(c.deps.Stderr, "%s Failed to delete branch %s: %v\n", coloredWarning(), status.Info.Branch, err)
-			} else {
-				fmt.Fprintf(c.deps.Stdout, "%s Deleted branch %s\n", coloredSuccess(), status.Info.Branch)
-			}
+		if status.Info.Branch != "" {
+			c.deleteBranch(status.Info.Branch)
 		}
 	}
 
 	// Summary
 	fmt.Fprintf(c.deps.Stdout, "\n")
 	if successCount > 0 {
-		fmt.Fprintf(c.deps.Stdout, "%s Successfully removed %d worktree(s)\n", coloredSuccess(), successCount)
+		fmt.Fprintf(c.deps.Stdout, "%s %s\n", coloredSuccess(), i18n.T(i18n.MsgCleanRemovedTotal, successCount))
+	}
+	if removedCurrent {
+		c.leaveRemovedDir(mainRoot)
 	}
 	if failCount > 0 {
-		fmt.Fprintf(c.deps.Stderr, "%s Failed to remove %d worktree(s)\n", coloredError(), failCount)
+		fmt.Fprintf(c.deps.Stderr, "%s %s\n", coloredError(), i18n.T(i18n.MsgCleanFailedTotal, failCount))
 		return fmt.Errorf("failed to remove %d worktree(s)", failCount)
 	}
 
 	return nil
 }
+
+// recordRemoved adds a removed worktree to the summary.
+func (c *CleanCommand) recordRemoved(status *WorktreeStatus) {
+	if c.summary != nil {
+		c.summary.Removed = append(c.summary.Removed, newCleanResult(status))
+	}
+}
+
+// recordFailed adds a worktree that could not be removed to the summary.
+func (c *CleanCommand) recordFailed(status *WorktreeStatus, err error) {
+	if c.summary != nil {
+		result := newCleanResult(status)
+		result.Error = err.Error()
+		c.summary.Failed = append(c.summary.Failed, result)
+	}
+}
+
+// guardCurrentDir finds the worktree in statuses that the current directory
+// is in, if any, and unless --force asks once more before removing it, since
+// that deletes the directory the user's shell is standing in. A declined
+// worktree is dropped from the returned statuses. current is the worktree
+// still set for removal, and mainRoot the main worktree to move to then.
+func (c *CleanCommand) guardCurrentDir(statuses []*WorktreeStatus) (kept []*WorktreeStatus, current *WorktreeStatus, mainRoot string, err error) {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return statuses, nil, "", nil
+	}
+	for _, status := range statuses {
+		if isWithinDir(cwd, status.Info.Path) {
+			current = status
+			break
+		}
+	}
+	if current == nil {
+		return statuses, nil, "", nil
+	}
+
+	if !c.force {
+		dirName := filepath.Base(current.Info.Path)
+		fmt.Fprintf(c.deps.Stderr, "\n%s You are inside %s: removing it deletes your current directory.\n", coloredWarning(), dirName)
+		confirmed, err := c.deps.UI.ConfirmPrompt(fmt.Sprintf("Remove %s as well? (y/N): ", dirName))
+		if err != nil {
+			return nil, nil, "", fmt.Errorf("failed to read response: %w", err)
+		}
+		if !confirmed {
+			fmt.Fprintf(c.deps.Stdout, "Keeping %s.\n", dirName)
+			kept = make([]*WorktreeStatus, 0, len(statuses)-1)
+			for _, status := range statuses {
+				if status != current {
+					kept = append(kept, status)
+				}
+			}
+			return kept, nil, "", nil
+		}
+	}
+
+	mainRoot, err = c.git().GetMainRepositoryRoot()
+	if err != nil {
+		return nil, nil, "", fmt.Errorf("failed to get main repository root: %w", err)
+	}
+	return statuses, current, mainRoot, nil
+}
+
+// leaveRemovedDir sends the shell, whose current directory was just removed,
+// to the main worktree when shell integration is active, and otherwise tells
+// the user where to go.
+func (c *CleanCommand) leaveRemovedDir(mainRoot string) {
+	if os.Getenv(envCDFile) != "" {
+		writeCDFile(c.deps, "", mainRoot)
+		return
+	}
+	fmt.Fprintf(c.deps.Stdout, "%s Your current directory was removed: cd %s\n", coloredWarning(), mainRoot)
+}
+
+// isWithinDir reports whether path is dir or inside it. Symlinks are resolved
+// first, so a path through a symlinked temp or home directory still matches.
+func isWithinDir(path, dir string) bool {
+	path, dir = canonicalDir(path), canonicalDir(dir)
+	rel, err := filepath.Rel(dir, path)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+// canonicalDir resolves symlinks in dir, falling back to dir itself.
+func canonicalDir(dir string) string {
+	if resolved, err := filepath.EvalSymlinks(dir); err == nil {
+		return resolved
+	}
+	return filepath.Clean(dir)
+}
+
+// deleteBranch deletes the local branch of a removed worktree when
+// auto_remove_branch is enabled or --force-delete-branch was given, then the
+// remote branch when requested. A local branch that could not be deleted
+// keeps its remote counterpart too.
+func (c *CleanCommand) deleteBranch(branch string) {
+	if c.deps.Config.AutoRemoveBranch || c.forceDelete {
+		fmt.Fprintln(c.deps.Stdout, i18n.T(i18n.MsgDeletingBranch, branch))
+		if err := c.git().DeleteBranch(branch, c.forceDelete); err != nil {
+			// Don't fail the command, just warn
+			warnBranchNotDeleted(c.deps, branch, err)
+			return
+		}
+		if c.forceDelete {
+			fmt.Fprintf(c.deps.Stdout, "%s Force-deleted branch %s, including any unmerged commits\n", coloredSuccess(), branch)
+		} else {
+			fmt.Fprintf(c.deps.Stdout, "%s Deleted branch %s\n", coloredSuccess(), branch)
+		}
+	}
+
+	deleteRemoteBranchIfConfigured(c.deps, c.git(), branch, c.deleteRemote)
+}
@@ -2,15 +2,23 @@ package cmd
 
 import (
 	"bytes"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"reflect"
+	"runtime"
+	"sort"
 	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/sotarok/gw/internal/config"
 	"github.com/sotarok/gw/internal/git"
+	"github.com/sotarok/gw/internal/ui"
 )
 
 func TestCleanCommand_Execute_NoWorktrees(t *testing.T) {
@@ -218,6 +226,174 @@ func TestCleanCommand_Execute_MixedRemovability(t *testing.T) {
 	}
 }
 
+func TestCleanCommand_Execute_Keep(t *testing.T) {
+	tmpDir := t.TempDir()
+	day := func(d int) time.Time { return time.Date(2026, 10, d, 12, 0, 0, 0, time.UTC) }
+	lastCommit := map[string]time.Time{}
+	var worktrees []git.WorktreeInfo
+	for i, d := range []int{3, 9, 1, 7, 5} {
+		path := filepath.Join(tmpDir, fmt.Sprintf("wt%d", i))
+		os.MkdirAll(path, 0755)
+		lastCommit[path] = day(d)
+		worktrees = append(worktrees, git.WorktreeInfo{Path: path, Branch: fmt.Sprintf("%d/impl", i)})
+	}
+	unmerged := worktrees[1].Path // the newest; it still takes one of the kept slots
+
+	var removed []string
+	mg := &mockGit{
+		ListWorktreesFn:          func() ([]git.WorktreeInfo, error) { return worktrees, nil },
+		HasUncommittedChangesFn:  func() (bool, error) { return false, nil },
+		HasUnpushedCommitsFn:     func() (bool, error) { return false, nil },
+		IsMergedToBaseBranchAtFn: func(path, branch, base string) (bool, error) { return path != unmerged, nil },
+		GetWorktreeDetailsFn: func(path string) (*git.WorktreeDetails, error) {
+			return &git.WorktreeDetails{LastCommit: git.CommitInfo{Date: lastCommit[path]}}, nil
+		},
+		RemoveWorktreeByPathFn: func(path string) error {
+			removed = append(removed, path)
+			return nil
+		},
+	}
+	deps, stdout, _ := newListTestDeps(mg)
+	deps.UI = &mockUI{confirmResult: true}
+
+	cmd := NewCleanCommand(deps, true, false, true, false)
+	cmd.keep = 2
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// wt1 (Oct 9) and wt3 (Oct 7) are the newest two; the rest are removable.
+	want := []string{worktrees[0].Path, worktrees[2].Path, worktrees[4].Path}
+	sort.Strings(removed)
+	if !reflect.DeepEqual(removed, want) {
+		t.Errorf("Removed %v, want %v", removed, want)
+	}
+	output := stdout.String()
+	if !strings.Contains(output, "kept by --keep 2 (last commit 2026-10-07)") {
+		t.Errorf("Expected the kept worktree to be explained, got:\n%s", output)
+	}
+	if !strings.Contains(output, "not merged to main, kept by --keep 2 (last commit 2026-10-09)") {
+		t.Errorf("Expected the unmerged worktree to list both reasons, got:\n%s", output)
+	}
+}
+
+func TestCleanCommand_Execute_KeepNegative(t *testing.T) {
+	deps, _, _ := newListTestDeps(&mockGit{})
+	cmd := NewCleanCommand(deps, true, false, true, false)
+	cmd.keep = -1
+	if err := cmd.Execute(); err == nil || !strings.Contains(err.Error(), "--keep") {
+		t.Errorf("Expected a --keep error, got %v", err)
+	}
+}
+
+func TestCleanCommand_Execute_Exclude(t *testing.T) {
+	tmpDir := t.TempDir()
+	var worktrees []git.WorktreeInfo
+	for _, branch := range []string{"123/impl", "spike/parser", "spike/deep/nested", "experiment/cache", "feature/login"} {
+		path := filepath.Join(tmpDir, strings.ReplaceAll(branch, "/", "-"))
+		os.MkdirAll(path, 0755)
+		worktrees = append(worktrees, git.WorktreeInfo{Path: path, Branch: branch})
+	}
+
+	var removed, checked []string
+	mg := &mockGit{
+		ListWorktreesFn:         func() ([]git.WorktreeInfo, error) { return worktrees, nil },
+		HasUncommittedChangesFn: func() (bool, error) { return false, nil },
+		HasUnpushedCommitsFn:    func() (bool, error) { return false, nil },
+		IsMergedToBaseBranchAtFn: func(path, branch, base string) (bool, error) {
+			checked = append(checked, branch)
+			return true, nil
+		},
+		RemoveWorktreeByPathFn: func(path string) error {
+			removed = append(removed, path)
+			return nil
+		},
+	}
+	deps, stdout, _ := newListTestDeps(mg)
+	deps.Config = &config.Config{MaxParallelChecks: 1}
+
+	cmd := NewCleanCommand(deps, true, false, true, false)
+	cmd.exclude = []string{"spike/*", "experiment/*"}
+	cmd.jsonOutput = true
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// "*" does not match across "/", so spike/deep/nested is not excluded.
+	want := []string{worktrees[0].Path, worktrees[2].Path, worktrees[4].Path}
+	sort.Strings(removed)
+	sort.Strings(want)
+	if !reflect.DeepEqual(removed, want) {
+		t.Errorf("Removed %v, want %v", removed, want)
+	}
+	for _, branch := range checked {
+		if branch == "spike/parser" || branch == "experiment/cache" {
+			t.Errorf("Expected excluded branch %s not to be checked", branch)
+		}
+	}
+
+	var summary cleanSummary
+	if err := json.Unmarshal(stdout.Bytes(), &summary); err != nil {
+		t.Fatalf("Invalid JSON: %v\n%s", err, stdout.String())
+	}
+	skipped := map[string][]string{}
+	for _, result := range summary.Skipped {
+		skipped[result.Branch] = result.Reasons
+	}
+	wantSkipped := map[string][]string{
+		"spike/parser":     {"excluded by pattern spike/*"},
+		"experiment/cache": {"excluded by pattern experiment/*"},
+	}
+	if !reflect.DeepEqual(skipped, wantSkipped) {
+		t.Errorf("Skipped %v, want %v", skipped, wantSkipped)
+	}
+}
+
+func TestCleanCommand_Execute_SkipsLocked(t *testing.T) {
+	tmpDir := t.TempDir()
+	unlocked := filepath.Join(tmpDir, "wt1")
+	locked := filepath.Join(tmpDir, "wt2")
+	os.MkdirAll(unlocked, 0755)
+	os.MkdirAll(locked, 0755)
+
+	var removed []string
+	mg := &mockGit{
+		ListWorktreesFn: func() ([]git.WorktreeInfo, error) {
+			return []git.WorktreeInfo{
+				{Path: unlocked, Branch: testBranch123},
+				{Path: locked, Branch: "456/impl", IsLocked: true, LockReason: "long-lived"},
+			}, nil
+		},
+		HasUncommittedChangesFn:  func() (bool, error) { return false, nil },
+		HasUnpushedCommitsFn:     func() (bool, error) { return false, nil },
+		IsMergedToBaseBranchAtFn: func(path, branch, base string) (bool, error) { return true, nil },
+		RemoveWorktreeByPathFn: func(path string) error {
+			removed = append(removed, path)
+			return nil
+		},
+	}
+	deps, stdout, _ := newListTestDeps(mg)
+
+	if err := NewCleanCommand(deps, true, false, true, false).Execute(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(removed, []string{unlocked}) {
+		t.Errorf("Removed %v, want only %s", removed, unlocked)
+	}
+	if !strings.Contains(stdout.String(), "locked (long-lived)") {
+		t.Errorf("Expected the locked worktree to be kept with its reason, got:\n%s", stdout.String())
+	}
+}
+
+func TestCleanCommand_Execute_ExcludeInvalidPattern(t *testing.T) {
+	deps, _, _ := newListTestDeps(&mockGit{})
+	cmd := NewCleanCommand(deps, true, false, true, false)
+	cmd.exclude = []string{"spike/["}
+	if err := cmd.Execute(); err == nil || !strings.Contains(err.Error(), `invalid --exclude pattern "spike/["`) {
+		t.Errorf("Expected an invalid pattern error, got %v", err)
+	}
+}
+
 func TestCleanCommand_Execute_DryRun(t *testing.T) {
 	stdout := &bytes.Buffer{}
 	stderr := &bytes.Buffer{}
@@ -334,8 +510,8 @@ func TestCleanCommand_Execute_UserDeclines(t *testing.T) {
 
 	err := cmd.Execute()
 
-	if err != nil {
-		t.Errorf("Expected no error, got: %v", err)
+	if !errors.Is(err, errAborted) {
+		t.Errorf("Expected abort error, got: %v", err)
 	}
 
 	output := stdout.String()
@@ -445,7 +621,10 @@ func TestCleanCommand_Execute_WithBranchDeletion(t *testing.T) {
 			removedPaths = append(removedPaths, path)
 			return nil
 		},
-		DeleteBranchFn: func(branch string) error {
+		DeleteBranchFn: func(branch string, force bool) error {
+			if force {
+				t.Errorf("Expected clean to use a safe (non-force) branch delete for %s", branch)
+			}
 			deletedBranches = append(deletedBranches, branch)
 			return nil
 		},
@@ -493,6 +672,79 @@ func TestCleanCommand_Execute_WithBranchDeletion(t *testing.T) {
 	}
 }
 
+func TestCleanCommand_Execute_ConfirmationRecap(t *testing.T) {
+	tests := []struct {
+		name             string
+		autoRemoveBranch bool
+		expectedPrompt   string
+	}{
+		{
+			name:             "auto_remove_branch disabled",
+			autoRemoveBranch: false,
+			expectedPrompt:   "\nWill remove 2 worktrees. Continue? (y/N): ",
+		},
+		{
+			name:             "auto_remove_branch enabled",
+			autoRemoveBranch: true,
+			expectedPrompt:   "\nWill remove 2 worktrees and delete 2 branches (123/impl, 456/impl). Continue? (y/N): ",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mg := &mockGit{
+				ListWorktreesFn: func() ([]git.WorktreeInfo, error) {
+					return []git.WorktreeInfo{
+						{Path: "/repo", Branch: "main"},
+						{Path: "/repo-123", Branch: testBranch123},
+						{Path: "/repo-456", Branch: "456/impl"},
+					}, nil
+				},
+				RemoveWorktreeByPathFn: func(string) error {
+					t.Error("nothing should be removed when the prompt is declined")
+					return nil
+				},
+			}
+			mu := &mockUI{confirmResult: false}
+
+			deps := &Dependencies{
+				Config: &config.Config{AutoRemoveBranch: tt.autoRemoveBranch},
+				Git:    mg,
+				UI:     mu,
+				Stdout: &bytes.Buffer{},
+				Stderr: &bytes.Buffer{},
+			}
+
+			// The mock declines the prompt, so the run ends as a user abort.
+			if err := NewCleanCommand(deps, false, false, true, false).Execute(); !errors.Is(err, errAborted) {
+				t.Fatalf("Expected abort error, got: %v", err)
+			}
+
+			if mu.confirmMessage != tt.expectedPrompt {
+				t.Errorf("prompt = %q, want %q", mu.confirmMessage, tt.expectedPrompt)
+			}
+			if !tt.autoRemoveBranch && strings.Contains(mu.confirmMessage, "branch") {
+				t.Errorf("prompt must not mention branch deletion when auto_remove_branch is off: %q", mu.confirmMessage)
+			}
+		})
+	}
+}
+
+func TestCleanCommand_ConfirmationPrompt_Singular(t *testing.T) {
+	deps := &Dependencies{Config: &config.Config{AutoRemoveBranch: true}}
+	cmd := NewCleanCommand(deps, false, false, true, false)
+
+	prompt := cmd.confirmationPrompt([]*WorktreeStatus{
+		{Info: &git.WorktreeInfo{Path: "/repo-123", Branch: testBranch123}, CanRemove: true},
+		{Info: &git.WorktreeInfo{Path: "/repo-456", Branch: "456/impl"}, CanRemove: false},
+	})
+
+	expected := "\nWill remove 1 worktree and delete 1 branch (123/impl). Continue? (y/N): "
+	if prompt != expected {
+		t.Errorf("prompt = %q, want %q", prompt, expected)
+	}
+}
+
 func TestCleanCommand_Execute_RemovalError(t *testing.T) {
 	stdout := &bytes.Buffer{}
 	stderr := &bytes.Buffer{}
@@ -688,6 +940,75 @@ func TestCleanCommand_checkWorktree_DeletedWorktreeDir_Integration(t *testing.T)
 	}
 }
 
+// TestCleanCommand_Execute_RebaseInProgress_Integration pauses a rebase in a
+// real worktree that would otherwise be removable (clean, merged) and asserts
+// that even `gw clean --force` keeps it and reports "rebase in progress".
+func TestCleanCommand_Execute_RebaseInProgress_Integration(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	originalDir, _ := os.Getwd()
+	defer os.Chdir(originalDir)
+
+	repo := t.TempDir()
+	runGit := func(dir string, args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", append([]string{"-c", "user.email=test@example.com", "-c", "user.name=Test User"}, args...)...)
+		cmd.Dir = dir
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v failed: %v\n%s", args, err, out)
+		}
+	}
+
+	runGit(repo, "init")
+	runGit(repo, "symbolic-ref", "HEAD", "refs/heads/main")
+	runGit(repo, "commit", "--allow-empty", "-m", "initial")
+
+	wtPath := filepath.Join(t.TempDir(), "wt-feature")
+	runGit(repo, "worktree", "add", wtPath, "-b", "feature/impl")
+	if err := os.WriteFile(filepath.Join(wtPath, "f.txt"), []byte("x"), 0644); err != nil {
+		t.Fatalf("write file: %v", err)
+	}
+	runGit(wtPath, "add", "f.txt")
+	runGit(wtPath, "commit", "-m", "feature")
+	runGit(repo, "merge", "feature/impl")
+
+	// `--exec false` stops the rebase right after replaying the commit,
+	// leaving a clean tree with the rebase still in progress.
+	rebase := exec.Command("git", "rebase", "--exec", "false", "HEAD~1")
+	rebase.Dir = wtPath
+	if err := rebase.Run(); err == nil {
+		t.Fatal("expected the rebase to stop on the failing exec")
+	}
+
+	if err := os.Chdir(repo); err != nil {
+		t.Fatalf("chdir: %v", err)
+	}
+	stdout := &bytes.Buffer{}
+	deps := &Dependencies{
+		Git:    git.NewClient(),
+		UI:     &mockUI{},
+		Config: &config.Config{},
+		Stdout: stdout,
+		Stderr: &bytes.Buffer{},
+	}
+	cmd := NewCleanCommand(deps, true, false, true, false)
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if _, err := os.Stat(wtPath); err != nil {
+		t.Errorf("expected worktree with a rebase in progress to be kept, stat err: %v", err)
+	}
+	output := stdout.String()
+	if !contains(output, "rebase in progress") {
+		t.Errorf("expected 'rebase in progress' reason, got:\n%s", output)
+	}
+	if !contains(output, "No worktrees to remove") {
+		t.Errorf("expected nothing to be removed, got:\n%s", output)
+	}
+}
+
 // Additional CleanCommand tests for uncovered paths
 
 func TestCleanCommand_Execute_ListWorktreesError(t *testing.T) {
@@ -968,12 +1289,12 @@ func TestCleanCommand_CheckWorktree_NotMerged(t *testing.T) {
 	}
 	found := false
 	for _, w := range status.Warnings {
-		if w == "not merged" {
+		if w == "not merged to main" {
 			found = true
 		}
 	}
 	if !found {
-		t.Errorf("Expected 'not merged' warning, got: %v", status.Warnings)
+		t.Errorf("Expected 'not merged to main' warning, got: %v", status.Warnings)
 	}
 }
 
@@ -999,7 +1320,7 @@ func TestCleanCommand_RemoveWorktrees_BranchDeletionError(t *testing.T) {
 		HasUnpushedCommitsFn:    func() (bool, error) { return false, nil },
 		IsMergedToBaseBranchFn:  func(branch string) (bool, error) { return true, nil },
 		RemoveWorktreeByPathFn:  func(path string) error { return nil },
-		DeleteBranchFn: func(branch string) error {
+		DeleteBranchFn: func(branch string, force bool) error {
 			return fmt.Errorf("branch deletion failed")
 		},
 	}
@@ -1029,6 +1350,309 @@ func TestCleanCommand_RemoveWorktrees_BranchDeletionError(t *testing.T) {
 	}
 }
 
+func TestCleanCommand_Execute_DeleteRemoteBranch(t *testing.T) {
+	tests := []struct {
+		name          string
+		configRemote  bool
+		flagRemote    bool
+		failLocal     string // branch whose local (safe) deletion fails
+		expectRemote  []string
+		expectWarning string
+	}{
+		{name: "disabled by default"},
+		{name: "enabled by flag", flagRemote: true, expectRemote: []string{"feature-a", "feature-b"}},
+		{name: "enabled by config", configRemote: true, expectRemote: []string{"feature-a", "feature-b"}},
+		{
+			name:          "kept when local branch is kept",
+			flagRemote:    true,
+			failLocal:     "feature-a",
+			expectRemote:  []string{"feature-b"},
+			expectWarning: "Failed to delete branch feature-a",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tempDir := t.TempDir()
+			wtA := filepath.Join(tempDir, "repo-feature-a")
+			wtB := filepath.Join(tempDir, "repo-feature-b")
+
+			stdout := &bytes.Buffer{}
+			stderr := &bytes.Buffer{}
+			var remoteDeleted []string
+
+			mg := &mockGit{
+				ListWorktreesFn: func() ([]git.WorktreeInfo, error) {
+					return []git.WorktreeInfo{
+						{Path: "/repo", Branch: "main"},
+						{Path: wtA, Branch: "feature-a"},
+						{Path: wtB, Branch: "feature-b"},
+					}, nil
+				},
+				DeleteBranchFn: func(branch string, force bool) error {
+					if branch == tt.failLocal {
+						return fmt.Errorf("branch not fully merged")
+					}
+					return nil
+				},
+				DeleteRemoteBranchFn: func(branch string) error {
+					remoteDeleted = append(remoteDeleted, branch)
+					return nil
+				},
+			}
+
+			deps := &Dependencies{
+				Config: &config.Config{AutoRemoveBranch: true, DeleteRemoteBranch: tt.configRemote},
+				Git:    mg,
+				UI:     &mockUI{confirmResult: true},
+				Stdout: stdout,
+				Stderr: stderr,
+			}
+
+			cmd := NewCleanCommand(deps, false, false, true, false)
+			cmd.deleteRemote = tt.flagRemote
+			if err := cmd.Execute(); err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+
+			if len(remoteDeleted) != len(tt.expectRemote) ||
+				(len(tt.expectRemote) > 0 && !reflect.DeepEqual(remoteDeleted, tt.expectRemote)) {
+				t.Errorf("Expected remote deletions %v, got %v", tt.expectRemote, remoteDeleted)
+			}
+			if tt.expectWarning != "" && !strings.Contains(stderr.String(), tt.expectWarning) {
+				t.Errorf("Expected stderr to contain %q, got: %s", tt.expectWarning, stderr.String())
+			}
+		})
+	}
+}
+
+func TestCleanCommand_Execute_ForceDeleteBranch(t *testing.T) {
+	for _, forceDelete := range []bool{false, true} {
+		t.Run(fmt.Sprintf("force-delete-branch=%v", forceDelete), func(t *testing.T) {
+			var deleted []string
+			mg := &mockGit{
+				ListWorktreesFn: func() ([]git.WorktreeInfo, error) {
+					return []git.WorktreeInfo{
+						{Path: "/repo", Branch: "main"},
+						{Path: "/repo-feature-a", Branch: "feature-a"},
+					}, nil
+				},
+				// Simulate an unmerged branch: git branch -d refuses it.
+				DeleteBranchFn: func(branch string, force bool) error {
+					if !force {
+						return fmt.Errorf("the branch '%s' is not fully merged", branch)
+					}
+					deleted = append(deleted, branch)
+					return nil
+				},
+			}
+			stdout := &bytes.Buffer{}
+			stderr := &bytes.Buffer{}
+			deps := &Dependencies{
+				Config: &config.Config{AutoRemoveBranch: true},
+				Git:    mg,
+				UI:     &mockUI{confirmResult: true},
+				Stdout: stdout,
+				Stderr: stderr,
+			}
+
+			cmd := NewCleanCommand(deps, false, false, true, false)
+			cmd.forceDelete = forceDelete
+			if err := cmd.Execute(); err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+
+			if forceDelete {
+				if !reflect.DeepEqual(deleted, []string{"feature-a"}) {
+					t.Errorf("Expected feature-a to be force-deleted, got %v", deleted)
+				}
+				if !strings.Contains(stdout.String(), "Force-deleted branch feature-a") {
+					t.Errorf("Expected force-deletion notice, got:\n%s", stdout.String())
+				}
+				return
+			}
+			if len(deleted) != 0 {
+				t.Errorf("Expected the unmerged branch to be kept, got deletions %v", deleted)
+			}
+			if !strings.Contains(stderr.String(), "use --force-delete-branch") {
+				t.Errorf("Expected a --force-delete-branch hint, got:\n%s", stderr.String())
+			}
+		})
+	}
+}
+
+func TestCleanCommand_Execute_RemoveBranchOnly(t *testing.T) {
+	var deleted []string
+	var prompt string
+	mg := &mockGit{
+		ListWorktreesFn: func() ([]git.WorktreeInfo, error) {
+			return []git.WorktreeInfo{
+				{Path: "/repo", Branch: "main"},
+				{Path: "/repo-123", Branch: testBranch123},
+			}, nil
+		},
+		ListLocalBranchesFn: func() ([]string, error) {
+			return []string{"main", testBranch123, "45/impl", "wip"}, nil
+		},
+		IsMergedToBaseBranchAtFn: func(_, branch, _ string) (bool, error) {
+			return branch != "wip", nil
+		},
+		DeleteBranchFn: func(branch string, force bool) error {
+			if force {
+				t.Errorf("Expected a safe delete of %s", branch)
+			}
+			deleted = append(deleted, branch)
+			return nil
+		},
+		RemoveWorktreeByPathFn: func(path string) error {
+			t.Errorf("Expected no worktree to be removed, got %s", path)
+			return nil
+		},
+	}
+	stdout := &bytes.Buffer{}
+	deps := &Dependencies{
+		Config: &config.Config{},
+		Git:    mg,
+		UI: &mockUI{ConfirmPromptFn: func(message string) (bool, error) {
+			prompt = message
+			return true, nil
+		}},
+		Stdout: stdout,
+		Stderr: &bytes.Buffer{},
+	}
+
+	cmd := NewCleanCommand(deps, false, false, true, false)
+	cmd.branchOnly = true
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	// Only the merged branch without a worktree is offered: 123/impl has a
+	// worktree, wip is unmerged, and main never qualifies.
+	if !strings.Contains(stdout.String(), "Merged branches without a worktree (1)\n  45/impl\n") {
+		t.Errorf("Expected 45/impl to be offered, got:\n%s", stdout.String())
+	}
+	if !strings.Contains(prompt, "Will delete 1 branch.") {
+		t.Errorf("Unexpected prompt: %q", prompt)
+	}
+	if !reflect.DeepEqual(deleted, []string{"45/impl"}) {
+		t.Errorf("Expected only 45/impl to be deleted, got %v", deleted)
+	}
+}
+
+func TestCleanCommand_Execute_Explain(t *testing.T) {
+	mg := &mockGit{
+		ListWorktreesFn: func() ([]git.WorktreeInfo, error) {
+			return []git.WorktreeInfo{
+				{Path: "/repo", Branch: "main"},
+				{Path: "/repo-123", Branch: testBranch123},
+			}, nil
+		},
+		HasUnpushedCommitsFn:   func() (bool, error) { return true, nil },
+		IsMergedToBaseBranchFn: func(string) (bool, error) { return false, nil },
+		IsInProgressOperationFn: func(string) (bool, string, error) {
+			return true, "rebase", nil
+		},
+		RemoveWorktreeByPathFn: func(path string) error {
+			t.Errorf("Expected nothing to be removed, got %s", path)
+			return nil
+		},
+	}
+	deps, stdout, _ := newListTestDeps(mg)
+
+	cmd := NewCleanCommand(deps, false, true, true, false)
+	cmd.explain = true
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	for _, want := range []string{
+		"unpushed commits: run `git push` in the worktree\n",
+		"not merged to main: merge it to main, e.g. through its pull request, or remove it with gw end --merge\n",
+		"rebase in progress: finish it with `git rebase --continue` or give it up with `git rebase --abort`\n",
+	} {
+		if !strings.Contains(stdout.String(), want) {
+			t.Errorf("Expected %q in the output, got:\n%s", want, stdout.String())
+		}
+	}
+}
+
+func TestCleanCommand_Execute_JSON(t *testing.T) {
+	mg := &mockGit{
+		ListWorktreesFn: func() ([]git.WorktreeInfo, error) {
+			return []git.WorktreeInfo{
+				{Path: "/repo", Branch: "main"},
+				{Path: "/repo-123", Branch: testBranch123},
+				{Path: "/repo-456", Branch: "456/impl"},
+				{Path: "/repo-789", Branch: "789/impl"},
+			}, nil
+		},
+		HasUncommittedChangesAtFn: func(path string) (bool, error) { return path == "/repo-456", nil },
+		RemoveWorktreeByPathFn: func(path string) error {
+			if path == "/repo-789" {
+				return fmt.Errorf("directory is busy")
+			}
+			return nil
+		},
+	}
+	deps, stdout, stderr := newListTestDeps(mg)
+
+	cmd := NewCleanCommand(deps, true, false, true, false)
+	cmd.jsonOutput = true
+	err := cmd.Execute()
+	if err == nil {
+		t.Fatal("Expected an error for the worktree that failed to be removed")
+	}
+
+	var summary cleanSummary
+	if err := json.Unmarshal(stdout.Bytes(), &summary); err != nil {
+		t.Fatalf("Expected only JSON on stdout, got %v:\n%s", err, stdout.String())
+	}
+	want := cleanSummary{
+		Counts:  cleanCounts{Removed: 1, Skipped: 1, Failed: 1},
+		Removed: []cleanResult{{Path: "/repo-123", Branch: testBranch123}},
+		Skipped: []cleanResult{{Path: "/repo-456", Branch: "456/impl", Reasons: []string{"uncommitted changes"}}},
+		Failed:  []cleanResult{{Path: "/repo-789", Branch: "789/impl", Error: "directory is busy"}},
+	}
+	if !reflect.DeepEqual(summary, want) {
+		t.Errorf("summary = %+v, want %+v", summary, want)
+	}
+	if !strings.Contains(stderr.String(), "Non-removable (1)") {
+		t.Errorf("Expected the usual output on stderr, got:\n%s", stderr.String())
+	}
+}
+
+func TestCleanCommand_Execute_JSONNeedsForceOrDryRun(t *testing.T) {
+	deps, _, _ := newListTestDeps(&mockGit{isGitRepo: true})
+
+	cmd := NewCleanCommand(deps, false, false, true, false)
+	cmd.jsonOutput = true
+	err := cmd.Execute()
+	if err == nil || !strings.Contains(err.Error(), "--json needs --force or --dry-run") {
+		t.Errorf("Expected an error asking for --force or --dry-run, got: %v", err)
+	}
+}
+
+func TestCleanRemediation(t *testing.T) {
+	tests := []struct {
+		warning string
+		want    string
+	}{
+		{"uncommitted changes", "commit or stash them"},
+		{"unpushed commits", "run `git push`"},
+		{"not merged to develop", "merge it to develop"},
+		{"cherry-pick in progress", "`git cherry-pick --continue`"},
+		{"invalid git repository", "`git worktree prune`"},
+		{"kept by --keep 2 (last commit 2026-01-02)", "lower --keep"},
+		{"Could not check unpushed commits: exit status 1", "fix the git error"},
+	}
+	for _, tt := range tests {
+		if got := cleanRemediation(tt.warning); !strings.Contains(got, tt.want) {
+			t.Errorf("cleanRemediation(%q) = %q, want it to contain %q", tt.warning, got, tt.want)
+		}
+	}
+}
+
 func TestCleanCommand_Execute_SkipsMasterAndEmptyBranch(t *testing.T) {
 	stdout := &bytes.Buffer{}
 	stderr := &bytes.Buffer{}
@@ -1221,3 +1845,438 @@ func TestCleanCommand_Execute_PreEndHookFailure(t *testing.T) {
 		t.Errorf("Expected worktree to still be removed despite hook failure, removedPaths=%v", removedPaths)
 	}
 }
+
+func TestCleanCommand_Execute_Interactive(t *testing.T) {
+	safePath := "/repo-123"
+	otherSafePath := "/repo-456"
+	dirtyPath := "/repo-789"
+
+	newGit := func(removed *[]string) *mockGit {
+		return &mockGit{
+			ListWorktreesFn: func() ([]git.WorktreeInfo, error) {
+				return []git.WorktreeInfo{
+					{Path: "/repo", Branch: "main"},
+					{Path: safePath, Branch: testBranch123},
+					{Path: dirtyPath, Branch: "789/impl"},
+					{Path: otherSafePath, Branch: "456/impl"},
+				}, nil
+			},
+			HasUncommittedChangesAtFn: func(path string) (bool, error) {
+				return path == dirtyPath, nil
+			},
+			RemoveWorktreeByPathFn: func(path string) error {
+				*removed = append(*removed, path)
+				return nil
+			},
+		}
+	}
+
+	t.Run("removes only the chosen removable worktrees", func(t *testing.T) {
+		var removed []string
+		var offered []ui.SelectorItem
+		mu := &mockUI{
+			ShowMultiSelectorFn: func(_ string, items []ui.SelectorItem) ([]ui.SelectorItem, error) {
+				offered = items
+				return []ui.SelectorItem{items[1]}, nil
+			},
+		}
+		deps := &Dependencies{Config: &config.Config{}, Git: newGit(&removed), UI: mu, Stdout: &bytes.Buffer{}, Stderr: &bytes.Buffer{}}
+
+		cmd := NewCleanCommand(deps, false, false, true, false)
+		cmd.interactive = true
+		if err := cmd.Execute(); err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+
+		if len(offered) != 3 {
+			t.Fatalf("expected 3 worktrees offered, got %d: %v", len(offered), offered)
+		}
+		if offered[2].ID != dirtyPath || !strings.Contains(offered[2].Name, "uncommitted changes") {
+			t.Errorf("expected non-removable worktree listed last with its reason, got %+v", offered[2])
+		}
+		if !reflect.DeepEqual(removed, []string{otherSafePath}) {
+			t.Errorf("expected only %s removed, got %v", otherSafePath, removed)
+		}
+		if mu.confirmCalled {
+			t.Error("selection alone should not trigger a confirmation prompt")
+		}
+	})
+
+	t.Run("non-removable selection requires force confirmation", func(t *testing.T) {
+		var removed []string
+		mu := &mockUI{
+			confirmResult: true,
+			ShowMultiSelectorFn: func(_ string, items []ui.SelectorItem) ([]ui.SelectorItem, error) {
+				return []ui.SelectorItem{items[0], items[2]}, nil
+			},
+		}
+		stderr := &bytes.Buffer{}
+		deps := &Dependencies{Config: &config.Config{}, Git: newGit(&removed), UI: mu, Stdout: &bytes.Buffer{}, Stderr: stderr}
+
+		cmd := NewCleanCommand(deps, false, false, true, false)
+		cmd.interactive = true
+		if err := cmd.Execute(); err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+
+		if !mu.confirmCalled || !strings.Contains(mu.confirmMessage, "Force removal") {
+			t.Errorf("expected force confirmation, got prompt %q", mu.confirmMessage)
+		}
+		if !strings.Contains(stderr.String(), "789/impl") {
+			t.Errorf("expected unsafe worktree listed on stderr, got: %s", stderr.String())
+		}
+		if !reflect.DeepEqual(removed, []string{safePath, dirtyPath}) {
+			t.Errorf("expected %s and %s removed, got %v", safePath, dirtyPath, removed)
+		}
+	})
+
+	t.Run("declining force confirmation keeps non-removable worktrees", func(t *testing.T) {
+		var removed []string
+		mu := &mockUI{
+			confirmResult: false,
+			ShowMultiSelectorFn: func(_ string, items []ui.SelectorItem) ([]ui.SelectorItem, error) {
+				return []ui.SelectorItem{items[0], items[2]}, nil
+			},
+		}
+		deps := &Dependencies{Config: &config.Config{}, Git: newGit(&removed), UI: mu, Stdout: &bytes.Buffer{}, Stderr: &bytes.Buffer{}}
+
+		cmd := NewCleanCommand(deps, false, false, true, false)
+		cmd.interactive = true
+		if err := cmd.Execute(); err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+
+		if !reflect.DeepEqual(removed, []string{safePath}) {
+			t.Errorf("expected only %s removed, got %v", safePath, removed)
+		}
+	})
+
+	t.Run("empty selection removes nothing", func(t *testing.T) {
+		var removed []string
+		stdout := &bytes.Buffer{}
+		deps := &Dependencies{Config: &config.Config{}, Git: newGit(&removed), UI: &mockUI{}, Stdout: stdout, Stderr: &bytes.Buffer{}}
+
+		cmd := NewCleanCommand(deps, false, false, true, false)
+		cmd.interactive = true
+		if err := cmd.Execute(); err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+
+		if len(removed) != 0 {
+			t.Errorf("expected nothing removed, got %v", removed)
+		}
+		if !strings.Contains(stdout.String(), "No worktrees selected") {
+			t.Errorf("expected 'No worktrees selected', got: %s", stdout.String())
+		}
+	})
+
+	t.Run("selector error is returned", func(t *testing.T) {
+		var removed []string
+		mu := &mockUI{
+			ShowMultiSelectorFn: func(string, []ui.SelectorItem) ([]ui.SelectorItem, error) {
+				return nil, fmt.Errorf("selection canceled")
+			},
+		}
+		deps := &Dependencies{Config: &config.Config{}, Git: newGit(&removed), UI: mu, Stdout: &bytes.Buffer{}, Stderr: &bytes.Buffer{}}
+
+		cmd := NewCleanCommand(deps, false, false, true, false)
+		cmd.interactive = true
+		if err := cmd.Execute(); err == nil || !strings.Contains(err.Error(), "selection canceled") {
+			t.Errorf("expected selection error, got: %v", err)
+		}
+		if len(removed) != 0 {
+			t.Errorf("expected nothing removed, got %v", removed)
+		}
+	})
+}
+
+func TestCleanCommand_Execute_NoProgressForNonTTY(t *testing.T) {
+	tmpDir := t.TempDir()
+	worktrees := []git.WorktreeInfo{{Path: "/repo", Branch: "main"}}
+	for _, name := range []string{"feature-a", "feature-b", "feature-c"} {
+		worktrees = append(worktrees, git.WorktreeInfo{Path: filepath.Join(tmpDir, name), Branch: name})
+	}
+
+	for _, quiet := range []bool{false, true} {
+		stdout := &bytes.Buffer{}
+		deps := &Dependencies{
+			Config: &config.Config{},
+			Git: &mockGit{
+				ListWorktreesFn: func() ([]git.WorktreeInfo, error) { return worktrees, nil },
+			},
+			UI:     &mockUI{},
+			Stdout: stdout,
+			Stderr: &bytes.Buffer{},
+		}
+
+		cmd := NewCleanCommand(deps, false, true, true, false)
+		cmd.quiet = quiet
+		if err := cmd.Execute(); err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+
+		if strings.Contains(stdout.String(), "Checking") {
+			t.Errorf("quiet=%v: expected no progress output for a non-TTY writer, got: %s", quiet, stdout.String())
+		}
+	}
+}
+
+func TestCleanCommand_Execute_CurrentDirIsRemovable(t *testing.T) {
+	// setup makes the current directory a sub directory of wt1, one of two
+	// removable worktrees.
+	setup := func(t *testing.T) (mg *mockGit, mainDir, wt1 string, removed *[]string) {
+		t.Helper()
+		originalDir, _ := os.Getwd()
+		t.Cleanup(func() { _ = os.Chdir(originalDir) })
+		t.Setenv("HOME", t.TempDir())
+
+		tmpDir := t.TempDir()
+		mainDir = filepath.Join(tmpDir, "repo")
+		wt1 = filepath.Join(tmpDir, "wt1")
+		wt2 := filepath.Join(tmpDir, "wt2")
+		for _, dir := range []string{mainDir, filepath.Join(wt1, "src"), wt2} {
+			if err := os.MkdirAll(dir, 0755); err != nil {
+				t.Fatalf("mkdir: %v", err)
+			}
+		}
+		if err := os.Chdir(filepath.Join(wt1, "src")); err != nil {
+			t.Fatalf("chdir: %v", err)
+		}
+
+		removed = &[]string{}
+		mg = &mockGit{
+			ListWorktreesFn: func() ([]git.WorktreeInfo, error) {
+				return []git.WorktreeInfo{
+					{Path: mainDir, Branch: "main"},
+					{Path: wt1, Branch: testBranch123},
+					{Path: wt2, Branch: "456/impl"},
+				}, nil
+			},
+			IsMergedToBaseBranchFn:  func(string) (bool, error) { return true, nil },
+			GetMainRepositoryRootFn: func() (string, error) { return mainDir, nil },
+			RemoveWorktreeByPathFn: func(path string) error {
+				*removed = append(*removed, path)
+				return nil
+			},
+		}
+		return mg, mainDir, wt1, removed
+	}
+	newCleanDeps := func(mg *mockGit, mu *mockUI) (*Dependencies, *bytes.Buffer) {
+		stdout := &bytes.Buffer{}
+		return &Dependencies{Git: mg, UI: mu, Config: &config.Config{}, Stdout: stdout, Stderr: &bytes.Buffer{}}, stdout
+	}
+
+	t.Run("asks again before removing the current worktree", func(t *testing.T) {
+		mg, mainDir, _, removed := setup(t)
+		var prompts []string
+		mu := &mockUI{ConfirmPromptFn: func(message string) (bool, error) {
+			prompts = append(prompts, message)
+			return true, nil
+		}}
+		deps, stdout := newCleanDeps(mg, mu)
+
+		if err := NewCleanCommand(deps, false, false, true, true).Execute(); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if len(prompts) != 2 || !strings.Contains(prompts[1], "Remove wt1 as well?") {
+			t.Errorf("Expected an extra confirmation for wt1, got prompts: %q", prompts)
+		}
+		if len(*removed) != 2 {
+			t.Errorf("Expected both worktrees to be removed, got: %v", *removed)
+		}
+		if cwd, _ := os.Getwd(); !isWithinDir(cwd, mainDir) {
+			t.Errorf("Expected to have moved to the main worktree, cwd is %s", cwd)
+		}
+		if !strings.Contains(stdout.String(), "Your current directory was removed: cd "+mainDir) {
+			t.Errorf("Expected a hint to leave the removed directory, got:\n%s", stdout.String())
+		}
+	})
+
+	t.Run("keeps the current worktree when declined", func(t *testing.T) {
+		mg, _, wt1, removed := setup(t)
+		answers := []bool{true, false}
+		mu := &mockUI{ConfirmPromptFn: func(string) (bool, error) {
+			answer := answers[0]
+			answers = answers[1:]
+			return answer, nil
+		}}
+		deps, stdout := newCleanDeps(mg, mu)
+
+		if err := NewCleanCommand(deps, false, false, true, true).Execute(); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		for _, path := range *removed {
+			if path == wt1 {
+				t.Errorf("Expected the current worktree to be kept, removed: %v", *removed)
+			}
+		}
+		if len(*removed) != 1 || !strings.Contains(stdout.String(), "Keeping wt1.") {
+			t.Errorf("Expected only wt2 removed and wt1 kept, removed %v, output:\n%s", *removed, stdout.String())
+		}
+	})
+
+	t.Run("hands the main worktree to shell integration", func(t *testing.T) {
+		mg, mainDir, _, _ := setup(t)
+		cdFile := filepath.Join(t.TempDir(), "cd")
+		t.Setenv(envCDFile, cdFile)
+		mu := &mockUI{}
+		deps, _ := newCleanDeps(mg, mu)
+
+		if err := NewCleanCommand(deps, true, false, true, true).Execute(); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if mu.confirmCalled {
+			t.Error("Expected --force to skip every confirmation")
+		}
+		if got, _ := os.ReadFile(cdFile); string(got) != mainDir {
+			t.Errorf("cd file = %q, want %q", got, mainDir)
+		}
+	})
+}
+
+func TestCleanCommand_CheckWorktrees_MaxParallelChecks(t *testing.T) {
+	const (
+		worktreeCount = 40
+		limit         = 3
+	)
+
+	worktrees := make([]git.WorktreeInfo, worktreeCount)
+	for i := range worktrees {
+		worktrees[i] = git.WorktreeInfo{Path: fmt.Sprintf("/wt%d", i), Branch: fmt.Sprintf("%d/impl", i)}
+	}
+
+	var inFlight, peak atomic.Int32
+	mg := &mockGit{
+		ListWorktreesFn: func() ([]git.WorktreeInfo, error) { return worktrees, nil },
+		// Even-numbered branches are merged; odd ones fail the merged check
+		// with an error, so each worktree's result is identifiable.
+		IsMergedToBaseBranchAtFn: func(_, branch, _ string) (bool, error) {
+			n := inFlight.Add(1)
+			defer inFlight.Add(-1)
+			for {
+				p := peak.Load()
+				if n <= p || peak.CompareAndSwap(p, n) {
+					break
+				}
+			}
+			time.Sleep(2 * time.Millisecond)
+
+			var i int
+			fmt.Sscanf(branch, "%d/", &i)
+			if i%2 == 1 {
+				return false, fmt.Errorf("merge check failed for %s", branch)
+			}
+			return true, nil
+		},
+	}
+	deps := &Dependencies{
+		Git:    mg,
+		UI:     &mockUI{},
+		Config: &config.Config{MaxParallelChecks: limit},
+		Stdout: &bytes.Buffer{},
+		Stderr: &bytes.Buffer{},
+	}
+
+	statuses, err := NewCleanCommand(deps, false, true, true, true).checkWorktrees()
+	if err != nil {
+		t.Fatalf("checkWorktrees failed: %v", err)
+	}
+
+	if got := peak.Load(); got > limit {
+		t.Errorf("%d worktrees were checked at once, want at most %d", got, limit)
+	}
+	if len(statuses) != worktreeCount {
+		t.Fatalf("got %d statuses, want %d", len(statuses), worktreeCount)
+	}
+	for i, status := range statuses {
+		if status.Info.Path != worktrees[i].Path {
+			t.Errorf("statuses[%d] is for %s, want %s", i, status.Info.Path, worktrees[i].Path)
+		}
+		if wantRemovable := i%2 == 0; status.CanRemove != wantRemovable {
+			t.Errorf("%s: CanRemove = %v, want %v (warnings: %v)", status.Info.Path, status.CanRemove, wantRemovable, status.Warnings)
+		}
+		if i%2 == 1 && !strings.Contains(strings.Join(status.Warnings, ", "), worktrees[i].Branch) {
+			t.Errorf("%s: expected its own merge check error, got: %v", status.Info.Path, status.Warnings)
+		}
+	}
+}
+
+func TestCheckConcurrency(t *testing.T) {
+	if got := checkConcurrency(&config.Config{MaxParallelChecks: 2}); got != 2 {
+		t.Errorf("checkConcurrency with max_parallel_checks = 2 returned %d", got)
+	}
+	if got := checkConcurrency(&config.Config{}); got != runtime.NumCPU() {
+		t.Errorf("checkConcurrency by default = %d, want NumCPU (%d)", got, runtime.NumCPU())
+	}
+}
+
+func TestCleanCommand_Execute_NoRemote_Integration(t *testing.T) {
+	repo, runGit := newDryRunTestRepo(t)
+	runGit("worktree", "add", "-b", "1/impl", repo+"-1")
+	runGit("worktree", "add", "-b", "2/impl", repo+"-2")
+	runGit("-C", repo+"-2", "commit", "--allow-empty", "-m", "work in progress")
+
+	stdout := &bytes.Buffer{}
+	stderr := &bytes.Buffer{}
+	deps := &Dependencies{Git: git.NewClient(), UI: &mockUI{}, Config: config.New(), Stdout: stdout, Stderr: stderr}
+	if err := NewCleanCommand(deps, true, false, false, false).Execute(); err != nil {
+		t.Fatalf("Unexpected error: %v\nstderr:\n%s", err, stderr.String())
+	}
+
+	if _, err := os.Stat(repo + "-1"); !os.IsNotExist(err) {
+		t.Errorf("Expected the merged worktree to be removed, stat error: %v", err)
+	}
+	if _, err := os.Stat(repo + "-2"); err != nil {
+		t.Errorf("Expected the unmerged worktree to be kept: %v", err)
+	}
+	out := stdout.String() + stderr.String()
+	if !strings.Contains(out, "not merged to main") {
+		t.Errorf("Expected the unmerged worktree to be kept as not merged, got:\n%s", out)
+	}
+	for _, unwanted := range []string{"unpushed commits", "Could not check"} {
+		if strings.Contains(out, unwanted) {
+			t.Errorf("Expected no %q without a remote, got:\n%s", unwanted, out)
+		}
+	}
+	if !strings.Contains(stderr.String(), "No remote named origin") {
+		t.Errorf("Expected a note about the missing remote, got:\n%s", stderr.String())
+	}
+}
+
+func TestCleanCommand_Execute_RemoveBranchOnly_TwoRemotes_Integration(t *testing.T) {
+	repo, runGit := newDryRunTestRepo(t)
+	runGit("branch", "45/impl")
+	for _, remote := range []string{"origin", "upstream"} {
+		remoteDir := filepath.Join(filepath.Dir(repo), remote+".git")
+		runGit("init", "--bare", remoteDir)
+		runGit("remote", "add", remote, remoteDir)
+		runGit("push", remote, "main", "main:refs/heads/45/impl", "main:refs/heads/foo")
+	}
+	runGit("fetch", "--all")
+
+	client := git.NewClient()
+	client.SetRemote("upstream")
+	stdout := &bytes.Buffer{}
+	stderr := &bytes.Buffer{}
+	deps := &Dependencies{Git: client, UI: &mockUI{}, Config: config.New(), Stdout: stdout, Stderr: stderr}
+	cmd := NewCleanCommand(deps, true, false, true, false)
+	cmd.branchOnly = true
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("Unexpected error: %v\nstderr:\n%s", err, stderr.String())
+	}
+
+	// Only the local 45/impl is offered: origin/... and upstream/... refs are
+	// remote-tracking branches whichever remote is the configured one.
+	if !strings.Contains(stdout.String(), "Merged branches without a worktree (1)\n  45/impl\n") {
+		t.Errorf("Expected only 45/impl to be offered, got:\n%s", stdout.String())
+	}
+	if out := runGit("branch", "--list", "45/impl"); out != "" {
+		t.Errorf("Expected 45/impl to be deleted, got %q", out)
+	}
+	if out := runGit("branch", "-r", "--list", "origin/45/impl", "upstream/45/impl"); !strings.Contains(out, "origin/45/impl") || !strings.Contains(out, "upstream/45/impl") {
+		t.Errorf("Expected the remote-tracking branches to be kept, got %q", out)
+	}
+}
@@ -0,0 +1,95 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/sotarok/gw/internal/detect"
+	"github.com/sotarok/gw/internal/git"
+)
+
+// diffGit is the subset of git operations DiffCommand actually uses.
+type diffGit interface {
+	git.WorktreeManager // GetWorktreeForIssue
+	git.BranchManager   // ResolveBaseBranch
+}
+
+// DiffCommand handles the diff command logic
+type DiffCommand struct {
+	deps       *Dependencies
+	baseBranch string
+	stat       bool
+	noPager    bool
+	executor   detect.CommandExecutor
+}
+
+// NewDiffCommand creates a new diff command handler
+func NewDiffCommand(deps *Dependencies, baseBranch string, stat, noPager bool) *DiffCommand {
+	return &DiffCommand{
+		deps:       deps,
+		baseBranch: baseBranch,
+		stat:       stat,
+		noPager:    noPager,
+		executor:   &detect.DefaultExecutor{Stdout: deps.Stdout, Stderr: deps.Stderr},
+	}
+}
+
+// git returns the command's git dependency narrowed to the operations it uses.
+func (c *DiffCommand) git() diffGit { return c.deps.Git }
+
+// Execute shows the changes on the worktree's branch since it forked from the
+// base branch. Paging is left to git itself, which honors $GIT_PAGER,
+// core.pager and $PAGER and only pages when stdout is a terminal.
+func (c *DiffCommand) Execute(issueNumber string) error {
+	if c.baseBranch != "" {
+		if err := git.ValidateRef(c.baseBranch); err != nil {
+			return fmt.Errorf("invalid --base branch: %w", err)
+		}
+	}
+	worktreePath, branchName, err := c.resolveWorktree(issueNumber)
+	if err != nil {
+		return err
+	}
+	if branchName == "" {
+		return fmt.Errorf("worktree at %s has no branch (detached HEAD)", worktreePath)
+	}
+
+	return c.executor.Execute(worktreePath, "git", c.diffArgs(branchName))
+}
+
+// diffArgs builds `git [--no-pager] diff [--stat] <base>...<branch>`. The
+// three-dot range diffs against the merge base, so commits that landed on the
+// base branch after the worktree was created do not show up as reverted.
+func (c *DiffCommand) diffArgs(branchName string) []string {
+	base, _ := c.git().ResolveBaseBranch(c.baseBranch)
+
+	var args []string
+	if c.noPager {
+		args = append(args, "--no-pager")
+	}
+	args = append(args, "diff")
+	if c.stat {
+		args = append(args, "--stat")
+	}
+	return append(args, fmt.Sprintf("%s...%s", base, branchName))
+}
+
+// resolveWorktree returns the worktree path and branch to diff, either via
+// interactive selection (when issueNumber is empty) or by issue number / branch.
+func (c *DiffCommand) resolveWorktree(issueNumber string) (worktreePath, branchName string, err error) {
+	if issueNumber == "" {
+		selected, selErr := c.deps.UI.SelectWorktree()
+		if selErr != nil {
+			return "", "", selErr
+		}
+		return selected.Path, selected.Branch, nil
+	}
+
+	wt, err := c.git().GetWorktreeForIssue(issueNumber)
+	if err != nil {
+		return "", "", err
+	}
+	if wt == nil {
+		return "", "", &git.WorktreeNotFoundError{Identifier: issueNumber}
+	}
+	return wt.Path, wt.Branch, nil
+}
@@ -0,0 +1,179 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/sotarok/gw/internal/config"
+	"github.com/sotarok/gw/internal/detect"
+	"github.com/sotarok/gw/internal/git"
+)
+
+func TestDiffCommand_Execute(t *testing.T) {
+	tests := []struct {
+		name         string
+		issueNumber  string
+		baseBranch   string
+		stat         bool
+		noPager      bool
+		resolvedBase string // what ResolveBaseBranch returns; defaults to baseBranch
+		expectedArgs []string
+	}{
+		{
+			name:         "merge-base range against main",
+			issueNumber:  "123",
+			baseBranch:   "main",
+			expectedArgs: []string{"diff", "main...123/impl"},
+		},
+		{
+			name:         "stat summary without pager",
+			issueNumber:  "123",
+			baseBranch:   "main",
+			stat:         true,
+			noPager:      true,
+			expectedArgs: []string{"--no-pager", "diff", "--stat", "main...123/impl"},
+		},
+		{
+			name:         "base only on the remote",
+			issueNumber:  "123",
+			baseBranch:   "develop",
+			resolvedBase: "origin/develop",
+			expectedArgs: []string{"diff", "origin/develop...123/impl"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mg := &mockGit{
+				GetWorktreeForIssueFn: func(issueNumber string) (*git.WorktreeInfo, error) {
+					return &git.WorktreeInfo{Path: "/repo-" + issueNumber, Branch: issueNumber + "/impl"}, nil
+				},
+				ResolveBaseBranchFn: func(base string) (string, bool) {
+					if tt.resolvedBase != "" {
+						return tt.resolvedBase, true
+					}
+					return base, false
+				},
+			}
+			deps := &Dependencies{
+				Git:    mg,
+				UI:     &mockUI{},
+				Config: config.New(),
+				Stdout: &bytes.Buffer{},
+				Stderr: &bytes.Buffer{},
+			}
+			executor := &detect.MockExecutor{}
+
+			cmd := NewDiffCommand(deps, tt.baseBranch, tt.stat, tt.noPager)
+			cmd.executor = executor
+			if err := cmd.Execute(tt.issueNumber); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if len(executor.ExecuteCalls) != 1 {
+				t.Fatalf("Expected 1 executed command, got %d", len(executor.ExecuteCalls))
+			}
+			call := executor.ExecuteCalls[0]
+			if call.Dir != "/repo-"+tt.issueNumber {
+				t.Errorf("Expected command to run in /repo-%s, got %s", tt.issueNumber, call.Dir)
+			}
+			if call.Command != "git" {
+				t.Errorf("Expected git command, got %s", call.Command)
+			}
+			if !reflect.DeepEqual(call.Args, tt.expectedArgs) {
+				t.Errorf("Expected args %v, got %v", tt.expectedArgs, call.Args)
+			}
+		})
+	}
+}
+
+func TestDiffCommand_Execute_Interactive(t *testing.T) {
+	ui := &mockUI{
+		SelectWorktreeFn: func() (*git.WorktreeInfo, error) {
+			return &git.WorktreeInfo{Path: "/repo-feature", Branch: testBranchFeature}, nil
+		},
+	}
+	deps := &Dependencies{
+		Git:    &mockGit{},
+		UI:     ui,
+		Config: config.New(),
+		Stdout: &bytes.Buffer{},
+		Stderr: &bytes.Buffer{},
+	}
+	executor := &detect.MockExecutor{}
+
+	cmd := NewDiffCommand(deps, "main", false, false)
+	cmd.executor = executor
+	if err := cmd.Execute(""); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(executor.ExecuteCalls) != 1 {
+		t.Fatalf("Expected 1 executed command, got %d", len(executor.ExecuteCalls))
+	}
+	want := []string{"diff", "main..." + testBranchFeature}
+	if call := executor.ExecuteCalls[0]; call.Dir != "/repo-feature" || !reflect.DeepEqual(call.Args, want) {
+		t.Errorf("Expected git %v in /repo-feature, got git %v in %s", want, call.Args, call.Dir)
+	}
+}
+
+func TestDiffCommand_Execute_Errors(t *testing.T) {
+	tests := []struct {
+		name          string
+		base          string
+		lookup        func(string) (*git.WorktreeInfo, error)
+		expectedError string
+	}{
+		{
+			name:          "worktree lookup fails",
+			lookup:        func(string) (*git.WorktreeInfo, error) { return nil, fmt.Errorf("worktree for 999 not found") },
+			expectedError: "worktree for 999 not found",
+		},
+		{
+			name: "detached worktree",
+			lookup: func(string) (*git.WorktreeInfo, error) {
+				return &git.WorktreeInfo{Path: "/repo-999", IsDetached: true}, nil
+			},
+			expectedError: "has no branch",
+		},
+		{
+			name: "invalid --base",
+			base: "--output=/tmp/x",
+			lookup: func(string) (*git.WorktreeInfo, error) {
+				t.Error("Expected --base to be rejected before the worktree lookup")
+				return &git.WorktreeInfo{Path: "/repo-999", Branch: "999/impl"}, nil
+			},
+			expectedError: "invalid --base branch",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			deps := &Dependencies{
+				Git:    &mockGit{GetWorktreeForIssueFn: tt.lookup},
+				UI:     &mockUI{},
+				Config: config.New(),
+				Stdout: &bytes.Buffer{},
+				Stderr: &bytes.Buffer{},
+			}
+			executor := &detect.MockExecutor{}
+
+			base := tt.base
+			if base == "" {
+				base = "main"
+			}
+			cmd := NewDiffCommand(deps, base, false, false)
+			cmd.executor = executor
+			err := cmd.Execute("999")
+			if err == nil || !strings.Contains(err.Error(), tt.expectedError) {
+				t.Errorf("Expected error containing %q, got %v", tt.expectedError, err)
+			}
+			if len(executor.ExecuteCalls) != 0 {
+				t.Errorf("Expected no command to run, got %v", executor.ExecuteCalls)
+			}
+		})
+	}
+}
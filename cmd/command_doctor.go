@@ -0,0 +1,279 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/sotarok/gw/internal/config"
+	"github.com/sotarok/gw/internal/git"
+)
+
+// doctorGit is the subset of git operations DoctorCommand actually uses.
+type doctorGit interface {
+	git.RepositoryReader // GitVersion, IsGitRepository, GetMainRepositoryRoot
+	git.WorktreeManager  // ListWorktrees, RepairWorktrees, PruneWorktrees
+}
+
+// doctorLevel is how serious a doctor finding is.
+type doctorLevel int
+
+const (
+	doctorOK doctorLevel = iota
+	doctorWarn
+	doctorFail
+)
+
+// doctorFinding is one line of the gw doctor report.
+type doctorFinding struct {
+	level   doctorLevel
+	message string
+	fix     *doctorFix // what --fix offers to do about it, if anything
+}
+
+// doctorFix is a remedy --fix can apply for a finding.
+type doctorFix struct {
+	prompt string       // the question asked before applying it
+	done   string       // reported once it was applied
+	apply  func() error // applies the fix
+}
+
+// DoctorCommand handles the doctor command logic
+type DoctorCommand struct {
+	deps       *Dependencies
+	configPath string
+	rcPath     string // For testing shell integration
+	fix        bool   // --fix: offer to repair what the checks find
+	yes        bool   // --yes: apply every fix without asking
+}
+
+// NewDoctorCommand creates a new doctor command handler
+func NewDoctorCommand(deps *Dependencies, configPath string) *DoctorCommand {
+	return &DoctorCommand{deps: deps, configPath: configPath}
+}
+
+// git returns the command's git dependency narrowed to the operations it uses.
+func (c *DoctorCommand) git() doctorGit { return c.deps.Git }
+
+// Execute runs every check and prints its findings. It fails when a check
+// failed; warnings are only reported. With --fix, each finding that has a fix
+// is followed by the offer to apply it.
+func (c *DoctorCommand) Execute() error {
+	findings := c.checkGitVersion()
+	findings = append(findings, c.checkConfig()...)
+	findings = append(findings, c.checkShellIntegration())
+	findings = append(findings, c.checkWorktrees()...)
+	return c.report(findings)
+}
+
+// ExecuteConfig runs only the config checks, for gw config doctor.
+func (c *DoctorCommand) ExecuteConfig() error {
+	return c.report(c.checkConfig())
+}
+
+// report prints findings, applying their fixes with --fix, and fails when
+// any of them is a failure that was not fixed.
+func (c *DoctorCommand) report(findings []doctorFinding) error {
+	failed := 0
+	for _, finding := range findings {
+		icon := coloredSuccess()
+		switch finding.level {
+		case doctorWarn:
+			icon = coloredWarning()
+		case doctorFail:
+			icon = coloredError()
+		}
+		fmt.Fprintf(c.deps.Stdout, "%s %s\n", icon, finding.message)
+		if c.fix && finding.fix != nil && c.applyFix(finding.fix) {
+			continue
+		}
+		if finding.level == doctorFail {
+			failed++
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("gw doctor found %d %s", failed, plural(failed, "problem", "problems"))
+	}
+	return nil
+}
+
+// checkGitVersion reports the installed git version and every git feature gw
+// uses that it is too old for.
+func (c *DoctorCommand) checkGitVersion() []doctorFinding {
+	version, err := c.git().GitVersion()
+	if err != nil {
+		return []doctorFinding{{level: doctorFail, message: fmt.Sprintf("Could not determine the git version: %v", err)}}
+	}
+
+	findings := []doctorFinding{{level: doctorOK, message: fmt.Sprintf("git %s", version)}}
+	for _, feature := range git.Features {
+		if version.AtLeast(feature.Requires) {
+			continue
+		}
+		findings = append(findings, doctorFinding{level: doctorWarn, message: fmt.Sprintf("%s needs git >= %d.%d: %s",
+			feature.Name, feature.Requires.Major, feature.Requires.Minor, feature.Impact)})
+	}
+	return findings
+}
+
+// applyFix asks before applying fix, unless --yes was given, and reports
+// whether it was applied.
+func (c *DoctorCommand) applyFix(fix *doctorFix) bool {
+	if !c.yes {
+		fmt.Fprintf(c.deps.Stdout, "  %s", fix.prompt)
+		confirmed, err := c.deps.UI.ConfirmPrompt(" (y/N): ")
+		if err != nil || !confirmed {
+			return false
+		}
+	}
+	if err := fix.apply(); err != nil {
+		fmt.Fprintf(c.deps.Stdout, "  %s %v\n", coloredError(), err)
+		return false
+	}
+	fmt.Fprintf(c.deps.Stdout, "  %s %s\n", coloredSuccess(), fix.done)
+	return true
+}
+
+// checkConfig reports whether the config file exists and, if it does, every
+// setting in it that conflicts with another or has no effect. Without one gw
+// runs on its defaults, and --fix can write them out as a starting point.
+func (c *DoctorCommand) checkConfig() []doctorFinding {
+	if _, err := os.Stat(c.configPath); err == nil {
+		findings := []doctorFinding{{level: doctorOK, message: fmt.Sprintf("Config file %s", c.configPath)}}
+		for _, warning := range c.deps.Config.Warnings() {
+			findings = append(findings, doctorFinding{level: doctorWarn, message: warning})
+		}
+		return findings
+	}
+	return []doctorFinding{{
+		level:   doctorWarn,
+		message: fmt.Sprintf("No config file at %s; gw uses the default settings (create one with gw init)", c.configPath),
+		fix: &doctorFix{
+			prompt: fmt.Sprintf("Create %s with the default settings?", c.configPath),
+			done:   fmt.Sprintf("Created %s", c.configPath),
+			apply:  func() error { return config.New().Save(c.configPath) },
+		},
+	}}
+}
+
+// checkShellIntegration reports whether the rc file of the user's shell loads
+// the shell integration, without which gw cannot change the shell's directory.
+func (c *DoctorCommand) checkShellIntegration() doctorFinding {
+	// The rc helpers live on InitCommand; they only need the output writers.
+	installer := &InitCommand{stdout: c.deps.Stdout, stderr: c.deps.Stderr}
+	shell := installer.detectShellType()
+	rcPath := c.rcPath
+	if rcPath == "" {
+		rcPath = installer.detectRCPath(shell)
+	}
+	if rcPath == "" {
+		return doctorFinding{level: doctorWarn, message: fmt.Sprintf(
+			"Could not tell which rc file to check for shell integration (shell: %s); see gw init --shell-only", shell)}
+	}
+
+	if installer.hasShellIntegration(rcPath, showScriptCommand) {
+		return doctorFinding{level: doctorOK, message: fmt.Sprintf("Shell integration is set up in %s", rcPath)}
+	}
+	return doctorFinding{
+		level:   doctorWarn,
+		message: fmt.Sprintf("Shell integration is not set up in %s; gw cannot change to new worktrees", rcPath),
+		fix: &doctorFix{
+			prompt: fmt.Sprintf("Add shell integration to %s?", rcPath),
+			done:   fmt.Sprintf("Shell integration added to %s; restart your shell or run: source %s", rcPath, rcPath),
+			apply:  func() error { return installer.addShellIntegration(rcPath, shell) },
+		},
+	}
+}
+
+// checkWorktrees reports the worktrees of the current repository that git has
+// lost track of: those whose directory was deleted, which --fix prunes, and
+// those whose .git file no longer points at the repository, which it repairs.
+// Outside a repository there is nothing to check.
+func (c *DoctorCommand) checkWorktrees() []doctorFinding {
+	if !c.git().IsGitRepository() {
+		return nil
+	}
+	worktrees, err := c.git().ListWorktrees()
+	if err != nil {
+		return []doctorFinding{{level: doctorFail, message: fmt.Sprintf("Could not list worktrees: %v", err)}}
+	}
+	mainRoot, _ := c.git().GetMainRepositoryRoot()
+
+	var missing, broken []string
+	for _, wt := range worktrees {
+		if wt.IsBare || samePath(wt.Path, mainRoot) {
+			continue
+		}
+		if _, err := os.Stat(wt.Path); err != nil {
+			missing = append(missing, wt.Path)
+		} else if !hasWorktreeGitFile(wt.Path) {
+			broken = append(broken, wt.Path)
+		}
+	}
+
+	var findings []doctorFinding
+	if len(broken) > 0 {
+		findings = append(findings, doctorFinding{
+			level: doctorWarn,
+			message: fmt.Sprintf("%d %s no longer linked to the repository: %s",
+				len(broken), plural(len(broken), "worktree is", "worktrees are"), strings.Join(broken, ", ")),
+			fix: &doctorFix{
+				prompt: "Re-link them with git worktree repair?",
+				done:   "Repaired the worktree links",
+				apply:  func() error { return c.repairWorktrees(broken) },
+			},
+		})
+	}
+	if len(missing) > 0 {
+		findings = append(findings, doctorFinding{
+			level: doctorWarn,
+			message: fmt.Sprintf("%d %s missing its directory: %s",
+				len(missing), plural(len(missing), "worktree is", "worktrees are"), strings.Join(missing, ", ")),
+			fix: &doctorFix{
+				prompt: "Forget them with git worktree prune?",
+				done:   "Pruned the missing worktrees",
+				apply:  c.git().PruneWorktrees,
+			},
+		})
+	}
+	if len(findings) == 0 {
+		findings = append(findings, doctorFinding{level: doctorOK, message: "All worktrees are linked to the repository"})
+	}
+	return findings
+}
+
+// repairWorktrees runs git worktree repair for paths. git exits with an error
+// for a broken .git file even when it rewrote it, so the result is judged by
+// whether the files are valid afterwards.
+func (c *DoctorCommand) repairWorktrees(paths []string) error {
+	err := c.git().RepairWorktrees(paths...)
+	for _, path := range paths {
+		if !hasWorktreeGitFile(path) {
+			if err == nil {
+				err = fmt.Errorf("%s is still not linked to the repository", path)
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// hasWorktreeGitFile reports whether dir has the .git file of a linked
+// worktree and it points at a directory that exists.
+func hasWorktreeGitFile(dir string) bool {
+	content, err := os.ReadFile(filepath.Join(dir, ".git"))
+	if err != nil {
+		return false
+	}
+	gitDir, ok := strings.CutPrefix(strings.TrimSpace(string(content)), "gitdir: ")
+	if !ok {
+		return false
+	}
+	if !filepath.IsAbs(gitDir) {
+		gitDir = filepath.Join(dir, gitDir)
+	}
+	info, err := os.Stat(gitDir)
+	return err == nil && info.IsDir()
+}
@@ -0,0 +1,243 @@
+package cmd
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/sotarok/gw/internal/git"
+)
+
+func TestDoctorCommand_Execute_GitVersion(t *testing.T) {
+	t.Run("old git", func(t *testing.T) {
+		deps, stdout, _ := newListTestDeps(&mockGit{
+			GitVersionFn: func() (git.Version, error) { return git.Version{Major: 2, Minor: 25, Patch: 1}, nil },
+		})
+
+		if err := NewDoctorCommand(deps, filepath.Join(t.TempDir(), ".gwrc")).Execute(); err != nil {
+			t.Fatalf("Expected warnings only, got: %v", err)
+		}
+		out := stdout.String()
+		if !strings.Contains(out, "git 2.25.1\n") {
+			t.Errorf("Expected the git version, got:\n%s", out)
+		}
+		if !strings.Contains(out, "git worktree repair needs git >= 2.29: gw reattach and gw import cannot re-link worktrees or import clones") {
+			t.Errorf("Expected worktree repair to be reported, got:\n%s", out)
+		}
+		for _, supported := range []string{"git worktree move needs", "git switch --orphan needs"} {
+			if strings.Contains(out, supported) {
+				t.Errorf("Expected %s not to be reported, got:\n%s", supported, out)
+			}
+		}
+	})
+
+	t.Run("current git", func(t *testing.T) {
+		deps, stdout, _ := newListTestDeps(&mockGit{})
+
+		if err := NewDoctorCommand(deps, filepath.Join(t.TempDir(), ".gwrc")).Execute(); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if strings.Contains(stdout.String(), "needs git") {
+			t.Errorf("Expected no feature warnings, got:\n%s", stdout.String())
+		}
+	})
+
+	t.Run("no git", func(t *testing.T) {
+		deps, stdout, _ := newListTestDeps(&mockGit{
+			GitVersionFn: func() (git.Version, error) { return git.Version{}, errors.New("executable file not found") },
+		})
+
+		err := NewDoctorCommand(deps, filepath.Join(t.TempDir(), ".gwrc")).Execute()
+		if err == nil || err.Error() != "gw doctor found 1 problem" {
+			t.Errorf("Expected one problem, got: %v", err)
+		}
+		if !strings.Contains(stdout.String(), "Could not determine the git version: executable file not found") {
+			t.Errorf("Unexpected output:\n%s", stdout.String())
+		}
+	})
+}
+
+func TestDoctorCommand_Execute_FixYes(t *testing.T) {
+	t.Setenv("SHELL", "/bin/zsh")
+	dir := t.TempDir()
+	rcPath := filepath.Join(dir, ".zshrc")
+	if err := os.WriteFile(rcPath, []byte("export EDITOR=vim\n"), 0644); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+	configPath := filepath.Join(dir, ".gwrc")
+
+	deps, stdout, _ := newListTestDeps(&mockGit{})
+	deps.UI = &mockUI{ConfirmPromptFn: func(string) (bool, error) {
+		t.Error("Expected no prompt with --yes")
+		return false, nil
+	}}
+	cmd := NewDoctorCommand(deps, configPath)
+	cmd.rcPath = rcPath
+	cmd.fix = true
+	cmd.yes = true
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	content, _ := os.ReadFile(rcPath)
+	if !strings.HasPrefix(string(content), "export EDITOR=vim\n") || !strings.Contains(string(content), shellIntegrationBlock(shellZsh)) {
+		t.Errorf("Expected shell integration to be added to the rc file, got:\n%s", content)
+	}
+	if _, err := os.Stat(configPath); err != nil {
+		t.Errorf("Expected a default config file to be created: %v", err)
+	}
+	for _, want := range []string{
+		"Shell integration is not set up in " + rcPath,
+		"Shell integration added to " + rcPath,
+		"Created " + configPath,
+	} {
+		if !strings.Contains(stdout.String(), want) {
+			t.Errorf("Expected %q in the output, got:\n%s", want, stdout.String())
+		}
+	}
+
+	// Nothing is left to fix on a second run.
+	stdout.Reset()
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !strings.Contains(stdout.String(), "Shell integration is set up in "+rcPath) {
+		t.Errorf("Expected shell integration to be found, got:\n%s", stdout.String())
+	}
+}
+
+func TestDoctorCommand_Execute_FixDeclined(t *testing.T) {
+	t.Setenv("SHELL", "/bin/bash")
+	dir := t.TempDir()
+	rcPath := filepath.Join(dir, ".bashrc")
+	configPath := filepath.Join(dir, ".gwrc")
+
+	var prompts int
+	deps, _, _ := newListTestDeps(&mockGit{})
+	deps.UI = &mockUI{ConfirmPromptFn: func(string) (bool, error) {
+		prompts++
+		return false, nil
+	}}
+	cmd := NewDoctorCommand(deps, configPath)
+	cmd.rcPath = rcPath
+	cmd.fix = true
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if prompts != 2 {
+		t.Errorf("Expected one prompt per fix, got %d", prompts)
+	}
+	for _, path := range []string{rcPath, configPath} {
+		if _, err := os.Stat(path); !os.IsNotExist(err) {
+			t.Errorf("Expected %s not to be written, stat error: %v", path, err)
+		}
+	}
+}
+
+func TestDoctorCommand_Execute_FixWorktrees(t *testing.T) {
+	dir := t.TempDir()
+	linked := filepath.Join(dir, "repo-123")
+	if err := os.MkdirAll(filepath.Join(dir, "repo", ".git", "worktrees", "repo-123"), 0755); err != nil {
+		t.Fatalf("MkdirAll failed: %v", err)
+	}
+	if err := os.MkdirAll(linked, 0755); err != nil {
+		t.Fatalf("MkdirAll failed: %v", err)
+	}
+	unlinked := filepath.Join(dir, "repo-456")
+	if err := os.MkdirAll(unlinked, 0755); err != nil {
+		t.Fatalf("MkdirAll failed: %v", err)
+	}
+	gitFile := "gitdir: " + filepath.Join(dir, "repo", ".git", "worktrees", "repo-123") + "\n"
+	if err := os.WriteFile(filepath.Join(linked, ".git"), []byte(gitFile), 0644); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+	missing := filepath.Join(dir, "repo-789")
+
+	var repaired []string
+	pruned := false
+	mg := &mockGit{
+		isGitRepo: true,
+		ListWorktreesFn: func() ([]git.WorktreeInfo, error) {
+			return []git.WorktreeInfo{
+				{Path: filepath.Join(dir, "repo"), Branch: "main"},
+				{Path: linked, Branch: testBranch123},
+				{Path: unlinked, Branch: "456/impl"},
+				{Path: missing, Branch: "789/impl", IsPrunable: true},
+			}, nil
+		},
+		GetMainRepositoryRootFn: func() (string, error) { return filepath.Join(dir, "repo"), nil },
+		RepairWorktreesFn: func(paths ...string) error {
+			repaired = paths
+			gitFile := "gitdir: " + filepath.Join(dir, "repo", ".git", "worktrees", "repo-123")
+			return os.WriteFile(filepath.Join(unlinked, ".git"), []byte(gitFile), 0644)
+		},
+		PruneWorktreesFn: func() error {
+			pruned = true
+			return nil
+		},
+	}
+	deps, stdout, _ := newListTestDeps(mg)
+	cmd := NewDoctorCommand(deps, filepath.Join(dir, ".gwrc"))
+	cmd.rcPath = filepath.Join(dir, ".zshrc")
+	cmd.fix = true
+	cmd.yes = true
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(repaired) != 1 || repaired[0] != unlinked {
+		t.Errorf("Expected only %s to be repaired, got %v", unlinked, repaired)
+	}
+	if !pruned {
+		t.Error("Expected the missing worktree to be pruned")
+	}
+	for _, want := range []string{
+		"1 worktree is no longer linked to the repository: " + unlinked,
+		"1 worktree is missing its directory: " + missing,
+		"Repaired the worktree links",
+		"Pruned the missing worktrees",
+	} {
+		if !strings.Contains(stdout.String(), want) {
+			t.Errorf("Expected %q in the output, got:\n%s", want, stdout.String())
+		}
+	}
+}
+
+func TestDoctorCommand_Execute_ConfigWarnings(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), ".gwrc")
+	if err := os.WriteFile(configPath, []byte("new_window_cmd = wezterm start --cwd {path}\n"), 0644); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+
+	deps, stdout, _ := newListTestDeps(&mockGit{})
+	deps.Config.NewWindowCmd = "wezterm start --cwd {path}"
+	deps.Config.WorktreeRoot = "worktrees"
+
+	if err := NewDoctorCommand(deps, configPath).ExecuteConfig(); err != nil {
+		t.Fatalf("Expected warnings only, got: %v", err)
+	}
+	out := stdout.String()
+	for _, want := range []string{
+		"Config file " + configPath,
+		"auto_cd has no effect while new_window_cmd is set",
+		`worktree_root "worktrees" is relative`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Expected %q, got:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "git ") {
+		t.Errorf("Expected only the config checks, got:\n%s", out)
+	}
+
+	stdout.Reset()
+	if err := NewDoctorCommand(deps, configPath).Execute(); err != nil {
+		t.Fatalf("Expected warnings only, got: %v", err)
+	}
+	if !strings.Contains(stdout.String(), "auto_cd has no effect while new_window_cmd is set") {
+		t.Errorf("Expected gw doctor to report the config warnings, got:\n%s", stdout.String())
+	}
+}
@@ -2,18 +2,22 @@ package cmd
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/sotarok/gw/internal/git"
+	"github.com/sotarok/gw/internal/i18n"
 	"github.com/sotarok/gw/internal/iterm2"
 	"github.com/sotarok/gw/internal/spinner"
 )
 
 // endGit is the subset of git operations EndCommand actually uses.
 type endGit interface {
-	git.RepositoryReader // GetRepositoryName, FetchAll
-	git.WorktreeManager  // GetWorktreeForIssue, RemoveWorktreeByPath
-	git.BranchManager    // DeleteBranch
+	git.RepositoryReader // GetRepositoryName, GetMainRepositoryRoot, GetCurrentBranch, FetchAll
+	git.WorktreeManager  // GetWorktreeForIssue, RemoveWorktreeByPath, FormatPatch
+	git.BranchManager    // DeleteBranch, DeleteRemoteBranch, MergeBranch, BranchExists, ResolveBaseBranch, RemoteDefaultBranch
 	git.StatusChecker
 }
 
@@ -23,6 +27,13 @@ type EndCommand struct {
 	force          bool
 	noFetch        bool
 	noProjectHooks bool
+	deleteRemote   bool
+	merge          bool   // --merge: merge the branch into the base branch before removing
+	noFF           bool   // --no-ff: with --merge, always create a merge commit
+	squash         bool   // --squash: with --merge, commit the branch as one squashed commit
+	cdMain         bool   // --cd-main: send the shell to the main worktree afterwards
+	forceDelete    bool   // --force-delete-branch: delete the branch even if it is not merged
+	archiveDir     string // --archive: save the branch's commits as patches here before removing
 }
 
 // NewEndCommand creates a new end command handler
@@ -51,6 +62,15 @@ func (c *EndCommand) Execute(issueNumber string) error {
 		return err
 	}
 
+	// Resolved up front: once the worktree is gone, git can no longer be asked
+	// from inside it.
+	var mainRoot string
+	if c.cdMain {
+		if mainRoot, err = c.git().GetMainRepositoryRoot(); err != nil {
+			return fmt.Errorf("failed to find the main worktree: %w", err)
+		}
+	}
+
 	// Fetch from remotes if configured
 	fetchIfConfigured(c.deps, c.noFetch)
 
@@ -67,19 +87,59 @@ func (c *EndCommand) Execute(issueNumber string) error {
 		return err
 	}
 	if !proceed {
-		return nil
+		return errAborted
+	}
+
+	if c.archiveDir != "" {
+		if err := c.archive(issueNumber, worktreePath, branchName); err != nil {
+			return err
+		}
+	}
+
+	if c.merge {
+		if err := c.mergeIntoBase(branchName); err != nil {
+			return err
+		}
 	}
 
-	return c.remove(issueNumber, worktreePath, branchName, hookRepoName)
+	if err := c.remove(issueNumber, worktreePath, branchName, hookRepoName); err != nil {
+		return err
+	}
+	if c.cdMain {
+		c.goToMain(mainRoot)
+	}
+	return nil
+}
+
+// goToMain hands mainRoot to the shell integration when it is active, and
+// otherwise prints it as the last line of output, for wrapping in cd "$(...)".
+func (c *EndCommand) goToMain(mainRoot string) {
+	if os.Getenv(envCDFile) != "" {
+		// The removed worktree is not worth returning to with checkout -.
+		writeCDFile(c.deps, "", mainRoot)
+		return
+	}
+	printWorktreePath(c.deps.Stdout, mainRoot)
 }
 
-// resolveWorktree determines the worktree to remove, either via interactive
-// selection (when issueNumber is empty) or by looking it up from the issue
-// number. It returns the resolved issue number, worktree path, and branch name.
+// resolveWorktree determines the worktree to remove, either by looking it up
+// from the issue number or, when issueNumber is empty, from the current branch
+// or via interactive selection. It returns the resolved issue number, worktree
+// path, and branch name.
 func (c *EndCommand) resolveWorktree(issueNumber string) (resolvedIssue, worktreePath, branchName string, err error) {
 	if issueNumber == "" {
+		if wt, issue := c.currentWorktree(); wt != nil {
+			useCurrent, offerErr := c.offerCurrentWorktree(issue, wt)
+			if offerErr != nil {
+				return "", "", "", offerErr
+			}
+			if useCurrent {
+				return issue, wt.Path, wt.Branch, nil
+			}
+		}
+
 		// Interactive mode
-		fmt.Fprintf(c.deps.Stdout, "No issue number provided, entering interactive mode...\n")
+		fmt.Fprintln(c.deps.Stdout, i18n.T(i18n.MsgEndInteractive))
 
 		selected, selErr := c.deps.UI.SelectWorktree()
 		if selErr != nil {
@@ -110,6 +170,40 @@ func (c *EndCommand) resolveWorktree(issueNumber string) (resolvedIssue, worktre
 	return issueNumber, worktreePath, branchName, nil
 }
 
+// currentWorktree returns the worktree checked out on the current branch and
+// the issue it was created for, or nil when the current branch is not a gw
+// issue branch (e.g. in the main worktree).
+func (c *EndCommand) currentWorktree() (*git.WorktreeInfo, string) {
+	branch, err := c.git().GetCurrentBranch()
+	if err != nil {
+		return nil, ""
+	}
+	issue := git.ExtractIssueFromBranch(branch)
+	if issue == "" {
+		return nil, ""
+	}
+	wt, err := c.git().GetWorktreeForIssue(branch)
+	if err != nil || wt == nil || wt.Branch != branch {
+		return nil, ""
+	}
+	return wt, issue
+}
+
+// offerCurrentWorktree asks whether to end the current worktree; declining
+// falls back to interactive selection. With --force it is used without asking.
+func (c *EndCommand) offerCurrentWorktree(issue string, wt *git.WorktreeInfo) (bool, error) {
+	if c.force {
+		fmt.Fprintln(c.deps.Stdout, i18n.T(i18n.MsgEndUsingCurrent, issue, wt.Branch))
+		return true, nil
+	}
+	fmt.Fprint(c.deps.Stdout, i18n.T(i18n.MsgEndOfferCurrent, issue, wt.Branch))
+	confirmed, err := c.deps.UI.ConfirmPrompt(" (y/N): ")
+	if err != nil {
+		return false, fmt.Errorf("failed to read response: %w", err)
+	}
+	return confirmed, nil
+}
+
 // confirmRemoval runs the safety checks (unless forced) and, when they raise
 // warnings, prompts the user to continue. It returns whether the removal should
 // proceed.
@@ -118,26 +212,33 @@ func (c *EndCommand) confirmRemoval(issueNumber, worktreePath, branchName string
 		return true, nil
 	}
 
+	if !c.merge {
+		noteMissingRemote(c.deps, c.git())
+	}
 	sp := spinner.New(fmt.Sprintf("Checking worktree for issue #%s...", issueNumber), c.deps.Stdout)
 	sp.Start()
-	warnings := c.performSafetyChecks(worktreePath, branchName)
+	evaluate := EvaluateWorktreeSafety
+	if c.merge {
+		evaluate = evaluateMergeSafety
+	}
+	canRemove, warnings := evaluate(c.git(), worktreePath, branchName, defaultBaseBranch)
 	sp.Stop()
 
 	// If there are warnings, ask for confirmation
-	if len(warnings) > 0 {
-		fmt.Fprintf(c.deps.Stderr, "\n%s Safety check warnings:\n", coloredWarning())
+	if !canRemove {
+		fmt.Fprintf(c.deps.Stderr, "\n%s %s\n", coloredWarning(), i18n.T(i18n.MsgSafetyWarnings))
 		for _, warning := range warnings {
-			fmt.Fprintf(c.deps.Stderr, "  • %s\n", warning)
+			fmt.Fprintf(c.deps.Stderr, "  %s %s\n", activeTheme.bullet, warning)
 		}
 
-		fmt.Fprintf(c.deps.Stdout, "\nDo you want to continue?")
+		fmt.Fprintf(c.deps.Stdout, "\n%s", i18n.T(i18n.MsgContinuePrompt))
 		confirmed, err := c.deps.UI.ConfirmPrompt(" (y/N): ")
 		if err != nil {
 			return false, fmt.Errorf("failed to read response: %w", err)
 		}
 
 		if !confirmed {
-			fmt.Fprintf(c.deps.Stdout, "Aborted.\n")
+			fmt.Fprintln(c.deps.Stdout, i18n.T(i18n.MsgAborted))
 			return false, nil
 		}
 	}
@@ -145,6 +246,108 @@ func (c *EndCommand) confirmRemoval(issueNumber, worktreePath, branchName string
 	return true, nil
 }
 
+// mergeIntoBase merges branchName into the base branch in the main worktree,
+// switching it to the base branch first. It refuses to touch a main worktree
+// with uncommitted changes; a failed merge is aborted and returned, so the
+// worktree being ended is kept.
+func (c *EndCommand) mergeIntoBase(branchName string) error {
+	if branchName == "" {
+		return fmt.Errorf("cannot merge a worktree in detached HEAD state")
+	}
+	mainRoot, err := c.git().GetMainRepositoryRoot()
+	if err != nil {
+		return fmt.Errorf("failed to find the main worktree: %w", err)
+	}
+	dirty, err := c.git().HasUncommittedChanges(mainRoot)
+	if err != nil {
+		return err
+	}
+	if dirty {
+		return fmt.Errorf("cannot merge into %s: the main worktree at %s has uncommitted changes", defaultBaseBranch, mainRoot)
+	}
+
+	opts := git.MergeOptions{NoFF: c.noFF, Squash: c.squash}
+	if err := c.git().MergeBranch(mainRoot, defaultBaseBranch, branchName, opts); err != nil {
+		return fmt.Errorf("%w; the worktree was kept", err)
+	}
+	verb := "Merged"
+	if c.squash {
+		verb = "Squash-merged"
+	}
+	fmt.Fprintf(c.deps.Stdout, "%s %s %s into %s\n", coloredSuccess(), verb, branchName, defaultBaseBranch)
+	return nil
+}
+
+// archive writes the commits the worktree has on top of the base branch into
+// <archiveDir>/<branch>-<date> as git format-patch files, so the work can be
+// brought back with git am after the branch is gone. It runs before anything
+// is removed; when it fails, the worktree is kept.
+func (c *EndCommand) archive(issueNumber, worktreePath, branchName string) error {
+	name := branchName
+	if name == "" {
+		name = issueNumber
+	}
+	dir := filepath.Join(c.archiveDir, git.SanitizeBranchNameForDirectory(name)+"-"+time.Now().Format("2006-01-02"))
+	base := c.archiveBase()
+	files, err := c.git().FormatPatch(worktreePath, base, dir)
+	if err != nil {
+		return fmt.Errorf("failed to archive %s: %w; the worktree was kept", name, err)
+	}
+	if dryRun {
+		return nil
+	}
+	if len(files) == 0 {
+		// git format-patch creates the directory even when it writes nothing.
+		_ = os.Remove(dir)
+		fmt.Fprintf(c.deps.Stdout, "%s No commits on %s since %s; nothing to archive\n", coloredWarning(), name, base)
+		return nil
+	}
+	fmt.Fprintf(c.deps.Stdout, "%s Archived %d %s to %s\n", coloredSuccess(), len(files), plural(len(files), "patch", "patches"), dir)
+	return nil
+}
+
+// archiveBase returns the ref --archive exports the commits since: main,
+// local or on the remote, as for the merge check; without one, the remote's
+// default branch (e.g. origin/develop), then master.
+func (c *EndCommand) archiveBase() string {
+	g := c.git()
+	if exists, _ := g.BranchExists(defaultBaseBranch); exists {
+		base, _ := g.ResolveBaseBranch(defaultBaseBranch)
+		return base
+	}
+	if base, err := g.RemoteDefaultBranch(); err == nil {
+		return base
+	}
+	if exists, _ := g.BranchExists("master"); exists {
+		base, _ := g.ResolveBaseBranch("master")
+		return base
+	}
+	return defaultBaseBranch
+}
+
+// deleteBranch deletes the local branch when auto_remove_branch is enabled, it
+// was just merged with --merge or --force-delete-branch was given, then the
+// remote branch when requested. A local branch that could not be deleted (e.g.
+// not fully merged) keeps its remote counterpart too. A squash-merged branch
+// is never an ancestor of the base branch, so it is deleted with force.
+func (c *EndCommand) deleteBranch(branchName string) {
+	if c.deps.Config.AutoRemoveBranch || c.merge || c.forceDelete {
+		fmt.Fprintln(c.deps.Stdout, i18n.T(i18n.MsgDeletingBranch, branchName))
+		if err := c.git().DeleteBranch(branchName, c.forceDelete || (c.merge && c.squash)); err != nil {
+			// Don't fail the command, just warn
+			warnBranchNotDeleted(c.deps, branchName, err)
+			return
+		}
+		if c.forceDelete {
+			fmt.Fprintf(c.deps.Stdout, "%s Force-deleted branch %s, including any unmerged commits\n", coloredSuccess(), branchName)
+		} else {
+			fmt.Fprintf(c.deps.Stdout, "%s %s\n", coloredSuccess(), i18n.T(i18n.MsgEndBranchDeleted, branchName))
+		}
+	}
+
+	deleteRemoteBranchIfConfigured(c.deps, c.git(), branchName, c.deleteRemote)
+}
+
 // remove runs the pre-end hook, removes the worktree, optionally deletes the
 // branch, and resets the iTerm2 tab.
 func (c *EndCommand) remove(issueNumber, worktreePath, branchName, hookRepoName string) error {
@@ -167,17 +370,10 @@ func (c *EndCommand) remove(issueNumber, worktreePath, branchName, hookRepoName
 		return removeErr
 	}
 
-	fmt.Fprintf(c.deps.Stdout, "%s Successfully removed worktree for issue #%s\n", coloredSuccess(), issueNumber)
+	fmt.Fprintf(c.deps.Stdout, "%s %s\n", coloredSuccess(), i18n.T(i18n.MsgEndRemoved, issueNumber))
 
-	// Delete the branch if auto-remove is enabled
-	if c.deps.Config.AutoRemoveBranch && branchName != "" {
-		fmt.Fprintf(c.deps.Stdout, "Deleting branch %s...\n", branchName)
-		if err := c.git().DeleteBranch(branchName); err != nil {
-			// Don't fail the command, just warn
-			fmt.Fprintf(c.deps.Stderr, "%s Failed to delete branch %s: %v\n", coloredWarning(), branchName, err)
-		} else {
-			fmt.Fprintf(c.deps.Stdout, "%s Successfully deleted branch %s\n", coloredSuccess(), branchName)
-		}
+	if branchName != "" {
+		c.deleteBranch(branchName)
 	}
 
 	// Reset iTerm2 tab if configured
@@ -187,32 +383,3 @@ func (c *EndCommand) remove(issueNumber, worktreePath, branchName, hookRepoName
 
 	return nil
 }
-
-// performSafetyChecks runs the three safety checks for the worktree at
-// worktreePath in parallel and formats them into end's warning wording.
-// Check failures are reported on stderr; only tripped checks become warnings.
-func (c *EndCommand) performSafetyChecks(worktreePath, branchName string) []string {
-	res := runSafetyChecks(c.git(), worktreePath, branchName, defaultBaseBranch)
-
-	checks := []struct {
-		check    safetyCheck
-		warning  string
-		errLabel string
-	}{
-		{res.Uncommitted, "You have uncommitted changes", "Could not check for uncommitted changes"},
-		{res.Unpushed, "You have unpushed commits", "Could not check for unpushed commits"},
-		{res.Merged, "Branch is not merged to main", "Could not check merge status"},
-	}
-
-	var warnings []string
-	for _, c2 := range checks {
-		if c2.check.Err != nil {
-			fmt.Fprintf(c.deps.Stderr, "%s Warning: %s: %v\n", coloredWarning(), c2.errLabel, c2.check.Err)
-			continue
-		}
-		if c2.check.Tripped {
-			warnings = append(warnings, c2.warning)
-		}
-	}
-	return warnings
-}
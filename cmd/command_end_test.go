@@ -4,132 +4,16 @@ import (
 	"bytes"
 	"fmt"
 	"os"
+	"os/exec"
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/sotarok/gw/internal/config"
 	"github.com/sotarok/gw/internal/git"
 )
 
-func TestEndCommand_PerformSafetyChecks(t *testing.T) {
-	tests := []struct {
-		name             string
-		mockSetup        func() *mockGit
-		expectedWarnings []string
-		checkStderr      bool
-	}{
-		{
-			name: "no warnings when everything is clean",
-			mockSetup: func() *mockGit {
-				return &mockGit{
-					HasUncommittedChangesFn: func() (bool, error) { return false, nil },
-					HasUnpushedCommitsFn:    func() (bool, error) { return false, nil },
-				}
-			},
-			expectedWarnings: []string{},
-		},
-		{
-			name: "warns about uncommitted changes",
-			mockSetup: func() *mockGit {
-				return &mockGit{
-					HasUncommittedChangesFn: func() (bool, error) { return true, nil },
-					HasUnpushedCommitsFn:    func() (bool, error) { return false, nil },
-				}
-			},
-			expectedWarnings: []string{"You have uncommitted changes"},
-		},
-		{
-			name: "warns about unpushed commits",
-			mockSetup: func() *mockGit {
-				return &mockGit{
-					HasUncommittedChangesFn: func() (bool, error) { return false, nil },
-					HasUnpushedCommitsFn:    func() (bool, error) { return true, nil },
-				}
-			},
-			expectedWarnings: []string{"You have unpushed commits"},
-		},
-		{
-			name: "multiple warnings",
-			mockSetup: func() *mockGit {
-				return &mockGit{
-					HasUncommittedChangesFn: func() (bool, error) { return true, nil },
-					HasUnpushedCommitsFn:    func() (bool, error) { return true, nil },
-					IsMergedToBaseBranchFn:  func(targetBranch string) (bool, error) { return false, nil },
-				}
-			},
-			expectedWarnings: []string{
-				"You have uncommitted changes",
-				"You have unpushed commits",
-				"Branch is not merged to main",
-			},
-		},
-		{
-			name: "handles errors checking uncommitted changes",
-			mockSetup: func() *mockGit {
-				return &mockGit{
-					HasUncommittedChangesFn: func() (bool, error) {
-						return false, fmt.Errorf("git command failed")
-					},
-					HasUnpushedCommitsFn: func() (bool, error) { return false, nil },
-				}
-			},
-			expectedWarnings: []string{}, // No warnings added on error
-			checkStderr:      true,
-		},
-		{
-			name: "handles errors checking unpushed commits",
-			mockSetup: func() *mockGit {
-				return &mockGit{
-					HasUncommittedChangesFn: func() (bool, error) { return false, nil },
-					HasUnpushedCommitsFn: func() (bool, error) {
-						return false, fmt.Errorf("no upstream branch")
-					},
-				}
-			},
-			expectedWarnings: []string{}, // No warnings added on error
-			checkStderr:      true,
-		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			stderr := &bytes.Buffer{}
-			mockGit := tt.mockSetup()
-
-			deps := &Dependencies{
-				Config: config.New(),
-				Git:    mockGit,
-				Stderr: stderr,
-			}
-
-			cmd := NewEndCommand(deps, false, true, false)
-			warnings := cmd.performSafetyChecks("/test/worktree", "feature/test")
-
-			// Check warnings count
-			if len(warnings) != len(tt.expectedWarnings) {
-				t.Errorf("Expected %d warnings, got %d: %v",
-					len(tt.expectedWarnings), len(warnings), warnings)
-			}
-
-			// Check warning messages
-			for i, expected := range tt.expectedWarnings {
-				if i >= len(warnings) {
-					break
-				}
-				if warnings[i] != expected {
-					t.Errorf("Warning %d: expected %q, got %q", i, expected, warnings[i])
-				}
-			}
-
-			// Check stderr output if there were errors
-			if tt.checkStderr && !strings.Contains(stderr.String(), "⚠ Warning:") {
-				t.Error("Expected warning in stderr for error cases")
-			}
-		})
-	}
-}
-
 func TestEndCommand_Execute(t *testing.T) {
 	// Save and restore working directory
 	originalDir, _ := os.Getwd()
@@ -198,7 +82,7 @@ func TestEndCommand_Execute(t *testing.T) {
 				if !contains(stderr, "Safety check warnings:") {
 					t.Error("Expected warnings in stderr")
 				}
-				if !contains(stderr, "You have uncommitted changes") {
+				if !contains(stderr, "uncommitted changes") {
 					t.Error("Expected uncommitted changes warning")
 				}
 				if !contains(stdout, "✓ Successfully removed worktree") {
@@ -223,6 +107,7 @@ func TestEndCommand_Execute(t *testing.T) {
 				ui := &mockUI{confirmResult: false}
 				return mockGitInstance, ui, &mockDetect{}, func() { os.RemoveAll(tempDir) }
 			},
+			expectedError: "aborted",
 			checkOutput: func(t *testing.T, stdout, stderr string) {
 				if !contains(stderr, "Safety check warnings:") {
 					t.Error("Expected warnings in stderr")
@@ -366,7 +251,7 @@ func TestEndCommand_BranchDeletion(t *testing.T) {
 					}, nil
 				}
 				deleteCalled := false
-				mockGitInstance.DeleteBranchFn = func(branch string) error {
+				mockGitInstance.DeleteBranchFn = func(branch string, force bool) error {
 					deleteCalled = true
 					t.Error("DeleteBranch should not be called when auto-remove is disabled")
 					return nil
@@ -396,7 +281,7 @@ func TestEndCommand_BranchDeletion(t *testing.T) {
 					}, nil
 				}
 				var deletedBranch string
-				mockGitInstance.DeleteBranchFn = func(branch string) error {
+				mockGitInstance.DeleteBranchFn = func(branch string, force bool) error {
 					deletedBranch = branch
 					return nil
 				}
@@ -424,7 +309,7 @@ func TestEndCommand_BranchDeletion(t *testing.T) {
 						Branch: "123/impl",
 					}, nil
 				}
-				mockGitInstance.DeleteBranchFn = func(branch string) error {
+				mockGitInstance.DeleteBranchFn = func(branch string, force bool) error {
 					return fmt.Errorf("branch is checked out in another worktree")
 				}
 				return mockGitInstance, &mockUI{}, &mockDetect{}, func() {
@@ -450,7 +335,7 @@ func TestEndCommand_BranchDeletion(t *testing.T) {
 					}, nil
 				}
 				var deletedBranch string
-				mockGitInstance.DeleteBranchFn = func(branch string) error {
+				mockGitInstance.DeleteBranchFn = func(branch string, force bool) error {
 					deletedBranch = branch
 					return nil
 				}
@@ -481,7 +366,7 @@ func TestEndCommand_BranchDeletion(t *testing.T) {
 				}
 				mockGitInstance.HasUncommittedChangesFn = func() (bool, error) { return true, nil }
 				var deletedBranch string
-				mockGitInstance.DeleteBranchFn = func(branch string) error {
+				mockGitInstance.DeleteBranchFn = func(branch string, force bool) error {
 					deletedBranch = branch
 					return nil
 				}
@@ -545,6 +430,93 @@ func TestEndCommand_BranchDeletion(t *testing.T) {
 	}
 }
 
+func TestEndCommand_DeleteRemoteBranch(t *testing.T) {
+	originalDir, _ := os.Getwd()
+	defer os.Chdir(originalDir)
+
+	tests := []struct {
+		name             string
+		autoRemoveBranch bool
+		configRemote     bool
+		flagRemote       bool
+		localDeleteErr   error
+		remoteDeleteErr  error
+		expectRemoteCall bool
+		expectStderr     string
+	}{
+		{name: "disabled by default", autoRemoveBranch: true},
+		{name: "enabled by flag", autoRemoveBranch: true, flagRemote: true, expectRemoteCall: true},
+		{name: "enabled by config", autoRemoveBranch: true, configRemote: true, expectRemoteCall: true},
+		{name: "runs without auto_remove_branch", flagRemote: true, expectRemoteCall: true},
+		{
+			name:             "skipped when local deletion fails",
+			autoRemoveBranch: true,
+			flagRemote:       true,
+			localDeleteErr:   fmt.Errorf("branch not fully merged"),
+			expectStderr:     "Failed to delete branch",
+		},
+		{
+			name:             "remote failure is a warning",
+			autoRemoveBranch: true,
+			flagRemote:       true,
+			remoteDeleteErr:  fmt.Errorf("remote rejected"),
+			expectRemoteCall: true,
+			expectStderr:     "Failed to delete remote branch 123/impl",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := os.Chdir(t.TempDir()); err != nil {
+				t.Fatalf("Failed to change to test directory: %v", err)
+			}
+			worktreeDir := t.TempDir()
+
+			var remoteDeleted string
+			mg := &mockGit{
+				GetWorktreeForIssueFn: func(string) (*git.WorktreeInfo, error) {
+					return &git.WorktreeInfo{Path: worktreeDir, Branch: testBranch123}, nil
+				},
+				DeleteBranchFn: func(string, bool) error { return tt.localDeleteErr },
+				DeleteRemoteBranchFn: func(branch string) error {
+					remoteDeleted = branch
+					return tt.remoteDeleteErr
+				},
+			}
+			stdout := &bytes.Buffer{}
+			stderr := &bytes.Buffer{}
+			deps := &Dependencies{
+				Git:    mg,
+				UI:     &mockUI{},
+				Detect: &mockDetect{},
+				Config: &config.Config{AutoRemoveBranch: tt.autoRemoveBranch, DeleteRemoteBranch: tt.configRemote},
+				Stdout: stdout,
+				Stderr: stderr,
+			}
+
+			cmd := NewEndCommand(deps, false, true, false)
+			cmd.deleteRemote = tt.flagRemote
+			if err := cmd.Execute("123"); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if tt.expectRemoteCall && remoteDeleted != testBranch123 {
+				t.Errorf("Expected remote branch %s to be deleted, got %q", testBranch123, remoteDeleted)
+			}
+			if !tt.expectRemoteCall && remoteDeleted != "" {
+				t.Errorf("Expected no remote deletion, got %q", remoteDeleted)
+			}
+			if tt.expectRemoteCall && tt.remoteDeleteErr == nil &&
+				!strings.Contains(stdout.String(), "Deleted remote branch origin/"+testBranch123) {
+				t.Errorf("Expected remote deletion message, got: %s", stdout.String())
+			}
+			if tt.expectStderr != "" && !strings.Contains(stderr.String(), tt.expectStderr) {
+				t.Errorf("Expected stderr to contain %q, got: %s", tt.expectStderr, stderr.String())
+			}
+		})
+	}
+}
+
 // Additional EndCommand tests for uncovered paths
 
 func TestEndCommand_Execute_InteractiveSelectError(t *testing.T) {
@@ -611,6 +583,77 @@ func TestEndCommand_Execute_EmptyIssueFromBranch(t *testing.T) {
 	}
 }
 
+func TestEndCommand_Execute_InferFromCurrentBranch(t *testing.T) {
+	originalDir, _ := os.Getwd()
+	defer os.Chdir(originalDir)
+
+	tempDir := t.TempDir()
+	if err := os.Chdir(tempDir); err != nil {
+		t.Fatalf("Failed to chdir: %v", err)
+	}
+
+	newDeps := func(ui *mockUI) (*Dependencies, *string) {
+		var removed string
+		mg := &mockGit{
+			GetCurrentBranchFn: func() (string, error) { return testBranch123, nil },
+			GetWorktreeForIssueFn: func(id string) (*git.WorktreeInfo, error) {
+				if id != testBranch123 {
+					t.Errorf("Expected lookup by the current branch, got %q", id)
+				}
+				return &git.WorktreeInfo{Path: tempDir, Branch: testBranch123}, nil
+			},
+			RemoveWorktreeByPathFn: func(path string) error {
+				removed = path
+				return nil
+			},
+		}
+		return &Dependencies{
+			Config: config.New(),
+			Git:    mg,
+			UI:     ui,
+			Stdout: &bytes.Buffer{},
+			Stderr: &bytes.Buffer{},
+		}, &removed
+	}
+
+	t.Run("accepted offer targets the current worktree", func(t *testing.T) {
+		ui := &mockUI{confirmResult: true}
+		ui.SelectWorktreeFn = func() (*git.WorktreeInfo, error) {
+			t.Fatal("Interactive selection should not be used")
+			return nil, nil
+		}
+		deps, removed := newDeps(ui)
+
+		if err := NewEndCommand(deps, false, true, false).Execute(""); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if !strings.Contains(deps.Stdout.(*bytes.Buffer).String(), "End the current worktree for issue #123 (123/impl)?") {
+			t.Errorf("Expected the current worktree to be offered, got:\n%s", deps.Stdout)
+		}
+		if *removed != tempDir {
+			t.Errorf("Expected %s to be removed, got %q", tempDir, *removed)
+		}
+	})
+
+	t.Run("declined offer falls back to interactive selection", func(t *testing.T) {
+		selected := false
+		ui := &mockUI{confirmResult: false}
+		ui.SelectWorktreeFn = func() (*git.WorktreeInfo, error) {
+			selected = true
+			return nil, fmt.Errorf("user canceled selection")
+		}
+		deps, _ := newDeps(ui)
+
+		err := NewEndCommand(deps, false, true, false).Execute("")
+		if !selected {
+			t.Error("Expected interactive selection after declining")
+		}
+		if err == nil || !strings.Contains(err.Error(), "user canceled selection") {
+			t.Errorf("Expected selection error, got: %v", err)
+		}
+	})
+}
+
 func TestEndCommand_Execute_ConfirmPromptError(t *testing.T) {
 	originalDir, _ := os.Getwd()
 	defer os.Chdir(originalDir)
@@ -690,33 +733,6 @@ func TestEndCommand_Execute_RemoveWorktreeByPathError(t *testing.T) {
 	}
 }
 
-func TestEndCommand_PerformSafetyChecks_MergeStatusError(t *testing.T) {
-	stderr := &bytes.Buffer{}
-	mg := &mockGit{
-		HasUncommittedChangesFn: func() (bool, error) { return false, nil },
-		HasUnpushedCommitsFn:    func() (bool, error) { return false, nil },
-		IsMergedToBaseBranchFn: func(targetBranch string) (bool, error) {
-			return false, fmt.Errorf("could not check merge status")
-		},
-	}
-
-	deps := &Dependencies{
-		Config: config.New(),
-		Git:    mg,
-		Stderr: stderr,
-	}
-
-	cmd := NewEndCommand(deps, false, true, false)
-	warnings := cmd.performSafetyChecks("/test/worktree", "feature/test")
-
-	if len(warnings) != 0 {
-		t.Errorf("Expected 0 warnings on error, got %d: %v", len(warnings), warnings)
-	}
-	if !strings.Contains(stderr.String(), "Could not check merge status") {
-		t.Error("Expected merge status error in stderr")
-	}
-}
-
 func TestEndCommand_Execute_PreEndHook(t *testing.T) {
 	originalDir, _ := os.Getwd()
 	defer os.Chdir(originalDir)
@@ -884,3 +900,406 @@ func TestEndCommand_Execute_PreEndHookFailure(t *testing.T) {
 		t.Errorf("Expected worktree to still be removed on hook failure, got:\n%s", stdout.String())
 	}
 }
+
+func TestEndCommand_Execute_Merge(t *testing.T) {
+	// setupMergeRepo creates a repository on main with a worktree for issue
+	// 123 that changes file.txt. With conflicting, main changes file.txt too.
+	setupMergeRepo := func(t *testing.T, conflicting bool) (repo, worktree string, runGit func(dir string, args ...string) string) {
+		t.Helper()
+		repo, _ = newDryRunTestRepo(t)
+		worktree = filepath.Join(filepath.Dir(repo), "repo-123")
+		runGit = func(dir string, args ...string) string {
+			t.Helper()
+			cmd := exec.Command("git", args...)
+			cmd.Dir = dir
+			out, err := cmd.CombinedOutput()
+			if err != nil {
+				t.Fatalf("git %v failed: %v\n%s", args, err, out)
+			}
+			return strings.TrimSpace(string(out))
+		}
+		writeFile := func(dir, content string) {
+			if err := os.WriteFile(filepath.Join(dir, "file.txt"), []byte(content), 0644); err != nil {
+				t.Fatalf("write: %v", err)
+			}
+		}
+		writeFile(repo, "base\n")
+		runGit(repo, "add", "file.txt")
+		runGit(repo, "commit", "-m", "add file")
+		runGit(repo, "worktree", "add", "-b", testBranch123, worktree)
+		writeFile(worktree, "feature\n")
+		runGit(worktree, "commit", "-am", "feature change")
+		if conflicting {
+			writeFile(repo, "main\n")
+			runGit(repo, "commit", "-am", "conflicting change")
+		}
+		return repo, worktree, runGit
+	}
+
+	newMergeDeps := func() (*Dependencies, *bytes.Buffer) {
+		stdout := &bytes.Buffer{}
+		return &Dependencies{
+			Git:    git.NewClient(),
+			UI:     &mockUI{},
+			Detect: &mockDetect{},
+			Config: config.New(),
+			Stdout: stdout,
+			Stderr: &bytes.Buffer{},
+		}, stdout
+	}
+
+	t.Run("fast-forwards main and removes the worktree and branch", func(t *testing.T) {
+		repo, worktree, runGit := setupMergeRepo(t, false)
+		featureHead := runGit(worktree, "rev-parse", "HEAD")
+		deps, stdout := newMergeDeps()
+
+		cmd := NewEndCommand(deps, false, true, false)
+		cmd.merge = true
+		if err := cmd.Execute("123"); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if head := runGit(repo, "rev-parse", "main"); head != featureHead {
+			t.Errorf("Expected main to be fast-forwarded to %s, got %s", featureHead, head)
+		}
+		if _, err := os.Stat(worktree); !os.IsNotExist(err) {
+			t.Errorf("Expected the worktree to be removed, stat error: %v", err)
+		}
+		if branches := runGit(repo, "branch", "--list", testBranch123); branches != "" {
+			t.Errorf("Expected branch %s to be deleted, got %q", testBranch123, branches)
+		}
+		if !strings.Contains(stdout.String(), "Merged 123/impl into main") {
+			t.Errorf("Expected a merge message, got:\n%s", stdout.String())
+		}
+	})
+
+	t.Run("squash-merges into a single commit on main", func(t *testing.T) {
+		repo, worktree, runGit := setupMergeRepo(t, false)
+		mainBefore := runGit(repo, "rev-parse", "main")
+		deps, stdout := newMergeDeps()
+
+		cmd := NewEndCommand(deps, false, true, false)
+		cmd.merge = true
+		cmd.squash = true
+		if err := cmd.Execute("123"); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if parent := runGit(repo, "rev-parse", "main^"); parent != mainBefore {
+			t.Errorf("Expected a single new commit on top of %s, main^ is %s", mainBefore, parent)
+		}
+		if subject := runGit(repo, "log", "-1", "--format=%s", "main"); subject != "Squashed commit of 123/impl" {
+			t.Errorf("Unexpected squash commit subject %q", subject)
+		}
+		if content, _ := os.ReadFile(filepath.Join(repo, "file.txt")); string(content) != "feature\n" {
+			t.Errorf("Expected main to contain the feature change, got %q", content)
+		}
+		if _, err := os.Stat(worktree); !os.IsNotExist(err) {
+			t.Errorf("Expected the worktree to be removed, stat error: %v", err)
+		}
+		if branches := runGit(repo, "branch", "--list", testBranch123); branches != "" {
+			t.Errorf("Expected branch %s to be deleted, got %q", testBranch123, branches)
+		}
+		if !strings.Contains(stdout.String(), "Squash-merged 123/impl into main") {
+			t.Errorf("Expected a squash message, got:\n%s", stdout.String())
+		}
+	})
+
+	t.Run("aborts on conflicts and keeps the worktree", func(t *testing.T) {
+		repo, worktree, runGit := setupMergeRepo(t, true)
+		mainBefore := runGit(repo, "rev-parse", "main")
+		deps, _ := newMergeDeps()
+
+		cmd := NewEndCommand(deps, false, true, false)
+		cmd.merge = true
+		err := cmd.Execute("123")
+		if err == nil || !strings.Contains(err.Error(), "the worktree was kept") {
+			t.Fatalf("Expected a merge failure, got: %v", err)
+		}
+
+		if head := runGit(repo, "rev-parse", "main"); head != mainBefore {
+			t.Errorf("Expected main to stay at %s, got %s", mainBefore, head)
+		}
+		if status := runGit(repo, "status", "--porcelain"); status != "" {
+			t.Errorf("Expected a clean main worktree after the abort, got:\n%s", status)
+		}
+		if _, err := os.Stat(worktree); err != nil {
+			t.Errorf("Expected the worktree to be kept: %v", err)
+		}
+		if branches := runGit(repo, "branch", "--list", testBranch123); branches == "" {
+			t.Errorf("Expected branch %s to be kept", testBranch123)
+		}
+	})
+
+	t.Run("refuses a dirty main worktree", func(t *testing.T) {
+		mg := &mockGit{
+			GetWorktreeForIssueFn: func(string) (*git.WorktreeInfo, error) {
+				return &git.WorktreeInfo{Path: "/repo-123", Branch: testBranch123}, nil
+			},
+			GetMainRepositoryRootFn:   func() (string, error) { return "/repo", nil },
+			HasUncommittedChangesAtFn: func(path string) (bool, error) { return path == "/repo", nil },
+			MergeBranchFn: func(string, string, string, git.MergeOptions) error {
+				t.Error("MergeBranch must not be called with a dirty main worktree")
+				return nil
+			},
+			RemoveWorktreeByPathFn: func(string) error {
+				t.Error("the worktree must not be removed")
+				return nil
+			},
+		}
+		deps := &Dependencies{Git: mg, UI: &mockUI{}, Config: config.New(), Stdout: &bytes.Buffer{}, Stderr: &bytes.Buffer{}}
+
+		cmd := NewEndCommand(deps, false, true, false)
+		cmd.merge = true
+		err := cmd.Execute("123")
+		if err == nil || !strings.Contains(err.Error(), "the main worktree at /repo has uncommitted changes") {
+			t.Errorf("Expected a dirty main worktree error, got: %v", err)
+		}
+	})
+}
+
+func TestEndCommand_Execute_CDMain(t *testing.T) {
+	newCDMainDeps := func() (*Dependencies, *bytes.Buffer) {
+		mg := &mockGit{
+			GetWorktreeForIssueFn: func(string) (*git.WorktreeInfo, error) {
+				return &git.WorktreeInfo{Path: "/repo-123", Branch: testBranch123}, nil
+			},
+			GetMainRepositoryRootFn: func() (string, error) { return "/repo", nil },
+		}
+		cfg := config.New()
+		cfg.AutoRemoveBranch = true
+		stdout := &bytes.Buffer{}
+		return &Dependencies{Git: mg, UI: &mockUI{}, Config: cfg, Stdout: stdout, Stderr: &bytes.Buffer{}}, stdout
+	}
+
+	t.Run("prints the main worktree last", func(t *testing.T) {
+		t.Setenv(envCDFile, "")
+		deps, stdout := newCDMainDeps()
+
+		cmd := NewEndCommand(deps, true, true, false)
+		cmd.cdMain = true
+		if err := cmd.Execute("123"); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		lines := strings.Split(strings.TrimRight(stdout.String(), "\n"), "\n")
+		if last := lines[len(lines)-1]; last != "/repo" {
+			t.Errorf("Expected /repo as the final line, got %q in:\n%s", last, stdout.String())
+		}
+	})
+
+	t.Run("hands the main worktree to shell integration", func(t *testing.T) {
+		t.Setenv("HOME", t.TempDir())
+		cdFile := filepath.Join(t.TempDir(), "cd")
+		t.Setenv(envCDFile, cdFile)
+		deps, stdout := newCDMainDeps()
+
+		cmd := NewEndCommand(deps, true, true, false)
+		cmd.cdMain = true
+		if err := cmd.Execute("123"); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if got, _ := os.ReadFile(cdFile); string(got) != "/repo" {
+			t.Errorf("%s content = %q, want /repo", envCDFile, got)
+		}
+		if strings.Contains(stdout.String(), "/repo\n") {
+			t.Errorf("Expected the path not to be printed with shell integration, got:\n%s", stdout.String())
+		}
+	})
+}
+
+func TestEndCommand_Execute_ForceDeleteBranch(t *testing.T) {
+	for _, forceDelete := range []bool{false, true} {
+		t.Run(fmt.Sprintf("force-delete-branch=%v", forceDelete), func(t *testing.T) {
+			deleted := false
+			mg := &mockGit{
+				GetWorktreeForIssueFn: func(string) (*git.WorktreeInfo, error) {
+					return &git.WorktreeInfo{Path: "/repo-123", Branch: testBranch123}, nil
+				},
+				// Simulate an unmerged branch: git branch -d refuses it.
+				DeleteBranchFn: func(branch string, force bool) error {
+					if !force {
+						return fmt.Errorf("the branch '%s' is not fully merged", branch)
+					}
+					deleted = true
+					return nil
+				},
+			}
+			cfg := config.New()
+			cfg.AutoRemoveBranch = true
+			stdout := &bytes.Buffer{}
+			stderr := &bytes.Buffer{}
+			deps := &Dependencies{Git: mg, UI: &mockUI{}, Config: cfg, Stdout: stdout, Stderr: stderr}
+
+			cmd := NewEndCommand(deps, true, true, false)
+			cmd.forceDelete = forceDelete
+			if err := cmd.Execute("123"); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if deleted != forceDelete {
+				t.Errorf("Expected branch deleted = %v, got %v", forceDelete, deleted)
+			}
+			if forceDelete {
+				if !strings.Contains(stdout.String(), "Force-deleted branch "+testBranch123) {
+					t.Errorf("Expected force-deletion notice, got:\n%s", stdout.String())
+				}
+			} else if !strings.Contains(stderr.String(), "use --force-delete-branch") {
+				t.Errorf("Expected a --force-delete-branch hint, got:\n%s", stderr.String())
+			}
+		})
+	}
+}
+
+func TestEndCommand_Execute_Archive_Integration(t *testing.T) {
+	repo, runGit := newDryRunTestRepo(t)
+	worktreePath := repo + "-123"
+	runGit("worktree", "add", "-b", testBranch123, worktreePath)
+	for _, name := range []string{"a.txt", "b.txt"} {
+		if err := os.WriteFile(filepath.Join(worktreePath, name), []byte(name), 0644); err != nil {
+			t.Fatalf("write %s: %v", name, err)
+		}
+		runGit("-C", worktreePath, "add", name)
+		runGit("-C", worktreePath, "commit", "-m", "add "+name)
+	}
+
+	archiveDir := t.TempDir()
+	stdout := &bytes.Buffer{}
+	deps := &Dependencies{
+		Git:    git.NewClient(),
+		UI:     &mockUI{},
+		Config: config.New(),
+		Stdout: stdout,
+		Stderr: &bytes.Buffer{},
+	}
+	cmd := NewEndCommand(deps, true, true, false)
+	cmd.archiveDir = archiveDir
+	if err := cmd.Execute("123"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	dir := filepath.Join(archiveDir, git.SanitizeBranchNameForDirectory(testBranch123)+"-"+time.Now().Format("2006-01-02"))
+	for _, name := range []string{"0001-add-a.txt.patch", "0002-add-b.txt.patch"} {
+		if _, err := os.Stat(filepath.Join(dir, name)); err != nil {
+			t.Errorf("Expected patch %s in the archive: %v", name, err)
+		}
+	}
+	if !strings.Contains(stdout.String(), "Archived 2 patches to "+dir) {
+		t.Errorf("Expected the archive to be reported, got:\n%s", stdout.String())
+	}
+	if _, err := os.Stat(worktreePath); !os.IsNotExist(err) {
+		t.Errorf("Expected the worktree to be removed, stat error: %v", err)
+	}
+}
+
+func TestEndCommand_Execute_ArchiveError(t *testing.T) {
+	removed := false
+	mg := &mockGit{
+		GetWorktreeForIssueFn: func(string) (*git.WorktreeInfo, error) {
+			return &git.WorktreeInfo{Path: "/repo-123", Branch: testBranch123}, nil
+		},
+		FormatPatchFn: func(worktreePath, base, outDir string) ([]string, error) {
+			return nil, fmt.Errorf("permission denied")
+		},
+		RemoveWorktreeByPathFn: func(string) error {
+			removed = true
+			return nil
+		},
+	}
+	deps, _, _ := newListTestDeps(mg)
+	cmd := NewEndCommand(deps, true, true, false)
+	cmd.archiveDir = t.TempDir()
+
+	err := cmd.Execute("123")
+	if err == nil || !strings.Contains(err.Error(), "the worktree was kept") {
+		t.Fatalf("Expected the archive failure to stop the removal, got %v", err)
+	}
+	if removed {
+		t.Error("Expected the worktree to be kept when archiving fails")
+	}
+}
+
+func TestEndCommand_Execute_NoRemote_Integration(t *testing.T) {
+	repo, runGit := newDryRunTestRepo(t)
+	worktreePath := repo + "-123"
+	runGit("worktree", "add", "-b", testBranch123, worktreePath)
+
+	stderr := &bytes.Buffer{}
+	deps := &Dependencies{
+		Git: git.NewClient(),
+		UI: &mockUI{ConfirmPromptFn: func(string) (bool, error) {
+			t.Error("Expected no confirmation prompt for a merged branch without a remote")
+			return false, nil
+		}},
+		Config: config.New(),
+		Stdout: &bytes.Buffer{},
+		Stderr: stderr,
+	}
+	cmd := NewEndCommand(deps, false, false, false)
+	cmd.deleteRemote = true
+	if err := cmd.Execute("123"); err != nil {
+		t.Fatalf("Unexpected error: %v\nstderr:\n%s", err, stderr.String())
+	}
+
+	if _, err := os.Stat(worktreePath); !os.IsNotExist(err) {
+		t.Errorf("Expected the worktree to be removed, stat error: %v", err)
+	}
+	for _, want := range []string{"No remote named origin", "there is no remote branch " + testBranch123 + " to delete"} {
+		if !strings.Contains(stderr.String(), want) {
+			t.Errorf("Expected %q, got:\n%s", want, stderr.String())
+		}
+	}
+}
+
+func TestEndCommand_Execute_Archive_NoLocalMain_Integration(t *testing.T) {
+	tests := []struct {
+		name  string
+		setup func(t *testing.T, repo string, runGit func(args ...string) string)
+	}{
+		{
+			name: "only origin/main",
+			setup: func(t *testing.T, repo string, runGit func(args ...string) string) {
+				remote := filepath.Join(filepath.Dir(repo), "origin.git")
+				runGit("init", "--bare", remote)
+				runGit("remote", "add", "origin", remote)
+				runGit("push", "origin", "main")
+				runGit("fetch", "origin")
+				runGit("switch", "--detach")
+				runGit("branch", "-D", "main")
+			},
+		},
+		{
+			name: "master",
+			setup: func(t *testing.T, repo string, runGit func(args ...string) string) {
+				runGit("branch", "-m", "main", "master")
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo, runGit := newDryRunTestRepo(t)
+			tt.setup(t, repo, runGit)
+			worktreePath := repo + "-123"
+			runGit("worktree", "add", "-b", testBranch123, worktreePath)
+			runGit("-C", worktreePath, "commit", "--allow-empty", "-m", "work")
+
+			archiveDir := t.TempDir()
+			stdout := &bytes.Buffer{}
+			deps := &Dependencies{Git: git.NewClient(), UI: &mockUI{}, Config: config.New(), Stdout: stdout, Stderr: &bytes.Buffer{}}
+			cmd := NewEndCommand(deps, true, true, false)
+			cmd.archiveDir = archiveDir
+			if err := cmd.Execute("123"); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			dir := filepath.Join(archiveDir, git.SanitizeBranchNameForDirectory(testBranch123)+"-"+time.Now().Format("2006-01-02"))
+			if _, err := os.Stat(filepath.Join(dir, "0001-work.patch")); err != nil {
+				t.Errorf("Expected only the branch's own commit in the archive: %v\n%s", err, stdout.String())
+			}
+			if _, err := os.Stat(filepath.Join(dir, "0002-work.patch")); !os.IsNotExist(err) {
+				t.Errorf("Expected a single patch, stat error: %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,88 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/sotarok/gw/internal/git"
+)
+
+// envReportGit is the subset of git operations EnvReportCommand actually uses.
+type envReportGit interface {
+	git.WorktreeManager // ListWorktrees
+	git.EnvFileHandler  // FindUntrackedEnvFiles
+}
+
+// EnvReportCommand handles the env-report command logic
+type EnvReportCommand struct {
+	deps       *Dependencies
+	jsonOutput bool
+}
+
+// NewEnvReportCommand creates a new env-report command handler
+func NewEnvReportCommand(deps *Dependencies, jsonOutput bool) *EnvReportCommand {
+	return &EnvReportCommand{
+		deps:       deps,
+		jsonOutput: jsonOutput,
+	}
+}
+
+// git returns the command's git dependency narrowed to the operations it uses.
+func (c *EnvReportCommand) git() envReportGit { return c.deps.Git }
+
+// envReportEntry is one worktree of the env-report output.
+type envReportEntry struct {
+	Path     string   `json:"path"`
+	Branch   string   `json:"branch"`
+	EnvFiles []string `json:"env_files"`
+}
+
+// Execute prints the untracked env files of every worktree. A worktree that
+// cannot be scanned is reported as a warning and left out.
+func (c *EnvReportCommand) Execute() error {
+	worktrees, err := c.git().ListWorktrees()
+	if err != nil {
+		return fmt.Errorf("failed to list worktrees: %w", err)
+	}
+
+	entries := make([]envReportEntry, 0, len(worktrees))
+	for _, wt := range worktrees {
+		envFiles, err := c.git().FindUntrackedEnvFiles(wt.Path)
+		if err != nil {
+			fmt.Fprintf(c.deps.Stderr, "%s Could not scan %s for env files: %v\n", coloredWarning(), wt.Path, err)
+			continue
+		}
+		paths := make([]string, len(envFiles))
+		for i, f := range envFiles {
+			paths[i] = f.Path
+		}
+		entries = append(entries, envReportEntry{Path: wt.Path, Branch: wt.Branch, EnvFiles: paths})
+	}
+
+	if c.jsonOutput {
+		enc := json.NewEncoder(c.deps.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(entries)
+	}
+	c.printEntries(entries)
+	return nil
+}
+
+func (c *EnvReportCommand) printEntries(entries []envReportEntry) {
+	total := 0
+	for _, e := range entries {
+		branch := e.Branch
+		if branch == "" {
+			branch = "detached HEAD"
+		}
+		fmt.Fprintf(c.deps.Stdout, "%s (%s)\n", e.Path, branch)
+		if len(e.EnvFiles) == 0 {
+			fmt.Fprintf(c.deps.Stdout, "  (no env files)\n")
+		}
+		for _, f := range e.EnvFiles {
+			fmt.Fprintf(c.deps.Stdout, "  %s\n", f)
+		}
+		total += len(e.EnvFiles)
+	}
+	fmt.Fprintf(c.deps.Stdout, "\n%d env %s in %d %s\n", total, plural(total, "file", "files"), len(entries), plural(len(entries), "worktree", "worktrees"))
+}
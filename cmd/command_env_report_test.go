@@ -0,0 +1,94 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/sotarok/gw/internal/git"
+)
+
+// envReportTestGit returns a mock with three worktrees holding different env
+// files: two in the main one, one in /repo-123 and none in /repo-456.
+func envReportTestGit() *mockGit {
+	envFiles := map[string][]git.EnvFile{
+		"/repo":     {{Path: ".env"}, {Path: "config/.env.local"}},
+		"/repo-123": {{Path: ".env"}},
+	}
+	return &mockGit{
+		ListWorktreesFn: func() ([]git.WorktreeInfo, error) {
+			return []git.WorktreeInfo{
+				{Path: "/repo", Branch: "main"},
+				{Path: "/repo-123", Branch: testBranch123},
+				{Path: "/repo-456", Branch: "456/impl"},
+			}, nil
+		},
+		FindUntrackedEnvFilesFn: func(path string) ([]git.EnvFile, error) {
+			return envFiles[path], nil
+		},
+	}
+}
+
+func TestEnvReportCommand_Execute(t *testing.T) {
+	deps, stdout, _ := newListTestDeps(envReportTestGit())
+
+	if err := NewEnvReportCommand(deps, false).Execute(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	want := "/repo (main)\n" +
+		"  .env\n" +
+		"  config/.env.local\n" +
+		"/repo-123 (123/impl)\n" +
+		"  .env\n" +
+		"/repo-456 (456/impl)\n" +
+		"  (no env files)\n" +
+		"\n3 env files in 3 worktrees\n"
+	if stdout.String() != want {
+		t.Errorf("Unexpected output:\ngot:\n%s\nwant:\n%s", stdout.String(), want)
+	}
+}
+
+func TestEnvReportCommand_Execute_JSON(t *testing.T) {
+	deps, stdout, _ := newListTestDeps(envReportTestGit())
+
+	if err := NewEnvReportCommand(deps, true).Execute(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	var got []envReportEntry
+	if err := json.Unmarshal(stdout.Bytes(), &got); err != nil {
+		t.Fatalf("Output is not valid JSON: %v\n%s", err, stdout.String())
+	}
+	want := []envReportEntry{
+		{Path: "/repo", Branch: "main", EnvFiles: []string{".env", "config/.env.local"}},
+		{Path: "/repo-123", Branch: testBranch123, EnvFiles: []string{".env"}},
+		{Path: "/repo-456", Branch: "456/impl", EnvFiles: []string{}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("report = %+v, want %+v", got, want)
+	}
+}
+
+func TestEnvReportCommand_Execute_ScanError(t *testing.T) {
+	mg := envReportTestGit()
+	mg.FindUntrackedEnvFilesFn = func(path string) ([]git.EnvFile, error) {
+		if path == "/repo-123" {
+			return nil, fmt.Errorf("permission denied")
+		}
+		return nil, nil
+	}
+	deps, stdout, stderr := newListTestDeps(mg)
+
+	if err := NewEnvReportCommand(deps, false).Execute(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !strings.Contains(stderr.String(), "Could not scan /repo-123") {
+		t.Errorf("Expected scan warning, got stderr:\n%s", stderr.String())
+	}
+	if strings.Contains(stdout.String(), "/repo-123") {
+		t.Errorf("Expected the unscannable worktree to be left out, got:\n%s", stdout.String())
+	}
+}
@@ -0,0 +1,83 @@
+package cmd
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// exampleCategory is one section of the gw examples cheat sheet.
+type exampleCategory struct {
+	title    string
+	commands []string // command names, in the order they are shown
+}
+
+// exampleCategories groups the commands by workflow. A command missing from
+// every category is still shown, under "Other", so a new command's examples
+// are never dropped from the cheat sheet.
+var exampleCategories = []exampleCategory{
+	{title: "Start work", commands: []string{"start", "checkout"}},
+	{title: "Switch between worktrees", commands: []string{"where", "list", "ls-branches", "info", "diff"}},
+	{title: "Finish work", commands: []string{"end", "clean"}},
+	{title: "Maintain worktrees", commands: []string{"pull-all", "sync-env", "setup", "env-report", "import", "reattach", "relocate"}},
+	{title: "Set up gw", commands: []string{"init", "config", "shell-integration", "doctor", "stats", "uninstall"}},
+}
+
+// ExamplesCommand handles the examples command logic
+type ExamplesCommand struct {
+	deps     *Dependencies
+	commands []*cobra.Command // the registered top-level commands
+}
+
+// NewExamplesCommand creates a new examples command handler
+func NewExamplesCommand(deps *Dependencies, commands []*cobra.Command) *ExamplesCommand {
+	return &ExamplesCommand{deps: deps, commands: commands}
+}
+
+// Execute prints the Example text of every available command that has one,
+// grouped by exampleCategories, each under the command's name and summary.
+func (c *ExamplesCommand) Execute() error {
+	byName := map[string]*cobra.Command{}
+	var uncategorized []string
+	for _, command := range c.commands {
+		if !command.IsAvailableCommand() || command.Example == "" || command.Name() == "examples" {
+			continue
+		}
+		byName[command.Name()] = command
+		if !slices.ContainsFunc(exampleCategories, func(category exampleCategory) bool {
+			return slices.Contains(category.commands, command.Name())
+		}) {
+			uncategorized = append(uncategorized, command.Name())
+		}
+	}
+
+	categories := exampleCategories
+	if len(uncategorized) > 0 {
+		categories = append(slices.Clip(categories), exampleCategory{title: "Other", commands: uncategorized})
+	}
+
+	first := true
+	for _, category := range categories {
+		var shown []*cobra.Command
+		for _, name := range category.commands {
+			if command, ok := byName[name]; ok {
+				shown = append(shown, command)
+			}
+		}
+		if len(shown) == 0 {
+			continue
+		}
+		if !first {
+			fmt.Fprintln(c.deps.Stdout)
+		}
+		first = false
+
+		fmt.Fprintf(c.deps.Stdout, "%s\n%s\n", category.title, strings.Repeat("=", len(category.title)))
+		for _, command := range shown {
+			fmt.Fprintf(c.deps.Stdout, "\ngw %s: %s\n%s\n", command.Name(), command.Short, command.Example)
+		}
+	}
+	return nil
+}
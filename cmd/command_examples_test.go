@@ -0,0 +1,53 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+func TestExamplesCommand_Execute(t *testing.T) {
+	deps, stdout, _ := newListTestDeps(&mockGit{})
+
+	if err := NewExamplesCommand(deps, rootCmd.Commands()).Execute(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	for _, command := range rootCmd.Commands() {
+		if !command.IsAvailableCommand() || command.Name() == "examples" {
+			continue
+		}
+		if command.Example == "" {
+			t.Errorf("gw %s has no examples", command.Name())
+			continue
+		}
+		if !strings.Contains(stdout.String(), "\ngw "+command.Name()+": ") {
+			t.Errorf("Expected gw %s in the cheat sheet, got:\n%s", command.Name(), stdout.String())
+		}
+	}
+	for _, category := range exampleCategories {
+		if !strings.Contains(stdout.String(), category.title+"\n") {
+			t.Errorf("Expected the %q section, got:\n%s", category.title, stdout.String())
+		}
+	}
+}
+
+func TestExamplesCommand_Execute_Uncategorized(t *testing.T) {
+	deps, stdout, _ := newListTestDeps(&mockGit{})
+	commands := []*cobra.Command{
+		{Use: "start", Short: "Start", Example: "  gw start 1", Run: func(*cobra.Command, []string) {}},
+		{Use: "frobnicate", Short: "Frobnicate", Example: "  gw frobnicate", Run: func(*cobra.Command, []string) {}},
+		{Use: "quiet", Short: "No examples", Run: func(*cobra.Command, []string) {}},
+	}
+
+	if err := NewExamplesCommand(deps, commands).Execute(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	want := "Start work\n==========\n\ngw start: Start\n  gw start 1\n\n" +
+		"Other\n=====\n\ngw frobnicate: Frobnicate\n  gw frobnicate\n"
+	if stdout.String() != want {
+		t.Errorf("Unexpected output:\ngot:\n%s\nwant:\n%s", stdout.String(), want)
+	}
+}
@@ -0,0 +1,78 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/sotarok/gw/internal/git"
+)
+
+// importGit is the subset of git operations ImportCommand actually uses.
+type importGit interface {
+	git.RepositoryReader // IsGitRepository
+	git.WorktreeManager  // ListWorktrees, InspectCheckout, AdoptClone, RepairWorktrees
+}
+
+// ImportCommand handles the import command logic
+type ImportCommand struct {
+	deps *Dependencies
+}
+
+// NewImportCommand creates a new import command handler
+func NewImportCommand(deps *Dependencies) *ImportCommand {
+	return &ImportCommand{deps: deps}
+}
+
+// git returns the command's git dependency narrowed to the operations it uses.
+func (c *ImportCommand) git() importGit { return c.deps.Git }
+
+// Execute registers the checkout at path as a worktree of the current
+// repository. A worktree of this repository that git lost track of is
+// repaired the way gw reattach does; a clone sharing history with it is
+// turned into a linked worktree on its current branch. Anything else, such as
+// a checkout of another repository, is refused.
+func (c *ImportCommand) Execute(path string) error {
+	if !c.git().IsGitRepository() {
+		return git.ErrNotGitRepository
+	}
+	checkout, err := c.git().InspectCheckout(path)
+	if err != nil {
+		return err
+	}
+	if info, err := os.Stat(checkout.Path); err != nil || !info.IsDir() {
+		return fmt.Errorf("%s is not a directory", checkout.Path)
+	}
+
+	worktrees, err := c.git().ListWorktrees()
+	if err != nil {
+		return fmt.Errorf("failed to list worktrees: %w", err)
+	}
+	for _, wt := range worktrees {
+		if samePath(wt.Path, checkout.Path) {
+			fmt.Fprintf(c.deps.Stdout, "%s is already a worktree of this repository\n", checkout.Path)
+			return nil
+		}
+	}
+
+	switch {
+	case checkout.Kind == git.NotACheckout:
+		return fmt.Errorf("%s is not a git checkout; pass the top directory of a clone or worktree", checkout.Path)
+	case !checkout.SameRepository:
+		return fmt.Errorf("%s is a checkout of another repository; run gw import from the repository it belongs to", checkout.Path)
+	case checkout.Kind == git.LinkedCheckout:
+		return NewReattachCommand(c.deps).Execute(checkout.Path)
+	case checkout.Branch == "":
+		return fmt.Errorf("%s is on a detached HEAD; check out a branch in it before importing", checkout.Path)
+	}
+
+	backup, err := c.git().AdoptClone(checkout.Path, checkout.Branch)
+	if err != nil {
+		return err
+	}
+	if dryRun {
+		return nil
+	}
+	fmt.Fprintf(c.deps.Stdout, "%s Imported %s as a worktree (%s)\n", coloredSuccess(), checkout.Path, checkout.Branch)
+	fmt.Fprintf(c.deps.Stdout, "  Its own .git directory was moved to %s; delete it once you no longer need its stashes or other branches\n", backup)
+	return nil
+}
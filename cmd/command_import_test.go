@@ -0,0 +1,136 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/sotarok/gw/internal/config"
+	"github.com/sotarok/gw/internal/git"
+)
+
+// listedBranch returns the branch of the worktree git lists at path, and
+// whether it is listed at all.
+func listedBranch(t *testing.T, path string) (string, bool) {
+	t.Helper()
+	worktrees, err := git.NewClient().ListWorktrees()
+	if err != nil {
+		t.Fatalf("ListWorktrees failed: %v", err)
+	}
+	for _, wt := range worktrees {
+		if samePath(wt.Path, path) {
+			return wt.Branch, true
+		}
+	}
+	return "", false
+}
+
+func TestImportCommand_Execute_Integration(t *testing.T) {
+	t.Run("sibling clone", func(t *testing.T) {
+		repo, runGit := newDryRunTestRepo(t)
+		clonePath := repo + "-experiment"
+		runGit("clone", "--quiet", repo, clonePath)
+		runGit("-C", clonePath, "switch", "--quiet", "-c", "experiment")
+		runGit("-C", clonePath, "-c", "user.email=test@example.com", "-c", "user.name=Test User", "commit", "--allow-empty", "-m", "try something")
+
+		stdout := &bytes.Buffer{}
+		deps := &Dependencies{Git: git.NewClient(), UI: &mockUI{}, Config: config.New(), Stdout: stdout, Stderr: &bytes.Buffer{}}
+		if err := NewImportCommand(deps).Execute(clonePath); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if branch, ok := listedBranch(t, clonePath); !ok || branch != "experiment" {
+			t.Errorf("Expected %s to be listed on branch experiment, got %q (listed: %v)", clonePath, branch, ok)
+		}
+		if !strings.Contains(stdout.String(), "Imported "+clonePath+" as a worktree (experiment)") {
+			t.Errorf("Expected the import to be reported, got:\n%s", stdout.String())
+		}
+		if _, err := os.Stat(clonePath + ".git-before-import"); err != nil {
+			t.Errorf("Expected the clone's .git directory to be kept: %v", err)
+		}
+	})
+
+	t.Run("worktree moved by hand", func(t *testing.T) {
+		repo, runGit := newDryRunTestRepo(t)
+		runGit("worktree", "add", "-b", testBranch123, repo+"-123")
+		movedPath := repo + "-moved"
+		if err := os.Rename(repo+"-123", movedPath); err != nil {
+			t.Fatalf("rename: %v", err)
+		}
+
+		stdout := &bytes.Buffer{}
+		deps := &Dependencies{Git: git.NewClient(), UI: &mockUI{}, Config: config.New(), Stdout: stdout, Stderr: &bytes.Buffer{}}
+		if err := NewImportCommand(deps).Execute(movedPath); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if branch, ok := listedBranch(t, movedPath); !ok || branch != testBranch123 {
+			t.Errorf("Expected %s to be listed on branch %s, got %q (listed: %v)", movedPath, testBranch123, branch, ok)
+		}
+		if !strings.Contains(stdout.String(), "Reattached worktree at") {
+			t.Errorf("Expected the repair to be reported, got:\n%s", stdout.String())
+		}
+	})
+}
+
+func TestImportCommand_Execute_Refused(t *testing.T) {
+	dir := t.TempDir()
+	tests := []struct {
+		name     string
+		checkout git.Checkout
+		wantErr  string
+	}{
+		{"not a checkout", git.Checkout{Kind: git.NotACheckout}, "is not a git checkout"},
+		{"another repository", git.Checkout{Kind: git.CloneCheckout, Branch: "main"}, "is a checkout of another repository"},
+		{"detached HEAD", git.Checkout{Kind: git.CloneCheckout, SameRepository: true}, "is on a detached HEAD"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			adopted := false
+			mg := &mockGit{
+				isGitRepo: true,
+				InspectCheckoutFn: func(path string) (*git.Checkout, error) {
+					checkout := tt.checkout
+					checkout.Path = path
+					return &checkout, nil
+				},
+				AdoptCloneFn: func(string, string) (string, error) {
+					adopted = true
+					return "", nil
+				},
+			}
+			deps := &Dependencies{Git: mg, UI: &mockUI{}, Config: config.New(), Stdout: &bytes.Buffer{}, Stderr: &bytes.Buffer{}}
+
+			err := NewImportCommand(deps).Execute(dir)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Expected error containing %q, got %v", tt.wantErr, err)
+			}
+			if adopted {
+				t.Error("Expected the checkout not to be imported")
+			}
+		})
+	}
+}
+
+func TestImportCommand_Execute_AlreadyListed(t *testing.T) {
+	dir := t.TempDir()
+	mg := &mockGit{
+		isGitRepo: true,
+		InspectCheckoutFn: func(path string) (*git.Checkout, error) {
+			return &git.Checkout{Path: path, Kind: git.LinkedCheckout, SameRepository: true}, nil
+		},
+		ListWorktreesFn: func() ([]git.WorktreeInfo, error) {
+			return []git.WorktreeInfo{{Path: filepath.Clean(dir), Branch: testBranch123}}, nil
+		},
+	}
+	deps, stdout, _ := newListTestDeps(mg)
+
+	if err := NewImportCommand(deps).Execute(dir); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !strings.Contains(stdout.String(), "is already a worktree of this repository") {
+		t.Errorf("Unexpected output: %s", stdout.String())
+	}
+}
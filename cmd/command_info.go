@@ -0,0 +1,194 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/sotarok/gw/internal/git"
+)
+
+// shortHashLen is how many characters of a commit hash the text output shows.
+const shortHashLen = 7
+
+// infoGit is the subset of git operations InfoCommand actually uses.
+type infoGit interface {
+	git.WorktreeManager // GetWorktreeForIssue
+	git.BranchManager   // ResolveRefDescription
+	git.StatusChecker   // GetWorktreeDetails, IsMergedToBaseBranch
+}
+
+// InfoCommand handles the info command logic
+type InfoCommand struct {
+	deps       *Dependencies
+	baseBranch string
+	jsonOutput bool
+}
+
+// NewInfoCommand creates a new info command handler
+func NewInfoCommand(deps *Dependencies, baseBranch string, jsonOutput bool) *InfoCommand {
+	return &InfoCommand{
+		deps:       deps,
+		baseBranch: baseBranch,
+		jsonOutput: jsonOutput,
+	}
+}
+
+// git returns the command's git dependency narrowed to the operations it uses.
+func (c *InfoCommand) git() infoGit { return c.deps.Git }
+
+// worktreeInfoReport is everything gw info prints, in both output formats.
+type worktreeInfoReport struct {
+	Path             string      `json:"path"`
+	Branch           string      `json:"branch"`
+	Describe         string      `json:"describe,omitempty"` // nearest tag of a detached HEAD
+	Base             string      `json:"base"`
+	Upstream         string      `json:"upstream"`
+	Ahead            int         `json:"ahead"`
+	Behind           int         `json:"behind"`
+	UncommittedFiles int         `json:"uncommitted_files"`
+	LastCommit       commitEntry `json:"last_commit"`
+	Locked           bool        `json:"locked"`
+	Merged           *bool       `json:"merged"` // nil when the check failed
+}
+
+type commitEntry struct {
+	Hash    string    `json:"hash"`
+	Subject string    `json:"subject"`
+	Author  string    `json:"author"`
+	Date    time.Time `json:"date"`
+}
+
+// Execute prints the details of one worktree, selected interactively when
+// issueNumber is empty.
+func (c *InfoCommand) Execute(issueNumber string) error {
+	wt, err := c.resolveWorktree(issueNumber)
+	if err != nil {
+		return err
+	}
+	if wt == nil {
+		return fmt.Errorf("no worktree selected")
+	}
+
+	report, err := c.gather(wt)
+	if err != nil {
+		return err
+	}
+
+	if c.jsonOutput {
+		enc := json.NewEncoder(c.deps.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(report)
+	}
+	c.printReport(report)
+	return nil
+}
+
+// resolveWorktree returns the worktree to describe, either via interactive
+// selection (when issueNumber is empty) or by issue number / branch.
+func (c *InfoCommand) resolveWorktree(issueNumber string) (*git.WorktreeInfo, error) {
+	if issueNumber == "" {
+		return c.deps.UI.SelectWorktree()
+	}
+
+	wt, err := c.git().GetWorktreeForIssue(issueNumber)
+	if err != nil {
+		return nil, err
+	}
+	if wt == nil {
+		return nil, &git.WorktreeNotFoundError{Identifier: issueNumber}
+	}
+	return wt, nil
+}
+
+// gather collects the report for wt. A failing merged check is not fatal: it
+// is reported as unknown so the rest of the information is still shown. The
+// tag lookup for a detached HEAD is likewise left out when it fails.
+func (c *InfoCommand) gather(wt *git.WorktreeInfo) (*worktreeInfoReport, error) {
+	details, err := c.git().GetWorktreeDetails(wt.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &worktreeInfoReport{
+		Path:             wt.Path,
+		Branch:           wt.Branch,
+		Base:             c.baseBranch,
+		Upstream:         details.Upstream,
+		Ahead:            details.Ahead,
+		Behind:           details.Behind,
+		UncommittedFiles: details.UncommittedFiles,
+		LastCommit: commitEntry{
+			Hash:    details.LastCommit.Hash,
+			Subject: details.LastCommit.Subject,
+			Author:  details.LastCommit.Author,
+			Date:    details.LastCommit.Date,
+		},
+		Locked: wt.IsLocked,
+	}
+
+	if wt.Branch != "" {
+		if merged, err := c.git().IsMergedToBaseBranch(wt.Path, wt.Branch, c.baseBranch); err == nil {
+			report.Merged = &merged
+		}
+	} else if wt.Commit != "" {
+		if description, err := c.git().ResolveRefDescription(wt.Commit); err == nil {
+			report.Describe = description
+		}
+	}
+	return report, nil
+}
+
+func (c *InfoCommand) printReport(r *worktreeInfoReport) {
+	out := c.deps.Stdout
+	branch := r.Branch
+	switch {
+	case branch != "":
+	case r.Describe != "":
+		branch = "(" + r.Describe + ")"
+	default:
+		branch = "(detached HEAD)"
+	}
+
+	upstream := "none"
+	if r.Upstream != "" {
+		upstream = fmt.Sprintf("%s (ahead %d, behind %d)", r.Upstream, r.Ahead, r.Behind)
+	}
+
+	merged := "unknown"
+	if r.Merged != nil {
+		merged = yesNo(*r.Merged)
+	}
+
+	fmt.Fprintf(out, "%-12s %s\n", "Path:", r.Path)
+	fmt.Fprintf(out, "%-12s %s\n", "Branch:", branch)
+	fmt.Fprintf(out, "%-12s %s\n", "Base:", r.Base)
+	fmt.Fprintf(out, "%-12s %s\n", "Upstream:", upstream)
+	fmt.Fprintf(out, "%-12s %d %s\n", "Uncommitted:", r.UncommittedFiles, plural(r.UncommittedFiles, "file", "files"))
+	fmt.Fprintf(out, "%-12s %s\n", "Last commit:", formatCommit(r.LastCommit))
+	fmt.Fprintf(out, "%-12s %s\n", "Locked:", yesNo(r.Locked))
+	fmt.Fprintf(out, "%-12s %s\n", "Merged:", merged)
+}
+
+// formatCommit renders a commit as "<short hash> <subject> (<author>, <date>)".
+func formatCommit(commit commitEntry) string {
+	if commit.Hash == "" {
+		return "none"
+	}
+	return fmt.Sprintf("%s %s (%s, %s)", shortHash(commit.Hash), commit.Subject, commit.Author, commit.Date.Format("2006-01-02 15:04"))
+}
+
+// shortHash abbreviates a commit hash to shortHashLen characters.
+func shortHash(hash string) string {
+	if len(hash) > shortHashLen {
+		return hash[:shortHashLen]
+	}
+	return hash
+}
+
+func yesNo(v bool) string {
+	if v {
+		return yes
+	}
+	return no
+}
@@ -0,0 +1,184 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sotarok/gw/internal/config"
+	"github.com/sotarok/gw/internal/git"
+)
+
+func newInfoTestDeps(mg *mockGit, ui *mockUI) (*Dependencies, *bytes.Buffer) {
+	stdout := &bytes.Buffer{}
+	return &Dependencies{
+		Git:    mg,
+		UI:     ui,
+		Config: config.New(),
+		Stdout: stdout,
+		Stderr: &bytes.Buffer{},
+	}, stdout
+}
+
+// knownInfoWorktree returns a mock describing a locked, unmerged worktree that
+// is one commit ahead of and two behind its upstream with three dirty files.
+func knownInfoWorktree() *mockGit {
+	return &mockGit{
+		GetWorktreeForIssueFn: func(issue string) (*git.WorktreeInfo, error) {
+			return &git.WorktreeInfo{Path: "/repo-123", Branch: testBranch123, IsLocked: true}, nil
+		},
+		GetWorktreeDetailsFn: func(path string) (*git.WorktreeDetails, error) {
+			return &git.WorktreeDetails{
+				Upstream:         "origin/" + testBranch123,
+				Ahead:            1,
+				Behind:           2,
+				UncommittedFiles: 3,
+				LastCommit: git.CommitInfo{
+					Hash:    "0123456789abcdef0123456789abcdef01234567",
+					Subject: "Add login form",
+					Author:  "Test User",
+					Date:    time.Date(2026, 10, 1, 9, 30, 0, 0, time.UTC),
+				},
+			}, nil
+		},
+		IsMergedToBaseBranchFn: func(string) (bool, error) { return false, nil },
+	}
+}
+
+func TestInfoCommand_Execute(t *testing.T) {
+	deps, stdout := newInfoTestDeps(knownInfoWorktree(), &mockUI{})
+
+	if err := NewInfoCommand(deps, defaultBaseBranch, false).Execute("123"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	output := stdout.String()
+	for _, want := range []string{
+		"Path:        /repo-123",
+		"Branch:      123/impl",
+		"Base:        main",
+		"Upstream:    origin/123/impl (ahead 1, behind 2)",
+		"Uncommitted: 3 files",
+		"Last commit: 0123456 Add login form (Test User, 2026-10-01 09:30)",
+		"Locked:      yes",
+		"Merged:      no",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Expected output to contain %q, got:\n%s", want, output)
+		}
+	}
+}
+
+func TestInfoCommand_Execute_JSON(t *testing.T) {
+	deps, stdout := newInfoTestDeps(knownInfoWorktree(), &mockUI{})
+
+	if err := NewInfoCommand(deps, "develop", true).Execute("123"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	var got map[string]any
+	if err := json.Unmarshal(stdout.Bytes(), &got); err != nil {
+		t.Fatalf("Output is not valid JSON: %v\n%s", err, stdout.String())
+	}
+
+	want := map[string]any{
+		"path":              "/repo-123",
+		"branch":            testBranch123,
+		"base":              "develop",
+		"upstream":          "origin/" + testBranch123,
+		"ahead":             float64(1),
+		"behind":            float64(2),
+		"uncommitted_files": float64(3),
+		"locked":            true,
+		"merged":            false,
+	}
+	for key, value := range want {
+		if got[key] != value {
+			t.Errorf("%s = %v, want %v", key, got[key], value)
+		}
+	}
+	lastCommit, _ := got["last_commit"].(map[string]any)
+	if lastCommit["subject"] != "Add login form" || lastCommit["date"] != "2026-10-01T09:30:00Z" {
+		t.Errorf("unexpected last_commit: %v", lastCommit)
+	}
+}
+
+func TestInfoCommand_Execute_Variants(t *testing.T) {
+	t.Run("interactive selection when no argument", func(t *testing.T) {
+		var detailsPath string
+		mg := &mockGit{
+			GetWorktreeDetailsFn: func(path string) (*git.WorktreeDetails, error) {
+				detailsPath = path
+				return &git.WorktreeDetails{}, nil
+			},
+		}
+		ui := &mockUI{SelectWorktreeFn: func() (*git.WorktreeInfo, error) {
+			return &git.WorktreeInfo{Path: "/selected", Branch: testBranchFeature}, nil
+		}}
+		deps, stdout := newInfoTestDeps(mg, ui)
+
+		if err := NewInfoCommand(deps, defaultBaseBranch, false).Execute(""); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if detailsPath != "/selected" {
+			t.Errorf("Expected details for /selected, got %q", detailsPath)
+		}
+		output := stdout.String()
+		if !strings.Contains(output, "Upstream:    none") || !strings.Contains(output, "Last commit: none") {
+			t.Errorf("Expected empty upstream and commit, got:\n%s", output)
+		}
+	})
+
+	t.Run("merged check failure is reported as unknown", func(t *testing.T) {
+		mg := knownInfoWorktree()
+		mg.IsMergedToBaseBranchFn = func(string) (bool, error) { return false, fmt.Errorf("boom") }
+		deps, stdout := newInfoTestDeps(mg, &mockUI{})
+
+		if err := NewInfoCommand(deps, defaultBaseBranch, false).Execute("123"); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if !strings.Contains(stdout.String(), "Merged:      unknown") {
+			t.Errorf("Expected unknown merge state, got:\n%s", stdout.String())
+		}
+	})
+
+	t.Run("detached HEAD shows the nearest tag", func(t *testing.T) {
+		mg := knownInfoWorktree()
+		mg.GetWorktreeForIssueFn = func(string) (*git.WorktreeInfo, error) {
+			return &git.WorktreeInfo{Path: "/repo-release", Commit: "abc123", IsDetached: true}, nil
+		}
+		mg.ResolveRefDescriptionFn = func(commit string) (string, error) { return "v1.2.3", nil }
+		deps, stdout := newInfoTestDeps(mg, &mockUI{})
+
+		if err := NewInfoCommand(deps, defaultBaseBranch, false).Execute("release"); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if !strings.Contains(stdout.String(), "Branch:      (v1.2.3)") {
+			t.Errorf("Expected the tag in place of the branch, got:\n%s", stdout.String())
+		}
+	})
+
+	t.Run("details error is returned", func(t *testing.T) {
+		mg := knownInfoWorktree()
+		mg.GetWorktreeDetailsFn = func(string) (*git.WorktreeDetails, error) { return nil, fmt.Errorf("status failed") }
+		deps, _ := newInfoTestDeps(mg, &mockUI{})
+
+		err := NewInfoCommand(deps, defaultBaseBranch, false).Execute("123")
+		if err == nil || !strings.Contains(err.Error(), "status failed") {
+			t.Errorf("Expected details error, got: %v", err)
+		}
+	})
+
+	t.Run("unknown worktree", func(t *testing.T) {
+		mg := &mockGit{GetWorktreeForIssueFn: func(string) (*git.WorktreeInfo, error) { return nil, nil }}
+		deps, _ := newInfoTestDeps(mg, &mockUI{})
+
+		err := NewInfoCommand(deps, defaultBaseBranch, false).Execute("999")
+		if err == nil || !strings.Contains(err.Error(), "worktree for 999 not found") {
+			t.Errorf("Expected not found error, got: %v", err)
+		}
+	})
+}
@@ -0,0 +1,307 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"slices"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/sotarok/gw/internal/git"
+	"github.com/sotarok/gw/internal/glyph"
+)
+
+// listGit is the subset of git operations ListCommand actually uses.
+type listGit interface {
+	git.WorktreeManager // ListWorktrees
+	git.BranchManager   // IsUpstreamGone, ResolveRefDescription, ResolveBaseBranch
+	git.StatusChecker   // GetWorktreeDetails (for .Age in --format), AheadBehind, CommitsSince
+}
+
+// ListCommand handles the list command logic
+type ListCommand struct {
+	deps     *Dependencies
+	stale    bool
+	noFetch  bool
+	format   string // --format: text/template executed for each worktree
+	allRepos bool   // --all-repos: scan worktree_root instead of the current repository
+	repo     string // --repo: scan worktree_root for this repository only
+	since    string // --since: only worktrees with commits that this revision does not have
+}
+
+// NewListCommand creates a new list command handler
+func NewListCommand(deps *Dependencies, stale, noFetch bool) *ListCommand {
+	return &ListCommand{
+		deps:    deps,
+		stale:   stale,
+		noFetch: noFetch,
+	}
+}
+
+// git returns the command's git dependency narrowed to the operations it uses.
+func (c *ListCommand) git() listGit { return c.deps.Git }
+
+// listEntry is one worktree row of the list output.
+type listEntry struct {
+	info  git.WorktreeInfo
+	stale bool
+}
+
+// Execute lists the worktrees of the current repository, or only the stale
+// ones with --stale. The fetch (which prunes deleted remote branches) runs
+// first so stale detection sees the current state of the remote. --all-repos
+// and --repo list worktrees found under worktree_root instead.
+func (c *ListCommand) Execute() error {
+	if c.since != "" {
+		if err := git.ValidateRef(c.since); err != nil {
+			return fmt.Errorf("invalid --since revision: %w", err)
+		}
+	}
+	if c.allRepos || c.repo != "" {
+		return c.listAllRepos()
+	}
+
+	fetchIfConfigured(c.deps, c.noFetch)
+
+	worktrees, err := c.git().ListWorktrees()
+	if err != nil {
+		return fmt.Errorf("failed to list worktrees: %w", err)
+	}
+	c.warnBranchConflicts(worktrees)
+
+	var entries []listEntry
+	for _, wt := range worktrees {
+		stale := c.isStale(wt)
+		if c.stale && !stale {
+			continue
+		}
+		if c.since != "" {
+			hasNew, err := c.hasCommitsSince(wt)
+			if err != nil {
+				return err
+			}
+			if !hasNew {
+				continue
+			}
+		}
+		entries = append(entries, listEntry{info: wt, stale: stale})
+	}
+
+	if len(entries) == 0 {
+		switch {
+		case c.since != "":
+			fmt.Fprintf(c.deps.Stdout, "No worktrees with commits since %s found.\n", c.since)
+		case c.stale:
+			fmt.Fprintf(c.deps.Stdout, "No stale worktrees found.\n")
+		}
+		return nil
+	}
+	c.countAheadBehind(entries)
+
+	if c.format != "" {
+		return c.printFormatted(entries)
+	}
+	c.printEntries(entries)
+	return nil
+}
+
+// listAllRepos prints the worktrees found under the worktree_root directory,
+// grouped by the repository they belong to, or only those of the repository
+// named by --repo. Which repository a worktree belongs to comes from the git
+// directory it shares with the others, not from where it is. It works from
+// anywhere, inside a repository or not, and only reads the filesystem.
+func (c *ListCommand) listAllRepos() error {
+	flag := "--all-repos"
+	if c.repo != "" {
+		flag = "--repo"
+	}
+	root := c.deps.Config.WorktreeRoot
+	if root == "" {
+		return fmt.Errorf("%s needs a worktree_root directory to scan; set worktree_root in ~/.gwrc", flag)
+	}
+	repos, err := git.ScanWorktrees(root)
+	if err != nil {
+		return fmt.Errorf("failed to scan %s: %w", root, err)
+	}
+	if c.repo != "" {
+		repos = slices.DeleteFunc(repos, func(r git.RepoWorktrees) bool { return r.Name() != c.repo })
+		if len(repos) == 0 {
+			return fmt.Errorf("no worktrees of a repository named %s found under %s", c.repo, root)
+		}
+	}
+	if len(repos) == 0 {
+		fmt.Fprintf(c.deps.Stdout, "No worktrees found under %s.\n", root)
+		return nil
+	}
+
+	for i, repo := range repos {
+		if i > 0 {
+			fmt.Fprintln(c.deps.Stdout)
+		}
+		fmt.Fprintf(c.deps.Stdout, "%s (%s)\n", repo.Name(), repo.Repo)
+		width := 0
+		for _, wt := range repo.Worktrees {
+			width = max(width, len(wt.Path))
+		}
+		for _, wt := range repo.Worktrees {
+			branch := wt.Branch
+			if branch == "" {
+				branch = "(detached HEAD)"
+			}
+			fmt.Fprintf(c.deps.Stdout, "  %-*s  %s\n", width, wt.Path, branch)
+		}
+	}
+	return nil
+}
+
+// countAheadBehind fills in how far each worktree's HEAD is ahead of and
+// behind the default branch. A worktree that cannot be compared, e.g. because
+// the repository has no main branch, keeps zero counts without a warning.
+func (c *ListCommand) countAheadBehind(entries []listEntry) {
+	base, _ := c.git().ResolveBaseBranch(defaultBaseBranch)
+	for i := range entries {
+		info := &entries[i].info
+		if info.IsBare || info.IsPrunable {
+			continue
+		}
+		if ahead, behind, err := c.git().AheadBehind(info.Path, base); err == nil {
+			info.Ahead, info.Behind = ahead, behind
+		}
+	}
+}
+
+// hasCommitsSince reports whether the worktree's HEAD has commits that --since
+// does not. Bare and missing worktrees have none to compare.
+func (c *ListCommand) hasCommitsSince(wt git.WorktreeInfo) (bool, error) {
+	if wt.IsBare || wt.IsPrunable {
+		return false, nil
+	}
+	n, err := c.git().CommitsSince(wt.Path, c.since)
+	if err != nil {
+		return false, err
+	}
+	return n > 0, nil
+}
+
+// isStale reports whether the worktree's branch tracked a remote branch that
+// no longer exists. A failing check is reported and treated as not stale.
+func (c *ListCommand) isStale(wt git.WorktreeInfo) bool {
+	if wt.Branch == "" {
+		return false
+	}
+	gone, err := c.git().IsUpstreamGone(wt.Branch)
+	if err != nil {
+		fmt.Fprintf(c.deps.Stderr, "%s Could not check upstream of %s: %v\n", coloredWarning(), wt.Branch, err)
+		return false
+	}
+	return gone
+}
+
+// warnBranchConflicts reports on stderr every branch that more than one
+// worktree has checked out, with the paths involved.
+func (c *ListCommand) warnBranchConflicts(worktrees []git.WorktreeInfo) {
+	for _, conflict := range git.FindBranchConflicts(worktrees) {
+		fmt.Fprintf(c.deps.Stderr, "%s Branch %s is checked out in %d worktrees:\n", coloredWarning(), conflict.Branch, len(conflict.Paths))
+		for _, path := range conflict.Paths {
+			fmt.Fprintf(c.deps.Stderr, "    %s\n", path)
+		}
+	}
+}
+
+// listFormatRow is what a --format template is executed against: the worktree
+// as git lists it (.Path, .Branch, .Commit, .IsLocked, ...) plus fields gw
+// derives from it.
+type listFormatRow struct {
+	git.WorktreeInfo
+	Issue string // issue number or identifier of a gw branch; empty otherwise
+	Stale bool   // the upstream branch was deleted on the remote
+	age   func() string
+}
+
+// Age is the time since the worktree's last commit, e.g. "3d". It is a method
+// so the commit is only looked up for templates that use it.
+func (r listFormatRow) Age() string { return r.age() }
+
+// formatEscapes turns the escapes people type in a shell-quoted --format into
+// the characters they mean.
+var formatEscapes = strings.NewReplacer(`\t`, "\t", `\n`, "\n")
+
+// printFormatted renders every entry with the --format template, one per
+// line. All rows are rendered before anything is printed so a template error
+// (such as an unknown field) does not leave partial output behind.
+func (c *ListCommand) printFormatted(entries []listEntry) error {
+	tmpl, err := template.New("format").Parse(formatEscapes.Replace(c.format))
+	if err != nil {
+		return fmt.Errorf("invalid --format template: %w", err)
+	}
+
+	var out bytes.Buffer
+	for _, e := range entries {
+		row := listFormatRow{
+			WorktreeInfo: e.info,
+			Issue:        git.ExtractIssueFromBranch(e.info.Branch),
+			Stale:        e.stale,
+			age:          func() string { return c.lastCommitAge(e.info.Path) },
+		}
+		if err := tmpl.Execute(&out, row); err != nil {
+			return fmt.Errorf("invalid --format template: %w", err)
+		}
+		out.WriteByte('\n')
+	}
+	_, err = out.WriteTo(c.deps.Stdout)
+	return err
+}
+
+// lastCommitAge returns how long ago the last commit in the worktree at path
+// was made, or "" when it cannot be determined.
+func (c *ListCommand) lastCommitAge(path string) string {
+	details, err := c.git().GetWorktreeDetails(path)
+	if err != nil || details.LastCommit.Date.IsZero() {
+		return ""
+	}
+	return formatAge(time.Since(details.LastCommit.Date))
+}
+
+// formatAge renders d in its largest whole unit: minutes, hours or days.
+func formatAge(d time.Duration) string {
+	switch {
+	case d < time.Hour:
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	case d < 24*time.Hour:
+		return fmt.Sprintf("%dh", int(d.Hours()))
+	default:
+		return fmt.Sprintf("%dd", int(d.Hours()/24))
+	}
+}
+
+func (c *ListCommand) printEntries(entries []listEntry) {
+	width := 0
+	for _, e := range entries {
+		width = max(width, len(e.info.Path))
+	}
+
+	for _, e := range entries {
+		branch := e.info.Branch
+		switch {
+		case e.info.IsBare:
+			branch = "(bare)"
+		case branch == "":
+			branch = detachedLabel(c.deps, c.git(), e.info.Commit)
+		}
+		line := fmt.Sprintf("%-*s  %s", width, e.info.Path, branch)
+		if e.info.Ahead > 0 || e.info.Behind > 0 {
+			line += fmt.Sprintf("  %s%d %s%d", glyph.Ahead, e.info.Ahead, glyph.Behind, e.info.Behind)
+		}
+		if e.stale {
+			line += "  [upstream gone]"
+		}
+		if e.info.IsPrunable {
+			line += "  [prunable]"
+		}
+		if e.info.IsLocked {
+			line += "  [locked]"
+		}
+		fmt.Fprintln(c.deps.Stdout, line)
+	}
+}
@@ -0,0 +1,482 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sotarok/gw/internal/config"
+	"github.com/sotarok/gw/internal/git"
+	"github.com/sotarok/gw/internal/glyph"
+)
+
+// staleListGit returns a mock with one worktree per state: the main worktree,
+// one whose upstream was deleted, one still on the remote, and a detached one.
+func staleListGit() *mockGit {
+	return &mockGit{
+		ListWorktreesFn: func() ([]git.WorktreeInfo, error) {
+			return []git.WorktreeInfo{
+				{Path: "/repo", Branch: "main"},
+				{Path: "/repo-123", Branch: testBranch123},
+				{Path: "/repo-456", Branch: "456/impl"},
+				{Path: "/repo-detached", IsDetached: true},
+				{Path: "/repo.git", IsBare: true},
+			}, nil
+		},
+		IsUpstreamGoneFn: func(branch string) (bool, error) {
+			return branch == testBranch123, nil
+		},
+	}
+}
+
+func newListTestDeps(mg *mockGit) (*Dependencies, *bytes.Buffer, *bytes.Buffer) {
+	stdout := &bytes.Buffer{}
+	stderr := &bytes.Buffer{}
+	return &Dependencies{
+		Git:    mg,
+		UI:     &mockUI{},
+		Config: config.New(),
+		Stdout: stdout,
+		Stderr: stderr,
+	}, stdout, stderr
+}
+
+func TestListCommand_Execute(t *testing.T) {
+	deps, stdout, _ := newListTestDeps(staleListGit())
+
+	if err := NewListCommand(deps, false, true).Execute(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	want := "/repo           main\n" +
+		"/repo-123       123/impl  [upstream gone]\n" +
+		"/repo-456       456/impl\n" +
+		"/repo-detached  (detached HEAD)\n" +
+		"/repo.git       (bare)\n"
+	if stdout.String() != want {
+		t.Errorf("Unexpected output:\ngot:\n%s\nwant:\n%s", stdout.String(), want)
+	}
+}
+
+func TestListCommand_Execute_DetachedTag(t *testing.T) {
+	mg := &mockGit{
+		ListWorktreesFn: func() ([]git.WorktreeInfo, error) {
+			return []git.WorktreeInfo{
+				{Path: "/repo", Branch: "main"},
+				{Path: "/repo-release", Commit: "abc123", IsDetached: true},
+				{Path: "/repo-scratch", Commit: "def456", IsDetached: true},
+			}, nil
+		},
+		ResolveRefDescriptionFn: func(commit string) (string, error) {
+			if commit == "abc123" {
+				return "v1.2.3", nil
+			}
+			return "", nil
+		},
+	}
+	deps, stdout, _ := newListTestDeps(mg)
+
+	if err := NewListCommand(deps, false, true).Execute(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	want := "/repo          main\n" +
+		"/repo-release  (v1.2.3)\n" +
+		"/repo-scratch  (detached HEAD)\n"
+	if stdout.String() != want {
+		t.Errorf("Unexpected output:\ngot:\n%s\nwant:\n%s", stdout.String(), want)
+	}
+}
+
+func TestListCommand_Execute_Stale(t *testing.T) {
+	t.Run("shows only worktrees whose upstream is gone", func(t *testing.T) {
+		deps, stdout, _ := newListTestDeps(staleListGit())
+
+		if err := NewListCommand(deps, true, true).Execute(); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if want := "/repo-123  123/impl  [upstream gone]\n"; stdout.String() != want {
+			t.Errorf("Expected %q, got %q", want, stdout.String())
+		}
+	})
+
+	t.Run("reports when nothing is stale", func(t *testing.T) {
+		mg := staleListGit()
+		mg.IsUpstreamGoneFn = func(string) (bool, error) { return false, nil }
+		deps, stdout, _ := newListTestDeps(mg)
+
+		if err := NewListCommand(deps, true, true).Execute(); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if !strings.Contains(stdout.String(), "No stale worktrees found.") {
+			t.Errorf("Expected no-stale message, got %q", stdout.String())
+		}
+	})
+
+	t.Run("upstream check failure is a warning", func(t *testing.T) {
+		mg := staleListGit()
+		mg.IsUpstreamGoneFn = func(string) (bool, error) { return false, fmt.Errorf("boom") }
+		deps, _, stderr := newListTestDeps(mg)
+
+		if err := NewListCommand(deps, true, true).Execute(); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if !strings.Contains(stderr.String(), "Could not check upstream of 456/impl: boom") {
+			t.Errorf("Expected warning, got %q", stderr.String())
+		}
+	})
+
+	t.Run("list failure is returned", func(t *testing.T) {
+		mg := &mockGit{ListWorktreesFn: func() ([]git.WorktreeInfo, error) { return nil, fmt.Errorf("not a git repository") }}
+		deps, _, _ := newListTestDeps(mg)
+
+		err := NewListCommand(deps, true, true).Execute()
+		if err == nil || !strings.Contains(err.Error(), "failed to list worktrees") {
+			t.Errorf("Expected list error, got %v", err)
+		}
+	})
+}
+
+func TestListCommand_Execute_BranchConflicts(t *testing.T) {
+	mg := staleListGit()
+	mg.ListWorktreesFn = func() ([]git.WorktreeInfo, error) {
+		return []git.WorktreeInfo{
+			{Path: "/repo", Branch: "main"},
+			{Path: "/repo-123", Branch: testBranch123},
+			{Path: "/moved/repo-123", Branch: testBranch123},
+			{Path: "/repo-detached", IsDetached: true},
+		}, nil
+	}
+	deps, stdout, stderr := newListTestDeps(mg)
+
+	if err := NewListCommand(deps, false, true).Execute(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	want := "Branch 123/impl is checked out in 2 worktrees:\n    /repo-123\n    /moved/repo-123\n"
+	if !strings.Contains(stderr.String(), want) {
+		t.Errorf("Expected conflict warning %q, got %q", want, stderr.String())
+	}
+	if strings.Count(stdout.String(), testBranch123) != 2 {
+		t.Errorf("Expected both worktrees to still be listed, got:\n%s", stdout.String())
+	}
+}
+
+func TestListCommand_Execute_Prunable(t *testing.T) {
+	mg := staleListGit()
+	mg.ListWorktreesFn = func() ([]git.WorktreeInfo, error) {
+		return []git.WorktreeInfo{
+			{Path: "/repo", Branch: "main"},
+			{Path: "/gone", Branch: "456/impl", IsPrunable: true},
+		}, nil
+	}
+	deps, stdout, _ := newListTestDeps(mg)
+
+	if err := NewListCommand(deps, false, true).Execute(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	want := "/repo  main\n" +
+		"/gone  456/impl  [prunable]\n"
+	if stdout.String() != want {
+		t.Errorf("Unexpected output:\ngot:\n%s\nwant:\n%s", stdout.String(), want)
+	}
+}
+
+func TestListCommand_Execute_Locked(t *testing.T) {
+	mg := staleListGit()
+	mg.ListWorktreesFn = func() ([]git.WorktreeInfo, error) {
+		return []git.WorktreeInfo{
+			{Path: "/repo", Branch: "main"},
+			{Path: "/kept", Branch: "456/impl", IsLocked: true, LockReason: "long-lived"},
+		}, nil
+	}
+	deps, stdout, _ := newListTestDeps(mg)
+
+	if err := NewListCommand(deps, false, true).Execute(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	want := "/repo  main\n" +
+		"/kept  456/impl  [locked]\n"
+	if stdout.String() != want {
+		t.Errorf("Unexpected output:\ngot:\n%s\nwant:\n%s", stdout.String(), want)
+	}
+}
+
+func TestListCommand_Execute_Format(t *testing.T) {
+	tests := []struct {
+		name   string
+		format string
+		want   string
+	}{
+		{
+			name:   "branch and path separated by an escaped tab",
+			format: `{{.Branch}}\t{{.Path}}`,
+			want:   "main\t/repo\n123/impl\t/repo-123\n456/impl\t/repo-456\n\t/repo-detached\n\t/repo.git\n",
+		},
+		{
+			name:   "derived fields",
+			format: `{{.Issue}} {{.Stale}} {{.Age}}`,
+			want:   " false 3d\n123 true 3d\n456 false 3d\n false 3d\n false 3d\n",
+		},
+		{
+			name:   "conditionals",
+			format: `{{if .IsBare}}bare{{else if .IsDetached}}detached{{else}}{{.Branch}}{{end}}`,
+			want:   "main\n123/impl\n456/impl\ndetached\nbare\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mg := staleListGit()
+			mg.GetWorktreeDetailsFn = func(string) (*git.WorktreeDetails, error) {
+				return &git.WorktreeDetails{LastCommit: git.CommitInfo{Date: time.Now().Add(-73 * time.Hour)}}, nil
+			}
+			deps, stdout, _ := newListTestDeps(mg)
+			cmd := NewListCommand(deps, false, true)
+			cmd.format = tt.format
+
+			if err := cmd.Execute(); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if stdout.String() != tt.want {
+				t.Errorf("Unexpected output:\ngot:  %q\nwant: %q", stdout.String(), tt.want)
+			}
+		})
+	}
+
+	t.Run("unknown field", func(t *testing.T) {
+		deps, stdout, _ := newListTestDeps(staleListGit())
+		cmd := NewListCommand(deps, false, true)
+		cmd.format = "{{.Branch}} {{.Nope}}"
+
+		err := cmd.Execute()
+		if err == nil || !strings.Contains(err.Error(), "invalid --format template") || !strings.Contains(err.Error(), "can't evaluate field Nope") {
+			t.Errorf("Expected an unknown field error, got: %v", err)
+		}
+		if stdout.Len() != 0 {
+			t.Errorf("Expected no partial output, got %q", stdout.String())
+		}
+	})
+
+	t.Run("syntax error", func(t *testing.T) {
+		deps, _, _ := newListTestDeps(staleListGit())
+		cmd := NewListCommand(deps, false, true)
+		cmd.format = "{{.Branch"
+
+		if err := cmd.Execute(); err == nil || !strings.Contains(err.Error(), "invalid --format template") {
+			t.Errorf("Expected a parse error, got: %v", err)
+		}
+	})
+}
+
+func TestListCommand_Execute_AheadBehind(t *testing.T) {
+	repo, runGit := newDryRunTestRepo(t)
+	t.Setenv(glyph.EnvASCII, "")
+
+	wt := filepath.Join(filepath.Dir(repo), "repo-123")
+	runGit("worktree", "add", "-b", testBranch123, wt)
+	for _, msg := range []string{"first", "second"} {
+		if out, err := exec.Command("git", "-C", wt, "commit", "--allow-empty", "-m", msg).CombinedOutput(); err != nil {
+			t.Fatalf("commit in worktree failed: %v\n%s", err, out)
+		}
+	}
+	runGit("commit", "--allow-empty", "-m", "on main")
+
+	deps, stdout, _ := newListTestDeps(nil)
+	deps.Git = git.NewClient()
+	cmd := NewListCommand(deps, false, true)
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(stdout.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("Expected two worktrees, got:\n%s", stdout.String())
+	}
+	if strings.Contains(lines[0], "↑") {
+		t.Errorf("Expected no counts for main itself, got %q", lines[0])
+	}
+	if !strings.HasSuffix(lines[1], "123/impl  ↑2 ↓1") {
+		t.Errorf("Expected the worktree to be 2 ahead and 1 behind, got %q", lines[1])
+	}
+
+	stdout.Reset()
+	cmd.format = "{{.Branch}} {{.Ahead}} {{.Behind}}"
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if want := "main 0 0\n123/impl 2 1\n"; stdout.String() != want {
+		t.Errorf("Unexpected output:\ngot:  %q\nwant: %q", stdout.String(), want)
+	}
+}
+
+func TestListCommand_Execute_Since(t *testing.T) {
+	repo, runGit := newDryRunTestRepo(t)
+	parent := filepath.Dir(repo)
+
+	runGit("tag", "v1.2.0")
+	ahead := filepath.Join(parent, "repo-123")
+	runGit("worktree", "add", "-b", testBranch123, ahead)
+	if out, err := exec.Command("git", "-C", ahead, "commit", "--allow-empty", "-m", "new work").CombinedOutput(); err != nil {
+		t.Fatalf("commit in worktree failed: %v\n%s", err, out)
+	}
+	runGit("worktree", "add", "-b", "456/impl", filepath.Join(parent, "repo-456"))
+
+	deps, stdout, _ := newListTestDeps(nil)
+	deps.Git = git.NewClient()
+	cmd := NewListCommand(deps, false, true)
+	cmd.format = "{{.Branch}}"
+	cmd.since = "v1.2.0"
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if want := "123/impl\n"; stdout.String() != want {
+		t.Errorf("Unexpected output:\ngot:  %q\nwant: %q", stdout.String(), want)
+	}
+
+	cmd.since = "v9.9.9"
+	if err := cmd.Execute(); err == nil || !strings.Contains(err.Error(), "failed to count commits since v9.9.9") {
+		t.Errorf("Expected an unknown revision error, got: %v", err)
+	}
+}
+
+func TestListCommand_Execute_InvalidSince(t *testing.T) {
+	mg := &mockGit{
+		ListWorktreesFn: func() ([]git.WorktreeInfo, error) {
+			t.Error("Expected --since to be rejected before listing worktrees")
+			return nil, nil
+		},
+		CommitsSinceFn: func(string, string) (int, error) {
+			t.Error("Expected --since to be rejected before counting commits")
+			return 0, nil
+		},
+	}
+	deps, stdout, _ := newListTestDeps(mg)
+	cmd := NewListCommand(deps, false, true)
+	cmd.since = "--all"
+
+	err := cmd.Execute()
+	if err == nil || !strings.Contains(err.Error(), "invalid --since revision") {
+		t.Errorf("Expected an invalid --since error, got: %v", err)
+	}
+	if stdout.Len() != 0 {
+		t.Errorf("Expected no output, got: %q", stdout.String())
+	}
+}
+
+func TestListCommand_Execute_AllRepos(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	tmpDir, err := filepath.EvalSymlinks(t.TempDir())
+	if err != nil {
+		t.Fatalf("EvalSymlinks failed: %v", err)
+	}
+	root := filepath.Join(tmpDir, "worktrees")
+	runGitIn := func(dir string, args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v failed: %v\n%s", args, err, out)
+		}
+	}
+	for _, name := range []string{"api", "web"} {
+		repo := filepath.Join(tmpDir, "src", name)
+		runGitIn(tmpDir, "init", "-b", "main", repo)
+		runGitIn(repo, "config", "user.email", "test@example.com")
+		runGitIn(repo, "config", "user.name", "Test User")
+		runGitIn(repo, "commit", "--allow-empty", "-m", "initial")
+		runGitIn(repo, "worktree", "add", "-b", "1/impl", filepath.Join(root, name, name+"-1"))
+		runGitIn(repo, "worktree", "add", "-b", "feature/login", filepath.Join(root, name, name+"-feature-login"))
+	}
+
+	t.Run("groups worktrees by repository", func(t *testing.T) {
+		deps, stdout, _ := newListTestDeps(&mockGit{})
+		deps.Config.WorktreeRoot = root
+		cmd := NewListCommand(deps, false, true)
+		cmd.allRepos = true
+
+		if err := cmd.Execute(); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		want := fmt.Sprintf("api (%[1]s/src/api)\n"+
+			"  %[2]s/api/api-1              1/impl\n"+
+			"  %[2]s/api/api-feature-login  feature/login\n"+
+			"\n"+
+			"web (%[1]s/src/web)\n"+
+			"  %[2]s/web/web-1              1/impl\n"+
+			"  %[2]s/web/web-feature-login  feature/login\n", tmpDir, root)
+		if stdout.String() != want {
+			t.Errorf("Unexpected output:\ngot:\n%s\nwant:\n%s", stdout.String(), want)
+		}
+	})
+
+	t.Run("--repo lists one repository", func(t *testing.T) {
+		deps, stdout, _ := newListTestDeps(&mockGit{})
+		deps.Config.WorktreeRoot = root
+		cmd := NewListCommand(deps, false, true)
+		cmd.repo = "web"
+
+		if err := cmd.Execute(); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		want := fmt.Sprintf("web (%[1]s/src/web)\n"+
+			"  %[2]s/web/web-1              1/impl\n"+
+			"  %[2]s/web/web-feature-login  feature/login\n", tmpDir, root)
+		if stdout.String() != want {
+			t.Errorf("Unexpected output:\ngot:\n%s\nwant:\n%s", stdout.String(), want)
+		}
+	})
+
+	t.Run("--repo of an unknown repository", func(t *testing.T) {
+		deps, _, _ := newListTestDeps(&mockGit{})
+		deps.Config.WorktreeRoot = root
+		cmd := NewListCommand(deps, false, true)
+		cmd.repo = "mobile"
+
+		if err := cmd.Execute(); err == nil || !strings.Contains(err.Error(), "no worktrees of a repository named mobile") {
+			t.Errorf("Expected an unknown repository error, got: %v", err)
+		}
+	})
+
+	t.Run("defaults to the current repository", func(t *testing.T) {
+		originalDir, _ := os.Getwd()
+		defer os.Chdir(originalDir)
+		if err := os.Chdir(filepath.Join(root, "web", "web-1")); err != nil {
+			t.Fatalf("chdir: %v", err)
+		}
+		deps, stdout, _ := newListTestDeps(&mockGit{})
+		deps.Git = git.NewClient()
+		deps.Config.WorktreeRoot = root
+		cmd := NewListCommand(deps, false, true)
+		cmd.format = "{{.Path}}"
+
+		if err := cmd.Execute(); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		want := fmt.Sprintf("%[1]s/src/web\n%[2]s/web/web-1\n%[2]s/web/web-feature-login\n", tmpDir, root)
+		if stdout.String() != want {
+			t.Errorf("Unexpected output:\ngot:\n%s\nwant:\n%s", stdout.String(), want)
+		}
+	})
+
+	t.Run("requires worktree_root", func(t *testing.T) {
+		deps, _, _ := newListTestDeps(&mockGit{})
+		cmd := NewListCommand(deps, false, true)
+		cmd.allRepos = true
+
+		if err := cmd.Execute(); err == nil || !strings.Contains(err.Error(), "worktree_root") {
+			t.Errorf("Expected a missing worktree_root error, got: %v", err)
+		}
+	})
+}
@@ -0,0 +1,70 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/sotarok/gw/internal/git"
+)
+
+// lsBranchesGit is the subset of git operations LsBranchesCommand actually uses.
+type lsBranchesGit interface {
+	git.RepositoryReader // Remote
+	git.WorktreeManager  // ListWorktrees
+	git.BranchManager    // ListAllBranches
+}
+
+// LsBranchesCommand handles the ls-branches command logic
+type LsBranchesCommand struct {
+	deps       *Dependencies
+	remoteOnly bool
+}
+
+// NewLsBranchesCommand creates a new ls-branches command handler
+func NewLsBranchesCommand(deps *Dependencies, remoteOnly bool) *LsBranchesCommand {
+	return &LsBranchesCommand{
+		deps:       deps,
+		remoteOnly: remoteOnly,
+	}
+}
+
+// git returns the command's git dependency narrowed to the operations it uses.
+func (c *LsBranchesCommand) git() lsBranchesGit { return c.deps.Git }
+
+// Execute prints, one per line, the branches that no worktree has checked out.
+func (c *LsBranchesCommand) Execute() error {
+	branches, err := c.git().ListAllBranches()
+	if err != nil {
+		return err
+	}
+	worktrees, err := c.git().ListWorktrees()
+	if err != nil {
+		return fmt.Errorf("failed to list worktrees: %w", err)
+	}
+
+	checkedOut := make(map[string]bool, len(worktrees))
+	for _, wt := range worktrees {
+		if wt.Branch != "" {
+			checkedOut[wt.Branch] = true
+		}
+	}
+
+	var free []string
+	for _, branch := range branches {
+		name, isRemote := git.CutRemotePrefix(branch, c.git().Remote())
+		if c.remoteOnly && !isRemote {
+			continue
+		}
+		if !checkedOut[name] {
+			free = append(free, branch)
+		}
+	}
+
+	if len(free) == 0 {
+		fmt.Fprintf(c.deps.Stdout, "Every branch already has a worktree.\n")
+		return nil
+	}
+	for _, branch := range free {
+		fmt.Fprintln(c.deps.Stdout, branch)
+	}
+	return nil
+}
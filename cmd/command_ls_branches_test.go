@@ -0,0 +1,117 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/sotarok/gw/internal/git"
+)
+
+// branchesListGit returns a mock where main and 123/impl have worktrees and
+// 456/impl, origin/789/impl and a local-only experiment branch do not.
+func branchesListGit() *mockGit {
+	return &mockGit{
+		ListAllBranchesFn: func() ([]string, error) {
+			return []string{
+				"main", testBranch123, "456/impl", "experiment",
+				"origin/main", "origin/" + testBranch123, "origin/456/impl", "origin/789/impl",
+			}, nil
+		},
+		ListWorktreesFn: func() ([]git.WorktreeInfo, error) {
+			return []git.WorktreeInfo{
+				{Path: "/repo", Branch: "main"},
+				{Path: "/repo-123", Branch: testBranch123},
+				{Path: "/repo-detached", IsDetached: true},
+			}, nil
+		},
+	}
+}
+
+func TestLsBranchesCommand_Execute(t *testing.T) {
+	tests := []struct {
+		name       string
+		remoteOnly bool
+		want       string
+	}{
+		{
+			name: "all branches",
+			want: "456/impl\nexperiment\norigin/456/impl\norigin/789/impl\n",
+		},
+		{
+			name:       "remote only",
+			remoteOnly: true,
+			want:       "origin/456/impl\norigin/789/impl\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			deps, stdout, _ := newListTestDeps(branchesListGit())
+
+			if err := NewLsBranchesCommand(deps, tt.remoteOnly).Execute(); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if stdout.String() != tt.want {
+				t.Errorf("Unexpected output:\ngot:\n%s\nwant:\n%s", stdout.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestLsBranchesCommand_Execute_ConfiguredRemote(t *testing.T) {
+	mg := branchesListGit()
+	mg.remote = "upstream"
+	mg.ListAllBranchesFn = func() ([]string, error) {
+		return []string{"main", testBranch123, "origin/456/impl", "upstream/" + testBranch123, "upstream/789/impl"}, nil
+	}
+	deps, stdout, _ := newListTestDeps(mg)
+
+	if err := NewLsBranchesCommand(deps, true).Execute(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if want := "upstream/789/impl\n"; stdout.String() != want {
+		t.Errorf("Expected only upstream branches without a worktree, got:\n%s", stdout.String())
+	}
+}
+
+func TestLsBranchesCommand_Execute_Variants(t *testing.T) {
+	t.Run("every branch checked out", func(t *testing.T) {
+		mg := &mockGit{
+			ListAllBranchesFn: func() ([]string, error) { return []string{"main", "origin/main"}, nil },
+			ListWorktreesFn: func() ([]git.WorktreeInfo, error) {
+				return []git.WorktreeInfo{{Path: "/repo", Branch: "main"}}, nil
+			},
+		}
+		deps, stdout, _ := newListTestDeps(mg)
+
+		if err := NewLsBranchesCommand(deps, false).Execute(); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if !strings.Contains(stdout.String(), "Every branch already has a worktree.") {
+			t.Errorf("Expected empty message, got:\n%s", stdout.String())
+		}
+	})
+
+	t.Run("list branches error", func(t *testing.T) {
+		mg := branchesListGit()
+		mg.ListAllBranchesFn = func() ([]string, error) { return nil, fmt.Errorf("failed to list branches") }
+		deps, _, _ := newListTestDeps(mg)
+
+		err := NewLsBranchesCommand(deps, false).Execute()
+		if err == nil || !strings.Contains(err.Error(), "failed to list branches") {
+			t.Errorf("Expected list branches error, got: %v", err)
+		}
+	})
+
+	t.Run("list worktrees error", func(t *testing.T) {
+		mg := branchesListGit()
+		mg.ListWorktreesFn = func() ([]git.WorktreeInfo, error) { return nil, fmt.Errorf("boom") }
+		deps, _, _ := newListTestDeps(mg)
+
+		err := NewLsBranchesCommand(deps, false).Execute()
+		if err == nil || !strings.Contains(err.Error(), "failed to list worktrees") {
+			t.Errorf("Expected list worktrees error, got: %v", err)
+		}
+	})
+}
@@ -0,0 +1,128 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/sotarok/gw/internal/git"
+	"github.com/sotarok/gw/internal/spinner"
+)
+
+// pullAllGit is the subset of git operations PullAllCommand actually uses.
+type pullAllGit interface {
+	git.RepositoryReader // IsGitRepository, FetchAll
+	git.WorktreeManager  // ListWorktrees
+	git.BranchManager    // UpstreamStatus, FastForwardBranch
+	git.StatusChecker    // HasUncommittedChanges, CommitsBehind
+}
+
+// PullAllCommand handles the pull-all command logic
+type PullAllCommand struct {
+	deps       *Dependencies
+	baseBranch string
+}
+
+// NewPullAllCommand creates a new pull-all command handler
+func NewPullAllCommand(deps *Dependencies, baseBranch string) *PullAllCommand {
+	return &PullAllCommand{deps: deps, baseBranch: baseBranch}
+}
+
+// git returns the command's git dependency narrowed to the operations it uses.
+func (c *PullAllCommand) git() pullAllGit { return c.deps.Git }
+
+// Execute fetches, fast-forwards the base branch to its upstream and reports
+// the other worktrees that are behind the updated base branch.
+func (c *PullAllCommand) Execute() error {
+	if !c.git().IsGitRepository() {
+		return git.ErrNotGitRepository
+	}
+
+	sp := spinner.New("Fetching from remotes...", c.deps.Stdout)
+	sp.Start()
+	err := c.git().FetchAll()
+	sp.Stop()
+	if err != nil {
+		return err
+	}
+
+	worktrees, err := c.git().ListWorktrees()
+	if err != nil {
+		return fmt.Errorf("failed to list worktrees: %w", err)
+	}
+	if err := c.fastForwardBase(worktrees); err != nil {
+		return err
+	}
+	c.reportBehind(worktrees)
+	return nil
+}
+
+// fastForwardBase moves the base branch to its upstream. It refuses when the
+// branch has local commits of its own or is checked out in a dirty worktree.
+func (c *PullAllCommand) fastForwardBase(worktrees []git.WorktreeInfo) error {
+	status, err := c.git().UpstreamStatus(c.baseBranch)
+	if err != nil {
+		return err
+	}
+	if status.Upstream == "" {
+		return fmt.Errorf("%s has no upstream to pull from", c.baseBranch)
+	}
+	if status.Behind == 0 {
+		fmt.Fprintf(c.deps.Stdout, "%s %s is already up to date with %s\n", coloredSuccess(), c.baseBranch, status.Upstream)
+		return nil
+	}
+	if status.Ahead > 0 {
+		return fmt.Errorf("%s has diverged from %s (%d local, %d upstream %s); update it by hand",
+			c.baseBranch, status.Upstream, status.Ahead, status.Behind, plural(status.Behind, "commit", "commits"))
+	}
+
+	for _, wt := range worktrees {
+		if wt.Branch != c.baseBranch {
+			continue
+		}
+		dirty, err := c.git().HasUncommittedChanges(wt.Path)
+		if err != nil {
+			return err
+		}
+		if dirty {
+			return fmt.Errorf("refusing to fast-forward %s: the worktree at %s has uncommitted changes", c.baseBranch, wt.Path)
+		}
+	}
+
+	if err := c.git().FastForwardBranch(c.baseBranch); err != nil {
+		return err
+	}
+	fmt.Fprintf(c.deps.Stdout, "%s Fast-forwarded %s to %s (%d %s)\n",
+		coloredSuccess(), c.baseBranch, status.Upstream, status.Behind, plural(status.Behind, "commit", "commits"))
+	return nil
+}
+
+// reportBehind lists the worktrees on other branches that are missing commits
+// from the base branch. A worktree that cannot be checked is a warning.
+func (c *PullAllCommand) reportBehind(worktrees []git.WorktreeInfo) {
+	type behindWorktree struct {
+		wt     git.WorktreeInfo
+		behind int
+	}
+	var behind []behindWorktree
+	for _, wt := range worktrees {
+		if wt.Branch == "" || wt.Branch == c.baseBranch || wt.IsBare || wt.IsPrunable {
+			continue
+		}
+		n, err := c.git().CommitsBehind(wt.Path, c.baseBranch)
+		if err != nil {
+			fmt.Fprintf(c.deps.Stderr, "%s Could not check %s: %v\n", coloredWarning(), wt.Path, err)
+			continue
+		}
+		if n > 0 {
+			behind = append(behind, behindWorktree{wt: wt, behind: n})
+		}
+	}
+
+	if len(behind) == 0 {
+		fmt.Fprintf(c.deps.Stdout, "All worktrees are up to date with %s\n", c.baseBranch)
+		return
+	}
+	fmt.Fprintf(c.deps.Stdout, "\nWorktrees behind %s:\n", c.baseBranch)
+	for _, b := range behind {
+		fmt.Fprintf(c.deps.Stdout, "  %s (%s): %d %s behind\n", b.wt.Path, b.wt.Branch, b.behind, plural(b.behind, "commit", "commits"))
+	}
+}
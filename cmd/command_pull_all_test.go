@@ -0,0 +1,181 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/sotarok/gw/internal/config"
+	"github.com/sotarok/gw/internal/git"
+)
+
+func TestPullAllCommand_Execute(t *testing.T) {
+	worktrees := []git.WorktreeInfo{
+		{Path: "/repo", Branch: "main"},
+		{Path: "/repo-123", Branch: testBranch123},
+		{Path: "/repo-456", Branch: "456/impl"},
+	}
+	behind := map[string]int{"/repo-123": 2}
+
+	t.Run("fast-forwards and reports worktrees behind", func(t *testing.T) {
+		var fastForwarded string
+		mg := &mockGit{
+			isGitRepo:       true,
+			ListWorktreesFn: func() ([]git.WorktreeInfo, error) { return worktrees, nil },
+			UpstreamStatusFn: func(string) (*git.TrackingStatus, error) {
+				return &git.TrackingStatus{Upstream: "origin/main", Behind: 3}, nil
+			},
+			FastForwardBranchFn: func(branch string) error {
+				fastForwarded = branch
+				return nil
+			},
+			CommitsBehindFn: func(path, base string) (int, error) { return behind[path], nil },
+		}
+		deps, stdout, _ := newListTestDeps(mg)
+
+		if err := NewPullAllCommand(deps, "main").Execute(); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if fastForwarded != "main" {
+			t.Errorf("Expected main to be fast-forwarded, got %q", fastForwarded)
+		}
+		output := stdout.String()
+		for _, want := range []string{
+			"Fast-forwarded main to origin/main (3 commits)",
+			"Worktrees behind main:",
+			"  /repo-123 (123/impl): 2 commits behind",
+		} {
+			if !strings.Contains(output, want) {
+				t.Errorf("Expected output to contain %q, got:\n%s", want, output)
+			}
+		}
+		if strings.Contains(output, "/repo-456") {
+			t.Errorf("Up-to-date worktree should not be listed, got:\n%s", output)
+		}
+	})
+
+	t.Run("refuses a dirty base worktree", func(t *testing.T) {
+		mg := &mockGit{
+			isGitRepo:       true,
+			ListWorktreesFn: func() ([]git.WorktreeInfo, error) { return worktrees, nil },
+			UpstreamStatusFn: func(string) (*git.TrackingStatus, error) {
+				return &git.TrackingStatus{Upstream: "origin/main", Behind: 3}, nil
+			},
+			HasUncommittedChangesAtFn: func(path string) (bool, error) { return path == "/repo", nil },
+			FastForwardBranchFn: func(string) error {
+				t.Error("FastForwardBranch must not be called for a dirty worktree")
+				return nil
+			},
+		}
+		deps, _, _ := newListTestDeps(mg)
+
+		err := NewPullAllCommand(deps, "main").Execute()
+		if err == nil || !strings.Contains(err.Error(), "the worktree at /repo has uncommitted changes") {
+			t.Errorf("Expected dirty worktree error, got: %v", err)
+		}
+	})
+
+	t.Run("refuses a diverged base branch", func(t *testing.T) {
+		mg := &mockGit{
+			isGitRepo:       true,
+			ListWorktreesFn: func() ([]git.WorktreeInfo, error) { return worktrees, nil },
+			UpstreamStatusFn: func(string) (*git.TrackingStatus, error) {
+				return &git.TrackingStatus{Upstream: "origin/main", Ahead: 1, Behind: 3}, nil
+			},
+		}
+		deps, _, _ := newListTestDeps(mg)
+
+		err := NewPullAllCommand(deps, "main").Execute()
+		if err == nil || !strings.Contains(err.Error(), "main has diverged from origin/main") {
+			t.Errorf("Expected diverged error, got: %v", err)
+		}
+	})
+
+	t.Run("already up to date", func(t *testing.T) {
+		mg := &mockGit{
+			isGitRepo:       true,
+			ListWorktreesFn: func() ([]git.WorktreeInfo, error) { return worktrees, nil },
+			UpstreamStatusFn: func(string) (*git.TrackingStatus, error) {
+				return &git.TrackingStatus{Upstream: "origin/main"}, nil
+			},
+			CommitsBehindFn: func(string, string) (int, error) { return 0, fmt.Errorf("boom") },
+		}
+		deps, stdout, stderr := newListTestDeps(mg)
+
+		if err := NewPullAllCommand(deps, "main").Execute(); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if !strings.Contains(stdout.String(), "main is already up to date with origin/main") {
+			t.Errorf("Unexpected output: %s", stdout.String())
+		}
+		if !strings.Contains(stderr.String(), "Could not check /repo-123: boom") {
+			t.Errorf("Expected a warning for the failed check, got: %s", stderr.String())
+		}
+	})
+}
+
+func TestPullAllCommand_Execute_BareRemote(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	originalDir, _ := os.Getwd()
+	t.Cleanup(func() { _ = os.Chdir(originalDir) })
+
+	tmpDir := t.TempDir()
+	remote := filepath.Join(tmpDir, "remote.git")
+	repo := filepath.Join(tmpDir, "repo")
+	other := filepath.Join(tmpDir, "other")
+	runGitIn := func(dir string, args ...string) string {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		out, err := cmd.CombinedOutput()
+		if err != nil {
+			t.Fatalf("git %v failed: %v\n%s", args, err, out)
+		}
+		return strings.TrimSpace(string(out))
+	}
+
+	runGitIn(tmpDir, "init", "--bare", "-b", "main", remote)
+	runGitIn(tmpDir, "clone", remote, repo)
+	runGitIn(repo, "config", "user.email", "test@example.com")
+	runGitIn(repo, "config", "user.name", "Test User")
+	runGitIn(repo, "commit", "--allow-empty", "-m", "initial")
+	runGitIn(repo, "push", "-u", "origin", "main")
+	runGitIn(repo, "worktree", "add", "-b", "123/impl", filepath.Join(tmpDir, "repo-123"))
+
+	// New upstream commits pushed from another clone.
+	runGitIn(tmpDir, "clone", remote, other)
+	runGitIn(other, "config", "user.email", "other@example.com")
+	runGitIn(other, "config", "user.name", "Other User")
+	runGitIn(other, "commit", "--allow-empty", "-m", "upstream 1")
+	runGitIn(other, "commit", "--allow-empty", "-m", "upstream 2")
+	runGitIn(other, "push", "origin", "main")
+	upstreamHead := runGitIn(other, "rev-parse", "HEAD")
+
+	if err := os.Chdir(repo); err != nil {
+		t.Fatalf("chdir: %v", err)
+	}
+	stdout := &bytes.Buffer{}
+	deps := &Dependencies{
+		Git:    git.NewClient(),
+		UI:     &mockUI{},
+		Config: config.New(),
+		Stdout: stdout,
+		Stderr: &bytes.Buffer{},
+	}
+
+	if err := NewPullAllCommand(deps, "main").Execute(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if head := runGitIn(repo, "rev-parse", "HEAD"); head != upstreamHead {
+		t.Errorf("Expected the main worktree at %s, got %s", upstreamHead, head)
+	}
+	if !strings.Contains(stdout.String(), "123/impl): 2 commits behind") {
+		t.Errorf("Expected 123/impl to be reported 2 commits behind, got:\n%s", stdout.String())
+	}
+}
@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/sotarok/gw/internal/git"
+)
+
+// reattachGit is the subset of git operations ReattachCommand actually uses.
+type reattachGit interface {
+	git.WorktreeManager // RepairWorktrees, ListWorktrees
+}
+
+// ReattachCommand handles the reattach command logic
+type ReattachCommand struct {
+	deps *Dependencies
+}
+
+// NewReattachCommand creates a new reattach command handler
+func NewReattachCommand(deps *Dependencies) *ReattachCommand {
+	return &ReattachCommand{deps: deps}
+}
+
+// git returns the command's git dependency narrowed to the operations it uses.
+func (c *ReattachCommand) git() reattachGit { return c.deps.Git }
+
+// Execute repairs the worktree now at worktreePath and verifies it is listed
+// at that location afterwards. Only the new location can be repaired: git
+// cannot find a moved worktree from its old path.
+func (c *ReattachCommand) Execute(worktreePath string) error {
+	absPath, err := filepath.Abs(worktreePath)
+	if err != nil {
+		return fmt.Errorf("failed to resolve %s: %w", worktreePath, err)
+	}
+	if info, err := os.Stat(absPath); err != nil || !info.IsDir() {
+		return fmt.Errorf("%s is not a directory; pass the worktree's new location", absPath)
+	}
+
+	if err := c.git().RepairWorktrees(absPath); err != nil {
+		return err
+	}
+
+	worktrees, err := c.git().ListWorktrees()
+	if err != nil {
+		return fmt.Errorf("failed to list worktrees: %w", err)
+	}
+	for _, wt := range worktrees {
+		if samePath(wt.Path, absPath) {
+			branch := wt.Branch
+			if branch == "" {
+				branch = "detached HEAD"
+			}
+			fmt.Fprintf(c.deps.Stdout, "%s Reattached worktree at %s (%s)\n", coloredSuccess(), absPath, branch)
+			return nil
+		}
+	}
+	return fmt.Errorf("%s is still not listed as a worktree after git worktree repair", absPath)
+}
+
+// samePath reports whether a and b name the same directory once symlinks are
+// resolved (git lists the resolved path, e.g. /private/var on macOS).
+func samePath(a, b string) bool {
+	if a == b {
+		return true
+	}
+	ra, errA := filepath.EvalSymlinks(a)
+	rb, errB := filepath.EvalSymlinks(b)
+	return errA == nil && errB == nil && ra == rb
+}
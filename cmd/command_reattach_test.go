@@ -0,0 +1,75 @@
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/sotarok/gw/internal/git"
+)
+
+func TestReattachCommand_Execute(t *testing.T) {
+	movedPath := t.TempDir()
+
+	t.Run("repairs and finds the worktree", func(t *testing.T) {
+		var repaired []string
+		mg := &mockGit{
+			RepairWorktreesFn: func(paths ...string) error {
+				repaired = paths
+				return nil
+			},
+			ListWorktreesFn: func() ([]git.WorktreeInfo, error) {
+				return []git.WorktreeInfo{{Path: "/repo", Branch: "main"}, {Path: movedPath, Branch: testBranch123}}, nil
+			},
+		}
+		deps, stdout, _ := newListTestDeps(mg)
+
+		if err := NewReattachCommand(deps).Execute(movedPath); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if len(repaired) != 1 || repaired[0] != movedPath {
+			t.Errorf("Expected repair of %s, got %v", movedPath, repaired)
+		}
+		if !strings.Contains(stdout.String(), "Reattached worktree at "+movedPath+" (123/impl)") {
+			t.Errorf("Unexpected output: %s", stdout.String())
+		}
+	})
+
+	t.Run("still not listed after repair", func(t *testing.T) {
+		mg := &mockGit{
+			ListWorktreesFn: func() ([]git.WorktreeInfo, error) {
+				return []git.WorktreeInfo{{Path: "/repo", Branch: "main"}}, nil
+			},
+		}
+		deps, _, _ := newListTestDeps(mg)
+
+		err := NewReattachCommand(deps).Execute(movedPath)
+		if err == nil || !strings.Contains(err.Error(), "still not listed") {
+			t.Errorf("Expected not listed error, got: %v", err)
+		}
+	})
+
+	t.Run("repair error", func(t *testing.T) {
+		mg := &mockGit{RepairWorktreesFn: func(...string) error { return fmt.Errorf("failed to repair worktrees") }}
+		deps, _, _ := newListTestDeps(mg)
+
+		err := NewReattachCommand(deps).Execute(movedPath)
+		if err == nil || !strings.Contains(err.Error(), "failed to repair worktrees") {
+			t.Errorf("Expected repair error, got: %v", err)
+		}
+	})
+
+	t.Run("old location that no longer exists", func(t *testing.T) {
+		mg := &mockGit{RepairWorktreesFn: func(...string) error {
+			t.Error("Repair should not run for a missing directory")
+			return nil
+		}}
+		deps, _, _ := newListTestDeps(mg)
+
+		err := NewReattachCommand(deps).Execute(filepath.Join(movedPath, "gone"))
+		if err == nil || !strings.Contains(err.Error(), "pass the worktree's new location") {
+			t.Errorf("Expected missing directory error, got: %v", err)
+		}
+	})
+}
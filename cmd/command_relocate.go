@@ -0,0 +1,149 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/sotarok/gw/internal/git"
+	"github.com/sotarok/gw/internal/recent"
+)
+
+// relocateGit is the subset of git operations RelocateCommand actually uses.
+type relocateGit interface {
+	git.RepositoryReader // IsGitRepository, GetOriginalRepositoryName, GetMainRepositoryRoot
+	git.WorktreeManager  // ListWorktrees, MoveWorktree
+}
+
+// RelocateCommand handles the relocate command logic
+type RelocateCommand struct {
+	deps *Dependencies
+	to   string // --to: the new worktree root; defaults to worktree_root
+}
+
+// NewRelocateCommand creates a new relocate command handler
+func NewRelocateCommand(deps *Dependencies, to string) *RelocateCommand {
+	return &RelocateCommand{deps: deps, to: to}
+}
+
+// git returns the command's git dependency narrowed to the operations it uses.
+func (c *RelocateCommand) git() relocateGit { return c.deps.Git }
+
+// Execute moves every linked worktree of the repository into the per-repository
+// directory under the new root, <root>/<repo>/<worktree-dir>, the layout
+// worktree_root gives new worktrees. The main worktree stays where it is,
+// locked worktrees are skipped, and a worktree that fails to move does not
+// stop the others.
+func (c *RelocateCommand) Execute() error {
+	if !c.git().IsGitRepository() {
+		return git.ErrNotGitRepository
+	}
+
+	to := c.to
+	if to == "" {
+		to = c.deps.Config.WorktreeRoot
+	}
+	if to == "" {
+		return fmt.Errorf("relocate needs a destination; pass --to <root> or set worktree_root in ~/.gwrc")
+	}
+	root, err := filepath.Abs(to)
+	if err != nil {
+		return fmt.Errorf("failed to resolve %s: %w", to, err)
+	}
+
+	repoName, err := c.git().GetOriginalRepositoryName()
+	if err != nil {
+		return err
+	}
+	mainRoot, err := c.git().GetMainRepositoryRoot()
+	if err != nil {
+		return err
+	}
+	worktrees, err := c.git().ListWorktrees()
+	if err != nil {
+		return fmt.Errorf("failed to list worktrees: %w", err)
+	}
+
+	// Run git from the main worktree: it is never moved, so the current
+	// directory stays valid whichever worktree the command was started in.
+	cwd, _ := os.Getwd()
+	if err := os.Chdir(mainRoot); err != nil {
+		return fmt.Errorf("failed to change to the main worktree: %w", err)
+	}
+
+	moved, failed := 0, 0
+	for _, wt := range worktrees {
+		if wt.IsBare || samePath(wt.Path, mainRoot) {
+			continue
+		}
+		newPath := filepath.Join(root, repoName, filepath.Base(wt.Path))
+		switch {
+		case samePath(wt.Path, newPath):
+			continue
+		case wt.IsPrunable:
+			fmt.Fprintf(c.deps.Stderr, "%s Skipped %s: its directory is missing (run git worktree prune)\n", coloredWarning(), wt.Path)
+			continue
+		case wt.IsLocked:
+			fmt.Fprintf(c.deps.Stderr, "%s Skipped %s: it is locked%s (unlock it with git worktree unlock)\n", coloredWarning(), wt.Path, lockReasonSuffix(wt.LockReason))
+			continue
+		}
+
+		if err := c.git().MoveWorktree(wt.Path, newPath); err != nil {
+			// Too old a git fails the same way for every worktree.
+			var versionErr *git.VersionError
+			if errors.As(err, &versionErr) {
+				_ = os.Chdir(cwd)
+				return err
+			}
+			fmt.Fprintf(c.deps.Stderr, "%s Could not move %s: %v\n", coloredError(), wt.Path, err)
+			failed++
+			continue
+		}
+		fmt.Fprintf(c.deps.Stdout, "%s Moved %s %s %s\n", coloredSuccess(), wt.Path, coloredArrow(), newPath)
+		moved++
+		c.renameRecent(wt.Path, newPath)
+
+		if cwd != "" && isWithinDir(cwd, wt.Path) {
+			rel, _ := filepath.Rel(canonicalDir(wt.Path), canonicalDir(cwd))
+			cwd = filepath.Join(newPath, rel)
+			fmt.Fprintf(c.deps.Stdout, "%s Your current worktree moved: cd %s\n", coloredWarning(), cwd)
+		}
+	}
+	if cwd != "" {
+		_ = os.Chdir(cwd)
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("failed to move %d worktree(s)", failed)
+	}
+	if moved == 0 {
+		fmt.Fprintf(c.deps.Stdout, "No worktrees to move; all are already under %s\n", filepath.Join(root, repoName))
+	}
+	return nil
+}
+
+// renameRecent points the recents entry for a moved worktree at its new path,
+// so gw checkout - still finds it. Dry runs leave the file alone, and one that
+// cannot be updated only produces a warning.
+func (c *RelocateCommand) renameRecent(oldPath, newPath string) {
+	if dryRun {
+		return
+	}
+	path, err := recent.DefaultPath()
+	if err == nil {
+		err = recent.Rename(path, oldPath, newPath)
+	}
+	if err != nil {
+		fmt.Fprintf(c.deps.Stderr, "%s Could not update the recent worktrees: %v\n", coloredWarning(), err)
+	}
+}
+
+// lockReasonSuffix renders a lock reason for a message, or nothing when the
+// worktree was locked without one.
+func lockReasonSuffix(reason string) string {
+	if reason == "" {
+		return ""
+	}
+	return fmt.Sprintf(" (%s)", reason)
+}
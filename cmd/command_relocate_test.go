@@ -0,0 +1,105 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/sotarok/gw/internal/config"
+	"github.com/sotarok/gw/internal/git"
+	"github.com/sotarok/gw/internal/recent"
+)
+
+func TestRelocateCommand_Execute(t *testing.T) {
+	repo, runGit := newDryRunTestRepo(t)
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	parent := filepath.Dir(repo)
+	wt123 := filepath.Join(parent, "repo-123")
+	wt456 := filepath.Join(parent, "repo-456")
+	locked := filepath.Join(parent, "repo-789")
+	runGit("worktree", "add", "-b", testBranch123, wt123)
+	runGit("worktree", "add", "-b", "456/impl", wt456)
+	runGit("worktree", "add", "-b", "789/impl", locked)
+	runGit("worktree", "lock", "--reason", "on a usb drive", locked)
+
+	recentsFile := filepath.Join(home, ".gw", "recent.json")
+	if err := recent.Record(recentsFile, wt456, repo); err != nil {
+		t.Fatalf("Record failed: %v", err)
+	}
+
+	// Run from a subdirectory of a worktree that is about to move.
+	subdir := filepath.Join(wt123, "sub")
+	if err := os.MkdirAll(subdir, 0755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if err := os.Chdir(subdir); err != nil {
+		t.Fatalf("chdir: %v", err)
+	}
+
+	root := filepath.Join(t.TempDir(), "worktrees")
+	stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+	deps := &Dependencies{
+		Git:    git.NewClient(),
+		UI:     &mockUI{},
+		Config: &config.Config{},
+		Stdout: stdout,
+		Stderr: stderr,
+	}
+	if err := NewRelocateCommand(deps, root).Execute(); err != nil {
+		t.Fatalf("Unexpected error: %v\nstderr: %s", err, stderr.String())
+	}
+
+	moved123 := filepath.Join(root, "repo", "repo-123")
+	moved456 := filepath.Join(root, "repo", "repo-456")
+	worktrees, err := git.NewClient().ListWorktrees()
+	if err != nil {
+		t.Fatalf("ListWorktrees failed: %v", err)
+	}
+	var paths []string
+	for _, wt := range worktrees {
+		paths = append(paths, canonicalDir(wt.Path))
+	}
+	for _, want := range []string{repo, moved123, moved456, locked} {
+		if !slices.Contains(paths, canonicalDir(want)) {
+			t.Errorf("Expected %s in the worktree list, got %v", want, paths)
+		}
+	}
+	for _, old := range []string{wt123, wt456} {
+		if _, err := os.Stat(old); !os.IsNotExist(err) {
+			t.Errorf("Expected %s to be gone, stat error: %v", old, err)
+		}
+	}
+
+	if !strings.Contains(stderr.String(), "Skipped "+locked+": it is locked (on a usb drive)") {
+		t.Errorf("Expected the locked worktree to be skipped, got:\n%s", stderr.String())
+	}
+	if !strings.Contains(stdout.String(), "Your current worktree moved: cd "+filepath.Join(moved123, "sub")) {
+		t.Errorf("Expected a hint for the moved current directory, got:\n%s", stdout.String())
+	}
+	if cwd, _ := os.Getwd(); canonicalDir(cwd) != canonicalDir(filepath.Join(moved123, "sub")) {
+		t.Errorf("Expected to end up in the moved subdirectory, got %s", cwd)
+	}
+
+	visited, err := recent.Load(recentsFile)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if want := []string{repo, moved456}; !slices.Equal(visited, want) {
+		t.Errorf("recents = %v, want %v", visited, want)
+	}
+}
+
+func TestRelocateCommand_Execute_NeedsDestination(t *testing.T) {
+	deps, _, _ := newListTestDeps(&mockGit{isGitRepo: true})
+	deps.Config = &config.Config{}
+
+	err := NewRelocateCommand(deps, "").Execute()
+	if err == nil || !strings.Contains(err.Error(), "pass --to <root> or set worktree_root") {
+		t.Errorf("Expected a missing destination error, got: %v", err)
+	}
+}
@@ -0,0 +1,64 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/sotarok/gw/internal/git"
+)
+
+// setupGit is the subset of git operations SetupCommand actually uses.
+type setupGit interface {
+	git.RepositoryReader // IsGitRepository, GetRepositoryRoot
+	git.WorktreeManager  // GetWorktreeForIssue
+}
+
+// SetupCommand handles the setup command logic
+type SetupCommand struct {
+	deps *Dependencies
+}
+
+// NewSetupCommand creates a new setup command handler
+func NewSetupCommand(deps *Dependencies) *SetupCommand {
+	return &SetupCommand{deps: deps}
+}
+
+// git returns the command's git dependency narrowed to the operations it uses.
+func (c *SetupCommand) git() setupGit { return c.deps.Git }
+
+// Execute runs package-manager setup in the worktree for issueNumber, or in
+// the current worktree when issueNumber is empty. Unlike after gw start, a
+// failing setup is an error here, since running it is all the command does.
+func (c *SetupCommand) Execute(issueNumber string) error {
+	worktreePath, err := c.resolveWorktree(issueNumber)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(c.deps.Stdout, "%s Setting up %s\n", coloredArrow(), worktreePath)
+	return c.deps.Detect.RunSetup(worktreePath)
+}
+
+// resolveWorktree returns the path of the worktree to set up: the one for
+// issueNumber (an issue number or branch), or the one containing the current
+// directory.
+func (c *SetupCommand) resolveWorktree(issueNumber string) (string, error) {
+	if issueNumber == "" {
+		if !c.git().IsGitRepository() {
+			return "", git.ErrNotGitRepository
+		}
+		root, err := c.git().GetRepositoryRoot()
+		if err != nil {
+			return "", fmt.Errorf("failed to get repository root: %w", err)
+		}
+		return root, nil
+	}
+
+	wt, err := c.git().GetWorktreeForIssue(issueNumber)
+	if err != nil {
+		return "", err
+	}
+	if wt == nil {
+		return "", &git.WorktreeNotFoundError{Identifier: issueNumber}
+	}
+	return wt.Path, nil
+}
@@ -0,0 +1,68 @@
+package cmd
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/sotarok/gw/internal/detect"
+	"github.com/sotarok/gw/internal/git"
+)
+
+func TestSetupCommand_Execute(t *testing.T) {
+	worktree := t.TempDir()
+	for _, name := range []string{"package.json", "pnpm-lock.yaml"} {
+		if err := os.WriteFile(filepath.Join(worktree, name), []byte("{}"), 0644); err != nil {
+			t.Fatalf("WriteFile failed: %v", err)
+		}
+	}
+
+	tests := []struct {
+		name  string
+		issue string
+	}{
+		{"issue", "123"},
+		{"current worktree", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mg := &mockGit{
+				isGitRepo:           true,
+				GetRepositoryRootFn: func() (string, error) { return worktree, nil },
+				GetWorktreeForIssueFn: func(issue string) (*git.WorktreeInfo, error) {
+					if issue != "123" {
+						t.Errorf("Expected the worktree of 123 to be looked up, got %q", issue)
+					}
+					return &git.WorktreeInfo{Path: worktree, Branch: testBranch123}, nil
+				},
+			}
+			deps, _, _ := newListTestDeps(mg)
+			executor := &detect.MockExecutor{}
+			deps.Detect = detect.NewDefaultDetectorWithExecutor(executor)
+
+			if err := NewSetupCommand(deps).Execute(tt.issue); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if len(executor.ExecuteCalls) != 1 {
+				t.Fatalf("Expected one setup command, got %+v", executor.ExecuteCalls)
+			}
+			call := executor.ExecuteCalls[0]
+			if call.Dir != worktree || call.Command != "pnpm" || !reflect.DeepEqual(call.Args, []string{"install"}) {
+				t.Errorf("Expected pnpm install in %s, got %+v", worktree, call)
+			}
+		})
+	}
+}
+
+func TestSetupCommand_Execute_WorktreeNotFound(t *testing.T) {
+	deps, _, _ := newListTestDeps(&mockGit{isGitRepo: true})
+
+	err := NewSetupCommand(deps).Execute("999")
+	var notFound *git.WorktreeNotFoundError
+	if !errors.As(err, &notFound) {
+		t.Errorf("Expected a worktree not found error, got: %v", err)
+	}
+}
@@ -4,9 +4,12 @@ import (
 	"bytes"
 	"os"
 	"path/filepath"
+	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/sotarok/gw/internal/config"
+	"github.com/sotarok/gw/internal/detect"
 	"github.com/sotarok/gw/internal/git"
 )
 
@@ -38,6 +41,31 @@ func TestDefaultDependencies(t *testing.T) {
 	}
 }
 
+func TestGWConfigOverride(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	customPath := filepath.Join(t.TempDir(), "ci.gwrc")
+	t.Setenv(config.EnvConfigPath, customPath)
+
+	// gw init writes to $GW_CONFIG (auto_remove_branch on, everything else off)
+	initCmd := NewInitCommand(strings.NewReader("n\nn\ny\nn\nn\nn\nn\nn\n"), &bytes.Buffer{}, &bytes.Buffer{}, config.GetConfigPath())
+	if err := initCmd.Execute(); err != nil {
+		t.Fatalf("init failed: %v", err)
+	}
+	if _, err := os.Stat(customPath); err != nil {
+		t.Fatalf("Expected config at %s: %v", customPath, err)
+	}
+	if _, err := os.Stat(filepath.Join(home, ".gwrc")); !os.IsNotExist(err) {
+		t.Errorf("Expected no ~/.gwrc to be written, stat err: %v", err)
+	}
+
+	// Every command loads its config through DefaultDependencies
+	deps := DefaultDependencies()
+	if !deps.Config.AutoRemoveBranch {
+		t.Error("Expected AutoRemoveBranch from $GW_CONFIG to be loaded")
+	}
+}
+
 // Test copy_envs configuration priority
 func TestHandleEnvFiles_ConfigPriority(t *testing.T) {
 	tests := []struct {
@@ -133,7 +161,7 @@ func TestHandleEnvFiles_ConfigPriority(t *testing.T) {
 			}
 
 			// Execute handleEnvFiles
-			err := handleEnvFiles(deps, tt.copyEnvsFlag, originalDir, worktreeDir)
+			err := handleEnvFiles(deps, tt.copyEnvsFlag, false, originalDir, worktreeDir)
 			if err != nil {
 				t.Fatalf("handleEnvFiles failed: %v", err)
 			}
@@ -162,3 +190,209 @@ func TestHandleEnvFiles_ConfigPriority(t *testing.T) {
 		})
 	}
 }
+
+func TestHandleEnvFiles_ExistingDestination(t *testing.T) {
+	tests := []struct {
+		name           string
+		configCopyEnvs *bool
+		overwriteEnvs  bool
+		answers        []bool // replies to the copy prompt, then the overwrite prompt
+		expectedEnv    string
+		expectedPrompt bool
+		expectedStderr string
+	}{
+		{
+			name:           "differing file is skipped with a warning",
+			configCopyEnvs: boolPtr(true),
+			expectedEnv:    "WORKTREE=1",
+			expectedStderr: "Skipped .env: it already exists in the worktree",
+		},
+		{
+			name:           "overwrite-envs replaces the differing file",
+			configCopyEnvs: boolPtr(true),
+			overwriteEnvs:  true,
+			expectedEnv:    "SOURCE=1",
+		},
+		{
+			name:           "interactive mode asks before overwriting (yes)",
+			answers:        []bool{true, true},
+			expectedEnv:    "SOURCE=1",
+			expectedPrompt: true,
+		},
+		{
+			name:           "interactive mode asks before overwriting (no)",
+			answers:        []bool{true, false},
+			expectedEnv:    "WORKTREE=1",
+			expectedPrompt: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			originalDir := t.TempDir()
+			worktreeDir := t.TempDir()
+			os.WriteFile(filepath.Join(originalDir, ".env"), []byte("SOURCE=1"), 0600)
+			os.WriteFile(filepath.Join(originalDir, ".env.local"), []byte("LOCAL=1"), 0600)
+			os.WriteFile(filepath.Join(worktreeDir, ".env"), []byte("WORKTREE=1"), 0600)
+
+			stdout := &bytes.Buffer{}
+			stderr := &bytes.Buffer{}
+			answers := tt.answers
+			mockUI := &mockUI{ConfirmPromptFn: func(string) (bool, error) {
+				answer := answers[0]
+				answers = answers[1:]
+				return answer, nil
+			}}
+			deps := &Dependencies{
+				Git: &mockGit{
+					isGitRepo: true,
+					envFiles:  []git.EnvFile{{Path: ".env"}, {Path: ".env.local"}},
+				},
+				UI:     mockUI,
+				Detect: &mockDetect{},
+				Config: &config.Config{CopyEnvs: tt.configCopyEnvs},
+				Stdout: stdout,
+				Stderr: stderr,
+			}
+
+			if err := handleEnvFiles(deps, false, tt.overwriteEnvs, originalDir, worktreeDir); err != nil {
+				t.Fatalf("handleEnvFiles failed: %v", err)
+			}
+
+			got, _ := os.ReadFile(filepath.Join(worktreeDir, ".env"))
+			if string(got) != tt.expectedEnv {
+				t.Errorf("Expected .env to contain %q, got %q", tt.expectedEnv, got)
+			}
+			if _, err := os.Stat(filepath.Join(worktreeDir, ".env.local")); err != nil {
+				t.Errorf("Expected non-conflicting .env.local to be copied: %v", err)
+			}
+			if tt.expectedPrompt && !strings.Contains(stdout.String(), ".env already exists in the worktree") {
+				t.Errorf("Expected overwrite prompt, got:\n%s", stdout.String())
+			}
+			if !tt.expectedPrompt && mockUI.confirmCalled {
+				t.Error("Expected no prompt")
+			}
+			if tt.expectedStderr != "" && !strings.Contains(stderr.String(), tt.expectedStderr) {
+				t.Errorf("Expected stderr to contain %q, got: %s", tt.expectedStderr, stderr.String())
+			}
+		})
+	}
+}
+
+func TestCopyAlwaysCopyFiles(t *testing.T) {
+	sourceRoot := t.TempDir()
+	worktreePath := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(sourceRoot, "config"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(sourceRoot, "config", "master.key"), []byte("secret"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	stdout := &bytes.Buffer{}
+	stderr := &bytes.Buffer{}
+	deps := &Dependencies{
+		Config: &config.Config{AlwaysCopy: []string{"config/master.key", "missing.txt", "../outside", "config"}},
+		Stdout: stdout,
+		Stderr: stderr,
+	}
+
+	copyAlwaysCopyFiles(deps, git.NewClient(), sourceRoot, worktreePath)
+
+	got, err := os.ReadFile(filepath.Join(worktreePath, "config", "master.key"))
+	if err != nil || string(got) != "secret" {
+		t.Errorf("Expected config/master.key to be copied, got %q (err: %v)", got, err)
+	}
+	if !strings.Contains(stdout.String(), "Copied 1 always_copy file") {
+		t.Errorf("Expected copy summary, got: %s", stdout.String())
+	}
+	for _, want := range []string{
+		"missing.txt not found",
+		"../outside is not a repository-relative path",
+		"config is a directory",
+	} {
+		if !strings.Contains(stderr.String(), want) {
+			t.Errorf("Expected warning %q, got: %s", want, stderr.String())
+		}
+	}
+}
+
+func TestCopyAlwaysCopyFiles_AllMissing(t *testing.T) {
+	stdout := &bytes.Buffer{}
+	stderr := &bytes.Buffer{}
+	copyCalled := false
+	mg := &mockGit{
+		CopyFilesFn: func([]git.EnvFile, string, string) (int, error) {
+			copyCalled = true
+			return 0, nil
+		},
+	}
+	deps := &Dependencies{
+		Config: &config.Config{AlwaysCopy: []string{"missing.txt"}},
+		Stdout: stdout,
+		Stderr: stderr,
+	}
+
+	copyAlwaysCopyFiles(deps, mg, t.TempDir(), t.TempDir())
+
+	if copyCalled {
+		t.Error("Expected CopyFiles not to be called when no listed file exists")
+	}
+	if !strings.Contains(stderr.String(), "missing.txt not found") {
+		t.Errorf("Expected missing file warning, got: %s", stderr.String())
+	}
+	if stdout.Len() != 0 {
+		t.Errorf("Expected no stdout output, got: %s", stdout.String())
+	}
+}
+
+func TestNewWindowArgs(t *testing.T) {
+	tests := []struct {
+		template    string
+		wantCommand string
+		wantArgs    []string
+	}{
+		{"wezterm cli spawn --cwd {path}", "wezterm", []string{"cli", "spawn", "--cwd", "/my repo-123"}},
+		{"gnome-terminal --working-directory={path}", "gnome-terminal", []string{"--working-directory=/my repo-123"}},
+		{"kitty @ launch --type=tab", "kitty", []string{"@", "launch", "--type=tab"}},
+		{"  ", "", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.template, func(t *testing.T) {
+			command, args := newWindowArgs(tt.template, "/my repo-123")
+			if command != tt.wantCommand || !reflect.DeepEqual(args, tt.wantArgs) {
+				t.Errorf("newWindowArgs(%q) = %q %q, want %q %q", tt.template, command, args, tt.wantCommand, tt.wantArgs)
+			}
+		})
+	}
+}
+
+func TestOpenInEditor(t *testing.T) {
+	t.Run("falls back to $EDITOR", func(t *testing.T) {
+		t.Setenv(envEditor, "nvim")
+		deps := &Dependencies{Config: config.New(), Stdout: &bytes.Buffer{}, Stderr: &bytes.Buffer{}}
+		executor := &detect.MockExecutor{}
+
+		openInEditor(deps, executor, "/repo-123")
+
+		if len(executor.ExecuteCalls) != 1 || executor.ExecuteCalls[0].Command != "nvim" {
+			t.Fatalf("Expected nvim to be launched, got %+v", executor.ExecuteCalls)
+		}
+	})
+
+	t.Run("no editor configured", func(t *testing.T) {
+		t.Setenv(envEditor, "")
+		stderr := &bytes.Buffer{}
+		deps := &Dependencies{Config: config.New(), Stdout: &bytes.Buffer{}, Stderr: stderr}
+		executor := &detect.MockExecutor{}
+
+		openInEditor(deps, executor, "/repo-123")
+
+		if len(executor.ExecuteCalls) != 0 {
+			t.Errorf("Expected no launch, got %+v", executor.ExecuteCalls)
+		}
+		if !strings.Contains(stderr.String(), "set the editor key") {
+			t.Errorf("Expected a warning about the missing editor, got %q", stderr.String())
+		}
+	})
+}
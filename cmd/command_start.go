@@ -2,11 +2,18 @@ package cmd
 
 import (
 	"fmt"
+	"io"
 	"os"
+	"os/signal"
 	"path/filepath"
+	"strings"
+	"syscall"
 
+	"github.com/sotarok/gw/internal/config"
+	"github.com/sotarok/gw/internal/detect"
 	"github.com/sotarok/gw/internal/git"
 	"github.com/sotarok/gw/internal/hook"
+	"github.com/sotarok/gw/internal/i18n"
 	"github.com/sotarok/gw/internal/iterm2"
 	"github.com/sotarok/gw/internal/spinner"
 )
@@ -14,16 +21,35 @@ import (
 // startGit is the subset of git operations StartCommand actually uses.
 type startGit interface {
 	git.RepositoryReader // IsGitRepository, GetOriginalRepositoryName, GetRepositoryRoot, FetchAll
-	git.WorktreeManager  // GetWorktreeForIssue, CreateWorktree
-	git.EnvFileHandler   // FindUntrackedEnvFiles, CopyEnvFiles (via handleEnvFiles)
+	git.WorktreeManager  // GetWorktreeForIssue, CreateWorktree, CreateOrphanWorktree, ApplyStash, ApplyPatch, RemoveWorktreeByPath
+	git.BranchManager    // UpstreamStatus, FastForwardBranch, RemoteDefaultBranch, DeleteBranch
+	git.EnvFileHandler   // FindUntrackedEnvFiles, CopyEnvFiles (via handleEnvFiles), FindUntrackedFiles, CopyFiles
 }
 
 // StartCommand handles the start command logic
 type StartCommand struct {
-	deps           *Dependencies
-	copyEnvs       bool
-	noFetch        bool
-	noProjectHooks bool
+	deps            *Dependencies
+	copyEnvs        bool
+	noFetch         bool
+	noProjectHooks  bool
+	applyStash      bool   // --stash: apply the latest stash entry in the new worktree
+	patchFile       string // --patch: apply this patch file in the new worktree
+	copyFrom        string // --copy-from: copy untracked/ignored files from this worktree
+	copyFromCurrent bool   // --copy-from-current: copy them from the current worktree
+	overwriteEnvs   bool   // --overwrite-envs: replace env files that differ in the worktree
+	openEditor      bool   // --open: launch the editor in the new worktree
+	trackBranch     string // --track: start the branch at this remote branch and track it
+	baseFromDefault bool   // --base-from-default: start the branch at the remote's default branch
+	printPath       bool   // --print-path: print only the worktree path on stdout and never prompt
+	orphan          bool   // --orphan: create the branch as named, with no history
+	preview         bool   // --preview: print the branch and worktree path, then stop
+	lock            bool   // --lock: create the worktree locked
+	lockReason      string // --lock-reason: why it is locked; implies --lock
+	noCD            bool   // --no-cd: leave the shell where it is despite auto_cd
+	editor          detect.CommandExecutor
+	terminal        detect.CommandExecutor // runs new_window_cmd
+	signals         chan os.Signal         // SIGINT and SIGTERM received while watching
+	wasInterrupted  bool
 }
 
 // NewStartCommand creates a new start command handler
@@ -33,29 +59,118 @@ func NewStartCommand(deps *Dependencies, copyEnvs, noFetch, noProjectHooks bool)
 		copyEnvs:       copyEnvs,
 		noFetch:        noFetch,
 		noProjectHooks: noProjectHooks,
+		editor:         newEditorExecutor(deps),
+		terminal:       newTerminalExecutor(deps),
 	}
 }
 
 // git returns the command's git dependency narrowed to the operations it uses.
 func (c *StartCommand) git() startGit { return c.deps.Git }
 
+// applyStartTemplate resolves the --template named name and returns the
+// target and base branch gw start should use. The template's prefix is
+// prepended to target unless it is already there, and its base replaces
+// baseBranch unless the base branch was given explicitly on the command line.
+// An empty name leaves both unchanged.
+func applyStartTemplate(cfg *config.Config, name, target, baseBranch string, baseGiven bool) (string, string, error) {
+	tmpl, err := cfg.ResolveTemplate(name)
+	if err != nil {
+		return "", "", err
+	}
+
+	if tmpl.Prefix != "" && !strings.HasPrefix(target, tmpl.Prefix) {
+		target = tmpl.Prefix + target
+	}
+	if tmpl.Base != "" && !baseGiven {
+		baseBranch = tmpl.Base
+	}
+	return target, baseBranch, nil
+}
+
 // Execute runs the start command
 func (c *StartCommand) Execute(issueNumber, baseBranch string) error {
+	var pathOut io.Writer
+	if c.printPath {
+		c.deps, pathOut = printPathDeps(c.deps)
+	}
+	if err := git.ValidateRef(issueNumber); err != nil {
+		return err
+	}
+	if c.trackBranch != "" {
+		if err := git.ValidateRef(c.trackBranch); err != nil {
+			return fmt.Errorf("invalid --track branch: %w", err)
+		}
+	}
+	if c.preview || dryRun {
+		if err := c.printPreview(issueNumber, baseBranch); err != nil {
+			return err
+		}
+		if c.preview {
+			return nil
+		}
+	}
 	if err := ResolveProjectConfig(c.deps, c.noProjectHooks); err != nil {
 		return err
 	}
 
+	// Catch a mistyped --patch path before a worktree gets created for nothing.
+	if c.patchFile != "" {
+		if _, err := os.Stat(c.patchFile); err != nil {
+			return fmt.Errorf("cannot read patch file: %w", err)
+		}
+	}
+	if c.copyFrom != "" {
+		info, err := os.Stat(c.copyFrom)
+		if err != nil {
+			return fmt.Errorf("cannot read --copy-from directory: %w", err)
+		}
+		if !info.IsDir() {
+			return fmt.Errorf("--copy-from %s is not a directory", c.copyFrom)
+		}
+	}
+
 	repoName, envSourceRoot, err := c.resolveTarget(issueNumber)
 	if err != nil {
 		return err
 	}
+	if c.copyFromCurrent {
+		// The root of the worktree gw runs in, even from a subdirectory.
+		c.copyFrom = envSourceRoot
+	}
 
+	switch {
+	case c.baseFromDefault:
+		if baseBranch, err = c.git().RemoteDefaultBranch(); err != nil {
+			return err
+		}
+	case c.trackBranch == "" && !c.orphan:
+		c.checkBaseUpToDate(baseBranch)
+	}
+
+	stopWatching := c.watchInterrupt()
+	defer stopWatching()
 	worktreePath, err := c.createWorktree(issueNumber, baseBranch)
 	if err != nil {
+		if c.interrupted() {
+			// git removes a worktree it was interrupted while adding.
+			return fmt.Errorf("%w: %w", errInterrupted, err)
+		}
 		return err
 	}
 
-	c.postCreate(issueNumber, worktreePath, repoName, envSourceRoot)
+	if dryRun {
+		c.printDryRunSetup(worktreePath)
+		return nil
+	}
+
+	if err := c.postCreate(issueNumber, worktreePath, repoName, envSourceRoot); err != nil {
+		branchName, _ := c.worktreeNames(issueNumber)
+		c.rollback(worktreePath, branchName, envSourceRoot)
+		return err
+	}
+	if pathOut != nil {
+		printWorktreePath(pathOut, worktreePath)
+	}
 	return nil
 }
 
@@ -67,15 +182,20 @@ func (c *StartCommand) resolveTarget(issueNumber string) (repoName, envSourceRoo
 
 	// Check if we're in a git repository
 	if !g.IsGitRepository() {
-		return "", "", fmt.Errorf("not in a git repository")
+		return "", "", git.ErrNotGitRepository
 	}
 
-	// Fetch from remotes if configured
-	fetchIfConfigured(c.deps, c.noFetch)
+	// Fetch from remotes if configured. Branching from the remote's default
+	// branch is only worth it with fresh refs, so that fetches regardless.
+	if c.baseFromDefault && !c.noFetch {
+		fetchRemotes(c.deps)
+	} else {
+		fetchIfConfigured(c.deps, c.noFetch)
+	}
 
 	// Check if worktree already exists
 	if wt, _ := g.GetWorktreeForIssue(issueNumber); wt != nil {
-		return "", "", fmt.Errorf("worktree for issue %s already exists at %s", issueNumber, wt.Path)
+		return "", "", &git.WorktreeExistsError{Issue: issueNumber, Path: wt.Path}
 	}
 
 	// Get the original repository name for the iTerm2 tab so that, when run from
@@ -98,78 +218,395 @@ func (c *StartCommand) resolveTarget(issueNumber string) (repoName, envSourceRoo
 	return repoName, envSourceRoot, nil
 }
 
-// createWorktree creates the worktree for the issue and reports the resulting path.
+// checkBaseUpToDate warns when the local base branch is behind its upstream, so
+// the new worktree would start from stale code. When prompting is possible and
+// the branch has no local commits of its own, it offers to fast-forward it
+// first. Nothing here stops the worktree from being created.
+func (c *StartCommand) checkBaseUpToDate(baseBranch string) {
+	status, err := c.git().UpstreamStatus(baseBranch)
+	if err != nil || status.Behind == 0 {
+		return
+	}
+
+	fmt.Fprintf(c.deps.Stderr, "%s base branch %s is %d %s behind %s; consider pulling first\n",
+		coloredWarning(), baseBranch, status.Behind, plural(status.Behind, "commit", "commits"), status.Upstream)
+	if status.Ahead > 0 || !isTerminalStdin() || c.deps.NoPrompt {
+		return
+	}
+
+	fmt.Fprintf(c.deps.Stdout, "Fast-forward %s to %s before creating the worktree?", baseBranch, status.Upstream)
+	confirmed, err := c.deps.UI.ConfirmPrompt(" (y/N): ")
+	if err != nil || !confirmed {
+		return
+	}
+	if err := c.git().FastForwardBranch(baseBranch); err != nil {
+		fmt.Fprintf(c.deps.Stderr, "%s Could not fast-forward %s: %v\n", coloredWarning(), baseBranch, err)
+		return
+	}
+	fmt.Fprintf(c.deps.Stdout, "%s Fast-forwarded %s to %s\n", coloredSuccess(), baseBranch, status.Upstream)
+}
+
+// createWorktree creates the worktree for the issue and reports the resulting
+// path. With --track the branch starts at the tracked remote branch instead of
+// baseBranch, and with --orphan it has no history at all. With --lock the
+// worktree is locked from the start.
 func (c *StartCommand) createWorktree(issueNumber, baseBranch string) (string, error) {
-	sp := spinner.New(fmt.Sprintf("Creating worktree for issue #%s based on %s...", issueNumber, baseBranch), c.deps.Stdout)
-	sp.Start()
-	worktreePath, err := c.git().CreateWorktree(issueNumber, baseBranch)
-	sp.Stop()
+	var (
+		worktreePath string
+		err          error
+		opts         []git.AddOption
+	)
+	if c.lock || c.lockReason != "" {
+		opts = append(opts, git.WithLock(c.lockReason))
+	}
+	switch {
+	case c.orphan:
+		sp := spinner.New(fmt.Sprintf("Creating worktree for orphan branch %s...", issueNumber), c.deps.Stdout)
+		sp.Start()
+		worktreePath, err = c.git().CreateOrphanWorktree(issueNumber, opts...)
+		sp.Stop()
+	case c.trackBranch != "":
+		sp := spinner.New(fmt.Sprintf("Creating worktree for issue #%s tracking %s...", issueNumber, c.trackBranch), c.deps.Stdout)
+		sp.Start()
+		worktreePath, err = c.git().CreateTrackingWorktree(issueNumber, c.trackBranch, opts...)
+		sp.Stop()
+	default:
+		sp := spinner.New(fmt.Sprintf("Creating worktree for issue #%s based on %s...", issueNumber, baseBranch), c.deps.Stdout)
+		sp.Start()
+		worktreePath, err = c.git().CreateWorktree(issueNumber, baseBranch, opts...)
+		sp.Stop()
+	}
 	if err != nil {
 		return "", err
 	}
 
 	if c.deps.Stdout != nil {
-		fmt.Fprintf(c.deps.Stdout, "%s Created worktree at %s\n", coloredSuccess(), worktreePath)
+		fmt.Fprintf(c.deps.Stdout, "%s %s\n", coloredSuccess(), i18n.T(i18n.MsgStartCreated, worktreePath))
+		if len(opts) > 0 {
+			fmt.Fprintf(c.deps.Stdout, "%s Locked the worktree%s; unlock it with git worktree unlock\n", coloredSuccess(), lockReasonSuffix(c.lockReason))
+		}
 	}
 	return worktreePath, nil
 }
 
-// postCreate performs the post-creation steps: optional auto-cd, env file copy,
-// package manager setup, the post-start hook, and the completion message.
-func (c *StartCommand) postCreate(issueNumber, worktreePath, repoName, envSourceRoot string) {
-	// Change to the new worktree directory for setup operations
-	// Note: This only affects the current process, not the parent shell
-	if c.deps.Config.AutoCD {
-		if err := os.Chdir(worktreePath); err != nil {
-			// Don't fail the command, just log the error
+// applyLocalChanges carries local changes into the new worktree: the latest
+// stash entry with --stash, a patch file with --patch. It runs before auto-cd so
+// a relative patch path still resolves against the directory gw was run from.
+// Failures are warnings: the worktree itself was created successfully.
+func (c *StartCommand) applyLocalChanges(worktreePath string) {
+	if c.applyStash {
+		if err := c.git().ApplyStash(worktreePath); err != nil {
 			if c.deps.Stderr != nil {
-				fmt.Fprintf(c.deps.Stderr, "%s Could not change to worktree directory: %v\n", coloredWarning(), err)
+				fmt.Fprintf(c.deps.Stderr, "%s Could not apply stash: %v\n", coloredWarning(), err)
 			}
+		} else if c.deps.Stdout != nil {
+			fmt.Fprintf(c.deps.Stdout, "%s Applied stash to the new worktree (the stash entry was kept)\n", coloredSuccess())
+		}
+	}
+
+	if c.patchFile != "" {
+		if err := c.git().ApplyPatch(worktreePath, c.patchFile); err != nil {
+			if c.deps.Stderr != nil {
+				fmt.Fprintf(c.deps.Stderr, "%s Could not apply patch: %v\n", coloredWarning(), err)
+			}
+		} else if c.deps.Stdout != nil {
+			fmt.Fprintf(c.deps.Stdout, "%s Applied patch %s to the new worktree\n", coloredSuccess(), c.patchFile)
+		}
+	}
+}
+
+// copyFromWorktree seeds the new worktree with the untracked and ignored files
+// of the --copy-from worktree (editor settings, local certificates, ...).
+// Files that already exist in the new worktree are kept. Like
+// applyLocalChanges it runs before auto-cd, and failures are warnings.
+func (c *StartCommand) copyFromWorktree(worktreePath string) {
+	if c.copyFrom == "" {
+		return
+	}
+
+	warn := func(err error) {
+		if c.deps.Stderr != nil {
+			fmt.Fprintf(c.deps.Stderr, "%s Could not copy files from %s: %v\n", coloredWarning(), c.copyFrom, err)
 		}
 	}
 
-	// Handle environment files
+	files, err := c.git().FindUntrackedFiles(c.copyFrom)
+	if err != nil {
+		warn(err)
+		return
+	}
+	copied, err := c.git().CopyFiles(files, c.copyFrom, worktreePath)
+	if err != nil {
+		warn(err)
+		return
+	}
+	if c.deps.Stdout != nil {
+		fmt.Fprintf(c.deps.Stdout, "%s Copied %d %s from %s\n", coloredSuccess(), copied, plural(copied, "file", "files"), c.copyFrom)
+	}
+}
+
+// printReady prints the message that the worktree is ready: the
+// start_success_template when one is configured, otherwise the default one.
+func (c *StartCommand) printReady(issueNumber, worktreePath string) {
+	template := c.deps.Config.StartSuccessTemplate
+	if template == "" {
+		fmt.Fprintf(c.deps.Stdout, "\n%s %s\n", activeTheme.ready, i18n.T(i18n.MsgWorktreeReady, worktreePath))
+		return
+	}
+	absWorktreePath, err := filepath.Abs(worktreePath)
+	if err != nil {
+		absWorktreePath = worktreePath
+	}
+	branchName, _ := c.worktreeNames(issueNumber)
+	fmt.Fprintf(c.deps.Stdout, "\n%s\n", renderStartSuccess(template, absWorktreePath, branchName, issueNumber))
+}
+
+// renderStartSuccess fills the {path}, {branch} and {issue} placeholders of a
+// start_success_template. Anything else in braces is left as written.
+func renderStartSuccess(template, worktreePath, branchName, issueNumber string) string {
+	return strings.NewReplacer(
+		newWindowPathPlaceholder, worktreePath,
+		"{branch}", branchName,
+		"{issue}", issueNumber,
+	).Replace(template)
+}
+
+// postCreate sets up the new worktree: local changes, copied files, auto-cd,
+// env files, package manager setup and the post-start hook, in that order.
+// An interrupt is checked between the steps; once one has arrived the rest
+// are skipped and errInterrupted is returned for Execute to roll back.
+// Otherwise the worktree is handed to the shell and reported ready.
+func (c *StartCommand) postCreate(issueNumber, worktreePath, repoName, envSourceRoot string) error {
+	steps := []func(){
+		func() { c.applyLocalChanges(worktreePath) },
+		func() { c.copyFromWorktree(worktreePath) },
+		func() { copyAlwaysCopyFiles(c.deps, c.git(), envSourceRoot, worktreePath) },
+		func() { c.enterWorktree(worktreePath) },
+		func() { c.copyEnvFiles(envSourceRoot, worktreePath) },
+		func() { c.runSetup(worktreePath) },
+		func() { c.runPostStartHook(issueNumber, worktreePath, repoName) },
+	}
+	for _, step := range steps {
+		if c.interrupted() {
+			return errInterrupted
+		}
+		step()
+	}
+	if c.interrupted() {
+		return errInterrupted
+	}
+	c.handOver(issueNumber, worktreePath, envSourceRoot)
+	return nil
+}
+
+// enterWorktree changes to the new worktree for the setup steps when auto_cd
+// is enabled. This only affects the current process, not the parent shell.
+func (c *StartCommand) enterWorktree(worktreePath string) {
+	if !c.deps.Config.AutoCD {
+		return
+	}
+	if err := os.Chdir(worktreePath); err != nil {
+		// Don't fail the command, just log the error
+		if c.deps.Stderr != nil {
+			fmt.Fprintf(c.deps.Stderr, "%s Could not change to worktree directory: %v\n", coloredWarning(), err)
+		}
+	}
+}
+
+// copyEnvFiles copies the untracked env files of envSourceRoot into the new
+// worktree, as --copy-envs and copy_envs decide.
+func (c *StartCommand) copyEnvFiles(envSourceRoot, worktreePath string) {
 	if err := c.handleEnvFiles(envSourceRoot, worktreePath); err != nil {
 		// Don't fail the command, just warn
 		if c.deps.Stderr != nil {
 			fmt.Fprintf(c.deps.Stderr, "%s Failed to handle env files: %v\n", coloredWarning(), err)
 		}
 	}
+}
 
-	// Run setup if a package manager is detected
+// runSetup runs package manager setup if a package manager is detected.
+func (c *StartCommand) runSetup(worktreePath string) {
 	if err := c.deps.Detect.RunSetup(worktreePath); err != nil {
 		// Don't fail if setup fails, just warn
 		if c.deps.Stderr != nil {
 			fmt.Fprintf(c.deps.Stderr, "%s Setup failed: %v\n", coloredWarning(), err)
 		}
 	}
+}
+
+// runPostStartHook executes post_start_hook if configured.
+func (c *StartCommand) runPostStartHook(issueNumber, worktreePath, repoName string) {
+	if c.deps.Config.PostStartHook == "" {
+		return
+	}
+	branchName, _ := c.worktreeNames(issueNumber)
+	absWorktreePath, _ := filepath.Abs(worktreePath)
+	hookEnv := hook.Env{
+		WorktreePath: absWorktreePath,
+		BranchName:   branchName,
+		RepoName:     repoName,
+		Command:      "start",
+	}
+	if err := hook.Execute(c.deps.Config.PostStartHook, hookEnv, c.deps.Stdout, c.deps.Stderr); err != nil {
+		fmt.Fprintf(c.deps.Stderr, "%s %s\n", coloredWarning(), i18n.T(i18n.MsgStartHookFailed, err))
+	}
+}
 
-	// Execute post-start hook if configured
-	if c.deps.Config.PostStartHook != "" {
-		// Derive the branch name via the same helper CreateWorktree uses, so an
-		// argument that already carries a "/impl" suffix (or any "/") is not
-		// doubled (e.g. "foo/impl" must stay "foo/impl", not "foo/impl/impl").
-		branchName, _ := git.DetermineWorktreeNames(issueNumber)
-		absWorktreePath, _ := filepath.Abs(worktreePath)
-		hookEnv := hook.Env{
-			WorktreePath: absWorktreePath,
-			BranchName:   branchName,
-			RepoName:     repoName,
-			Command:      "start",
+// handOver points the shell (or a new window) and the editor at the set-up
+// worktree and prints the completion message.
+func (c *StartCommand) handOver(issueNumber, worktreePath, envSourceRoot string) {
+	// An empty $GW_CD_FILE tells the shell integration to stay put, which is
+	// also what --no-cd asks for.
+	newWindow := opensNewWindow(c.deps)
+	if !newWindow && !c.noCD {
+		writeCDFile(c.deps, envSourceRoot, worktreePath)
+	}
+
+	if c.deps.Stdout != nil {
+		c.printReady(issueNumber, worktreePath)
+		if c.deps.Config.AutoCD && !newWindow && !c.noCD {
+			fmt.Fprintf(c.deps.Stdout, "\n%s %s\n", activeTheme.hint, i18n.T(i18n.MsgShellIntegCD))
 		}
-		if err := hook.Execute(c.deps.Config.PostStartHook, hookEnv, c.deps.Stdout, c.deps.Stderr); err != nil {
-			fmt.Fprintf(c.deps.Stderr, "%s Post-start hook failed: %v\n", coloredWarning(), err)
+	}
+
+	if newWindow {
+		openNewWindow(c.deps, c.terminal, worktreePath)
+	}
+
+	if c.openEditor {
+		openInEditor(c.deps, c.editor, worktreePath)
+	}
+}
+
+// printDryRunSetup prints, for --dry-run, what Execute would do in the new
+// worktree after creating it, from applying the stash to changing the shell
+// to it. Nothing of it runs.
+func (c *StartCommand) printDryRunSetup(worktreePath string) {
+	var steps []string
+	if c.applyStash {
+		steps = append(steps, "apply the latest stash")
+	}
+	if c.patchFile != "" {
+		steps = append(steps, "apply the patch "+c.patchFile)
+	}
+	if c.copyFrom != "" {
+		steps = append(steps, "copy untracked and ignored files from "+c.copyFrom)
+	}
+	steps = append(steps, dryRunSetupSteps(c.deps, c.copyEnvs, "post_start_hook", c.deps.Config.PostStartHook, !c.noCD, c.openEditor)...)
+	printDryRunSetup(c.deps, worktreePath, steps)
+}
+
+// worktreeNames returns the branch gw start creates for issueNumber and the
+// suffix of its worktree directory. They are derived via the same helpers
+// CreateWorktree and CreateOrphanWorktree use, so an argument that already
+// carries a "/impl" suffix (or any "/") is not doubled (e.g. "foo/impl" must
+// stay "foo/impl", not "foo/impl/impl"). An orphan branch is created exactly
+// as named.
+func (c *StartCommand) worktreeNames(issueNumber string) (branchName, dirSuffix string) {
+	if c.orphan {
+		return issueNumber, git.SanitizeBranchNameForDirectory(issueNumber)
+	}
+	return git.DetermineWorktreeNames(issueNumber)
+}
+
+// printPreview prints the branch, worktree directory and base gw start would
+// use for issueNumber, for --preview and --dry-run. It only reads the
+// repository: nothing is fetched or created.
+func (c *StartCommand) printPreview(issueNumber, baseBranch string) error {
+	g := c.git()
+	if !g.IsGitRepository() {
+		return git.ErrNotGitRepository
+	}
+	repoName, err := g.GetOriginalRepositoryName()
+	if err != nil {
+		return err
+	}
+	repoRoot, err := g.GetRepositoryRoot()
+	if err != nil {
+		return fmt.Errorf("failed to get repository root: %w", err)
+	}
+
+	branchName, dirSuffix := c.worktreeNames(issueNumber)
+	worktreePath, err := filepath.Abs(git.ResolveWorktreePath(g.WorktreeRoot(), repoRoot, repoName, dirSuffix))
+	if err != nil {
+		return fmt.Errorf("failed to get absolute path: %w", err)
+	}
+
+	var base string
+	switch {
+	case c.orphan:
+		base = "none (orphan branch)"
+	case c.trackBranch != "":
+		base = c.trackBranch + " (tracked as upstream)"
+	case c.baseFromDefault:
+		if base, err = g.RemoteDefaultBranch(); err != nil {
+			return err
 		}
+	default:
+		base, _ = g.ResolveBaseBranch(baseBranch)
 	}
 
-	if c.deps.Stdout != nil {
-		fmt.Fprintf(c.deps.Stdout, "\n✨ Worktree ready at:\n   %s\n", worktreePath)
-		if c.deps.Config.AutoCD {
-			fmt.Fprintf(c.deps.Stdout, "\n💡 Shell integration will change to this directory after the command completes.\n")
+	fmt.Fprintf(c.deps.Stdout, "%s gw start %s would create:\n", coloredArrow(), issueNumber)
+	fmt.Fprintf(c.deps.Stdout, "  Branch:   %s\n", branchName)
+	fmt.Fprintf(c.deps.Stdout, "  Worktree: %s\n", worktreePath)
+	fmt.Fprintf(c.deps.Stdout, "  Base:     %s\n", base)
+	if wt, _ := g.GetWorktreeForIssue(issueNumber); wt != nil {
+		fmt.Fprintf(c.deps.Stdout, "%s A worktree for %s already exists at %s\n", coloredWarning(), issueNumber, wt.Path)
+	}
+	return nil
+}
+
+// watchInterrupt starts recording SIGINT and SIGTERM, so that an interrupt
+// while the worktree is created and set up leads to a rollback instead of a
+// half-configured worktree. The signal only ends the git or setup process it
+// interrupts; Execute checks interrupted between the steps and rolls back on
+// its own. The returned function stops watching.
+func (c *StartCommand) watchInterrupt() (stop func()) {
+	c.signals = make(chan os.Signal, 1)
+	signal.Notify(c.signals, os.Interrupt, syscall.SIGTERM)
+	return func() { signal.Stop(c.signals) }
+}
+
+// interrupted reports whether a SIGINT or SIGTERM has arrived since
+// watchInterrupt.
+func (c *StartCommand) interrupted() bool {
+	if !c.wasInterrupted {
+		select {
+		case <-c.signals:
+			c.wasInterrupted = true
+		default:
+		}
+	}
+	return c.wasInterrupted
+}
+
+// rollback removes a worktree whose setup was interrupted, together with the
+// branch gw start just created for it, after moving back to returnDir in case
+// auto-cd entered the worktree. git refuses to remove a worktree with
+// modified or untracked files (an applied stash, a half-finished install);
+// such a worktree is left in place with a message on how to finish or remove
+// it, rather than risk losing anything.
+func (c *StartCommand) rollback(worktreePath, branch, returnDir string) {
+	fmt.Fprintf(c.deps.Stderr, "\n%s Interrupted; rolling back %s\n", coloredWarning(), worktreePath)
+	if returnDir != "" {
+		_ = os.Chdir(returnDir)
+	}
+
+	if err := c.git().RemoveWorktreeByPath(worktreePath); err != nil {
+		fmt.Fprintf(c.deps.Stderr, "%s Could not remove the partially set up worktree %s: %v\n", coloredError(), worktreePath, err)
+		fmt.Fprintf(c.deps.Stderr, "  Finish its setup with gw setup %s, or remove it with gw end %s\n", branch, branch)
+		return
+	}
+	// An orphan branch has no commits yet, so there is no branch to delete.
+	// Any other branch was created moments ago and holds no work of its own.
+	if !c.orphan {
+		if err := c.git().DeleteBranch(branch, true); err != nil {
+			fmt.Fprintf(c.deps.Stderr, "%s Could not delete branch %s: %v\n", coloredWarning(), branch, err)
 		}
 	}
+	fmt.Fprintf(c.deps.Stderr, "%s Removed the partially set up worktree %s\n", coloredSuccess(), worktreePath)
 }
 
 func (c *StartCommand) handleEnvFiles(originalDir, worktreePath string) error {
-	return handleEnvFiles(c.deps, c.copyEnvs, originalDir, worktreePath)
+	return handleEnvFiles(c.deps, c.copyEnvs, c.overwriteEnvs, originalDir, worktreePath)
 }
@@ -4,11 +4,16 @@ import (
 	"bytes"
 	"fmt"
 	"os"
+	"os/exec"
 	"path/filepath"
+	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/sotarok/gw/internal/config"
+	"github.com/sotarok/gw/internal/detect"
 	"github.com/sotarok/gw/internal/git"
+	"github.com/sotarok/gw/internal/i18n"
 )
 
 func TestStartCommand_Execute(t *testing.T) {
@@ -474,6 +479,318 @@ func TestStartCommand_Execute_AutoCDEnabled(t *testing.T) {
 	}
 }
 
+func TestStartCommand_Execute_WritesCDFile(t *testing.T) {
+	originalDir, _ := os.Getwd()
+	defer os.Chdir(originalDir)
+	os.Chdir(t.TempDir())
+
+	worktreeDir := t.TempDir()
+	cdFile := filepath.Join(t.TempDir(), "cd")
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv(envCDFile, cdFile)
+
+	deps := &Dependencies{
+		Git:    &mockGit{isGitRepo: true, worktreePath: worktreeDir},
+		UI:     &mockUI{},
+		Detect: &mockDetect{},
+		Config: config.New(),
+		Stdout: &bytes.Buffer{},
+		Stderr: &bytes.Buffer{},
+	}
+
+	if err := NewStartCommand(deps, false, true, false).Execute("123", "main"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	got, err := os.ReadFile(cdFile)
+	if err != nil {
+		t.Fatalf("Expected %s to be written: %v", envCDFile, err)
+	}
+	want, _ := filepath.Abs(worktreeDir)
+	if string(got) != want {
+		t.Errorf("%s content = %q, want only the worktree path %q", envCDFile, got, want)
+	}
+}
+
+func TestStartCommand_Execute_PrintPath(t *testing.T) {
+	originalDir, _ := os.Getwd()
+	defer os.Chdir(originalDir)
+	os.Chdir(t.TempDir())
+
+	worktreeDir := t.TempDir()
+	ui := &mockUI{confirmResult: true}
+	stdout := &bytes.Buffer{}
+	stderr := &bytes.Buffer{}
+	deps := &Dependencies{
+		Git: &mockGit{
+			isGitRepo:    true,
+			worktreePath: worktreeDir,
+			envFiles:     []git.EnvFile{{Path: ".env"}},
+		},
+		UI:     ui,
+		Detect: &mockDetect{},
+		Config: config.New(), // copy_envs unset: would normally prompt
+		Stdout: stdout,
+		Stderr: stderr,
+	}
+	cmd := NewStartCommand(deps, false, true, false)
+	cmd.printPath = true
+
+	if err := cmd.Execute("123", "main"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	want, _ := filepath.Abs(worktreeDir)
+	if stdout.String() != want+"\n" {
+		t.Errorf("stdout = %q, want only the worktree path %q", stdout.String(), want+"\n")
+	}
+	if ui.confirmCalled {
+		t.Error("Expected no prompt with --print-path")
+	}
+	for _, msg := range []string{"Worktree ready", "Not copying 1 untracked environment file(s) without asking"} {
+		if !strings.Contains(stderr.String(), msg) {
+			t.Errorf("Expected %q on stderr, got:\n%s", msg, stderr.String())
+		}
+	}
+}
+
+func TestStartCommand_Execute_Open(t *testing.T) {
+	for _, open := range []bool{true, false} {
+		t.Run(fmt.Sprintf("open=%v", open), func(t *testing.T) {
+			originalDir, _ := os.Getwd()
+			defer os.Chdir(originalDir)
+			os.Chdir(t.TempDir())
+
+			worktreeDir := t.TempDir()
+			t.Setenv(envEditor, "vim")
+			cfg := config.New()
+			cfg.Editor = "code --new-window"
+			deps := &Dependencies{
+				Git:    &mockGit{isGitRepo: true, worktreePath: worktreeDir},
+				UI:     &mockUI{},
+				Detect: &mockDetect{},
+				Config: cfg,
+				Stdout: &bytes.Buffer{},
+				Stderr: &bytes.Buffer{},
+			}
+			executor := &detect.MockExecutor{}
+			cmd := NewStartCommand(deps, false, true, false)
+			cmd.editor = executor
+			cmd.openEditor = open
+
+			if err := cmd.Execute("123", "main"); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if !open {
+				if len(executor.ExecuteCalls) != 0 {
+					t.Errorf("Expected no editor launch without --open, got %+v", executor.ExecuteCalls)
+				}
+				return
+			}
+			if len(executor.ExecuteCalls) != 1 {
+				t.Fatalf("Expected 1 editor launch, got %d", len(executor.ExecuteCalls))
+			}
+			want, _ := filepath.Abs(worktreeDir)
+			call := executor.ExecuteCalls[0]
+			if call.Dir != want || call.Command != "code" || !reflect.DeepEqual(call.Args, []string{"--new-window", want}) {
+				t.Errorf("Expected code --new-window %s in %s, got %+v", want, want, call)
+			}
+		})
+	}
+}
+
+func TestStartCommand_Execute_NewWindowCmd(t *testing.T) {
+	originalDir, _ := os.Getwd()
+	defer os.Chdir(originalDir)
+	os.Chdir(t.TempDir())
+
+	cdFile := filepath.Join(t.TempDir(), "cd")
+	t.Setenv(envCDFile, cdFile)
+	worktreeDir := filepath.Join(t.TempDir(), "my repo-123")
+	if err := os.MkdirAll(worktreeDir, 0755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	cfg := config.New()
+	cfg.NewWindowCmd = "wezterm cli spawn --cwd {path}"
+	stdout := &bytes.Buffer{}
+	deps := &Dependencies{
+		Git:    &mockGit{isGitRepo: true, worktreePath: worktreeDir},
+		UI:     &mockUI{},
+		Detect: &mockDetect{},
+		Config: cfg,
+		Stdout: stdout,
+		Stderr: &bytes.Buffer{},
+	}
+	executor := &detect.MockExecutor{}
+	cmd := NewStartCommand(deps, false, true, false)
+	cmd.terminal = executor
+
+	if err := cmd.Execute("123", "main"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(executor.ExecuteCalls) != 1 {
+		t.Fatalf("Expected 1 new window command, got %d", len(executor.ExecuteCalls))
+	}
+	want, _ := filepath.Abs(worktreeDir)
+	call := executor.ExecuteCalls[0]
+	if call.Dir != want || call.Command != "wezterm" || !reflect.DeepEqual(call.Args, []string{"cli", "spawn", "--cwd", want}) {
+		t.Errorf("Expected wezterm cli spawn --cwd %s in %s, got %+v", want, want, call)
+	}
+	if content, err := os.ReadFile(cdFile); err == nil && len(content) > 0 {
+		t.Errorf("Expected no directory change with new_window_cmd, got %s = %q", envCDFile, content)
+	}
+	if strings.Contains(stdout.String(), i18n.T(i18n.MsgShellIntegCD)) {
+		t.Errorf("Expected no shell integration hint with new_window_cmd, got:\n%s", stdout.String())
+	}
+}
+
+func TestStartCommand_Execute_Track(t *testing.T) {
+	originalDir, _ := os.Getwd()
+	defer os.Chdir(originalDir)
+	os.Chdir(t.TempDir())
+
+	var gotIssue, gotRemote string
+	mg := &mockGit{
+		isGitRepo:    true,
+		worktreePath: t.TempDir(),
+		CreateTrackingWorktreeFn: func(issueNumber, remoteBranch string) (string, error) {
+			gotIssue, gotRemote = issueNumber, remoteBranch
+			return t.TempDir(), nil
+		},
+	}
+	mg.createWorktreeError = fmt.Errorf("CreateWorktree must not be called with --track")
+	stdout := &bytes.Buffer{}
+	deps := &Dependencies{
+		Git:    mg,
+		UI:     &mockUI{},
+		Detect: &mockDetect{},
+		Config: config.New(),
+		Stdout: stdout,
+		Stderr: &bytes.Buffer{},
+	}
+	cmd := NewStartCommand(deps, false, true, false)
+	cmd.trackBranch = "origin/foo"
+
+	if err := cmd.Execute("feature/foo", "main"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if gotIssue != "feature/foo" || gotRemote != "origin/foo" {
+		t.Errorf("Expected tracking worktree for feature/foo at origin/foo, got %q at %q", gotIssue, gotRemote)
+	}
+	if !strings.Contains(stdout.String(), "tracking origin/foo") {
+		t.Errorf("Expected progress message to mention the tracked branch, got:\n%s", stdout.String())
+	}
+}
+
+func TestStartCommand_Execute_Orphan(t *testing.T) {
+	originalDir, _ := os.Getwd()
+	defer os.Chdir(originalDir)
+	os.Chdir(t.TempDir())
+
+	var gotBranch string
+	mg := &mockGit{
+		isGitRepo: true,
+		CreateOrphanWorktreeFn: func(branch string) (string, error) {
+			gotBranch = branch
+			return t.TempDir(), nil
+		},
+		UpstreamStatusFn: func(branch string) (*git.TrackingStatus, error) {
+			t.Errorf("Expected no base branch check for an orphan branch, got one for %s", branch)
+			return &git.TrackingStatus{}, nil
+		},
+	}
+	mg.createWorktreeError = fmt.Errorf("CreateWorktree must not be called with --orphan")
+	stdout := &bytes.Buffer{}
+	deps := &Dependencies{
+		Git:    mg,
+		UI:     &mockUI{},
+		Detect: &mockDetect{},
+		Config: config.New(),
+		Stdout: stdout,
+		Stderr: &bytes.Buffer{},
+	}
+	cmd := NewStartCommand(deps, false, true, false)
+	cmd.orphan = true
+
+	if err := cmd.Execute("gh-pages", "main"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if gotBranch != "gh-pages" {
+		t.Errorf("Expected an orphan worktree for gh-pages, got %q", gotBranch)
+	}
+	if !strings.Contains(stdout.String(), "orphan branch gh-pages") {
+		t.Errorf("Expected progress message to mention the orphan branch, got:\n%s", stdout.String())
+	}
+}
+
+func TestStartCommand_Execute_BaseBehindUpstream(t *testing.T) {
+	tests := []struct {
+		name        string
+		status      git.TrackingStatus
+		tty         bool
+		confirm     bool
+		wantWarning bool
+		wantPrompt  bool
+		wantFastFwd bool
+	}{
+		{name: "up to date", status: git.TrackingStatus{Upstream: "origin/main"}},
+		{name: "behind, not interactive", status: git.TrackingStatus{Upstream: "origin/main", Behind: 3}, wantWarning: true},
+		{name: "behind, declined", status: git.TrackingStatus{Upstream: "origin/main", Behind: 3}, tty: true, wantWarning: true, wantPrompt: true},
+		{name: "behind, confirmed", status: git.TrackingStatus{Upstream: "origin/main", Behind: 3}, tty: true, confirm: true, wantWarning: true, wantPrompt: true, wantFastFwd: true},
+		{name: "diverged is never fast-forwarded", status: git.TrackingStatus{Upstream: "origin/main", Ahead: 1, Behind: 3}, tty: true, confirm: true, wantWarning: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			originalDir, _ := os.Getwd()
+			defer os.Chdir(originalDir)
+			os.Chdir(t.TempDir())
+
+			var fastForwarded string
+			mg := &mockGit{
+				isGitRepo:        true,
+				worktreePath:     t.TempDir(),
+				UpstreamStatusFn: func(string) (*git.TrackingStatus, error) { return &tt.status, nil },
+				FastForwardBranchFn: func(branch string) error {
+					fastForwarded = branch
+					return nil
+				},
+			}
+			ui := &mockUI{confirmResult: tt.confirm}
+			stderr := &bytes.Buffer{}
+			deps := &Dependencies{
+				Git:    mg,
+				UI:     ui,
+				Detect: &mockDetect{},
+				Config: config.New(),
+				Stdout: &bytes.Buffer{},
+				Stderr: stderr,
+			}
+
+			orig := isTerminalStdin
+			isTerminalStdin = func() bool { return tt.tty }
+			defer func() { isTerminalStdin = orig }()
+
+			if err := NewStartCommand(deps, false, true, false).Execute("123", "main"); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			warned := strings.Contains(stderr.String(), "base branch main is 3 commits behind origin/main; consider pulling first")
+			if warned != tt.wantWarning {
+				t.Errorf("warning shown = %v, want %v; stderr:\n%s", warned, tt.wantWarning, stderr.String())
+			}
+			if ui.confirmCalled != tt.wantPrompt {
+				t.Errorf("prompted = %v, want %v", ui.confirmCalled, tt.wantPrompt)
+			}
+			if (fastForwarded == "main") != tt.wantFastFwd {
+				t.Errorf("fast-forwarded %q, want fast-forward = %v", fastForwarded, tt.wantFastFwd)
+			}
+		})
+	}
+}
+
 func TestStartCommand_Execute_ITerm2Tab(t *testing.T) {
 	originalDir, _ := os.Getwd()
 	defer os.Chdir(originalDir)
@@ -835,3 +1152,726 @@ func TestStartCommand_Execute_PostHook_BranchNameNoDoubleSuffix(t *testing.T) {
 		t.Errorf("GW_BRANCH_NAME has a doubled /impl suffix, got:\n%s", output)
 	}
 }
+
+func TestStartCommand_Execute_ApplyLocalChanges(t *testing.T) {
+	originalDir, _ := os.Getwd()
+	defer os.Chdir(originalDir)
+
+	tests := []struct {
+		name          string
+		applyStash    bool
+		patchFile     string // created in the cwd unless missingPatch is set
+		missingPatch  bool
+		stashErr      error
+		expectedError string
+		expectStash   bool
+		expectPatch   bool
+		expectStdout  string
+		expectStderr  string
+	}{
+		{name: "no flags applies nothing"},
+		{
+			name:         "stash applied",
+			applyStash:   true,
+			expectStash:  true,
+			expectStdout: "Applied stash to the new worktree",
+		},
+		{
+			name:         "stash failure is a warning",
+			applyStash:   true,
+			stashErr:     fmt.Errorf("No stash entries found."),
+			expectStash:  true,
+			expectStderr: "Could not apply stash",
+		},
+		{
+			name:         "patch applied",
+			patchFile:    "fix.patch",
+			expectPatch:  true,
+			expectStdout: "Applied patch fix.patch to the new worktree",
+		},
+		{
+			name:          "missing patch fails before creating the worktree",
+			patchFile:     "missing.patch",
+			missingPatch:  true,
+			expectedError: "cannot read patch file",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := os.Chdir(t.TempDir()); err != nil {
+				t.Fatalf("Failed to change dir: %v", err)
+			}
+			if tt.patchFile != "" && !tt.missingPatch {
+				if err := os.WriteFile(tt.patchFile, []byte("diff"), 0644); err != nil {
+					t.Fatalf("Failed to write patch: %v", err)
+				}
+			}
+			worktreeDir := t.TempDir()
+
+			var stashPath, patchPath, patchFile string
+			created := false
+			mg := &mockGit{
+				isGitRepo:    true,
+				worktreePath: worktreeDir,
+				ApplyStashFn: func(worktreePath string) error {
+					stashPath = worktreePath
+					return tt.stashErr
+				},
+				ApplyPatchFn: func(worktreePath, file string) error {
+					patchPath, patchFile = worktreePath, file
+					return nil
+				},
+				GetWorktreeForIssueFn: func(string) (*git.WorktreeInfo, error) {
+					created = true // resolveTarget ran, so a worktree would be created
+					return nil, nil
+				},
+			}
+			stdout := &bytes.Buffer{}
+			stderr := &bytes.Buffer{}
+			deps := &Dependencies{
+				Git:    mg,
+				UI:     &mockUI{},
+				Detect: &mockDetect{},
+				Config: &config.Config{},
+				Stdout: stdout,
+				Stderr: stderr,
+			}
+
+			cmd := NewStartCommand(deps, false, true, false)
+			cmd.applyStash = tt.applyStash
+			cmd.patchFile = tt.patchFile
+			err := cmd.Execute("123", "main")
+
+			if tt.expectedError != "" {
+				if err == nil || !contains(err.Error(), tt.expectedError) {
+					t.Fatalf("Expected error containing %q, got %v", tt.expectedError, err)
+				}
+				if created {
+					t.Error("Expected no worktree to be created")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if tt.expectStash != (stashPath == worktreeDir) {
+				t.Errorf("ApplyStash called with %q, expected call=%v", stashPath, tt.expectStash)
+			}
+			if tt.expectPatch && (patchPath != worktreeDir || patchFile != tt.patchFile) {
+				t.Errorf("ApplyPatch called with (%q, %q)", patchPath, patchFile)
+			}
+			if !tt.expectPatch && patchPath != "" {
+				t.Errorf("Expected ApplyPatch not to be called, got %q", patchPath)
+			}
+			if tt.expectStdout != "" && !contains(stdout.String(), tt.expectStdout) {
+				t.Errorf("Expected stdout to contain %q, got:\n%s", tt.expectStdout, stdout.String())
+			}
+			if tt.expectStderr != "" && !contains(stderr.String(), tt.expectStderr) {
+				t.Errorf("Expected stderr to contain %q, got:\n%s", tt.expectStderr, stderr.String())
+			}
+		})
+	}
+}
+
+// TestStartCommand_Execute_Stash_Integration drives the real git.Client: a
+// change stashed in the main checkout must show up in the worktree created
+// by `gw start --stash`.
+func TestStartCommand_Execute_Stash_Integration(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	originalDir, _ := os.Getwd()
+	defer os.Chdir(originalDir)
+
+	repo := filepath.Join(t.TempDir(), "repo")
+	runGit := func(args ...string) string {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = repo
+		out, err := cmd.CombinedOutput()
+		if err != nil {
+			t.Fatalf("git %v failed: %v\n%s", args, err, out)
+		}
+		return strings.TrimSpace(string(out))
+	}
+
+	if err := os.MkdirAll(repo, 0755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	runGit("init")
+	runGit("config", "user.email", "test@example.com")
+	runGit("config", "user.name", "Test User")
+	if err := os.WriteFile(filepath.Join(repo, "f.txt"), []byte("original\n"), 0644); err != nil {
+		t.Fatalf("write file: %v", err)
+	}
+	runGit("add", "f.txt")
+	runGit("commit", "-m", "initial")
+	baseBranch := runGit("symbolic-ref", "--short", "HEAD")
+
+	if err := os.WriteFile(filepath.Join(repo, "f.txt"), []byte("work in progress\n"), 0644); err != nil {
+		t.Fatalf("modify file: %v", err)
+	}
+	runGit("stash")
+
+	if err := os.Chdir(repo); err != nil {
+		t.Fatalf("chdir: %v", err)
+	}
+	deps := &Dependencies{
+		Git:    git.NewClient(),
+		UI:     &mockUI{},
+		Detect: &mockDetect{},
+		Config: &config.Config{},
+		Stdout: &bytes.Buffer{},
+		Stderr: &bytes.Buffer{},
+	}
+	cmd := NewStartCommand(deps, false, true, false)
+	cmd.applyStash = true
+	if err := cmd.Execute("123", baseBranch); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(repo, "..", "repo-123", "f.txt"))
+	if err != nil {
+		t.Fatalf("read worktree file: %v", err)
+	}
+	if string(content) != "work in progress\n" {
+		t.Errorf("Expected stashed change in the new worktree, got %q", content)
+	}
+}
+
+func TestStartCommand_Execute_BaseFromDefault_Integration(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	originalDir, _ := os.Getwd()
+	defer os.Chdir(originalDir)
+
+	root := t.TempDir()
+	remote := filepath.Join(root, "remote.git")
+	repo := filepath.Join(root, "repo")
+	other := filepath.Join(root, "other")
+	runGit := func(dir string, args ...string) string {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		out, err := cmd.CombinedOutput()
+		if err != nil {
+			t.Fatalf("git %v failed: %v\n%s", args, err, out)
+		}
+		return strings.TrimSpace(string(out))
+	}
+
+	// The bare remote starts with one commit; repo is cloned from it, so
+	// origin/HEAD is set, and then falls behind when another clone pushes.
+	runGit(root, "init", "--bare", remote)
+	runGit(root, "clone", remote, other)
+	runGit(other, "config", "user.email", "test@example.com")
+	runGit(other, "config", "user.name", "Test User")
+	runGit(other, "commit", "--allow-empty", "-m", "initial")
+	defaultBranch := runGit(other, "symbolic-ref", "--short", "HEAD")
+	runGit(other, "push", "origin", defaultBranch)
+	runGit(root, "clone", remote, repo)
+	if err := os.WriteFile(filepath.Join(other, "new.txt"), []byte("new\n"), 0644); err != nil {
+		t.Fatalf("write file: %v", err)
+	}
+	runGit(other, "add", "new.txt")
+	runGit(other, "commit", "-m", "pushed after the clone")
+	runGit(other, "push", "origin", defaultBranch)
+
+	if err := os.Chdir(repo); err != nil {
+		t.Fatalf("chdir: %v", err)
+	}
+	deps := &Dependencies{
+		Git:    git.NewClient(),
+		UI:     &mockUI{},
+		Detect: &mockDetect{},
+		Config: &config.Config{},
+		Stdout: &bytes.Buffer{},
+		Stderr: &bytes.Buffer{},
+	}
+	cmd := NewStartCommand(deps, false, false, false)
+	cmd.baseFromDefault = true
+	if err := cmd.Execute("123", defaultBranch); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if _, err := os.Stat(filepath.Join(root, "repo-123", "new.txt")); err != nil {
+		t.Errorf("Expected the worktree to include the commit pushed after the clone: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(repo, "new.txt")); err == nil {
+		t.Error("Expected the local base branch to be left behind")
+	}
+}
+
+func TestApplyStartTemplate(t *testing.T) {
+	cfg := config.New()
+	cfg.Templates = map[string]config.Template{
+		"feature": {Base: "develop", Prefix: "feature/"},
+		"hotfix":  {Prefix: "hotfix/"},
+	}
+
+	tests := []struct {
+		name       string
+		template   string
+		target     string
+		baseBranch string
+		baseGiven  bool
+		wantTarget string
+		wantBase   string
+		wantErr    bool
+	}{
+		{name: "no template keeps target and base", template: "", target: "123", baseBranch: "main", wantTarget: "123", wantBase: "main"},
+		{name: "template applies base and prefix", template: "feature", target: "login", baseBranch: "main", wantTarget: "feature/login", wantBase: "develop"},
+		{name: "explicit base wins over template", template: "feature", target: "login", baseBranch: "release", baseGiven: true, wantTarget: "feature/login", wantBase: "release"},
+		{name: "prefix is not doubled", template: "feature", target: "feature/login", baseBranch: "main", wantTarget: "feature/login", wantBase: "develop"},
+		{name: "template without base keeps default", template: "hotfix", target: "crash", baseBranch: "main", wantTarget: "hotfix/crash", wantBase: "main"},
+		{name: "unknown template", template: "chore", target: "x", baseBranch: "main", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			target, base, err := applyStartTemplate(cfg, tt.template, tt.target, tt.baseBranch, tt.baseGiven)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("Expected error for unknown template")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if target != tt.wantTarget || base != tt.wantBase {
+				t.Errorf("applyStartTemplate() = (%q, %q), want (%q, %q)", target, base, tt.wantTarget, tt.wantBase)
+			}
+		})
+	}
+}
+
+func TestStartCommand_Execute_InvalidInput(t *testing.T) {
+	tests := []struct {
+		input   string
+		wantErr string
+	}{
+		{input: " 123", wantErr: "whitespace"},
+		{input: "--oops", wantErr: "cannot start with '-'"},
+		{input: "foo..bar", wantErr: "cannot contain '..'"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			mockGitInstance := &mockGit{isGitRepo: true}
+			mockGitInstance.GetWorktreeForIssueFn = func(string) (*git.WorktreeInfo, error) {
+				t.Error("worktree lookup should not be reached for invalid input")
+				return nil, nil
+			}
+
+			deps := &Dependencies{
+				Git:    mockGitInstance,
+				UI:     &mockUI{},
+				Detect: &mockDetect{},
+				Config: config.New(),
+				Stdout: &bytes.Buffer{},
+				Stderr: &bytes.Buffer{},
+			}
+
+			err := NewStartCommand(deps, false, true, false).Execute(tt.input, defaultBaseBranch)
+			if err == nil {
+				t.Fatal("Expected error for invalid input")
+			}
+			if !strings.Contains(err.Error(), "invalid branch name") || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Expected invalid branch name error containing %q, got: %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestStartCommand_Execute_CopyFromCurrent_Integration(t *testing.T) {
+	repo, runGit := newDryRunTestRepo(t)
+	if err := os.WriteFile(filepath.Join(repo, ".gitignore"), []byte(".idea/\n"), 0644); err != nil {
+		t.Fatalf("write .gitignore: %v", err)
+	}
+	runGit("add", ".gitignore")
+	runGit("commit", "-m", "ignore .idea")
+
+	// One ignored and one plain untracked file, with gw run from a subdirectory.
+	untracked := map[string]string{
+		filepath.Join(".idea", "workspace.xml"): "<project/>",
+		"notes.txt":                             "todo",
+	}
+	for rel, content := range untracked {
+		path := filepath.Join(repo, rel)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("mkdir: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("write %s: %v", rel, err)
+		}
+	}
+	if err := os.Chdir(filepath.Join(repo, ".idea")); err != nil {
+		t.Fatalf("chdir: %v", err)
+	}
+
+	stdout := &bytes.Buffer{}
+	deps := &Dependencies{
+		Git:    git.NewClient(),
+		UI:     &mockUI{},
+		Detect: &mockDetect{},
+		Config: &config.Config{},
+		Stdout: stdout,
+		Stderr: &bytes.Buffer{},
+	}
+	cmd := NewStartCommand(deps, false, true, false)
+	cmd.copyFromCurrent = true
+	if err := cmd.Execute("124", "main"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	worktree := filepath.Join(filepath.Dir(repo), "repo-124")
+	for rel, want := range untracked {
+		got, err := os.ReadFile(filepath.Join(worktree, rel))
+		if err != nil || string(got) != want {
+			t.Errorf("Expected %s to be copied with %q, got %q (%v)", rel, want, got, err)
+		}
+	}
+	if !strings.Contains(stdout.String(), "Copied 2 files from "+repo) {
+		t.Errorf("Expected a copy summary, got:\n%s", stdout.String())
+	}
+}
+
+func TestStartCommand_Execute_CopyFrom(t *testing.T) {
+	originalDir, _ := os.Getwd()
+	defer os.Chdir(originalDir)
+
+	sourceDir := t.TempDir()
+	notADir := filepath.Join(sourceDir, "file.txt")
+	os.WriteFile(notADir, []byte("x"), 0644)
+
+	tests := []struct {
+		name          string
+		copyFrom      string
+		findErr       error
+		expectedError string
+		expectCopy    bool
+		expectStdout  string
+		expectStderr  string
+	}{
+		{name: "copies from the source worktree", copyFrom: sourceDir, expectCopy: true, expectStdout: "Copied 2 files from " + sourceDir},
+		{name: "listing failure is a warning", copyFrom: sourceDir, findErr: fmt.Errorf("not a git repository"), expectStderr: "Could not copy files from"},
+		{name: "missing source fails before creating the worktree", copyFrom: filepath.Join(sourceDir, "missing"), expectedError: "cannot read --copy-from directory"},
+		{name: "source must be a directory", copyFrom: notADir, expectedError: "is not a directory"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := os.Chdir(t.TempDir()); err != nil {
+				t.Fatalf("Failed to change dir: %v", err)
+			}
+			worktreeDir := t.TempDir()
+
+			var findPath, copySrc, copyDest string
+			created := false
+			mg := &mockGit{
+				isGitRepo:    true,
+				worktreePath: worktreeDir,
+				FindUntrackedFilesFn: func(repoPath string) ([]git.EnvFile, error) {
+					findPath = repoPath
+					return []git.EnvFile{{Path: ".idea/workspace.xml"}, {Path: "certs/local.pem"}}, tt.findErr
+				},
+				CopyFilesFn: func(files []git.EnvFile, sourceRoot, destRoot string) (int, error) {
+					copySrc, copyDest = sourceRoot, destRoot
+					return len(files), nil
+				},
+				GetWorktreeForIssueFn: func(string) (*git.WorktreeInfo, error) {
+					created = true
+					return nil, nil
+				},
+			}
+			stdout := &bytes.Buffer{}
+			stderr := &bytes.Buffer{}
+			deps := &Dependencies{
+				Git:    mg,
+				UI:     &mockUI{},
+				Detect: &mockDetect{},
+				Config: &config.Config{},
+				Stdout: stdout,
+				Stderr: stderr,
+			}
+
+			cmd := NewStartCommand(deps, false, true, false)
+			cmd.copyFrom = tt.copyFrom
+			err := cmd.Execute("123", "main")
+
+			if tt.expectedError != "" {
+				if err == nil || !contains(err.Error(), tt.expectedError) {
+					t.Fatalf("Expected error containing %q, got %v", tt.expectedError, err)
+				}
+				if created {
+					t.Error("Expected no worktree to be created")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if findPath != tt.copyFrom {
+				t.Errorf("FindUntrackedFiles called with %q, want %q", findPath, tt.copyFrom)
+			}
+			if tt.expectCopy && (copySrc != tt.copyFrom || copyDest != worktreeDir) {
+				t.Errorf("CopyFiles called with (%q, %q)", copySrc, copyDest)
+			}
+			if !tt.expectCopy && copyDest != "" {
+				t.Errorf("Expected CopyFiles not to be called, got dest %q", copyDest)
+			}
+			if tt.expectStdout != "" && !contains(stdout.String(), tt.expectStdout) {
+				t.Errorf("Expected stdout to contain %q, got:\n%s", tt.expectStdout, stdout.String())
+			}
+			if tt.expectStderr != "" && !contains(stderr.String(), tt.expectStderr) {
+				t.Errorf("Expected stderr to contain %q, got:\n%s", tt.expectStderr, stderr.String())
+			}
+		})
+	}
+}
+
+func TestStartCommand_Execute_InterruptRollsBack_Integration(t *testing.T) {
+	repo, runGit := newDryRunTestRepo(t)
+	worktree := filepath.Join(filepath.Dir(repo), "repo-123")
+	hookMarker := filepath.Join(t.TempDir(), "post-start-ran")
+	cdFile := filepath.Join(t.TempDir(), "cd")
+	t.Setenv(envCDFile, cdFile)
+
+	stdout := &bytes.Buffer{}
+	stderr := &bytes.Buffer{}
+	detector := &mockDetect{}
+	deps := &Dependencies{
+		Git:    git.NewClient(),
+		UI:     &mockUI{},
+		Detect: detector,
+		Config: &config.Config{AutoCD: true, PostStartHook: "touch " + hookMarker},
+		Stdout: stdout,
+		Stderr: stderr,
+	}
+	cmd := NewStartCommand(deps, false, true, false)
+	// Interrupt while the package manager setup runs inside the new worktree.
+	detector.RunSetupFn = func(path string) error {
+		if _, err := os.Stat(path); err != nil {
+			t.Fatalf("Expected the worktree to exist during setup: %v", err)
+		}
+		cmd.signals <- os.Interrupt
+		return fmt.Errorf("signal: interrupt")
+	}
+	err := cmd.Execute("123", "main")
+	if code := ExitCode(err); code != ExitInterrupted {
+		t.Fatalf("Expected exit code %d, got %d (%v)", ExitInterrupted, code, err)
+	}
+
+	if _, err := os.Stat(worktree); !os.IsNotExist(err) {
+		t.Errorf("Expected %s to be removed, stat error: %v", worktree, err)
+	}
+	if out := runGit("branch", "--list", testBranch123); out != "" {
+		t.Errorf("Expected branch %s to be deleted, got %q", testBranch123, out)
+	}
+	if !contains(stderr.String(), "Removed the partially set up worktree "+worktree) {
+		t.Errorf("Expected a rollback message, got:\n%s", stderr.String())
+	}
+	if _, err := os.Stat(hookMarker); !os.IsNotExist(err) {
+		t.Errorf("Expected post_start_hook to be skipped, stat error: %v", err)
+	}
+	if _, err := os.Stat(cdFile); !os.IsNotExist(err) {
+		t.Errorf("Expected $%s not to be written, stat error: %v", envCDFile, err)
+	}
+	if contains(stdout.String(), "Worktree ready") {
+		t.Errorf("Expected no ready message, got:\n%s", stdout.String())
+	}
+	if wd, _ := os.Getwd(); !samePath(wd, repo) {
+		t.Errorf("Expected to be back in %s, got %s", repo, wd)
+	}
+}
+
+func TestStartCommand_Rollback_LeavesWorktreeGitRefuses(t *testing.T) {
+	deleted := false
+	mg := &mockGit{
+		isGitRepo: true,
+		RemoveWorktreeByPathFn: func(string) error {
+			return fmt.Errorf("contains modified or untracked files")
+		},
+		DeleteBranchFn: func(string, bool) error {
+			deleted = true
+			return nil
+		},
+	}
+	deps, _, stderr := newListTestDeps(mg)
+	cmd := NewStartCommand(deps, false, true, false)
+	cmd.rollback("/path/to/repo-123", testBranch123, "")
+
+	if deleted {
+		t.Error("Expected the branch of a worktree left in place to be kept")
+	}
+	for _, want := range []string{
+		"Could not remove the partially set up worktree /path/to/repo-123",
+		"gw setup " + testBranch123,
+		"gw end " + testBranch123,
+	} {
+		if !contains(stderr.String(), want) {
+			t.Errorf("Expected stderr to contain %q, got:\n%s", want, stderr.String())
+		}
+	}
+}
+
+func TestStartCommand_Execute_Preview(t *testing.T) {
+	repo, runGit := newDryRunTestRepo(t)
+	worktreesBefore := runGit("worktree", "list", "--porcelain")
+	branchesBefore := runGit("branch", "--list")
+
+	for _, target := range []string{"123", "476/impl-migration-script"} {
+		t.Run(target, func(t *testing.T) {
+			stdout := &bytes.Buffer{}
+			deps := &Dependencies{
+				Git:    git.NewClient(),
+				UI:     &mockUI{},
+				Detect: &mockDetect{},
+				Config: &config.Config{FetchBeforeCommand: true},
+				Stdout: stdout,
+				Stderr: &bytes.Buffer{},
+			}
+			cmd := NewStartCommand(deps, false, false, false)
+			cmd.preview = true
+			if err := cmd.Execute(target, "main"); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			branch, suffix := git.DetermineWorktreeNames(target)
+			worktree := filepath.Join(filepath.Dir(repo), "repo-"+suffix)
+			for _, want := range []string{"Branch:   " + branch, "Worktree: " + worktree, "Base:     main"} {
+				if !contains(stdout.String(), want) {
+					t.Errorf("Expected %q in the preview, got:\n%s", want, stdout.String())
+				}
+			}
+			if _, err := os.Stat(worktree); !os.IsNotExist(err) {
+				t.Errorf("Expected %s not to be created, stat error: %v", worktree, err)
+			}
+		})
+	}
+
+	if after := runGit("worktree", "list", "--porcelain"); after != worktreesBefore {
+		t.Errorf("Expected no new worktree, got:\n%s", after)
+	}
+	if after := runGit("branch", "--list"); after != branchesBefore {
+		t.Errorf("Expected no new branch, got:\n%s", after)
+	}
+}
+
+func TestStartCommand_Execute_Lock_Integration(t *testing.T) {
+	newDryRunTestRepo(t)
+
+	stdout := &bytes.Buffer{}
+	deps := &Dependencies{
+		Git:    git.NewClient(),
+		UI:     &mockUI{},
+		Detect: &mockDetect{},
+		Config: &config.Config{},
+		Stdout: stdout,
+		Stderr: &bytes.Buffer{},
+	}
+	cmd := NewStartCommand(deps, false, true, false)
+	cmd.lockReason = "long-lived"
+	if err := cmd.Execute("123", "main"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	wt, err := git.NewClient().GetWorktreeForIssue("123")
+	if err != nil || wt == nil {
+		t.Fatalf("Expected the worktree for 123, got %v (err: %v)", wt, err)
+	}
+	if !wt.IsLocked || wt.LockReason != "long-lived" {
+		t.Errorf("Expected the worktree to be locked with reason %q, got locked=%v reason=%q", "long-lived", wt.IsLocked, wt.LockReason)
+	}
+	if !contains(stdout.String(), "Locked the worktree (long-lived)") {
+		t.Errorf("Expected the lock to be reported, got:\n%s", stdout.String())
+	}
+}
+
+func TestStartCommand_Execute_StartSuccessTemplate(t *testing.T) {
+	originalDir, _ := os.Getwd()
+	defer os.Chdir(originalDir)
+	os.Chdir(t.TempDir())
+
+	worktreeDir := filepath.Join(t.TempDir(), "repo-123")
+	if err := os.MkdirAll(worktreeDir, 0755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	cfg := config.New()
+	cfg.AutoCD = false
+	cfg.StartSuccessTemplate = "[{issue}] {branch} -> {path} {unknown}"
+	stdout := &bytes.Buffer{}
+	deps := &Dependencies{
+		Git:    &mockGit{isGitRepo: true, worktreePath: worktreeDir},
+		UI:     &mockUI{},
+		Detect: &mockDetect{},
+		Config: cfg,
+		Stdout: stdout,
+		Stderr: &bytes.Buffer{},
+	}
+	cmd := NewStartCommand(deps, false, true, false)
+
+	if err := cmd.Execute("123", "main"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	want := "\n[123] 123/impl -> " + worktreeDir + " {unknown}\n"
+	if !strings.Contains(stdout.String(), want) {
+		t.Errorf("Expected %q in output, got:\n%s", want, stdout.String())
+	}
+	if strings.Contains(stdout.String(), i18n.T(i18n.MsgWorktreeReady, worktreeDir)) {
+		t.Errorf("Expected the default message to be replaced, got:\n%s", stdout.String())
+	}
+}
+
+func TestStartCommand_Execute_NoCD(t *testing.T) {
+	for _, noCD := range []bool{false, true} {
+		t.Run(fmt.Sprintf("no-cd=%v", noCD), func(t *testing.T) {
+			originalDir, _ := os.Getwd()
+			defer os.Chdir(originalDir)
+			os.Chdir(t.TempDir())
+
+			t.Setenv("HOME", t.TempDir())
+			cdFile := filepath.Join(t.TempDir(), "cd")
+			t.Setenv(envCDFile, cdFile)
+			worktreeDir := filepath.Join(t.TempDir(), "repo-123")
+			if err := os.MkdirAll(worktreeDir, 0755); err != nil {
+				t.Fatalf("mkdir: %v", err)
+			}
+			stdout := &bytes.Buffer{}
+			deps := &Dependencies{
+				Git:    &mockGit{isGitRepo: true, worktreePath: worktreeDir},
+				UI:     &mockUI{},
+				Detect: &mockDetect{},
+				Config: config.New(),
+				Stdout: stdout,
+				Stderr: &bytes.Buffer{},
+			}
+			cmd := NewStartCommand(deps, false, true, false)
+			cmd.noCD = noCD
+
+			if err := cmd.Execute("123", "main"); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			content, _ := os.ReadFile(cdFile)
+			if noCD && len(content) > 0 {
+				t.Errorf("Expected no directory change with --no-cd, got %s = %q", envCDFile, content)
+			}
+			if !noCD && string(content) != worktreeDir {
+				t.Errorf("Expected %s = %q, got %q", envCDFile, worktreeDir, content)
+			}
+			if got := strings.Contains(stdout.String(), i18n.T(i18n.MsgShellIntegCD)); got == noCD {
+				t.Errorf("Expected the shell integration hint shown = %v, got output:\n%s", !noCD, stdout.String())
+			}
+			if !strings.Contains(stdout.String(), worktreeDir) {
+				t.Errorf("Expected the worktree path to be reported, got:\n%s", stdout.String())
+			}
+		})
+	}
+}
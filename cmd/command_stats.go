@@ -0,0 +1,57 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/sotarok/gw/internal/stats"
+)
+
+// StatsCommand handles the stats command logic
+type StatsCommand struct {
+	deps      *Dependencies
+	statsPath string
+}
+
+// NewStatsCommand creates a new stats command handler
+func NewStatsCommand(deps *Dependencies, statsPath string) *StatsCommand {
+	return &StatsCommand{deps: deps, statsPath: statsPath}
+}
+
+// Execute prints the recorded counts, or clears them when reset is set.
+func (c *StatsCommand) Execute(reset bool) error {
+	if reset {
+		if err := stats.Reset(c.statsPath); err != nil {
+			return err
+		}
+		fmt.Fprintf(c.deps.Stdout, "%s Usage stats reset\n", coloredSuccess())
+		return nil
+	}
+
+	counts, err := stats.Load(c.statsPath)
+	if err != nil {
+		return err
+	}
+	if !c.deps.Config.TrackStats {
+		fmt.Fprintf(c.deps.Stderr, "%s Usage tracking is off; set track_stats = true in ~/.gwrc to record counts\n", coloredWarning())
+	}
+	for _, command := range stats.Commands {
+		fmt.Fprintf(c.deps.Stdout, "%-6s %d\n", command, counts[command])
+	}
+	return nil
+}
+
+// recordUsage counts a completed run of command when track_stats is on. Dry
+// runs change nothing and are not counted, and a stats file that cannot be
+// written only produces a warning.
+func recordUsage(deps *Dependencies, command string) {
+	if !deps.Config.TrackStats || dryRun {
+		return
+	}
+	path, err := stats.DefaultPath()
+	if err == nil {
+		err = stats.Increment(path, command)
+	}
+	if err != nil {
+		fmt.Fprintf(deps.Stderr, "%s Could not record usage stats: %v\n", coloredWarning(), err)
+	}
+}
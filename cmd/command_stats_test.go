@@ -0,0 +1,88 @@
+package cmd
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/sotarok/gw/internal/config"
+	"github.com/sotarok/gw/internal/stats"
+)
+
+func newStatsTestDeps(trackStats bool) (*Dependencies, *bytes.Buffer, *bytes.Buffer) {
+	cfg := config.New()
+	cfg.TrackStats = trackStats
+	stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+	return &Dependencies{Config: cfg, Stdout: stdout, Stderr: stderr}, stdout, stderr
+}
+
+func TestStatsCommand_Execute(t *testing.T) {
+	t.Run("prints the recorded counts", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "stats.json")
+		for _, command := range []string{stats.CommandStart, stats.CommandStart, stats.CommandClean} {
+			if err := stats.Increment(path, command); err != nil {
+				t.Fatalf("Increment failed: %v", err)
+			}
+		}
+		deps, stdout, stderr := newStatsTestDeps(true)
+
+		if err := NewStatsCommand(deps, path).Execute(false); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if want := "start  2\nend    0\nclean  1\n"; stdout.String() != want {
+			t.Errorf("output = %q, want %q", stdout.String(), want)
+		}
+		if stderr.Len() != 0 {
+			t.Errorf("Expected no warning, got: %s", stderr.String())
+		}
+	})
+
+	t.Run("warns when tracking is off", func(t *testing.T) {
+		deps, _, stderr := newStatsTestDeps(false)
+
+		if err := NewStatsCommand(deps, filepath.Join(t.TempDir(), "stats.json")).Execute(false); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if !strings.Contains(stderr.String(), "track_stats = true") {
+			t.Errorf("Expected a hint to enable tracking, got: %s", stderr.String())
+		}
+	})
+
+	t.Run("reset clears the counts", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "stats.json")
+		if err := stats.Increment(path, stats.CommandEnd); err != nil {
+			t.Fatalf("Increment failed: %v", err)
+		}
+		deps, stdout, _ := newStatsTestDeps(true)
+
+		if err := NewStatsCommand(deps, path).Execute(true); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if !strings.Contains(stdout.String(), "Usage stats reset") {
+			t.Errorf("Expected a reset message, got: %s", stdout.String())
+		}
+		if counts, _ := stats.Load(path); len(counts) != 0 {
+			t.Errorf("Expected no counts after reset, got %v", counts)
+		}
+	})
+}
+
+func TestRecordUsage(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	path := filepath.Join(home, ".gw", "stats.json")
+
+	deps, _, _ := newStatsTestDeps(false)
+	recordUsage(deps, stats.CommandStart)
+	if counts, _ := stats.Load(path); counts[stats.CommandStart] != 0 {
+		t.Errorf("Expected nothing recorded with tracking off, got %v", counts)
+	}
+
+	deps, _, _ = newStatsTestDeps(true)
+	recordUsage(deps, stats.CommandStart)
+	recordUsage(deps, stats.CommandStart)
+	if counts, _ := stats.Load(path); counts[stats.CommandStart] != 2 {
+		t.Errorf("Expected 2 recorded starts, got %v", counts)
+	}
+}
@@ -0,0 +1,107 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/sotarok/gw/internal/git"
+)
+
+// syncEnvGit is the subset of git operations SyncEnvCommand actually uses.
+type syncEnvGit interface {
+	git.RepositoryReader // GetMainRepositoryRoot
+	git.WorktreeManager  // GetWorktreeForIssue
+	git.EnvFileHandler   // FindUntrackedEnvFiles, CopyEnvFiles
+}
+
+// SyncEnvCommand handles the sync-env command logic
+type SyncEnvCommand struct {
+	deps      *Dependencies
+	overwrite bool // --overwrite: replace env files that differ in the worktree
+}
+
+// NewSyncEnvCommand creates a new sync-env command handler
+func NewSyncEnvCommand(deps *Dependencies, overwrite bool) *SyncEnvCommand {
+	return &SyncEnvCommand{
+		deps:      deps,
+		overwrite: overwrite,
+	}
+}
+
+// git returns the command's git dependency narrowed to the operations it uses.
+func (c *SyncEnvCommand) git() syncEnvGit { return c.deps.Git }
+
+// Execute copies the main worktree's untracked env files that are missing
+// from the target worktree into it. Files that exist in the worktree with
+// different content are skipped with a warning unless overwrite is set;
+// identical ones are left alone.
+func (c *SyncEnvCommand) Execute(issueNumber string) error {
+	g := c.git()
+
+	worktreePath, err := c.resolveWorktree(issueNumber)
+	if err != nil {
+		return err
+	}
+	mainRoot, err := g.GetMainRepositoryRoot()
+	if err != nil {
+		return fmt.Errorf("failed to get main repository root: %w", err)
+	}
+	if filepath.Clean(worktreePath) == filepath.Clean(mainRoot) {
+		return fmt.Errorf("%s is the main worktree: choose a worktree to copy env files into", worktreePath)
+	}
+
+	envFiles, err := g.FindUntrackedEnvFiles(mainRoot)
+	if err != nil {
+		return fmt.Errorf("failed to find env files: %w", err)
+	}
+
+	differing := make(map[string]bool)
+	for _, f := range git.FindEnvFileConflicts(envFiles, mainRoot, worktreePath) {
+		differing[f.Path] = true
+	}
+	var toCopy []git.EnvFile
+	for _, f := range envFiles {
+		switch {
+		case differing[f.Path] && !c.overwrite:
+			fmt.Fprintf(c.deps.Stderr, "%s Skipped %s: it differs in the worktree (use --overwrite to replace it)\n", coloredWarning(), f.Path)
+		case differing[f.Path]:
+			toCopy = append(toCopy, f)
+		default:
+			if _, err := os.Stat(filepath.Join(worktreePath, f.Path)); os.IsNotExist(err) {
+				toCopy = append(toCopy, f)
+			}
+		}
+	}
+
+	if len(toCopy) == 0 {
+		fmt.Fprintf(c.deps.Stdout, "%s No env files to copy into %s\n", coloredSuccess(), worktreePath)
+		return nil
+	}
+	if err := g.CopyEnvFiles(toCopy, mainRoot, worktreePath); err != nil {
+		return fmt.Errorf("failed to copy env files: %w", err)
+	}
+	fmt.Fprintf(c.deps.Stdout, "%s Copied %d env %s into %s\n", coloredSuccess(), len(toCopy), plural(len(toCopy), "file", "files"), worktreePath)
+	return nil
+}
+
+// resolveWorktree returns the path of the worktree to sync, either via
+// interactive selection (when issueNumber is empty) or by issue number / branch.
+func (c *SyncEnvCommand) resolveWorktree(issueNumber string) (string, error) {
+	if issueNumber == "" {
+		selected, err := c.deps.UI.SelectWorktree()
+		if err != nil {
+			return "", err
+		}
+		return selected.Path, nil
+	}
+
+	wt, err := c.git().GetWorktreeForIssue(issueNumber)
+	if err != nil {
+		return "", err
+	}
+	if wt == nil {
+		return "", &git.WorktreeNotFoundError{Identifier: issueNumber}
+	}
+	return wt.Path, nil
+}
@@ -0,0 +1,123 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/sotarok/gw/internal/config"
+	"github.com/sotarok/gw/internal/git"
+)
+
+func TestSyncEnvCommand_Execute(t *testing.T) {
+	// setup creates a repository with a worktree for issue 123, then adds env
+	// files to the main worktree: .env is new, .env.local differs from the
+	// worktree's copy and .env.test is identical to it.
+	setup := func(t *testing.T) (repo, worktree string) {
+		t.Helper()
+		repo, runGit := newDryRunTestRepo(t)
+		worktree = filepath.Join(filepath.Dir(repo), "repo-123")
+		runGit("worktree", "add", "-b", testBranch123, worktree)
+
+		writeEnv := func(dir, name, content string) {
+			t.Helper()
+			if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0600); err != nil {
+				t.Fatalf("write %s: %v", name, err)
+			}
+		}
+		writeEnv(worktree, ".env.local", "LOCAL=worktree\n")
+		writeEnv(worktree, ".env.test", "TEST=1\n")
+		writeEnv(repo, ".env", "NEW=1\n")
+		writeEnv(repo, ".env.local", "LOCAL=main\n")
+		writeEnv(repo, ".env.test", "TEST=1\n")
+		return repo, worktree
+	}
+	newSyncDeps := func() (*Dependencies, *bytes.Buffer, *bytes.Buffer) {
+		stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+		return &Dependencies{
+			Git:    git.NewClient(),
+			UI:     &mockUI{},
+			Detect: &mockDetect{},
+			Config: &config.Config{},
+			Stdout: stdout,
+			Stderr: stderr,
+		}, stdout, stderr
+	}
+	readEnv := func(t *testing.T, dir, name string) string {
+		t.Helper()
+		data, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			t.Fatalf("read %s: %v", name, err)
+		}
+		return string(data)
+	}
+
+	t.Run("copies env files added after the worktree was created", func(t *testing.T) {
+		_, worktree := setup(t)
+		deps, stdout, stderr := newSyncDeps()
+
+		if err := NewSyncEnvCommand(deps, false).Execute("123"); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if got := readEnv(t, worktree, ".env"); got != "NEW=1\n" {
+			t.Errorf(".env = %q, want it copied from the main worktree", got)
+		}
+		if got := readEnv(t, worktree, ".env.local"); got != "LOCAL=worktree\n" {
+			t.Errorf(".env.local = %q, want the worktree's own copy kept", got)
+		}
+		if !strings.Contains(stdout.String(), "Copied 1 env file into") {
+			t.Errorf("Expected a summary of one copied file, got:\n%s", stdout.String())
+		}
+		if !strings.Contains(stderr.String(), "Skipped .env.local") {
+			t.Errorf("Expected the differing .env.local to be reported, got:\n%s", stderr.String())
+		}
+	})
+
+	t.Run("overwrite replaces differing env files", func(t *testing.T) {
+		_, worktree := setup(t)
+		deps, stdout, _ := newSyncDeps()
+
+		if err := NewSyncEnvCommand(deps, true).Execute("123"); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if got := readEnv(t, worktree, ".env.local"); got != "LOCAL=main\n" {
+			t.Errorf(".env.local = %q, want it replaced with the main worktree's", got)
+		}
+		if !strings.Contains(stdout.String(), "Copied 2 env files into") {
+			t.Errorf("Expected .env and .env.local to be copied, got:\n%s", stdout.String())
+		}
+	})
+
+	t.Run("reports a worktree already in sync", func(t *testing.T) {
+		_, _ = setup(t)
+		deps, _, _ := newSyncDeps()
+		if err := NewSyncEnvCommand(deps, true).Execute("123"); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		deps, stdout, _ := newSyncDeps()
+		if err := NewSyncEnvCommand(deps, false).Execute("123"); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if !strings.Contains(stdout.String(), "No env files to copy") {
+			t.Errorf("Expected nothing to copy on the second run, got:\n%s", stdout.String())
+		}
+	})
+
+	t.Run("refuses the main worktree", func(t *testing.T) {
+		repo, _ := setup(t)
+		deps, _, _ := newSyncDeps()
+		deps.UI = &mockUI{SelectWorktreeFn: func() (*git.WorktreeInfo, error) {
+			return &git.WorktreeInfo{Path: repo, Branch: "main"}, nil
+		}}
+
+		err := NewSyncEnvCommand(deps, false).Execute("")
+		if err == nil || !strings.Contains(err.Error(), "is the main worktree") {
+			t.Errorf("Expected a main worktree error, got: %v", err)
+		}
+	})
+}
@@ -0,0 +1,49 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/sotarok/gw/internal/git"
+)
+
+// whereGit is the subset of git operations WhereCommand actually uses.
+type whereGit interface {
+	git.RepositoryReader // GetRepositoryRoot
+	git.WorktreeManager  // GetWorktreeForIssue
+}
+
+// WhereCommand handles the where command logic
+type WhereCommand struct {
+	deps *Dependencies
+}
+
+// NewWhereCommand creates a new where command handler
+func NewWhereCommand(deps *Dependencies) *WhereCommand {
+	return &WhereCommand{deps: deps}
+}
+
+// git returns the command's git dependency narrowed to the operations it uses.
+func (c *WhereCommand) git() whereGit { return c.deps.Git }
+
+// Execute prints the absolute path of the worktree for issueNumber, or of the
+// current worktree when issueNumber is empty.
+func (c *WhereCommand) Execute(issueNumber string) error {
+	if issueNumber == "" {
+		root, err := c.git().GetRepositoryRoot()
+		if err != nil {
+			return fmt.Errorf("failed to get repository root: %w", err)
+		}
+		printWorktreePath(c.deps.Stdout, root)
+		return nil
+	}
+
+	wt, err := c.git().GetWorktreeForIssue(issueNumber)
+	if err != nil {
+		return err
+	}
+	if wt == nil {
+		return &git.WorktreeNotFoundError{Identifier: issueNumber}
+	}
+	printWorktreePath(c.deps.Stdout, wt.Path)
+	return nil
+}
@@ -0,0 +1,79 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/sotarok/gw/internal/config"
+	"github.com/sotarok/gw/internal/git"
+)
+
+func newWhereTestDeps(mg *mockGit) (*Dependencies, *bytes.Buffer) {
+	stdout := &bytes.Buffer{}
+	return &Dependencies{
+		Git:    mg,
+		UI:     &mockUI{},
+		Config: config.New(),
+		Stdout: stdout,
+		Stderr: &bytes.Buffer{},
+	}, stdout
+}
+
+func TestWhereCommand_Execute(t *testing.T) {
+	t.Run("prints the worktree path for an issue", func(t *testing.T) {
+		var gotIssue string
+		mg := &mockGit{GetWorktreeForIssueFn: func(issue string) (*git.WorktreeInfo, error) {
+			gotIssue = issue
+			return &git.WorktreeInfo{Path: "/repo-123", Branch: testBranch123}, nil
+		}}
+		deps, stdout := newWhereTestDeps(mg)
+
+		if err := NewWhereCommand(deps).Execute("123"); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if gotIssue != "123" {
+			t.Errorf("Expected lookup for 123, got %q", gotIssue)
+		}
+		if stdout.String() != "/repo-123\n" {
+			t.Errorf("Expected only the path, got %q", stdout.String())
+		}
+	})
+
+	t.Run("prints the current worktree without an argument", func(t *testing.T) {
+		mg := &mockGit{GetRepositoryRootFn: func() (string, error) { return "/repo-456", nil }}
+		deps, stdout := newWhereTestDeps(mg)
+
+		if err := NewWhereCommand(deps).Execute(""); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if stdout.String() != "/repo-456\n" {
+			t.Errorf("Expected only the current path, got %q", stdout.String())
+		}
+	})
+
+	t.Run("missing worktree is an error", func(t *testing.T) {
+		mg := &mockGit{GetWorktreeForIssueFn: func(string) (*git.WorktreeInfo, error) { return nil, nil }}
+		deps, stdout := newWhereTestDeps(mg)
+
+		err := NewWhereCommand(deps).Execute("999")
+		if !errors.Is(err, git.ErrWorktreeNotFound) {
+			t.Errorf("Expected a worktree not found error, got: %v", err)
+		}
+		if stdout.Len() != 0 {
+			t.Errorf("Expected no output, got %q", stdout.String())
+		}
+	})
+
+	t.Run("lookup failure is returned", func(t *testing.T) {
+		mg := &mockGit{GetWorktreeForIssueFn: func(string) (*git.WorktreeInfo, error) {
+			return nil, fmt.Errorf("not a git repository")
+		}}
+		deps, _ := newWhereTestDeps(mg)
+
+		if err := NewWhereCommand(deps).Execute("123"); err == nil {
+			t.Error("Expected the lookup error to be returned")
+		}
+	})
+}
@@ -15,7 +15,8 @@ import (
 )
 
 var (
-	configList bool
+	configList     bool
+	configDefaults bool
 )
 
 const (
@@ -37,12 +38,31 @@ var configCmd = &cobra.Command{
 	Long: `View and edit gw configuration stored in ~/.gwrc file.
 Use arrow keys or j/k to navigate, Enter to toggle values, and q to quit.
 
-Use --list flag to view configuration in non-interactive mode.`,
+Use --list flag to view configuration in non-interactive mode, and --defaults
+to also mark the keys whose value differs from the default.`,
+	Example: `  # Edit the configuration interactively
+  gw config
+
+  # Show every key with its value and default
+  gw config --list`,
 	RunE: runConfig,
 }
 
+var configDoctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "Check the configuration for conflicting or ineffective settings",
+	Long: `Checks ~/.gwrc for settings that conflict with each other or have no
+effect as configured, such as auto_cd together with new_window_cmd, and
+explains each one. gw doctor runs the same checks along with its others.`,
+	Example: `  gw config doctor`,
+	Args:    cobra.NoArgs,
+	RunE:    runConfigDoctor,
+}
+
 func init() {
+	configCmd.AddCommand(configDoctorCmd)
 	configCmd.Flags().BoolVar(&configList, "list", false, "List configuration values (non-interactive)")
+	configCmd.Flags().BoolVar(&configDefaults, "defaults", false, "With the list, mark keys that differ from their default (implies --list)")
 	rootCmd.AddCommand(configCmd)
 }
 
@@ -53,16 +73,10 @@ func runConfig(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to load config: %w", err)
 	}
 
-	if configList {
+	if configList || configDefaults {
 		// Non-interactive mode: just list the configuration
 		fmt.Printf("Configuration file: %s\n\n", configPath)
-		for _, item := range cfg.GetConfigItems() {
-			status := statusFalse
-			if item.Value {
-				status = statusTrue
-			}
-			fmt.Printf("%-20s: %-5s  # %s (default: %v)\n", item.Key, status, item.Description, item.Default)
-		}
+		fmt.Print(formatConfigItems(cfg.GetConfigItems(), configDefaults))
 
 		fmt.Println()
 		for _, hookStatus := range resolveHookStatusesForDisplay(cfg, git.NewClient()) {
@@ -80,6 +94,44 @@ func runConfig(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// formatConfigItems renders the bool config items as aligned columns of key,
+// current value, default and description. With markDefaults, keys whose value
+// differs from the default are prefixed with "*" and a legend is appended.
+func formatConfigItems(items []config.Item, markDefaults bool) string {
+	keyWidth := len("KEY")
+	for _, item := range items {
+		keyWidth = max(keyWidth, len(item.Key))
+	}
+
+	var b strings.Builder
+	row := func(marker, key, value, def, description string) {
+		if markDefaults {
+			b.WriteString(marker + " ")
+		}
+		fmt.Fprintf(&b, "%-*s  %-5s  %-7s  %s\n", keyWidth, key, value, def, description)
+	}
+
+	row(" ", "KEY", "VALUE", "DEFAULT", "DESCRIPTION")
+	for _, item := range items {
+		marker := " "
+		if item.Value != item.Default {
+			marker = "*"
+		}
+		row(marker, item.Key, boolStatus(item.Value), boolStatus(item.Default), item.Description)
+	}
+	if markDefaults {
+		b.WriteString("\n* differs from the default\n")
+	}
+	return b.String()
+}
+
+func boolStatus(v bool) string {
+	if v {
+		return statusTrue
+	}
+	return statusFalse
+}
+
 type configItem struct {
 	title       string
 	description string
@@ -270,3 +322,7 @@ func (m *configModel) View() string {
 
 	return fmt.Sprintf("%s\n%s", m.list.View(), helpView)
 }
+
+func runConfigDoctor(cmd *cobra.Command, args []string) error {
+	return NewDoctorCommand(DefaultDependencies(), config.GetConfigPath()).ExecuteConfig()
+}
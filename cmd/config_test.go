@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/charmbracelet/bubbles/key"
@@ -38,9 +39,9 @@ func TestConfigCommand(t *testing.T) {
 		assert.Equal(t, loadedCfg, model.config)
 		assert.Equal(t, configPath, model.configPath)
 
-		// Verify the list has the correct items (now 5 with fetch_before_command)
+		// Verify the list has the correct items (now 8 with always_branch_from_remote_default)
 		items := model.list.Items()
-		assert.Len(t, items, 5)
+		assert.Len(t, items, 8)
 
 		// Check auto_cd item
 		item0 := items[0].(configItem)
@@ -129,7 +130,7 @@ func TestConfigIntegration(t *testing.T) {
 		}
 
 		items := cfg.GetConfigItems()
-		assert.Len(t, items, 5) // Now 5 items with fetch_before_command
+		assert.Len(t, items, 8) // Now 8 items with always_branch_from_remote_default
 
 		// Check auto_cd
 		assert.Equal(t, "auto_cd", items[0].Key)
@@ -242,6 +243,30 @@ func TestRunConfig(t *testing.T) {
 	})
 }
 
+func TestFormatConfigItems(t *testing.T) {
+	cfg := config.New()
+	assert.NoError(t, cfg.SetConfigItem("auto_cd", false)) // default: true
+	items := cfg.GetConfigItems()
+
+	t.Run("aligned columns", func(t *testing.T) {
+		output := formatConfigItems(items, false)
+		lines := strings.Split(strings.TrimRight(output, "\n"), "\n")
+		assert.Len(t, lines, len(items)+1)
+		assert.Equal(t, "KEY                                VALUE  DEFAULT  DESCRIPTION", lines[0])
+		assert.Contains(t, output, "auto_cd                            false  true     ")
+		assert.NotContains(t, output, "*")
+	})
+
+	t.Run("defaults marks changed keys only", func(t *testing.T) {
+		output := formatConfigItems(items, true)
+		assert.Contains(t, output, "* auto_cd ")
+		assert.Contains(t, output, "  update_iterm2_tab ")
+		assert.True(t, strings.HasSuffix(output, "\n\n* differs from the default\n"))
+		legend := strings.LastIndex(output, "\n\n")
+		assert.Equal(t, 1, strings.Count(output[:legend], "\n* "), "only auto_cd should be marked")
+	})
+}
+
 func TestKeyMapMethods(t *testing.T) {
 	k := &keyMap{
 		Up:     keys.Up,
@@ -0,0 +1,47 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var (
+	diffBase    string
+	diffStat    bool
+	diffNoPager bool
+)
+
+var diffCmd = &cobra.Command{
+	Use:   "diff [issue-number-or-branch]",
+	Short: "Show the changes in a worktree's branch since it left the base branch",
+	Long: `Shows the diff between the base branch and a worktree's branch, using
+git diff <base>...<branch> so only the changes made on the branch are shown.
+If no issue number is provided, an interactive selector will be shown.
+
+The base branch is looked up locally first, then as origin/<base>.
+Output is paged through git's pager ($GIT_PAGER, core.pager or $PAGER);
+use --no-pager to print it directly.`,
+	Example: `  # What the current worktree changed since it left main
+  gw diff
+
+  gw diff 123 --stat`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runDiff,
+}
+
+func init() {
+	rootCmd.AddCommand(diffCmd)
+	diffCmd.Flags().StringVar(&diffBase, "base", defaultBaseBranch, "Base branch to compare against")
+	diffCmd.Flags().BoolVar(&diffStat, "stat", false, "Show a diffstat summary instead of the full diff")
+	diffCmd.Flags().BoolVar(&diffNoPager, "no-pager", false, "Print the diff without a pager")
+}
+
+func runDiff(cmd *cobra.Command, args []string) error {
+	var issueNumber string
+	if len(args) > 0 {
+		issueNumber = args[0]
+	}
+
+	deps := DefaultDependencies()
+	diffCmd := NewDiffCommand(deps, diffBase, diffStat, diffNoPager)
+	return diffCmd.Execute(issueNumber)
+}
@@ -0,0 +1,53 @@
+package cmd
+
+import (
+	"github.com/sotarok/gw/internal/config"
+	"github.com/spf13/cobra"
+)
+
+var (
+	doctorFixProblems bool
+	doctorYes         bool
+)
+
+var doctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "Check that gw's environment is set up correctly",
+	Long: `Checks the environment gw runs in and reports anything that keeps a gw
+feature from working. It shows the installed git version and which gw
+features need a newer one.
+
+It also checks for a config file and for settings in it that conflict or
+have no effect (see gw config doctor), for shell integration in your shell's
+rc file, and, inside a repository, for worktrees git has lost track of.
+
+Use --fix to repair what it finds: create a default config file, add shell
+integration, and re-link (git worktree repair) or forget (git worktree prune)
+broken worktrees. Each fix is confirmed first, unless --yes is given.
+
+Exits with an error when a check fails; warnings alone do not.`,
+	Example: `  # Check the environment
+  gw doctor
+
+  # Repair what it finds, asking before each fix
+  gw doctor --fix
+
+  # Repair everything without asking
+  gw doctor --fix --yes`,
+	Args: cobra.NoArgs,
+	RunE: runDoctor,
+}
+
+func init() {
+	rootCmd.AddCommand(doctorCmd)
+	doctorCmd.Flags().BoolVar(&doctorFixProblems, "fix", false, "Offer to repair each problem found")
+	doctorCmd.Flags().BoolVarP(&doctorYes, "yes", "y", false, "With --fix, apply every fix without asking")
+}
+
+func runDoctor(cmd *cobra.Command, args []string) error {
+	deps := DefaultDependencies()
+	doctorCmd := NewDoctorCommand(deps, config.GetConfigPath())
+	doctorCmd.fix = doctorFixProblems
+	doctorCmd.yes = doctorYes
+	return doctorCmd.Execute()
+}
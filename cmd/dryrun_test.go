@@ -0,0 +1,181 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/sotarok/gw/internal/config"
+	"github.com/sotarok/gw/internal/git"
+)
+
+// newDryRunTestRepo creates a repository with one commit on main, changes into
+// it for the rest of the test and returns a helper that runs git there.
+func newDryRunTestRepo(t *testing.T) (repo string, runGit func(args ...string) string) {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	originalDir, _ := os.Getwd()
+	t.Cleanup(func() { _ = os.Chdir(originalDir) })
+
+	repo = filepath.Join(t.TempDir(), "repo")
+	if err := os.MkdirAll(repo, 0755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	runGit = func(args ...string) string {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = repo
+		out, err := cmd.CombinedOutput()
+		if err != nil {
+			t.Fatalf("git %v failed: %v\n%s", args, err, out)
+		}
+		return strings.TrimSpace(string(out))
+	}
+	runGit("init", "-b", "main")
+	runGit("config", "user.email", "test@example.com")
+	runGit("config", "user.name", "Test User")
+	runGit("commit", "--allow-empty", "-m", "initial")
+	if err := os.Chdir(repo); err != nil {
+		t.Fatalf("chdir: %v", err)
+	}
+	return repo, runGit
+}
+
+// newDryRunDeps returns dependencies around a real git client in dry-run mode
+// and sets the --dry-run flag for the rest of the test.
+func newDryRunDeps(t *testing.T, cfg *config.Config) (*Dependencies, *bytes.Buffer) {
+	t.Helper()
+	dryRun = true
+	t.Cleanup(func() { dryRun = false })
+	stdout := &bytes.Buffer{}
+	client := git.NewClient()
+	client.SetDryRun(stdout)
+	return &Dependencies{
+		Git:    client,
+		UI:     &mockUI{},
+		Detect: &mockDetect{},
+		Config: cfg,
+		Stdout: stdout,
+		Stderr: &bytes.Buffer{},
+	}, stdout
+}
+
+func TestStartCommand_Execute_DryRun(t *testing.T) {
+	repo, runGit := newDryRunTestRepo(t)
+	before := runGit("worktree", "list", "--porcelain")
+
+	deps, stdout := newDryRunDeps(t, &config.Config{FetchBeforeCommand: true})
+	if err := NewStartCommand(deps, false, false, false).Execute("123", "main"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if after := runGit("worktree", "list", "--porcelain"); after != before {
+		t.Errorf("Expected no new worktree, got:\n%s", after)
+	}
+	if branches := runGit("branch", "--list", "123/impl"); branches != "" {
+		t.Errorf("Expected branch 123/impl not to be created, got %q", branches)
+	}
+	if _, err := os.Stat(filepath.Join(repo, "..", "repo-123")); !os.IsNotExist(err) {
+		t.Errorf("Expected the worktree directory not to be created, stat error: %v", err)
+	}
+	for _, want := range []string{"[dry-run] git fetch --all --prune", "[dry-run] git worktree add"} {
+		if !strings.Contains(stdout.String(), want) {
+			t.Errorf("Expected %q in the output, got:\n%s", want, stdout.String())
+		}
+	}
+}
+
+func TestEndCommand_Execute_DryRun(t *testing.T) {
+	repo, runGit := newDryRunTestRepo(t)
+	worktreePath := filepath.Join(repo, "..", "repo-123")
+	runGit("worktree", "add", worktreePath, "-b", "123/impl")
+	before := runGit("worktree", "list", "--porcelain")
+
+	deps, stdout := newDryRunDeps(t, &config.Config{AutoRemoveBranch: true})
+	if err := NewEndCommand(deps, true, true, false).Execute("123"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if after := runGit("worktree", "list", "--porcelain"); after != before {
+		t.Errorf("Expected the worktree to be kept, got:\n%s", after)
+	}
+	if _, err := os.Stat(worktreePath); err != nil {
+		t.Errorf("Expected the worktree directory to be kept: %v", err)
+	}
+	if branches := runGit("branch", "--list", "123/impl"); branches == "" {
+		t.Error("Expected branch 123/impl to be kept")
+	}
+	for _, want := range []string{"[dry-run] git worktree remove", "[dry-run] git branch -d 123/impl"} {
+		if !strings.Contains(stdout.String(), want) {
+			t.Errorf("Expected %q in the output, got:\n%s", want, stdout.String())
+		}
+	}
+}
+
+func TestStartCommand_Execute_DryRunSkipsSetup(t *testing.T) {
+	repo, _ := newDryRunTestRepo(t)
+	t.Setenv("HOME", t.TempDir())
+	if err := os.WriteFile(filepath.Join(repo, ".env"), []byte("SECRET=1\n"), 0644); err != nil {
+		t.Fatalf("write .env: %v", err)
+	}
+	hookMarker := filepath.Join(t.TempDir(), "post-start-ran")
+	cdFile := filepath.Join(t.TempDir(), "cd")
+	t.Setenv(envCDFile, cdFile)
+
+	copyEnvs := true
+	deps, stdout := newDryRunDeps(t, &config.Config{
+		AutoCD:        true,
+		CopyEnvs:      &copyEnvs,
+		PostStartHook: "touch " + hookMarker,
+	})
+	setupRan := false
+	deps.Detect = &mockDetect{RunSetupFn: func(string) error {
+		setupRan = true
+		return nil
+	}}
+	if err := NewStartCommand(deps, false, true, true).Execute("123", "main"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if _, err := os.Stat(filepath.Join(repo, "..", "repo-123")); !os.IsNotExist(err) {
+		t.Errorf("Expected the worktree directory not to be created, stat error: %v", err)
+	}
+	if _, err := os.Stat(hookMarker); !os.IsNotExist(err) {
+		t.Errorf("Expected post_start_hook not to run, stat error: %v", err)
+	}
+	if setupRan {
+		t.Error("Expected package-manager setup not to run")
+	}
+	if _, err := os.Stat(cdFile); !os.IsNotExist(err) {
+		t.Errorf("Expected $%s not to be written, stat error: %v", envCDFile, err)
+	}
+	for _, want := range []string{"[dry-run]   copy untracked .env files", "[dry-run]   run post_start_hook: touch " + hookMarker} {
+		if !strings.Contains(stdout.String(), want) {
+			t.Errorf("Expected %q in the output, got:\n%s", want, stdout.String())
+		}
+	}
+}
+
+func TestEndCommand_Execute_DryRunSkipsPreEndHook(t *testing.T) {
+	repo, runGit := newDryRunTestRepo(t)
+	worktreePath := filepath.Join(repo, "..", "repo-123")
+	runGit("worktree", "add", worktreePath, "-b", "123/impl")
+	hookMarker := filepath.Join(t.TempDir(), "pre-end-ran")
+
+	deps, stdout := newDryRunDeps(t, &config.Config{PreEndHook: "touch " + hookMarker})
+	if err := NewEndCommand(deps, true, true, false).Execute("123"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if _, err := os.Stat(hookMarker); !os.IsNotExist(err) {
+		t.Errorf("Expected pre_end_hook not to run, stat error: %v", err)
+	}
+	if want := "[dry-run] run pre_end_hook in"; !strings.Contains(stdout.String(), want) {
+		t.Errorf("Expected %q in the output, got:\n%s", want, stdout.String())
+	}
+}
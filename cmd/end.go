@@ -1,6 +1,9 @@
 package cmd
 
 import (
+	"fmt"
+
+	"github.com/sotarok/gw/internal/stats"
 	"github.com/spf13/cobra"
 )
 
@@ -8,14 +11,49 @@ var (
 	forceEnd          bool
 	endNoFetch        bool
 	endNoProjectHooks bool
+	endDeleteRemote   bool
+	endMerge          bool
+	endNoFF           bool
+	endSquash         bool
+	endCDMain         bool
+	endForceDelete    bool
+	endArchive        string
 )
 
 var endCmd = &cobra.Command{
 	Use:   "end [issue-number]",
 	Short: "Remove a worktree for the specified issue",
 	Long: `Removes a git worktree for the specified issue number.
-If no issue number is provided, an interactive selector will be shown.
-The command will check for uncommitted changes and unpushed commits before removing.`,
+If no issue number is provided and the current branch is an issue branch
+(e.g. 123/impl), you are offered to end the current worktree; otherwise an
+interactive selector will be shown.
+The command will check for uncommitted changes and unpushed commits before removing.
+
+With --merge, the branch is first merged into main in the main worktree
+(fast-forward when possible, always a merge commit with --no-ff, or a single
+squashed commit with --squash), then the worktree and the branch are
+removed. A merge that fails, for example on conflicts, is aborted and the
+worktree is kept.
+
+With --archive <dir>, the commits the branch has on top of main are first
+saved as git format-patch files in <dir>/<branch>-<date>, so the work can be
+restored later with git am.
+
+With --cd-main, the main worktree's path is printed as the last line of output
+so you can get out of the removed worktree without shell integration:
+  cd "$(gw end 123 --cd-main | tail -n 1)"
+With shell integration and auto_cd enabled, the shell changes to it instead.`,
+	Example: `  # Remove the worktree for issue 123 after the safety checks
+  gw end 123
+
+  # Remove the current worktree and go back to the main one
+  gw end --cd-main
+
+  # Keep the branch's commits as patches, then remove the worktree and branch
+  gw end 123 --archive ~/gw-archive --force-delete-branch
+
+  # Merge the branch into main, then remove its worktree
+  gw end 123 --merge --squash`,
 	Args: cobra.MaximumNArgs(1),
 	RunE: runEnd,
 }
@@ -24,7 +62,15 @@ func init() {
 	rootCmd.AddCommand(endCmd)
 	endCmd.Flags().BoolVarP(&forceEnd, "force", "f", false, "Force removal without safety checks")
 	endCmd.Flags().BoolVar(&endNoFetch, "no-fetch", false, "Skip git fetch before running the command")
+	endCmd.Flags().BoolVar(&endDeleteRemote, "delete-remote", false, "Also delete the worktree's branch on origin")
+	endCmd.Flags().BoolVar(&endMerge, "merge", false, "Merge the branch into main in the main worktree before removing it")
+	endCmd.Flags().BoolVar(&endNoFF, "no-ff", false, "With --merge, always create a merge commit")
+	endCmd.Flags().BoolVar(&endSquash, "squash", false, "With --merge, commit the branch's changes as a single squashed commit")
+	endCmd.MarkFlagsMutuallyExclusive("no-ff", "squash")
 	endCmd.Flags().BoolVar(&endNoProjectHooks, "no-project-hooks", false, "Skip project-local .gwrc hook overrides for this run")
+	endCmd.Flags().BoolVar(&endForceDelete, "force-delete-branch", false, "Delete the branch even if it is not merged (git branch -D)")
+	endCmd.Flags().StringVar(&endArchive, "archive", "", "Save the branch's commits as patch files under this directory before removing")
+	endCmd.Flags().BoolVar(&endCDMain, "cd-main", false, "Print the main worktree's path last, or change to it with shell integration")
 }
 
 func runEnd(cmd *cobra.Command, args []string) error {
@@ -33,8 +79,26 @@ func runEnd(cmd *cobra.Command, args []string) error {
 		issueNumber = args[0]
 	}
 
+	if endNoFF && !endMerge {
+		return fmt.Errorf("--no-ff requires --merge")
+	}
+	if endSquash && !endMerge {
+		return fmt.Errorf("--squash requires --merge")
+	}
+
 	// Use the new command structure
 	deps := DefaultDependencies()
 	endCmd := NewEndCommand(deps, forceEnd, endNoFetch, endNoProjectHooks)
-	return endCmd.Execute(issueNumber)
+	endCmd.deleteRemote = endDeleteRemote
+	endCmd.merge = endMerge
+	endCmd.noFF = endNoFF
+	endCmd.squash = endSquash
+	endCmd.cdMain = endCDMain
+	endCmd.forceDelete = endForceDelete
+	endCmd.archiveDir = endArchive
+	if err := endCmd.Execute(issueNumber); err != nil {
+		return err
+	}
+	recordUsage(deps, stats.CommandEnd)
+	return nil
 }
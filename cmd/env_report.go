@@ -0,0 +1,33 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var envReportJSON bool
+
+var envReportCmd = &cobra.Command{
+	Use:   "env-report",
+	Short: "List the untracked env files in every worktree",
+	Long: `Lists, for every worktree of the repository, the untracked environment files
+(.env, .env.local, ...) it contains. Use it to audit where copies of secrets
+ended up after env files were copied into new worktrees.
+
+Use --json for machine-readable output.`,
+	Example: `  gw env-report
+
+  gw env-report --json`,
+	Args: cobra.NoArgs,
+	RunE: runEnvReport,
+}
+
+func init() {
+	rootCmd.AddCommand(envReportCmd)
+	envReportCmd.Flags().BoolVar(&envReportJSON, "json", false, "Print the report as JSON")
+}
+
+func runEnvReport(cmd *cobra.Command, args []string) error {
+	deps := DefaultDependencies()
+	envReportCmd := NewEnvReportCommand(deps, envReportJSON)
+	return envReportCmd.Execute()
+}
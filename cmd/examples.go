@@ -0,0 +1,26 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var examplesCmd = &cobra.Command{
+	Use:   "examples",
+	Short: "Show a cheat sheet of common gw workflows",
+	Long: `Prints the examples of every gw command, grouped by workflow: starting work,
+switching between worktrees, finishing work, maintaining worktrees and
+setting gw up. Each command's own examples are also shown by gw <command> --help.`,
+	Example: `  gw examples | less`,
+	Args:    cobra.NoArgs,
+	RunE:    runExamples,
+}
+
+func init() {
+	rootCmd.AddCommand(examplesCmd)
+}
+
+func runExamples(cmd *cobra.Command, args []string) error {
+	deps := DefaultDependencies()
+	examplesCmd := NewExamplesCommand(deps, rootCmd.Commands())
+	return examplesCmd.Execute()
+}
@@ -0,0 +1,51 @@
+package cmd
+
+import (
+	"errors"
+
+	"github.com/sotarok/gw/internal/git"
+	"github.com/sotarok/gw/internal/ui"
+)
+
+// Exit codes reported by gw, so scripts can tell "nothing to do" or "the user
+// said no" apart from real failures.
+const (
+	ExitOK               = 0   // success, including "nothing to do"
+	ExitError            = 1   // any other error
+	ExitNotGitRepository = 2   // not inside a git repository
+	ExitWorktreeNotFound = 3   // no worktree matches the given issue or branch
+	ExitAborted          = 4   // the user declined a confirmation or canceled a selector
+	ExitWorktreeExists   = 5   // gw start found a worktree for the issue already
+	ExitBranchNotFound   = 6   // the branch exists neither locally nor on the remote
+	ExitInterrupted      = 130 // gw start was interrupted while setting up a worktree
+)
+
+// errAborted is returned when the user declines a confirmation prompt. The
+// command has already said "Aborted.", so Execute does not print it again.
+var errAborted = errors.New("aborted")
+
+// errInterrupted is returned when gw start was interrupted while setting up a
+// worktree, after rolling it back.
+var errInterrupted = errors.New("interrupted")
+
+// ExitCode maps an error returned by Execute to the process exit code.
+func ExitCode(err error) int {
+	switch {
+	case err == nil:
+		return ExitOK
+	case errors.Is(err, git.ErrNotGitRepository):
+		return ExitNotGitRepository
+	case errors.Is(err, git.ErrWorktreeNotFound):
+		return ExitWorktreeNotFound
+	case errors.Is(err, errAborted), errors.Is(err, ui.ErrCanceled):
+		return ExitAborted
+	case errors.Is(err, git.ErrWorktreeExists):
+		return ExitWorktreeExists
+	case errors.Is(err, git.ErrBranchNotFound):
+		return ExitBranchNotFound
+	case errors.Is(err, errInterrupted):
+		return ExitInterrupted
+	default:
+		return ExitError
+	}
+}
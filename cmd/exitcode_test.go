@@ -0,0 +1,139 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/sotarok/gw/internal/config"
+	"github.com/sotarok/gw/internal/git"
+	"github.com/sotarok/gw/internal/ui"
+)
+
+func TestExitCode(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{name: "success", err: nil, want: ExitOK},
+		{name: "generic error", err: fmt.Errorf("boom"), want: ExitError},
+		{name: "not a git repository", err: git.ErrNotGitRepository, want: ExitNotGitRepository},
+		{name: "wrapped not a git repository", err: fmt.Errorf("start: %w", git.ErrNotGitRepository), want: ExitNotGitRepository},
+		{name: "worktree not found", err: &git.WorktreeNotFoundError{Identifier: "123"}, want: ExitWorktreeNotFound},
+		{name: "user declined", err: errAborted, want: ExitAborted},
+		{name: "selector canceled", err: fmt.Errorf("no worktree selected: %w", ui.ErrCanceled), want: ExitAborted},
+		{name: "worktree exists", err: &git.WorktreeExistsError{Issue: "123", Path: "/repo-123"}, want: ExitWorktreeExists},
+		{name: "wrapped branch not found", err: fmt.Errorf("checkout: %w", &git.BranchNotFoundError{Branch: "nope"}), want: ExitBranchNotFound},
+		{name: "interrupted", err: fmt.Errorf("%w: git worktree add failed", errInterrupted), want: ExitInterrupted},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ExitCode(tt.err); got != tt.want {
+				t.Errorf("ExitCode(%v) = %d, want %d", tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+// TestExitCode_Commands checks the exit code each command's error maps to.
+func TestExitCode_Commands(t *testing.T) {
+	newDeps := func(mg *mockGit, mu *mockUI) *Dependencies {
+		return &Dependencies{
+			Git:    mg,
+			UI:     mu,
+			Detect: &mockDetect{},
+			Config: &config.Config{},
+			Stdout: &bytes.Buffer{},
+			Stderr: &bytes.Buffer{},
+		}
+	}
+
+	t.Run("end on a missing issue exits 3", func(t *testing.T) {
+		mg := &mockGit{GetWorktreeForIssueFn: func(issue string) (*git.WorktreeInfo, error) {
+			return nil, &git.WorktreeNotFoundError{Identifier: issue}
+		}}
+
+		err := NewEndCommand(newDeps(mg, &mockUI{}), false, true, true).Execute("999")
+		if got := ExitCode(err); got != ExitWorktreeNotFound {
+			t.Errorf("ExitCode = %d, want %d (err: %v)", got, ExitWorktreeNotFound, err)
+		}
+	})
+
+	t.Run("end declined at the safety prompt exits 4", func(t *testing.T) {
+		worktreeDir := t.TempDir()
+		mg := &mockGit{
+			GetWorktreeForIssueFn: func(string) (*git.WorktreeInfo, error) {
+				return &git.WorktreeInfo{Path: worktreeDir, Branch: testBranch123}, nil
+			},
+			HasUncommittedChangesFn: func() (bool, error) { return true, nil },
+		}
+
+		err := NewEndCommand(newDeps(mg, &mockUI{confirmResult: false}), false, true, true).Execute("123")
+		if got := ExitCode(err); got != ExitAborted {
+			t.Errorf("ExitCode = %d, want %d (err: %v)", got, ExitAborted, err)
+		}
+	})
+
+	t.Run("info on a missing issue exits 3", func(t *testing.T) {
+		mg := &mockGit{GetWorktreeForIssueFn: func(string) (*git.WorktreeInfo, error) { return nil, nil }}
+
+		err := NewInfoCommand(newDeps(mg, &mockUI{}), defaultBaseBranch, false).Execute("999")
+		if got := ExitCode(err); got != ExitWorktreeNotFound {
+			t.Errorf("ExitCode = %d, want %d (err: %v)", got, ExitWorktreeNotFound, err)
+		}
+	})
+
+	t.Run("start outside a git repository exits 2", func(t *testing.T) {
+		originalDir, _ := os.Getwd()
+		defer os.Chdir(originalDir)
+		os.Chdir(t.TempDir())
+
+		err := NewStartCommand(newDeps(&mockGit{isGitRepo: false}, &mockUI{}), false, true, true).Execute("123", "main")
+		if got := ExitCode(err); got != ExitNotGitRepository {
+			t.Errorf("ExitCode = %d, want %d (err: %v)", got, ExitNotGitRepository, err)
+		}
+	})
+
+	t.Run("start with an existing worktree exits 5", func(t *testing.T) {
+		originalDir, _ := os.Getwd()
+		defer os.Chdir(originalDir)
+		os.Chdir(t.TempDir())
+
+		err := NewStartCommand(newDeps(&mockGit{isGitRepo: true, worktreeExists: true}, &mockUI{}), false, true, true).Execute("123", "main")
+		if !errors.Is(err, git.ErrWorktreeExists) {
+			t.Errorf("expected git.ErrWorktreeExists, got: %v", err)
+		}
+		if got := ExitCode(err); got != ExitWorktreeExists {
+			t.Errorf("ExitCode = %d, want %d (err: %v)", got, ExitWorktreeExists, err)
+		}
+	})
+
+	t.Run("checkout of a missing branch exits 6", func(t *testing.T) {
+		originalDir, _ := os.Getwd()
+		defer os.Chdir(originalDir)
+		os.Chdir(t.TempDir())
+
+		err := NewCheckoutCommand(newDeps(&mockGit{isGitRepo: true}, &mockUI{}), false, true, true).Execute("no-such-branch")
+		if !errors.Is(err, git.ErrBranchNotFound) {
+			t.Errorf("expected git.ErrBranchNotFound, got: %v", err)
+		}
+		if got := ExitCode(err); got != ExitBranchNotFound {
+			t.Errorf("ExitCode = %d, want %d (err: %v)", got, ExitBranchNotFound, err)
+		}
+	})
+
+	t.Run("clean with nothing to remove exits 0", func(t *testing.T) {
+		mg := &mockGit{ListWorktreesFn: func() ([]git.WorktreeInfo, error) {
+			return []git.WorktreeInfo{{Path: "/repo", Branch: "main"}}, nil
+		}}
+
+		err := NewCleanCommand(newDeps(mg, &mockUI{}), false, false, true, true).Execute()
+		if got := ExitCode(err); got != ExitOK {
+			t.Errorf("ExitCode = %d, want %d (err: %v)", got, ExitOK, err)
+		}
+	})
+}
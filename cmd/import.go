@@ -0,0 +1,37 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var importCmd = &cobra.Command{
+	Use:   "import <path>",
+	Short: "Register an existing checkout as a worktree of this repository",
+	Long: `Registers a directory checked out outside gw as a worktree of the current
+repository, so gw list, gw end and friends manage it.
+
+A worktree of this repository that git lost track of, for example after
+being moved by hand, is repaired as gw reattach does. A separate clone of
+this repository becomes a linked worktree on its current branch: the branch
+is fetched into this repository, and its files, including uncommitted
+changes, stay in place. The clone's own .git directory is moved next to it
+as <path>.git-before-import rather than deleted, since it may hold stashes
+and branches this repository does not have.
+
+A checkout of another repository, one on a detached HEAD, or one whose
+branch is already checked out in another worktree is refused.`,
+	Example: `  # Turn a separate clone into a worktree of this repository
+  gw import ../myapp-experiment`,
+	Args: cobra.ExactArgs(1),
+	RunE: runImport,
+}
+
+func init() {
+	rootCmd.AddCommand(importCmd)
+}
+
+func runImport(cmd *cobra.Command, args []string) error {
+	deps := DefaultDependencies()
+	importCmd := NewImportCommand(deps)
+	return importCmd.Execute(args[0])
+}
@@ -0,0 +1,44 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var (
+	infoBase string
+	infoJSON bool
+)
+
+var infoCmd = &cobra.Command{
+	Use:   "info [issue-number-or-branch]",
+	Short: "Show detailed information about a worktree",
+	Long: `Shows everything gw knows about a single worktree: path, branch, base
+branch, upstream tracking status (commits ahead/behind), number of uncommitted
+files, last commit, and whether the worktree is locked or merged.
+If no issue number is provided, an interactive selector will be shown.
+
+Use --json for machine-readable output.`,
+	Example: `  # The worktree you are in
+  gw info
+
+  gw info 123 --json`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runInfo,
+}
+
+func init() {
+	rootCmd.AddCommand(infoCmd)
+	infoCmd.Flags().StringVar(&infoBase, "base", defaultBaseBranch, "Base branch used for the merged check")
+	infoCmd.Flags().BoolVar(&infoJSON, "json", false, "Print the information as JSON")
+}
+
+func runInfo(cmd *cobra.Command, args []string) error {
+	var issueNumber string
+	if len(args) > 0 {
+		issueNumber = args[0]
+	}
+
+	deps := DefaultDependencies()
+	infoCmd := NewInfoCommand(deps, infoBase, infoJSON)
+	return infoCmd.Execute(issueNumber)
+}
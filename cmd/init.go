@@ -12,20 +12,32 @@ import (
 	"github.com/spf13/cobra"
 )
 
+var initShellOnly bool
+
 var initCmd = &cobra.Command{
 	Use:   "init",
 	Short: "Initialize gw configuration",
-	Long:  `Initialize gw configuration by creating a .gwrc file in your home directory.`,
-	RunE:  runInit,
+	Long: `Initialize gw configuration by creating a .gwrc file in your home directory.
+
+Use --shell-only to leave the configuration untouched and only add (or check)
+the shell integration line in your shell's rc file.`,
+	Example: `  # Create ~/.gwrc interactively
+  gw init
+
+  # Only set up shell integration
+  gw init --shell-only`,
+	RunE: runInit,
 }
 
 func init() {
 	rootCmd.AddCommand(initCmd)
+	initCmd.Flags().BoolVar(&initShellOnly, "shell-only", false, "Skip configuration prompts and only set up shell integration")
 }
 
 func runInit(cmd *cobra.Command, args []string) error {
 	configPath := config.GetConfigPath()
 	initCmd := NewInitCommand(os.Stdin, os.Stdout, os.Stderr, configPath)
+	initCmd.shellOnly = initShellOnly
 	return initCmd.Execute()
 }
 
@@ -36,6 +48,7 @@ type InitCommand struct {
 	stderr     io.Writer
 	configPath string
 	rcPath     string // For testing shell integration
+	shellOnly  bool   // --shell-only: skip the config prompts, only offer shell integration
 }
 
 // NewInitCommand creates a new init command handler
@@ -68,15 +81,29 @@ const (
 	shellFish = "fish"
 
 	showScriptCommand = "gw shell-integration --show-script"
+
+	// shellBlockStart and shellBlockEnd delimit the shell integration that gw
+	// writes to rc files, so it can later be found, replaced or removed.
+	shellBlockStart = "# >>> gw integration >>>"
+	shellBlockEnd   = "# <<< gw integration <<<"
+	// shellBlockVersion is bumped whenever the block contents change, so
+	// existing installs can tell they are out of date.
+	shellBlockVersion = 1
 )
 
 // Execute runs the init command
 func (c *InitCommand) Execute() error {
+	reader := bufio.NewReader(c.stdin)
+
+	// --shell-only never reads or writes the config file, so an existing
+	// ~/.gwrc does not trigger the overwrite prompt.
+	if c.shellOnly {
+		return c.offerShellIntegration(reader)
+	}
+
 	fmt.Fprintln(c.stdout, "Welcome to gw configuration!")
 	fmt.Fprintln(c.stdout)
 
-	reader := bufio.NewReader(c.stdin)
-
 	// Check if config already exists
 	if _, err := os.Stat(c.configPath); err == nil {
 		fmt.Fprintf(c.stdout, "Configuration file already exists at %s\n", c.configPath)
@@ -226,7 +253,14 @@ func (c *InitCommand) offerShellIntegration(reader *bufio.Reader) error {
 		return c.showManualInstructions(shell)
 	}
 
-	// Check if eval command already exists
+	// A managed block can be refreshed in place
+	if content, err := os.ReadFile(rcPath); err == nil {
+		if _, _, found := findShellIntegrationBlock(string(content)); found {
+			return c.refreshShellIntegration(rcPath, shell)
+		}
+	}
+
+	// Check if an unmanaged eval command already exists
 	evalCommand := c.getEvalCommand(shell)
 	if c.hasShellIntegration(rcPath, evalCommand) {
 		return c.showUpdateInstructions(rcPath, shell)
@@ -355,10 +389,109 @@ func (c *InitCommand) showUpdateInstructions(rcPath, shell string) error {
 	fmt.Fprintln(c.stdout, "1. Remove the existing gw shell integration from your file")
 	fmt.Fprintln(c.stdout, "2. Add the following line:")
 	fmt.Fprintf(c.stdout, "   %s\n", c.getEvalCommand(shell))
+	fmt.Fprintln(c.stdout)
+	fmt.Fprintln(c.stdout, "Alternatively, remove it and run 'gw init --shell-only' to let gw manage it for you.")
 
 	return nil
 }
 
+// refreshShellIntegration rewrites an existing managed block and reports
+// whether anything changed.
+func (c *InitCommand) refreshShellIntegration(rcPath, shell string) error {
+	updated, err := c.updateShellIntegration(rcPath, shell)
+	if err != nil {
+		fmt.Fprintf(c.stderr, "%s Failed to update shell integration: %v\n", coloredWarning(), err)
+		return c.showManualInstructions(shell)
+	}
+
+	fmt.Fprintln(c.stdout)
+	if !updated {
+		fmt.Fprintf(c.stdout, "%s Shell integration is already up to date in %s\n", coloredSuccess(), rcPath)
+		return nil
+	}
+	fmt.Fprintf(c.stdout, "%s Shell integration updated in %s\n", coloredSuccess(), rcPath)
+	fmt.Fprintln(c.stdout, "Please restart your shell or run:")
+	fmt.Fprintf(c.stdout, "  source %s\n", rcPath)
+
+	return nil
+}
+
+// shellIntegrationBlock renders the managed block for shell, including the
+// trailing newline.
+func shellIntegrationBlock(shell string) string {
+	return fmt.Sprintf("%s\n# version: %d (managed by gw; edits inside this block will be overwritten)\n%s\n%s\n",
+		shellBlockStart, shellBlockVersion, shellIntegrationLine(shell), shellBlockEnd)
+}
+
+// shellIntegrationLine is the single line that loads the integration script.
+func shellIntegrationLine(shell string) string {
+	if shell == shellFish {
+		return fmt.Sprintf("%s --shell=%s | source", showScriptCommand, shell)
+	}
+	return fmt.Sprintf("eval \"$(%s --shell=%s)\"", showScriptCommand, shell)
+}
+
+// legacyShellIntegrationComment is the comment line gw wrote above the
+// integration line before the managed block existed.
+const legacyShellIntegrationComment = "# gw shell integration"
+
+// legacyShellIntegration is the exact snippet gw appended to rc files before
+// the managed block existed.
+func legacyShellIntegration(shell string) string {
+	return "\n" + legacyShellIntegrationComment + "\n" + shellIntegrationLine(shell) + "\n"
+}
+
+// removeLegacyShellIntegration drops every legacy snippet from content: the
+// comment line directly followed by the integration line for one of the
+// shells, together with the blank line gw put in front of them. A lone
+// integration line is left alone, as gw never wrote one.
+func removeLegacyShellIntegration(content string) string {
+	legacy := map[string]bool{}
+	for _, shell := range []string{shellBash, shellZsh, shellFish} {
+		legacy[shellIntegrationLine(shell)] = true
+	}
+
+	lines := strings.SplitAfter(content, "\n")
+	kept := make([]string, 0, len(lines))
+	for i := 0; i < len(lines); i++ {
+		if i+1 < len(lines) && strings.TrimSuffix(lines[i], "\n") == legacyShellIntegrationComment &&
+			legacy[strings.TrimSuffix(lines[i+1], "\n")] {
+			if n := len(kept); n > 0 && kept[n-1] == "\n" {
+				kept = kept[:n-1]
+			}
+			i++
+			continue
+		}
+		kept = append(kept, lines[i])
+	}
+	return strings.Join(kept, "")
+}
+
+// findShellIntegrationBlock returns the byte range of the managed block in
+// content, from the start of its opening marker line through the newline that
+// ends its closing marker line. A block without a closing marker is treated
+// as absent so that a hand-edited file is never truncated.
+func findShellIntegrationBlock(content string) (start, end int, found bool) {
+	start = strings.Index(content, shellBlockStart)
+	if start < 0 || (start > 0 && content[start-1] != '\n') {
+		return 0, 0, false
+	}
+
+	rel := strings.Index(content[start:], shellBlockEnd)
+	if rel < 0 {
+		return 0, 0, false
+	}
+	end = start + rel + len(shellBlockEnd)
+	if end < len(content) && content[end] == '\n' {
+		end++
+	}
+
+	return start, end, true
+}
+
+// addShellIntegration appends the managed block to rcPath. If the block is
+// already present it is updated in place instead, so calling this repeatedly
+// never duplicates the integration.
 func (c *InitCommand) addShellIntegration(rcPath, shell string) error {
 	// Read existing content
 	content, err := os.ReadFile(rcPath)
@@ -366,6 +499,11 @@ func (c *InitCommand) addShellIntegration(rcPath, shell string) error {
 		return fmt.Errorf("failed to read %s: %w", rcPath, err)
 	}
 
+	if _, _, found := findShellIntegrationBlock(string(content)); found {
+		_, err := c.updateShellIntegration(rcPath, shell)
+		return err
+	}
+
 	// Open file for appending
 	file, err := os.OpenFile(rcPath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, permShellRC)
 	if err != nil {
@@ -380,17 +518,73 @@ func (c *InitCommand) addShellIntegration(rcPath, shell string) error {
 		}
 	}
 
-	// Add shell integration comment and command
-	shellIntegration := "\n# gw shell integration\n"
-	if shell == shellFish {
-		shellIntegration += fmt.Sprintf("%s --shell=%s | source\n", showScriptCommand, shell)
-	} else {
-		shellIntegration += fmt.Sprintf("eval \"$(%s --shell=%s)\"\n", showScriptCommand, shell)
-	}
-
-	if _, err := file.WriteString(shellIntegration); err != nil {
+	if _, err := file.WriteString("\n" + shellIntegrationBlock(shell)); err != nil {
 		return fmt.Errorf("failed to write shell integration: %w", err)
 	}
 
 	return nil
 }
+
+// updateShellIntegration replaces the managed block in rcPath with the
+// current version for shell. It returns false without writing when there is
+// no block or the block is already current.
+func (c *InitCommand) updateShellIntegration(rcPath, shell string) (bool, error) {
+	content, err := os.ReadFile(rcPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to read %s: %w", rcPath, err)
+	}
+
+	text := string(content)
+	start, end, found := findShellIntegrationBlock(text)
+	if !found {
+		return false, nil
+	}
+
+	block := shellIntegrationBlock(shell)
+	if text[start:end] == block {
+		return false, nil
+	}
+
+	updated := text[:start] + block + text[end:]
+	if err := os.WriteFile(rcPath, []byte(updated), permShellRC); err != nil {
+		return false, fmt.Errorf("failed to write %s: %w", rcPath, err)
+	}
+
+	return true, nil
+}
+
+// removeShellIntegration deletes the managed block from rcPath, along with
+// the blank line addShellIntegration puts in front of it, and the snippet
+// older gw versions appended; lines the user wrote by hand are never touched.
+// It returns false when there was nothing to remove.
+func (c *InitCommand) removeShellIntegration(rcPath string) (bool, error) {
+	content, err := os.ReadFile(rcPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to read %s: %w", rcPath, err)
+	}
+
+	text := string(content)
+	updated := text
+	if start, end, found := findShellIntegrationBlock(text); found {
+		if strings.HasSuffix(text[:start], "\n\n") {
+			start--
+		}
+		updated = text[:start] + text[end:]
+	}
+	updated = removeLegacyShellIntegration(updated)
+	if updated == text {
+		return false, nil
+	}
+
+	if err := os.WriteFile(rcPath, []byte(updated), permShellRC); err != nil {
+		return false, fmt.Errorf("failed to write %s: %w", rcPath, err)
+	}
+
+	return true, nil
+}
@@ -3,6 +3,7 @@ package cmd
 import (
 	"bufio"
 	"bytes"
+	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
@@ -25,7 +26,7 @@ func TestInitCommand_Execute(t *testing.T) {
 		{
 			name: "user selects all true",
 			// Enable all options + shell integration
-			userInput: "y\ny\ny\ny\ny\ny\n",
+			userInput: "y\ny\ny\ny\ny\ny\ny\ny\ny\n",
 			checkConfig: func(t *testing.T, cfg *config.Config) {
 				if !cfg.AutoCD {
 					t.Error("Expected AutoCD to be true")
@@ -43,7 +44,7 @@ func TestInitCommand_Execute(t *testing.T) {
 		},
 		{
 			name:      "user selects all false",
-			userInput: "n\nn\nn\nn\nn\n", // Disable all (auto-cd, iterm2, auto-remove, copy-envs, fetch-before-command)
+			userInput: "n\nn\nn\nn\nn\nn\nn\nn\n", // Disable all (auto-cd, iterm2, auto-remove, copy-envs, fetch-before-command, delete-remote-branch, track-stats, always-branch-from-remote-default)
 			checkConfig: func(t *testing.T, cfg *config.Config) {
 				if cfg.AutoCD {
 					t.Error("Expected AutoCD to be false")
@@ -61,7 +62,7 @@ func TestInitCommand_Execute(t *testing.T) {
 		},
 		{
 			name:      "user uses defaults (press enter)",
-			userInput: "\n\n\n\n\ny\n", // Use defaults (true, false, false, false, true), enable shell integration
+			userInput: "\n\n\n\n\n\n\n\ny\n", // Use defaults (true, false, false, false, true, false, false, false), enable shell integration
 			checkConfig: func(t *testing.T, cfg *config.Config) {
 				if !cfg.AutoCD {
 					t.Error("Expected AutoCD to be true (default)")
@@ -77,7 +78,7 @@ func TestInitCommand_Execute(t *testing.T) {
 		},
 		{
 			name:      "mixed selections",
-			userInput: "n\ny\ny\nn\nn\n", // Disable auto-cd, enable iterm2, enable auto-remove, disable copy-envs, disable fetch-before-command
+			userInput: "n\ny\ny\nn\nn\nn\nn\nn\n", // Disable auto-cd, enable iterm2, enable auto-remove, disable copy-envs, disable fetch-before-command, disable delete-remote-branch, disable track-stats, disable always-branch-from-remote-default
 			checkConfig: func(t *testing.T, cfg *config.Config) {
 				if cfg.AutoCD {
 					t.Error("Expected AutoCD to be false")
@@ -155,7 +156,7 @@ func TestInitCommand_ExistingConfig(t *testing.T) {
 	}
 
 	// Setup mock stdin/stdout
-	stdin := strings.NewReader("y\n\n\n\n\ny\n") // Confirm overwrite, use defaults (true, false, false, false), enable shell integration
+	stdin := strings.NewReader("y\n\n\n\n\n\ny\n\n\n") // Confirm overwrite, use defaults (true, false, false, false, true), enable shell integration
 	stdout := &bytes.Buffer{}
 	stderr := &bytes.Buffer{}
 
@@ -429,8 +430,8 @@ func TestInitCommand_ShellIntegration(t *testing.T) {
 	}{
 		{
 			name: "user enables auto-cd and shell integration added automatically",
-			// auto-cd=y, iterm2=n, auto-remove=n, copy-envs=n, fetch=default, shell-int=y
-			userInput:      "y\nn\nn\nn\n\ny\n",
+			// auto-cd=y, iterm2=n, auto-remove=n, copy-envs=n, fetch=default, delete-remote=default, shell-int=y
+			userInput:      "y\nn\nn\nn\n\n\n\n\ny\n",
 			shellPath:      "/bin/bash",
 			expectRcUpdate: true, // Now writes to rc file
 			checkOutput: func(t *testing.T, output string) {
@@ -444,8 +445,8 @@ func TestInitCommand_ShellIntegration(t *testing.T) {
 		},
 		{
 			name: "user enables auto-cd but declines shell integration instructions",
-			// auto-cd=y, iterm2=n, auto-remove=n, copy-envs=n, fetch=default, shell-int=n
-			userInput:      "y\nn\nn\nn\n\nn\n",
+			// auto-cd=y, iterm2=n, auto-remove=n, copy-envs=n, fetch=default, delete-remote=default, shell-int=n
+			userInput:      "y\nn\nn\nn\n\n\n\n\nn\n",
 			shellPath:      "/bin/bash",
 			expectRcUpdate: false,
 			checkOutput: func(t *testing.T, output string) {
@@ -460,7 +461,7 @@ func TestInitCommand_ShellIntegration(t *testing.T) {
 		{
 			name: "user disables auto-cd, no shell integration prompt",
 			// Disable all options
-			userInput:      "n\nn\nn\nn\nn\n",
+			userInput:      "n\nn\nn\nn\nn\nn\nn\nn\n",
 			shellPath:      "/bin/bash",
 			expectRcUpdate: false,
 			checkOutput: func(t *testing.T, output string) {
@@ -471,8 +472,8 @@ func TestInitCommand_ShellIntegration(t *testing.T) {
 		},
 		{
 			name: "shell integration already exists - shows update instructions",
-			// auto-cd=y, iterm2=n, auto-remove=n, copy-envs=n, fetch=default, shell-int=y
-			userInput:      "y\nn\nn\nn\n\ny\n",
+			// auto-cd=y, iterm2=n, auto-remove=n, copy-envs=n, fetch=default, delete-remote=default, shell-int=y
+			userInput:      "y\nn\nn\nn\n\n\n\n\ny\n",
 			shellPath:      "/bin/bash",
 			expectRcUpdate: false, // Should not update because it already exists
 			existingRc:     true,
@@ -539,6 +540,89 @@ eval "$(gw shell-integration --show-script --shell=bash)"
 	}
 }
 
+func TestInitCommand_ShellOnly(t *testing.T) {
+	tests := []struct {
+		name         string
+		userInput    string
+		existingRc   bool
+		expectOutput string
+		expectRcLine bool
+	}{
+		{
+			name:         "adds missing shell integration",
+			userInput:    "y\n",
+			expectOutput: "✓ Shell integration added to",
+			expectRcLine: true,
+		},
+		{
+			name:         "shows update instructions when already present",
+			userInput:    "y\n",
+			existingRc:   true,
+			expectOutput: "⚠ Shell integration already exists",
+			expectRcLine: true,
+		},
+		{
+			name:         "user declines",
+			userInput:    "n\n",
+			expectOutput: "Shell integration setup skipped",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tempDir := t.TempDir()
+			configPath := filepath.Join(tempDir, ".gwrc")
+			rcPath := filepath.Join(tempDir, ".bashrc")
+
+			// An existing config must neither trigger the overwrite prompt nor change.
+			existingConfig := &config.Config{AutoCD: false, UpdateITerm2Tab: true}
+			if err := existingConfig.Save(configPath); err != nil {
+				t.Fatalf("Failed to create existing config: %v", err)
+			}
+			before, _ := os.ReadFile(configPath)
+
+			if tt.existingRc {
+				existing := "# Existing content\neval \"$(gw shell-integration --show-script --shell=bash)\"\n"
+				if err := os.WriteFile(rcPath, []byte(existing), 0644); err != nil {
+					t.Fatalf("Failed to create existing rc file: %v", err)
+				}
+			}
+
+			os.Setenv("SHELL", "/bin/bash")
+			defer os.Unsetenv("SHELL")
+
+			stdout := &bytes.Buffer{}
+			stderr := &bytes.Buffer{}
+			cmd := NewInitCommandWithShell(strings.NewReader(tt.userInput), stdout, stderr, configPath, rcPath)
+			cmd.shellOnly = true
+			if err := cmd.Execute(); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			output := stdout.String()
+			for _, unexpected := range []string{"Welcome to gw configuration!", "already exists at", "Enable "} {
+				if strings.Contains(output, unexpected) {
+					t.Errorf("Expected config prompts to be skipped, found %q in:\n%s", unexpected, output)
+				}
+			}
+			if !strings.Contains(output, tt.expectOutput) {
+				t.Errorf("Expected %q in output, got:\n%s", tt.expectOutput, output)
+			}
+
+			after, _ := os.ReadFile(configPath)
+			if !bytes.Equal(before, after) {
+				t.Errorf("Expected config file to be untouched, got:\n%s", after)
+			}
+
+			content, _ := os.ReadFile(rcPath)
+			hasLine := strings.Count(string(content), "gw shell-integration --show-script") == 1
+			if hasLine != tt.expectRcLine {
+				t.Errorf("Expected rc integration line present=%v (exactly once), rc content:\n%s", tt.expectRcLine, content)
+			}
+		})
+	}
+}
+
 // Additional init tests for uncovered paths
 
 func TestInitCommand_ExistingConfig_UserDeclinesOverwrite(t *testing.T) {
@@ -574,7 +658,7 @@ func TestInitCommand_PromptForConfigItem_InvalidInput(t *testing.T) {
 	configPath := filepath.Join(tempDir, ".gwrc")
 
 	// Provide invalid input for first config item, then defaults for the rest
-	stdin := strings.NewReader("invalid\nn\nn\nn\nn\n")
+	stdin := strings.NewReader("invalid\nn\nn\nn\nn\nn\n\n\n")
 	stdout := &bytes.Buffer{}
 	stderr := &bytes.Buffer{}
 
@@ -899,8 +983,8 @@ func TestInitCommand_Execute_SaveError(t *testing.T) {
 	os.Chmod(readOnlyDir, 0444)
 	defer os.Chmod(readOnlyDir, 0755)
 
-	// Provide all inputs (5 config items)
-	stdin := strings.NewReader("n\nn\nn\nn\nn\n")
+	// Provide all inputs (8 config items)
+	stdin := strings.NewReader("n\nn\nn\nn\nn\nn\nn\nn\n")
 	stdout := &bytes.Buffer{}
 	stderr := &bytes.Buffer{}
 
@@ -934,3 +1018,155 @@ func TestFormatKeyForPrompt(t *testing.T) {
 		})
 	}
 }
+
+func TestInitCommand_ShellIntegrationBlock(t *testing.T) {
+	const before = "# my aliases\nalias ll='ls -l'\n"
+	const after = "export PATH=\"$HOME/bin:$PATH\"\n"
+
+	t.Run("add writes a delimited, versioned block", func(t *testing.T) {
+		rcPath := filepath.Join(t.TempDir(), ".zshrc")
+		os.WriteFile(rcPath, []byte(before), 0644)
+
+		cmd := &InitCommand{}
+		if err := cmd.addShellIntegration(rcPath, "zsh"); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		// A second add must not duplicate the block
+		if err := cmd.addShellIntegration(rcPath, "zsh"); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		content, _ := os.ReadFile(rcPath)
+		want := before + "\n" + shellIntegrationBlock("zsh")
+		if string(content) != want {
+			t.Errorf("rc content = %q, want %q", content, want)
+		}
+		if n := strings.Count(string(content), shellBlockStart); n != 1 {
+			t.Errorf("Expected exactly one block, found %d", n)
+		}
+	})
+
+	t.Run("update replaces an outdated block in place", func(t *testing.T) {
+		rcPath := filepath.Join(t.TempDir(), ".bashrc")
+		oldBlock := shellBlockStart + "\n# version: 0\neval \"$(gw shell-integration --show-script)\"\n" + shellBlockEnd + "\n"
+		os.WriteFile(rcPath, []byte(before+"\n"+oldBlock+after), 0644)
+
+		cmd := &InitCommand{}
+		updated, err := cmd.updateShellIntegration(rcPath, "bash")
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if !updated {
+			t.Error("Expected outdated block to be updated")
+		}
+
+		content, _ := os.ReadFile(rcPath)
+		want := before + "\n" + shellIntegrationBlock("bash") + after
+		if string(content) != want {
+			t.Errorf("rc content = %q, want %q", content, want)
+		}
+		if !strings.Contains(string(content), fmt.Sprintf("# version: %d", shellBlockVersion)) {
+			t.Errorf("Expected current version in block, got: %s", content)
+		}
+
+		// Updating again is a no-op
+		updated, err = cmd.updateShellIntegration(rcPath, "bash")
+		if err != nil || updated {
+			t.Errorf("Expected no-op on current block, got updated=%v err=%v", updated, err)
+		}
+	})
+
+	t.Run("update without a block does nothing", func(t *testing.T) {
+		rcPath := filepath.Join(t.TempDir(), ".bashrc")
+		os.WriteFile(rcPath, []byte(before), 0644)
+
+		cmd := &InitCommand{}
+		updated, err := cmd.updateShellIntegration(rcPath, "bash")
+		if err != nil || updated {
+			t.Errorf("Expected no update, got updated=%v err=%v", updated, err)
+		}
+		content, _ := os.ReadFile(rcPath)
+		if string(content) != before {
+			t.Errorf("rc file should be unchanged, got %q", content)
+		}
+	})
+
+	t.Run("remove strips only the block", func(t *testing.T) {
+		rcPath := filepath.Join(t.TempDir(), ".bashrc")
+		os.WriteFile(rcPath, []byte(before), 0644)
+
+		cmd := &InitCommand{}
+		if err := cmd.addShellIntegration(rcPath, "bash"); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		f, _ := os.OpenFile(rcPath, os.O_APPEND|os.O_WRONLY, 0644)
+		f.WriteString(after)
+		f.Close()
+
+		removed, err := cmd.removeShellIntegration(rcPath)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if !removed {
+			t.Error("Expected block to be removed")
+		}
+
+		content, _ := os.ReadFile(rcPath)
+		if string(content) != before+after {
+			t.Errorf("rc content = %q, want %q", content, before+after)
+		}
+
+		removed, err = cmd.removeShellIntegration(rcPath)
+		if err != nil || removed {
+			t.Errorf("Expected nothing to remove, got removed=%v err=%v", removed, err)
+		}
+	})
+
+	t.Run("unterminated block is left alone", func(t *testing.T) {
+		rcPath := filepath.Join(t.TempDir(), ".bashrc")
+		broken := before + shellBlockStart + "\n" + after
+		os.WriteFile(rcPath, []byte(broken), 0644)
+
+		cmd := &InitCommand{}
+		removed, err := cmd.removeShellIntegration(rcPath)
+		if err != nil || removed {
+			t.Errorf("Expected nothing to remove, got removed=%v err=%v", removed, err)
+		}
+		content, _ := os.ReadFile(rcPath)
+		if string(content) != broken {
+			t.Errorf("rc file should be unchanged, got %q", content)
+		}
+	})
+}
+
+func TestInitCommand_OfferShellIntegration_RefreshesManagedBlock(t *testing.T) {
+	tempDir := t.TempDir()
+	rcPath := filepath.Join(tempDir, ".bashrc")
+	oldBlock := shellBlockStart + "\n# version: 0\n" + shellBlockEnd + "\n"
+	os.WriteFile(rcPath, []byte("# config\n\n"+oldBlock), 0644)
+
+	oldShell := os.Getenv("SHELL")
+	os.Setenv("SHELL", "/bin/bash")
+	defer os.Setenv("SHELL", oldShell)
+
+	stdout := &bytes.Buffer{}
+	cmd := NewInitCommandWithShell(strings.NewReader(""), stdout, &bytes.Buffer{}, filepath.Join(tempDir, ".gwrc"), rcPath)
+
+	if err := cmd.offerShellIntegration(bufio.NewReader(strings.NewReader("y\n"))); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !strings.Contains(stdout.String(), "Shell integration updated in") {
+		t.Errorf("Expected update message, got: %s", stdout.String())
+	}
+
+	stdout.Reset()
+	if err := cmd.offerShellIntegration(bufio.NewReader(strings.NewReader("y\n"))); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !strings.Contains(stdout.String(), "already up to date") {
+		t.Errorf("Expected up-to-date message, got: %s", stdout.String())
+	}
+	if strings.Contains(stdout.String(), "already exists") {
+		t.Errorf("Managed block should not trigger manual update instructions, got: %s", stdout.String())
+	}
+}
@@ -0,0 +1,89 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var (
+	listStale    bool
+	listNoFetch  bool
+	listFormat   string
+	listAllRepos bool
+	listRepo     string
+	listSince    string
+)
+
+var listCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List worktrees",
+	Long: `Lists all worktrees of the repository with their branches. Worktrees whose
+branch tracked a remote branch that has since been deleted (for example after
+its pull request was merged) are marked as "upstream gone". A worktree whose
+HEAD differs from main shows how many commits it is ahead of and behind it,
+as "↑3 ↓1".
+
+Use --stale to show only those worktrees.
+
+Use --since <rev> to show only the worktrees with commits that <rev> does not
+have (git rev-list <rev>..HEAD is not empty), e.g. the new work since the last
+release tag.
+
+Use --format to print each worktree with a Go template instead, for scripting.
+The template sees the worktree fields (.Path, .Branch, .Commit, .IsLocked,
+.IsBare, .IsPrunable, .Ahead, .Behind) plus .Issue (the issue a gw branch was
+created for), .Stale (upstream gone) and .Age (time since the last commit,
+e.g. "3d").
+\t and \n in the template stand for a tab and a newline:
+
+  gw list --format '{{.Branch}}\t{{.Path}}'
+
+By default only the worktrees of the current repository are listed. Use
+--all-repos to list those of every repository found under the worktree_root
+directory set in ~/.gwrc, grouped by repository, or --repo <name> for only
+the repository of that name. Both can be run from anywhere.`,
+	Example: `  gw list
+
+  # Only worktrees whose pull request was merged and branch deleted
+  gw list --stale
+
+  # Only worktrees with new work since the v1.2.0 tag
+  gw list --since v1.2.0
+
+  # Branch and path, tab-separated, for scripts
+  gw list --format '{{.Branch}}\t{{.Path}}'
+
+  # Worktrees of every repository under worktree_root
+  gw list --all-repos
+
+  # Worktrees of the api repository, from anywhere
+  gw list --repo api`,
+	Args: cobra.NoArgs,
+	RunE: runList,
+}
+
+func init() {
+	rootCmd.AddCommand(listCmd)
+	listCmd.Flags().BoolVar(&listStale, "stale", false, "Show only worktrees whose upstream branch was deleted on the remote")
+	listCmd.Flags().BoolVar(&listNoFetch, "no-fetch", false, "Skip git fetch before running the command")
+	listCmd.Flags().StringVar(&listFormat, "format", "", "Print each worktree with a Go template (e.g. '{{.Branch}}\\t{{.Path}}')")
+	listCmd.Flags().BoolVar(&listAllRepos, "all-repos", false, "List the worktrees of every repository under worktree_root, grouped by repository")
+	listCmd.Flags().StringVar(&listRepo, "repo", "", "List the worktrees of the repository with this name under worktree_root")
+	listCmd.Flags().StringVar(&listSince, "since", "", "Show only worktrees with commits that this revision (e.g. a tag) does not have")
+	listCmd.MarkFlagsMutuallyExclusive("all-repos", "stale")
+	listCmd.MarkFlagsMutuallyExclusive("all-repos", "format")
+	listCmd.MarkFlagsMutuallyExclusive("all-repos", "repo")
+	listCmd.MarkFlagsMutuallyExclusive("repo", "stale")
+	listCmd.MarkFlagsMutuallyExclusive("repo", "format")
+	listCmd.MarkFlagsMutuallyExclusive("all-repos", "since")
+	listCmd.MarkFlagsMutuallyExclusive("repo", "since")
+}
+
+func runList(cmd *cobra.Command, args []string) error {
+	deps := DefaultDependencies()
+	listCmd := NewListCommand(deps, listStale, listNoFetch)
+	listCmd.format = listFormat
+	listCmd.allRepos = listAllRepos
+	listCmd.repo = listRepo
+	listCmd.since = listSince
+	return listCmd.Execute()
+}
@@ -0,0 +1,34 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var lsBranchesRemoteOnly bool
+
+var lsBranchesCmd = &cobra.Command{
+	Use:   "ls-branches",
+	Short: "List branches that have no worktree",
+	Long: `Lists the local and remote branches that are not currently checked out in
+any worktree, to help decide what to check out next. A remote branch
+origin/<name> counts as checked out when <name> has a worktree.
+
+Use --remote-only to show only origin/* branches.`,
+	Example: `  # Branches you could check out with gw checkout
+  gw ls-branches
+
+  gw ls-branches --remote-only`,
+	Args: cobra.NoArgs,
+	RunE: runLsBranches,
+}
+
+func init() {
+	rootCmd.AddCommand(lsBranchesCmd)
+	lsBranchesCmd.Flags().BoolVar(&lsBranchesRemoteOnly, "remote-only", false, "Show only remote (origin/*) branches")
+}
+
+func runLsBranches(cmd *cobra.Command, args []string) error {
+	deps := DefaultDependencies()
+	lsBranchesCmd := NewLsBranchesCommand(deps, lsBranchesRemoteOnly)
+	return lsBranchesCmd.Execute()
+}
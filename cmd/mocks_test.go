@@ -25,11 +25,16 @@ type mockGit struct {
 	envFiles            []git.EnvFile
 	findEnvError        error
 	copyEnvError        error
+	remote              string // Remote(); empty means git.DefaultRemote
+	worktreeRoot        string // WorktreeRoot()
+	noRemote            bool   // HasRemote() reports false
 
 	// Override functions for custom behavior
 	FetchAllFn              func() error
 	BranchExistsFn          func(string) (bool, error)
 	ListAllBranchesFn       func() ([]string, error)
+	ListLocalBranchesFn     func() ([]string, error)
+	ResolveBaseBranchFn     func(string) (string, bool)
 	GetCurrentBranchFn      func() (string, error)
 	GetWorktreeForIssueFn   func(string) (*git.WorktreeInfo, error)
 	HasUncommittedChangesFn func() (bool, error)
@@ -38,19 +43,49 @@ type mockGit struct {
 	// "*AtFn" callbacks receive the same args as the real Git interface
 	// methods. Use them when a test needs to vary results by worktree path or
 	// branch (the simpler Fn forms above still work for fixed return values).
-	HasUncommittedChangesAtFn   func(worktreePath string) (bool, error)
-	HasUnpushedCommitsAtFn      func(worktreePath, currentBranch string) (bool, error)
-	IsMergedToBaseBranchAtFn    func(worktreePath, currentBranch, targetBranch string) (bool, error)
-	DeleteBranchFn              func(string) error
-	ListWorktreesFn             func() ([]git.WorktreeInfo, error)
-	RemoveWorktreeByPathFn      func(string) error
-	GetRepositoryNameFn         func() (string, error)
-	GetOriginalRepositoryNameFn func() (string, error)
-	GetRepositoryRootFn         func() (string, error)
-	GetMainRepositoryRootFn     func() (string, error)
-	CreateWorktreeFromBranchFn  func(string, string, string) error
-	FindUntrackedEnvFilesFn     func(string) ([]git.EnvFile, error)
-	SanitizeBranchNameForDirFn  func(string) string
+	HasUncommittedChangesAtFn           func(worktreePath string) (bool, error)
+	HasUnpushedCommitsAtFn              func(worktreePath, currentBranch string) (bool, error)
+	IsMergedToBaseBranchAtFn            func(worktreePath, currentBranch, targetBranch string) (bool, error)
+	IsInProgressOperationFn             func(worktreePath string) (bool, string, error)
+	GetWorktreeDetailsFn                func(worktreePath string) (*git.WorktreeDetails, error)
+	DeleteBranchFn                      func(branch string, force bool) error
+	DeleteRemoteBranchFn                func(branch string) error
+	IsUpstreamGoneFn                    func(branch string) (bool, error)
+	UpstreamStatusFn                    func(branch string) (*git.TrackingStatus, error)
+	FastForwardBranchFn                 func(branch string) error
+	CommitsBehindFn                     func(worktreePath, baseBranch string) (int, error)
+	CommitsSinceFn                      func(worktreePath, rev string) (int, error)
+	AheadBehindFn                       func(worktreePath, baseBranch string) (int, int, error)
+	MergeBranchFn                       func(worktreePath, baseBranch, branch string, opts git.MergeOptions) error
+	ResolveRefDescriptionFn             func(commit string) (string, error)
+	RemoteDefaultBranchFn               func() (string, error)
+	FetchPullRequestFn                  func(number, branch string) (git.RemoteRepo, error)
+	RemoteBranchCandidatesFn            func(branch string) ([]string, error)
+	ListWorktreesFn                     func() ([]git.WorktreeInfo, error)
+	RemoveWorktreeByPathFn              func(string) error
+	RepairWorktreesFn                   func(...string) error
+	PruneWorktreesFn                    func() error
+	MoveWorktreeFn                      func(worktreePath, newPath string) error
+	GetRepositoryNameFn                 func() (string, error)
+	GetOriginalRepositoryNameFn         func() (string, error)
+	GetRepositoryRootFn                 func() (string, error)
+	GetMainRepositoryRootFn             func() (string, error)
+	CreateWorktreeFromBranchFn          func(string, string, string) error
+	CreateUntrackedWorktreeFromBranchFn func(string, string, string) error
+	CreateWorktreeAtFn                  func(worktreePath, commit, newBranch string) error
+	CreateOrphanWorktreeFn              func(branch string) (string, error)
+	GitVersionFn                        func() (git.Version, error)
+	MergeBaseFn                         func(a, b string) (string, error)
+	CreateTrackingWorktreeFn            func(issueNumber, remoteBranch string) (string, error)
+	ApplyStashFn                        func(worktreePath string) error
+	ApplyPatchFn                        func(worktreePath, patchFile string) error
+	FormatPatchFn                       func(worktreePath, base, outDir string) ([]string, error)
+	InspectCheckoutFn                   func(path string) (*git.Checkout, error)
+	AdoptCloneFn                        func(clonePath, branch string) (string, error)
+	FindUntrackedEnvFilesFn             func(string) ([]git.EnvFile, error)
+	FindUntrackedFilesFn                func(string) ([]git.EnvFile, error)
+	CopyFilesFn                         func(files []git.EnvFile, sourceRoot, destRoot string) (int, error)
+	SanitizeBranchNameForDirFn          func(string) string
 }
 
 func (m *mockGit) IsGitRepository() bool {
@@ -100,6 +135,21 @@ func (m *mockGit) FetchAll() error {
 	return nil
 }
 
+func (m *mockGit) Remote() string {
+	if m.remote == "" {
+		return git.DefaultRemote
+	}
+	return m.remote
+}
+
+func (m *mockGit) HasRemote() (bool, error) {
+	return !m.noRemote, nil
+}
+
+func (m *mockGit) WorktreeRoot() string {
+	return m.worktreeRoot
+}
+
 func (m *mockGit) GetCurrentBranch() (string, error) {
 	if m.GetCurrentBranchFn != nil {
 		return m.GetCurrentBranchFn()
@@ -107,13 +157,20 @@ func (m *mockGit) GetCurrentBranch() (string, error) {
 	return defaultBaseBranch, nil
 }
 
-func (m *mockGit) CreateWorktree(issueNumber, baseBranch string) (string, error) {
+func (m *mockGit) CreateWorktree(issueNumber, baseBranch string, opts ...git.AddOption) (string, error) {
 	if m.createWorktreeError != nil {
 		return "", m.createWorktreeError
 	}
 	return m.worktreePath, nil
 }
 
+func (m *mockGit) CreateTrackingWorktree(issueNumber, remoteBranch string, opts ...git.AddOption) (string, error) {
+	if m.CreateTrackingWorktreeFn != nil {
+		return m.CreateTrackingWorktreeFn(issueNumber, remoteBranch)
+	}
+	return m.CreateWorktree(issueNumber, remoteBranch)
+}
+
 func (m *mockGit) CreateWorktreeFromBranch(worktreePath, sourceBranch, targetBranch string) error {
 	if m.CreateWorktreeFromBranchFn != nil {
 		return m.CreateWorktreeFromBranchFn(worktreePath, sourceBranch, targetBranch)
@@ -124,6 +181,48 @@ func (m *mockGit) CreateWorktreeFromBranch(worktreePath, sourceBranch, targetBra
 	return nil
 }
 
+func (m *mockGit) CreateUntrackedWorktreeFromBranch(worktreePath, sourceBranch, targetBranch string) error {
+	if m.CreateUntrackedWorktreeFromBranchFn != nil {
+		return m.CreateUntrackedWorktreeFromBranchFn(worktreePath, sourceBranch, targetBranch)
+	}
+	return m.CreateWorktreeFromBranch(worktreePath, sourceBranch, targetBranch)
+}
+
+func (m *mockGit) ApplyStash(worktreePath string) error {
+	if m.ApplyStashFn != nil {
+		return m.ApplyStashFn(worktreePath)
+	}
+	return nil
+}
+
+func (m *mockGit) ApplyPatch(worktreePath, patchFile string) error {
+	if m.ApplyPatchFn != nil {
+		return m.ApplyPatchFn(worktreePath, patchFile)
+	}
+	return nil
+}
+
+func (m *mockGit) FormatPatch(worktreePath, base, outDir string) ([]string, error) {
+	if m.FormatPatchFn != nil {
+		return m.FormatPatchFn(worktreePath, base, outDir)
+	}
+	return nil, nil
+}
+
+func (m *mockGit) InspectCheckout(path string) (*git.Checkout, error) {
+	if m.InspectCheckoutFn != nil {
+		return m.InspectCheckoutFn(path)
+	}
+	return &git.Checkout{Path: path}, nil
+}
+
+func (m *mockGit) AdoptClone(clonePath, branch string) (string, error) {
+	if m.AdoptCloneFn != nil {
+		return m.AdoptCloneFn(clonePath, branch)
+	}
+	return "", nil
+}
+
 func (m *mockGit) RemoveWorktree(issueNumber string) error {
 	return nil
 }
@@ -135,6 +234,55 @@ func (m *mockGit) RemoveWorktreeByPath(worktreePath string) error {
 	return nil
 }
 
+func (m *mockGit) CreateWorktreeAt(worktreePath, commit, newBranch string) error {
+	if m.CreateWorktreeAtFn != nil {
+		return m.CreateWorktreeAtFn(worktreePath, commit, newBranch)
+	}
+	return nil
+}
+
+func (m *mockGit) GitVersion() (git.Version, error) {
+	if m.GitVersionFn != nil {
+		return m.GitVersionFn()
+	}
+	return git.Version{Major: 2, Minor: 45, Patch: 0}, nil
+}
+
+func (m *mockGit) CreateOrphanWorktree(branch string, opts ...git.AddOption) (string, error) {
+	if m.CreateOrphanWorktreeFn != nil {
+		return m.CreateOrphanWorktreeFn(branch)
+	}
+	return "/path/to/worktree", nil
+}
+
+func (m *mockGit) MergeBase(a, b string) (string, error) {
+	if m.MergeBaseFn != nil {
+		return m.MergeBaseFn(a, b)
+	}
+	return "", nil
+}
+
+func (m *mockGit) MoveWorktree(worktreePath, newPath string) error {
+	if m.MoveWorktreeFn != nil {
+		return m.MoveWorktreeFn(worktreePath, newPath)
+	}
+	return nil
+}
+
+func (m *mockGit) RepairWorktrees(worktreePaths ...string) error {
+	if m.RepairWorktreesFn != nil {
+		return m.RepairWorktreesFn(worktreePaths...)
+	}
+	return nil
+}
+
+func (m *mockGit) PruneWorktrees() error {
+	if m.PruneWorktreesFn != nil {
+		return m.PruneWorktreesFn()
+	}
+	return nil
+}
+
 func (m *mockGit) ListWorktrees() ([]git.WorktreeInfo, error) {
 	if m.ListWorktreesFn != nil {
 		return m.ListWorktreesFn()
@@ -159,6 +307,13 @@ func (m *mockGit) BranchExists(branch string) (bool, error) {
 	return false, nil
 }
 
+func (m *mockGit) ListLocalBranches() ([]string, error) {
+	if m.ListLocalBranchesFn != nil {
+		return m.ListLocalBranchesFn()
+	}
+	return nil, nil
+}
+
 func (m *mockGit) ListAllBranches() ([]string, error) {
 	if m.ListAllBranchesFn != nil {
 		return m.ListAllBranchesFn()
@@ -166,6 +321,13 @@ func (m *mockGit) ListAllBranches() ([]string, error) {
 	return []string{defaultBaseBranch, "feature"}, nil
 }
 
+func (m *mockGit) ResolveBaseBranch(baseBranch string) (string, bool) {
+	if m.ResolveBaseBranchFn != nil {
+		return m.ResolveBaseBranchFn(baseBranch)
+	}
+	return baseBranch, false
+}
+
 func (m *mockGit) HasUncommittedChanges(worktreePath string) (bool, error) {
 	if m.HasUncommittedChangesAtFn != nil {
 		return m.HasUncommittedChangesAtFn(worktreePath)
@@ -197,6 +359,20 @@ func (m *mockGit) IsMergedToBaseBranch(worktreePath, currentBranch, targetBranch
 	return true, nil
 }
 
+func (m *mockGit) IsInProgressOperation(worktreePath string) (bool, string, error) {
+	if m.IsInProgressOperationFn != nil {
+		return m.IsInProgressOperationFn(worktreePath)
+	}
+	return false, "", nil
+}
+
+func (m *mockGit) GetWorktreeDetails(worktreePath string) (*git.WorktreeDetails, error) {
+	if m.GetWorktreeDetailsFn != nil {
+		return m.GetWorktreeDetailsFn(worktreePath)
+	}
+	return &git.WorktreeDetails{}, nil
+}
+
 func (m *mockGit) FindUntrackedEnvFiles(repoPath string) ([]git.EnvFile, error) {
 	if m.FindUntrackedEnvFilesFn != nil {
 		return m.FindUntrackedEnvFilesFn(repoPath)
@@ -215,6 +391,20 @@ func (m *mockGit) CopyEnvFiles(envFiles []git.EnvFile, sourceRoot, destRoot stri
 	return git.NewClient().CopyEnvFiles(envFiles, sourceRoot, destRoot)
 }
 
+func (m *mockGit) FindUntrackedFiles(repoPath string) ([]git.EnvFile, error) {
+	if m.FindUntrackedFilesFn != nil {
+		return m.FindUntrackedFilesFn(repoPath)
+	}
+	return nil, nil
+}
+
+func (m *mockGit) CopyFiles(files []git.EnvFile, sourceRoot, destRoot string) (int, error) {
+	if m.CopyFilesFn != nil {
+		return m.CopyFilesFn(files, sourceRoot, destRoot)
+	}
+	return len(files), nil
+}
+
 func (m *mockGit) RunCommand(command string) error {
 	return nil
 }
@@ -227,17 +417,106 @@ func (m *mockGit) SanitizeBranchNameForDirectory(branch string) string {
 	return strings.ReplaceAll(branch, "/", "_")
 }
 
-func (m *mockGit) DeleteBranch(branch string) error {
+func (m *mockGit) DeleteBranch(branch string, force bool) error {
 	if m.DeleteBranchFn != nil {
-		return m.DeleteBranchFn(branch)
+		return m.DeleteBranchFn(branch, force)
+	}
+	return nil
+}
+
+func (m *mockGit) DeleteRemoteBranch(branch string) error {
+	if m.DeleteRemoteBranchFn != nil {
+		return m.DeleteRemoteBranchFn(branch)
+	}
+	return nil
+}
+
+func (m *mockGit) IsUpstreamGone(branch string) (bool, error) {
+	if m.IsUpstreamGoneFn != nil {
+		return m.IsUpstreamGoneFn(branch)
+	}
+	return false, nil
+}
+
+func (m *mockGit) UpstreamStatus(branch string) (*git.TrackingStatus, error) {
+	if m.UpstreamStatusFn != nil {
+		return m.UpstreamStatusFn(branch)
+	}
+	return &git.TrackingStatus{}, nil
+}
+
+func (m *mockGit) FastForwardBranch(branch string) error {
+	if m.FastForwardBranchFn != nil {
+		return m.FastForwardBranchFn(branch)
+	}
+	return nil
+}
+
+func (m *mockGit) MergeBranch(worktreePath, baseBranch, branch string, opts git.MergeOptions) error {
+	if m.MergeBranchFn != nil {
+		return m.MergeBranchFn(worktreePath, baseBranch, branch, opts)
 	}
 	return nil
 }
 
+func (m *mockGit) ResolveRefDescription(commit string) (string, error) {
+	if m.ResolveRefDescriptionFn != nil {
+		return m.ResolveRefDescriptionFn(commit)
+	}
+	return "", nil
+}
+
+func (m *mockGit) RemoteDefaultBranch() (string, error) {
+	if m.RemoteDefaultBranchFn != nil {
+		return m.RemoteDefaultBranchFn()
+	}
+	return m.Remote() + "/main", nil
+}
+
+func (m *mockGit) FetchPullRequest(number, branch string) (git.RemoteRepo, error) {
+	if m.FetchPullRequestFn != nil {
+		return m.FetchPullRequestFn(number, branch)
+	}
+	return git.RemoteRepo{Host: "github.com", Owner: "owner", Name: "repo"}, nil
+}
+
+func (m *mockGit) RemoteBranchCandidates(branch string) ([]string, error) {
+	if m.RemoteBranchCandidatesFn != nil {
+		return m.RemoteBranchCandidatesFn(branch)
+	}
+	return nil, nil
+}
+
+func (m *mockGit) CutRemoteBranch(branch string) (string, bool) {
+	return git.CutRemotePrefix(branch, m.Remote())
+}
+
+func (m *mockGit) CommitsBehind(worktreePath, baseBranch string) (int, error) {
+	if m.CommitsBehindFn != nil {
+		return m.CommitsBehindFn(worktreePath, baseBranch)
+	}
+	return 0, nil
+}
+
+func (m *mockGit) CommitsSince(worktreePath, rev string) (int, error) {
+	if m.CommitsSinceFn != nil {
+		return m.CommitsSinceFn(worktreePath, rev)
+	}
+	return 0, nil
+}
+
+func (m *mockGit) AheadBehind(worktreePath, baseBranch string) (int, int, error) {
+	if m.AheadBehindFn != nil {
+		return m.AheadBehindFn(worktreePath, baseBranch)
+	}
+	return 0, 0, nil
+}
+
 type mockUI struct {
-	confirmResult bool
-	confirmError  error
-	confirmCalled bool
+	confirmResult  bool
+	confirmError   error
+	confirmCalled  bool
+	confirmMessage string
 
 	trustPromptResult bool
 	trustPromptError  error
@@ -246,9 +525,11 @@ type mockUI struct {
 	trustPromptLines  []string
 
 	// Override functions for custom behavior
-	ShowSelectorFn   func(string, []ui.SelectorItem) (*ui.SelectorItem, error)
-	SelectWorktreeFn func() (*git.WorktreeInfo, error)
-	TrustPromptFn    func(string, []string) (bool, error)
+	ShowSelectorFn      func(string, []ui.SelectorItem) (*ui.SelectorItem, error)
+	ShowMultiSelectorFn func(string, []ui.SelectorItem) ([]ui.SelectorItem, error)
+	SelectWorktreeFn    func() (*git.WorktreeInfo, error)
+	TrustPromptFn       func(string, []string) (bool, error)
+	ConfirmPromptFn     func(string) (bool, error)
 }
 
 func (m *mockUI) SelectWorktree() (*git.WorktreeInfo, error) {
@@ -265,8 +546,19 @@ func (m *mockUI) ShowSelector(title string, items []ui.SelectorItem) (*ui.Select
 	return nil, nil
 }
 
+func (m *mockUI) ShowMultiSelector(title string, items []ui.SelectorItem) ([]ui.SelectorItem, error) {
+	if m.ShowMultiSelectorFn != nil {
+		return m.ShowMultiSelectorFn(title, items)
+	}
+	return []ui.SelectorItem{}, nil
+}
+
 func (m *mockUI) ConfirmPrompt(message string) (bool, error) {
 	m.confirmCalled = true
+	m.confirmMessage = message
+	if m.ConfirmPromptFn != nil {
+		return m.ConfirmPromptFn(message)
+	}
 	return m.confirmResult, m.confirmError
 }
 
@@ -286,6 +578,7 @@ func (m *mockUI) ShowEnvFilesList(files []string) {
 
 type mockDetect struct {
 	setupError error
+	RunSetupFn func(path string) error
 }
 
 func (m *mockDetect) DetectPackageManager(path string) (*detect.PackageManager, error) {
@@ -293,5 +586,8 @@ func (m *mockDetect) DetectPackageManager(path string) (*detect.PackageManager,
 }
 
 func (m *mockDetect) RunSetup(path string) error {
+	if m.RunSetupFn != nil {
+		return m.RunSetupFn(path)
+	}
 	return m.setupError
 }
@@ -153,7 +153,7 @@ func resolveTrust(deps *Dependencies, projectPath string, content []byte, projec
 		return true
 	}
 
-	if !isTerminalStdin() {
+	if !isTerminalStdin() || deps.NoPrompt {
 		fmt.Fprintf(deps.Stderr, "%s Untrusted project hook(s) at %s (non-interactive session);"+
 			" using global configuration for those keys.\n", coloredWarning(), projectPath)
 		return false
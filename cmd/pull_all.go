@@ -0,0 +1,34 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var pullAllBase string
+
+var pullAllCmd = &cobra.Command{
+	Use:   "pull-all",
+	Short: "Fast-forward the default branch and report worktrees that fell behind",
+	Long: `Fetches from the remotes, fast-forwards the local default branch to its
+upstream (in the worktree that has it checked out) and lists the feature
+worktrees that are now behind it.
+
+Only fast-forwards are made: a default branch with local commits of its own,
+or checked out in a worktree with uncommitted changes, is left untouched.
+Feature worktrees are only reported, never changed.`,
+	Example: `  # Fast-forward main and every worktree branch that tracks a remote
+  gw pull-all`,
+	Args: cobra.NoArgs,
+	RunE: runPullAll,
+}
+
+func init() {
+	rootCmd.AddCommand(pullAllCmd)
+	pullAllCmd.Flags().StringVar(&pullAllBase, "base", defaultBaseBranch, "Default branch to fast-forward")
+}
+
+func runPullAll(cmd *cobra.Command, args []string) error {
+	deps := DefaultDependencies()
+	pullAllCmd := NewPullAllCommand(deps, pullAllBase)
+	return pullAllCmd.Execute()
+}
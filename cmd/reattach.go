@@ -0,0 +1,28 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var reattachCmd = &cobra.Command{
+	Use:   "reattach <worktree-path>",
+	Short: "Re-register a worktree directory that was moved by hand",
+	Long: `Moving a worktree directory with mv breaks the links git keeps between the
+repository and the worktree. Run reattach with the directory's new location
+to repair them with git worktree repair and check that the worktree is listed
+again.`,
+	Example: `  # After moving a worktree directory by hand
+  gw reattach ../myapp-123`,
+	Args: cobra.ExactArgs(1),
+	RunE: runReattach,
+}
+
+func init() {
+	rootCmd.AddCommand(reattachCmd)
+}
+
+func runReattach(cmd *cobra.Command, args []string) error {
+	deps := DefaultDependencies()
+	reattachCmd := NewReattachCommand(deps)
+	return reattachCmd.Execute(args[0])
+}
@@ -0,0 +1,35 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var relocateTo string
+
+var relocateCmd = &cobra.Command{
+	Use:   "relocate",
+	Short: "Move all worktrees of the repository under a new root directory",
+	Long: `Moves every worktree of the current repository into <root>/<repo>/ with
+git worktree move, the layout worktree_root gives new worktrees. Use it to
+migrate sibling worktrees after setting worktree_root; without --to, the
+configured worktree_root is the destination.
+
+The main worktree is not moved. Locked worktrees are skipped until they are
+unlocked, and the recent worktrees remembered for gw checkout - follow the
+moved ones.`,
+	Example: `  # Move every worktree under ~/worktrees/<repo>/
+  gw relocate --to ~/worktrees`,
+	Args: cobra.NoArgs,
+	RunE: runRelocate,
+}
+
+func init() {
+	rootCmd.AddCommand(relocateCmd)
+	relocateCmd.Flags().StringVar(&relocateTo, "to", "", "Root directory to move the worktrees under (default: worktree_root)")
+}
+
+func runRelocate(cmd *cobra.Command, args []string) error {
+	deps := DefaultDependencies()
+	relocateCmd := NewRelocateCommand(deps, relocateTo)
+	return relocateCmd.Execute()
+}
@@ -1,8 +1,12 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
+	"os"
 
+	"github.com/sotarok/gw/internal/config"
+	"github.com/sotarok/gw/internal/glyph"
 	"github.com/spf13/cobra"
 )
 
@@ -10,6 +14,9 @@ var (
 	version   string
 	commit    string
 	buildDate string
+
+	asciiOutput bool
+	dryRun      bool
 )
 
 var rootCmd = &cobra.Command{
@@ -21,8 +28,22 @@ setup for various package managers.`,
 	Version: version,
 }
 
+// Execute runs the root command. Errors are printed here rather than by cobra
+// so that a user abort, which the command has already reported, stays quiet;
+// pass the returned error to ExitCode for the process exit code.
+//
+// Aliases from the config are expanded before cobra sees the arguments. A
+// config that fails to load is reported later by DefaultDependencies, so it
+// only disables aliases here.
 func Execute() error {
-	return rootCmd.Execute()
+	if cfg, err := config.Load(config.GetConfigPath()); err == nil {
+		rootCmd.SetArgs(expandAlias(rootCmd, cfg, os.Args[1:]))
+	}
+	err := rootCmd.Execute()
+	if err != nil && !errors.Is(err, errAborted) {
+		fmt.Fprintln(rootCmd.ErrOrStderr(), "Error:", err)
+	}
+	return err
 }
 
 func SetVersionInfo(v, c, d string) {
@@ -34,6 +55,18 @@ func SetVersionInfo(v, c, d string) {
 
 func init() {
 	rootCmd.CompletionOptions.DisableDefaultCmd = true
+	rootCmd.SilenceErrors = true
+	// Usage is still shown for flag and argument errors, which cobra reports
+	// before this hook runs, but not for failures while the command runs.
+	rootCmd.PersistentPreRun = func(cmd *cobra.Command, args []string) {
+		cmd.SilenceUsage = true
+		// Exported so internal packages and hooks see ASCII mode too
+		if asciiOutput {
+			_ = os.Setenv(glyph.EnvASCII, "1")
+		}
+	}
+	rootCmd.PersistentFlags().BoolVar(&dryRun, "dry-run", false, "Print the git commands that would change the repository instead of running them")
+	rootCmd.PersistentFlags().BoolVar(&asciiOutput, "ascii", false, "Use only ASCII characters in the output (same as GW_ASCII=1)")
 	rootCmd.SetVersionTemplate(`{{with .Name}}{{printf "%s " .}}{{end}}{{printf "version %s" .Version}}
 `)
 }
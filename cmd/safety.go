@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"errors"
+	"fmt"
 	"sync"
 
 	"github.com/sotarok/gw/internal/git"
@@ -16,13 +17,16 @@ type safetyCheck struct {
 	Err error
 }
 
-// safetyResult is the outcome of the three pre-removal checks for one worktree.
-// Each field is reported verbatim; callers format the warnings in their own
-// wording (end and clean intentionally differ).
+// safetyResult is the outcome of the four pre-removal checks for one worktree.
+// Each field is reported verbatim; EvaluateWorktreeSafety turns it into the
+// reasons shown by end and clean.
 type safetyResult struct {
 	Uncommitted safetyCheck
 	Unpushed    safetyCheck
 	Merged      safetyCheck // Tripped means "not merged to baseBranch"
+	InProgress  safetyCheck // Tripped means a rebase/merge/cherry-pick/revert is under way
+	// Operation names the in-progress operation when InProgress is tripped.
+	Operation string
 	// InvalidRepo is true when the worktree is broken or missing (git exits
 	// 128). When set, the remaining checks were not meaningful and callers
 	// should surface a single "invalid git repository" reason.
@@ -30,11 +34,11 @@ type safetyResult struct {
 }
 
 // numSafetyChecks is the number of parallel goroutines started by runSafetyChecks
-// (uncommitted, unpushed, merged). Centralized so wg.Add stays in sync with
-// the actual goroutine count.
-const numSafetyChecks = 3
+// (uncommitted, unpushed, merged, in-progress operation). Centralized so wg.Add
+// stays in sync with the actual goroutine count.
+const numSafetyChecks = 4
 
-// runSafetyChecks runs the three pre-removal checks in parallel against the
+// runSafetyChecks runs the four pre-removal checks in parallel against the
 // worktree at worktreePath using the StatusChecker.
 //
 // If the uncommitted-changes check fails with a git exit code 128 (a broken or
@@ -72,11 +76,21 @@ func runSafetyChecks(g git.StatusChecker, worktreePath, branch, baseBranch strin
 		}
 		result.Merged.Tripped = !isMerged
 	}()
+	go func() {
+		defer wg.Done()
+		inProgress, operation, err := g.IsInProgressOperation(worktreePath)
+		if err != nil {
+			result.InProgress.Err = err
+			return
+		}
+		result.InProgress.Tripped = inProgress
+		result.Operation = operation
+	}()
 	wg.Wait()
 
 	// A broken or missing worktree surfaces as git exit 128 on the first
 	// check. Flag it so callers can report a single clear reason instead of
-	// three meaningless ones.
+	// several meaningless ones.
 	if isInvalidRepoErr(result.Uncommitted.Err) {
 		result.InvalidRepo = true
 	}
@@ -91,3 +105,59 @@ func isInvalidRepoErr(err error) bool {
 	var gitErr *git.GitError
 	return errors.As(err, &gitErr) && gitErr.ExitCode == 128
 }
+
+// EvaluateWorktreeSafety runs the pre-removal checks against the worktree at
+// worktreePath and reports whether it can be removed without losing work,
+// along with one human-readable reason per check that blocked removal. Both
+// `gw end` and `gw clean` use these shared reasons, so the two commands can
+// never disagree about why a worktree is unsafe.
+//
+// A check that could not be evaluated also blocks removal: failing open would
+// let a transient git error look like a clean worktree.
+func EvaluateWorktreeSafety(g git.StatusChecker, worktreePath, branch, baseBranch string) (canRemove bool, warnings []string) {
+	return safetyWarnings(runSafetyChecks(g, worktreePath, branch, baseBranch), baseBranch, true)
+}
+
+// evaluateMergeSafety is EvaluateWorktreeSafety for `gw end --merge`: the
+// branch is merged into baseBranch before removal, so its commits are kept
+// whether or not they were pushed or merged, and only the checks that guard
+// the worktree's own state (uncommitted changes, an operation in progress)
+// apply.
+func evaluateMergeSafety(g git.StatusChecker, worktreePath, branch, baseBranch string) (canRemove bool, warnings []string) {
+	return safetyWarnings(runSafetyChecks(g, worktreePath, branch, baseBranch), baseBranch, false)
+}
+
+// safetyWarnings turns res into the reasons shown by end and clean. The
+// unpushed and merged checks are only reported when branchChecks is set.
+func safetyWarnings(res safetyResult, baseBranch string, branchChecks bool) (canRemove bool, warnings []string) {
+	// A broken or missing worktree (git exit 128) — surface a single clear
+	// reason instead of several meaningless ones.
+	if res.InvalidRepo {
+		return false, []string{"invalid git repository"}
+	}
+
+	checks := []struct {
+		check       safetyCheck
+		warning     string
+		errLabel    string
+		branchCheck bool
+	}{
+		{res.Uncommitted, "uncommitted changes", "Could not check uncommitted changes", false},
+		{res.Unpushed, "unpushed commits", "Could not check unpushed commits", true},
+		{res.Merged, "not merged to " + baseBranch, "Could not check merge status", true},
+		{res.InProgress, res.Operation + " in progress", "Could not check for an in-progress rebase/merge", false},
+	}
+
+	warnings = []string{}
+	for _, chk := range checks {
+		if chk.branchCheck && !branchChecks {
+			continue
+		}
+		if chk.check.Err != nil {
+			warnings = append(warnings, fmt.Sprintf("%s: %v", chk.errLabel, chk.check.Err))
+		} else if chk.check.Tripped {
+			warnings = append(warnings, chk.warning)
+		}
+	}
+	return len(warnings) == 0, warnings
+}
@@ -0,0 +1,179 @@
+package cmd
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/sotarok/gw/internal/git"
+)
+
+func TestEvaluateWorktreeSafety(t *testing.T) {
+	checkErr := fmt.Errorf("git command failed")
+
+	tests := []struct {
+		name             string
+		uncommitted      bool
+		uncommittedErr   error
+		unpushed         bool
+		unpushedErr      error
+		merged           bool
+		mergedErr        error
+		operation        string // in-progress operation, "" for none
+		operationErr     error
+		expectCanRemove  bool
+		expectedWarnings []string
+	}{
+		{
+			name:             "clean, pushed and merged",
+			merged:           true,
+			expectCanRemove:  true,
+			expectedWarnings: []string{},
+		},
+		{
+			name:             "uncommitted changes",
+			uncommitted:      true,
+			merged:           true,
+			expectedWarnings: []string{"uncommitted changes"},
+		},
+		{
+			name:             "unpushed commits",
+			unpushed:         true,
+			merged:           true,
+			expectedWarnings: []string{"unpushed commits"},
+		},
+		{
+			name:             "not merged",
+			expectedWarnings: []string{"not merged to main"},
+		},
+		{
+			name:        "all checks tripped",
+			uncommitted: true,
+			unpushed:    true,
+			expectedWarnings: []string{
+				"uncommitted changes",
+				"unpushed commits",
+				"not merged to main",
+			},
+		},
+		{
+			name:             "uncommitted check error blocks removal",
+			uncommittedErr:   checkErr,
+			merged:           true,
+			expectedWarnings: []string{"Could not check uncommitted changes: git command failed"},
+		},
+		{
+			name:             "unpushed check error blocks removal",
+			unpushedErr:      checkErr,
+			merged:           true,
+			expectedWarnings: []string{"Could not check unpushed commits: git command failed"},
+		},
+		{
+			name:             "merge check error blocks removal",
+			mergedErr:        checkErr,
+			expectedWarnings: []string{"Could not check merge status: git command failed"},
+		},
+		{
+			name:             "rebase in progress",
+			merged:           true,
+			operation:        "rebase",
+			expectedWarnings: []string{"rebase in progress"},
+		},
+		{
+			name:             "in-progress check error blocks removal",
+			merged:           true,
+			operationErr:     checkErr,
+			expectedWarnings: []string{"Could not check for an in-progress rebase/merge: git command failed"},
+		},
+		{
+			name:        "error and tripped checks are both reported",
+			uncommitted: true,
+			unpushedErr: checkErr,
+			expectedWarnings: []string{
+				"uncommitted changes",
+				"Could not check unpushed commits: git command failed",
+				"not merged to main",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mg := &mockGit{
+				HasUncommittedChangesFn: func() (bool, error) { return tt.uncommitted, tt.uncommittedErr },
+				HasUnpushedCommitsFn:    func() (bool, error) { return tt.unpushed, tt.unpushedErr },
+				IsMergedToBaseBranchFn:  func(string) (bool, error) { return tt.merged, tt.mergedErr },
+				IsInProgressOperationFn: func(string) (bool, string, error) {
+					return tt.operation != "", tt.operation, tt.operationErr
+				},
+			}
+
+			canRemove, warnings := EvaluateWorktreeSafety(mg, "/test/worktree", "feature/test", defaultBaseBranch)
+
+			if canRemove != tt.expectCanRemove {
+				t.Errorf("canRemove = %v, want %v", canRemove, tt.expectCanRemove)
+			}
+			if !reflect.DeepEqual(warnings, tt.expectedWarnings) {
+				t.Errorf("warnings = %q, want %q", warnings, tt.expectedWarnings)
+			}
+		})
+	}
+}
+
+func TestEvaluateWorktreeSafety_InvalidRepo(t *testing.T) {
+	mg := &mockGit{
+		HasUncommittedChangesFn: func() (bool, error) {
+			return false, &git.GitError{Args: []string{"status"}, ExitCode: 128}
+		},
+		HasUnpushedCommitsFn:   func() (bool, error) { return true, nil },
+		IsMergedToBaseBranchFn: func(string) (bool, error) { return false, nil },
+	}
+
+	canRemove, warnings := EvaluateWorktreeSafety(mg, "/gone", "feature/test", defaultBaseBranch)
+
+	if canRemove {
+		t.Error("expected canRemove=false for an invalid repository")
+	}
+	if !reflect.DeepEqual(warnings, []string{"invalid git repository"}) {
+		t.Errorf("expected single 'invalid git repository' warning, got %q", warnings)
+	}
+}
+
+func TestEvaluateWorktreeSafety_PassesBaseBranch(t *testing.T) {
+	var gotTarget string
+	mg := &mockGit{
+		IsMergedToBaseBranchAtFn: func(_, _, targetBranch string) (bool, error) {
+			gotTarget = targetBranch
+			return false, nil
+		},
+	}
+
+	_, warnings := EvaluateWorktreeSafety(mg, "/test/worktree", "feature/test", "develop")
+
+	if gotTarget != "develop" {
+		t.Errorf("expected merge check against develop, got %q", gotTarget)
+	}
+	if !reflect.DeepEqual(warnings, []string{"not merged to develop"}) {
+		t.Errorf("unexpected warnings: %q", warnings)
+	}
+}
+
+func TestEvaluateMergeSafety(t *testing.T) {
+	mg := &mockGit{
+		HasUncommittedChangesFn: func() (bool, error) { return true, nil },
+		HasUnpushedCommitsFn:    func() (bool, error) { return true, nil },
+		IsMergedToBaseBranchFn:  func(string) (bool, error) { return false, fmt.Errorf("git command failed") },
+		IsInProgressOperationFn: func(string) (bool, string, error) { return true, "rebase", nil },
+	}
+
+	canRemove, warnings := evaluateMergeSafety(mg, "/test/worktree", "feature/test", defaultBaseBranch)
+
+	if canRemove {
+		t.Error("expected canRemove=false")
+	}
+	// The branch is about to be merged: unpushed and merge-status results
+	// (including a failed merge check) do not matter.
+	if want := []string{"uncommitted changes", "rebase in progress"}; !reflect.DeepEqual(warnings, want) {
+		t.Errorf("warnings = %q, want %q", warnings, want)
+	}
+}
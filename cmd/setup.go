@@ -0,0 +1,35 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var setupCmd = &cobra.Command{
+	Use:   "setup [issue-number-or-branch]",
+	Short: "Run package-manager setup in an existing worktree",
+	Long: `Runs the package-manager setup gw start and gw checkout run in a new
+worktree (npm install, bundle install, ...) again in an existing one, e.g.
+after the setup was interrupted. If no issue number is provided, the worktree
+you are in is set up.`,
+	Example: `  # Finish an interrupted setup in the current worktree
+  gw setup
+
+  gw setup 123`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runSetup,
+}
+
+func init() {
+	rootCmd.AddCommand(setupCmd)
+}
+
+func runSetup(cmd *cobra.Command, args []string) error {
+	var issueNumber string
+	if len(args) > 0 {
+		issueNumber = args[0]
+	}
+
+	deps := DefaultDependencies()
+	setupCmd := NewSetupCommand(deps)
+	return setupCmd.Execute(issueNumber)
+}
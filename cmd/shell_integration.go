@@ -15,6 +15,7 @@ var (
 	shellIntegrationShowScript bool
 	shellIntegrationShell      string
 	shellIntegrationPrintPath  string
+	shellIntegrationPrintOnly  bool
 )
 
 var shellIntegrationCmd = &cobra.Command{
@@ -26,7 +27,16 @@ Use --show-script to output shell integration code that can be eval'd:
   eval "$(gw shell-integration --show-script --shell=zsh)"
 
 Use --print-path to get the worktree path for a specific issue or branch:
-  cd $(gw shell-integration --print-path=123)`,
+  cd "$(gw shell-integration --print-path=123)"
+
+Use --print-only to inspect the script that would be eval'd, headed by a
+comment saying which shell it was generated for:
+  gw shell-integration --print-only`,
+	Example: `  # Add to ~/.bashrc or ~/.zshrc
+  eval "$(gw shell-integration --show-script --shell=bash)"
+
+  # fish: add to ~/.config/fish/config.fish
+  gw shell-integration --show-script --shell=fish | source`,
 	RunE: runShellIntegration,
 }
 
@@ -35,6 +45,8 @@ func init() {
 	shellIntegrationCmd.Flags().StringVar(&shellIntegrationShell, "shell", "", "Shell type (bash, zsh, fish). Auto-detected if not specified")
 	shellIntegrationCmd.Flags().StringVar(&shellIntegrationPrintPath, "print-path", "",
 		"Print worktree path for the specified issue or branch")
+	shellIntegrationCmd.Flags().BoolVar(&shellIntegrationPrintOnly, "print-only", false,
+		"Print the shell integration script for inspection, with a header comment naming the shell")
 	rootCmd.AddCommand(shellIntegrationCmd)
 }
 
@@ -44,6 +56,7 @@ func runShellIntegration(cmd *cobra.Command, args []string) error {
 	shellCmd.showScript = shellIntegrationShowScript
 	shellCmd.shell = shellIntegrationShell
 	shellCmd.printPath = shellIntegrationPrintPath
+	shellCmd.printOnly = shellIntegrationPrintOnly
 	return shellCmd.Execute()
 }
 
@@ -55,6 +68,7 @@ type ShellIntegrationCommand struct {
 	showScript bool
 	shell      string
 	printPath  string
+	printOnly  bool // --print-only: show the script with a debug header
 }
 
 // NewShellIntegrationCommand creates a new shell integration command handler
@@ -69,14 +83,17 @@ func NewShellIntegrationCommand(gitClient git.Interface, stdout, stderr io.Write
 // Execute runs the shell integration command
 func (c *ShellIntegrationCommand) Execute() error {
 	// Validate flags
-	if !c.showScript && c.printPath == "" {
+	if !c.showScript && !c.printOnly && c.printPath == "" {
 		return fmt.Errorf("either --show-script or --print-path must be specified")
 	}
 	if c.showScript && c.printPath != "" {
 		return fmt.Errorf("cannot use both --show-script and --print-path")
 	}
+	if c.printOnly && c.printPath != "" {
+		return fmt.Errorf("cannot use both --print-only and --print-path")
+	}
 
-	if c.showScript {
+	if c.showScript || c.printOnly {
 		return c.showShellScript()
 	}
 
@@ -86,21 +103,36 @@ func (c *ShellIntegrationCommand) Execute() error {
 func (c *ShellIntegrationCommand) showShellScript() error {
 	// Auto-detect shell if not specified
 	shell := c.shell
+	source := "--shell"
 	if shell == "" {
 		shell = c.detectShell()
+		source = "auto-detected from $SHELL"
+	}
+
+	script, err := shellIntegrationScript(shell)
+	if err != nil {
+		return err
+	}
+
+	if c.printOnly {
+		fmt.Fprintf(c.stdout, "# gw shell integration for %s (%s)\n", shell, source)
+		fmt.Fprintf(c.stdout, "# Printed by --print-only for inspection; this is what --show-script emits for eval.\n")
 	}
+	fmt.Fprint(c.stdout, script)
+	return nil
+}
 
-	// Validate shell
+// shellIntegrationScript returns the integration script for shell. It has no
+// side effects, so each shell's script can be checked directly in tests.
+func shellIntegrationScript(shell string) (string, error) {
 	switch shell {
 	case shellBash, shellZsh:
-		fmt.Fprint(c.stdout, c.getBashZshScript(shell))
+		return bashZshScript(shell), nil
 	case shellFish:
-		fmt.Fprint(c.stdout, c.getFishScript())
+		return fishScript(), nil
 	default:
-		return fmt.Errorf("unsupported shell: %s (supported: bash, zsh, fish)", shell)
+		return "", fmt.Errorf("unsupported shell: %s (supported: bash, zsh, fish)", shell)
 	}
-
-	return nil
 }
 
 func (c *ShellIntegrationCommand) detectShell() string {
@@ -121,13 +153,13 @@ func (c *ShellIntegrationCommand) detectShell() string {
 	}
 }
 
-// getBashZshScript returns the shell integration script for bash/zsh.
+// bashZshScript returns the shell integration script for bash/zsh.
 // The function body is dominated by raw string literals containing the embedded
 // shell script and zsh completion definition — not by Go logic — so the line
 // count is an artifact of the template rather than structural complexity.
 //
 //nolint:funlen // shell-script template string accounts for the line count
-func (c *ShellIntegrationCommand) getBashZshScript(shell string) string {
+func bashZshScript(shell string) string {
 	shebang := "#!/bin/bash"
 	if shell == shellZsh {
 		shebang = "#!/bin/zsh"
@@ -140,35 +172,49 @@ func (c *ShellIntegrationCommand) getBashZshScript(shell string) string {
 
 gw() {
     # Check if we should auto-cd after command
-    if [[ "$1" == "start" || "$1" == "checkout" ]] && [[ -f ~/.gwrc ]]; then
+    local gw_config="${GW_CONFIG:-$HOME/.gwrc}"
+    if [[ "$1" == "start" || "$1" == "checkout" || "$1" == "clean" || ( "$1" == "end" && " $* " == *" --cd-main "* ) ]] && [[ -f "$gw_config" ]]; then
         # Check if auto_cd is enabled
-        if grep -q "auto_cd = true" ~/.gwrc 2>/dev/null; then
-            # Run the actual command (output goes directly to terminal)
-            command gw "$@"
+        if grep -q "auto_cd = true" "$gw_config" 2>/dev/null; then
+            # gw writes the new worktree's path (the main worktree's for
+            # end --cd-main, or for a clean that removed the current
+            # directory) to $GW_CD_FILE, so its normal output still goes
+            # directly to the terminal
+            local gw_cd_file
+            gw_cd_file=$(mktemp 2>/dev/null) || gw_cd_file=""
+            GW_CD_FILE="$gw_cd_file" command gw "$@"
             local exit_code=$?
 
             # If command succeeded, get the worktree path and cd to it
             if [[ $exit_code -eq 0 ]]; then
+                local worktree_path=""
+                if [[ -n "$gw_cd_file" ]]; then
+                    worktree_path=$(cat "$gw_cd_file" 2>/dev/null)
+                fi
+
+                # Fall back to asking gw when no path file could be created;
+                # an empty file means gw chose not to change directory (e.g.
+                # new_window_cmd opened the worktree in a new window)
                 local identifier="${2:-}"  # Get issue number or branch name
-                if [[ -n "$identifier" ]]; then
-                    # Get the worktree path using shell-integration command
-                    local worktree_path=$(command gw shell-integration --print-path="$identifier" 2>/dev/null)
+                if [[ -z "$gw_cd_file" && -n "$identifier" ]]; then
+                    worktree_path=$(command gw shell-integration --print-path="$identifier" 2>/dev/null)
+                fi
 
-                    # If we got a path, cd to it
-                    if [[ -n "$worktree_path" && -d "$worktree_path" ]]; then
-                        cd "$worktree_path"
-                        echo "Changed directory to: $worktree_path"
-                    fi
+                # If we got a path, cd to it
+                if [[ -n "$worktree_path" && -d "$worktree_path" ]]; then
+                    cd "$worktree_path"
+                    echo "Changed directory to: $worktree_path"
                 fi
             fi
 
+            [[ -n "$gw_cd_file" ]] && rm -f "$gw_cd_file"
             return $exit_code
         else
             # Auto CD disabled, just run the command normally
             command gw "$@"
         fi
     else
-        # Not a start/checkout command, just run normally
+        # Not a start/checkout/clean/end --cd-main command, just run normally
         command gw "$@"
     fi
 }
@@ -229,7 +275,7 @@ compdef _gw gw
 	return script
 }
 
-func (c *ShellIntegrationCommand) getFishScript() string {
+func fishScript() string {
 	return `#!/usr/bin/env fish
 # gw shell integration for Fish
 # This script is dynamically generated by 'gw shell-integration --show-script'
@@ -237,29 +283,49 @@ func (c *ShellIntegrationCommand) getFishScript() string {
 
 function gw
     # Check if we should auto-cd after command
-    if test "$argv[1]" = "start" -o "$argv[1]" = "checkout"
-        if test -f ~/.gwrc
+    set -l gw_config ~/.gwrc
+    if set -q GW_CONFIG
+        set gw_config $GW_CONFIG
+    end
+    if test "$argv[1]" = "start" -o "$argv[1]" = "checkout" -o "$argv[1]" = "clean"; or begin; test "$argv[1]" = "end"; and contains -- --cd-main $argv; end
+        if test -f "$gw_config"
             # Check if auto_cd is enabled
-            if grep -q "auto_cd = true" ~/.gwrc 2>/dev/null
-                # Run the actual command (output goes directly to terminal)
+            if grep -q "auto_cd = true" "$gw_config" 2>/dev/null
+                # gw writes the new worktree's path (the main worktree's for
+                # end --cd-main, or for a clean that removed the current
+                # directory) to $GW_CD_FILE, so its normal output still goes
+                # directly to the terminal
+                set -l gw_cd_file (mktemp 2>/dev/null)
+                set -lx GW_CD_FILE $gw_cd_file
                 command gw $argv
                 set exit_code $status
-                
+                set -e GW_CD_FILE
+
                 # If command succeeded, get the worktree path and cd to it
                 if test $exit_code -eq 0
+                    set -l worktree_path ""
+                    if test -n "$gw_cd_file"
+                        set worktree_path (cat "$gw_cd_file" 2>/dev/null)
+                    end
+
+                    # Fall back to asking gw when no path file could be created;
+                    # an empty file means gw chose not to change directory (e.g.
+                    # new_window_cmd opened the worktree in a new window)
                     set identifier "$argv[2]"  # Get issue number or branch name
-                    if test -n "$identifier"
-                        # Get the worktree path using shell-integration command
+                    if test -z "$gw_cd_file" -a -n "$identifier"
                         set worktree_path (command gw shell-integration --print-path="$identifier" 2>/dev/null)
-                        
-                        # If we got a path, cd to it
-                        if test -n "$worktree_path" -a -d "$worktree_path"
-                            cd "$worktree_path"
-                            echo "Changed directory to: $worktree_path"
-                        end
                     end
+
+                    # If we got a path, cd to it
+                    if test -n "$worktree_path" -a -d "$worktree_path"
+                        cd "$worktree_path"
+                        echo "Changed directory to: $worktree_path"
+                    end
+                end
+
+                if test -n "$gw_cd_file"
+                    rm -f "$gw_cd_file"
                 end
-                
                 return $exit_code
             else
                 # Auto CD disabled, just run the command normally
@@ -270,7 +336,7 @@ function gw
             command gw $argv
         end
     else
-        # Not a start/checkout command, just run normally
+        # Not a start/checkout/clean/end --cd-main command, just run normally
         command gw $argv
     end
 end
@@ -290,7 +356,7 @@ func (c *ShellIntegrationCommand) printWorktreePath() error {
 
 	// Check if we're in a git repository
 	if !gitClient.IsGitRepository() {
-		return fmt.Errorf("not in a git repository")
+		return git.ErrNotGitRepository
 	}
 
 	// Try to find the worktree path
@@ -324,7 +390,7 @@ func findWorktreePath(gitClient git.Interface, identifier string) (string, error
 
 	// First, check if the expected directory exists (most common case after 'gw start')
 	// This works even if git worktree list hasn't updated yet
-	expectedPath := git.ResolveWorktreePath(repoRoot, repoName, identifier)
+	expectedPath := git.ResolveWorktreePath(gitClient.WorktreeRoot(), repoRoot, repoName, identifier)
 	if info, err := os.Stat(expectedPath); err == nil && info.IsDir() {
 		return expectedPath, nil
 	}
@@ -347,11 +413,11 @@ func findWorktreePath(gitClient git.Interface, identifier string) (string, error
 	// If not found as issue, try as branch name (for checkout command)
 	sanitizedBranchName := git.SanitizeBranchNameForDirectory(identifier)
 	if sanitizedBranchName != identifier {
-		expectedPath = git.ResolveWorktreePath(repoRoot, repoName, sanitizedBranchName)
+		expectedPath = git.ResolveWorktreePath(gitClient.WorktreeRoot(), repoRoot, repoName, sanitizedBranchName)
 		if info, err := os.Stat(expectedPath); err == nil && info.IsDir() {
 			return expectedPath, nil
 		}
 	}
 
-	return "", fmt.Errorf("worktree not found for: %s", identifier)
+	return "", fmt.Errorf("%w for: %s", git.ErrWorktreeNotFound, identifier)
 }
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"fmt"
 	"os"
+	"os/exec"
 	"path/filepath"
 	"strings"
 	"testing"
@@ -539,10 +540,8 @@ func TestFindWorktreePath_ListWorktreesError(t *testing.T) {
 }
 
 func TestShellIntegrationCommand_GetBashZshScript(t *testing.T) {
-	cmd := &ShellIntegrationCommand{}
-
 	t.Run("bash script has bash shebang", func(t *testing.T) {
-		script := cmd.getBashZshScript("bash")
+		script := bashZshScript("bash")
 		if !strings.HasPrefix(script, "#!/bin/bash") {
 			t.Error("expected bash shebang at start")
 		}
@@ -552,7 +551,7 @@ func TestShellIntegrationCommand_GetBashZshScript(t *testing.T) {
 	})
 
 	t.Run("zsh script has zsh shebang", func(t *testing.T) {
-		script := cmd.getBashZshScript("zsh")
+		script := bashZshScript("zsh")
 		if !strings.HasPrefix(script, "#!/bin/zsh") {
 			t.Error("expected zsh shebang at start")
 		}
@@ -562,7 +561,7 @@ func TestShellIntegrationCommand_GetBashZshScript(t *testing.T) {
 	})
 
 	t.Run("zsh script has completion function", func(t *testing.T) {
-		script := cmd.getBashZshScript("zsh")
+		script := bashZshScript("zsh")
 		if !strings.Contains(script, "_gw()") {
 			t.Error("expected _gw completion function in zsh script")
 		}
@@ -578,7 +577,7 @@ func TestShellIntegrationCommand_GetBashZshScript(t *testing.T) {
 	})
 
 	t.Run("bash script does not have zsh completion", func(t *testing.T) {
-		script := cmd.getBashZshScript("bash")
+		script := bashZshScript("bash")
 		if strings.Contains(script, "compdef") {
 			t.Error("bash script should not contain zsh compdef")
 		}
@@ -586,9 +585,7 @@ func TestShellIntegrationCommand_GetBashZshScript(t *testing.T) {
 }
 
 func TestShellIntegrationCommand_GetFishScript(t *testing.T) {
-	cmd := &ShellIntegrationCommand{}
-
-	script := cmd.getFishScript()
+	script := fishScript()
 	if !strings.Contains(script, "#!/usr/bin/env fish") {
 		t.Error("expected fish shebang")
 	}
@@ -600,6 +597,181 @@ func TestShellIntegrationCommand_GetFishScript(t *testing.T) {
 	}
 }
 
+func TestShellIntegrationScript(t *testing.T) {
+	tests := []struct {
+		shell string
+		want  []string
+	}{
+		{
+			shell: "bash",
+			want: []string{
+				`if [[ "$1" == "start" || "$1" == "checkout" || "$1" == "clean" || ( "$1" == "end" && " $* " == *" --cd-main "* ) ]]`,
+				`command gw shell-integration --print-path="$identifier"`,
+				`cd "$worktree_path"`,
+				`return $exit_code`,
+			},
+		},
+		{
+			shell: "zsh",
+			want: []string{
+				`if [[ "$1" == "start" || "$1" == "checkout" || "$1" == "clean" || ( "$1" == "end" && " $* " == *" --cd-main "* ) ]]`,
+				`command gw shell-integration --print-path="$identifier"`,
+				`cd "$worktree_path"`,
+				`compdef _gw gw`,
+			},
+		},
+		{
+			shell: "fish",
+			want: []string{
+				`if test "$argv[1]" = "start" -o "$argv[1]" = "checkout" -o "$argv[1]" = "clean"; or begin; test "$argv[1]" = "end"; and contains -- --cd-main $argv; end`,
+				`command gw shell-integration --print-path="$identifier"`,
+				`cd "$worktree_path"`,
+				`return $exit_code`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.shell, func(t *testing.T) {
+			script, err := shellIntegrationScript(tt.shell)
+			if err != nil {
+				t.Fatalf("shellIntegrationScript(%q) failed: %v", tt.shell, err)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(script, want) {
+					t.Errorf("%s script missing %q", tt.shell, want)
+				}
+			}
+		})
+	}
+
+	if _, err := shellIntegrationScript("tcsh"); err == nil {
+		t.Error("expected error for unsupported shell")
+	}
+}
+
+// TestShellIntegrationScript_PathWithSpaces sources the generated script in a
+// real shell, with a stub gw that reports a worktree under a directory whose
+// name has spaces and quotes, and checks the function cds into it intact. The
+// stub reports the path either through $GW_CD_FILE or, like an older gw, only
+// through --print-path.
+func TestShellIntegrationScript_PathWithSpaces(t *testing.T) {
+	tempDir := t.TempDir()
+	worktreePath := filepath.Join(tempDir, "My Projects", `it's "here"`, "repo-123")
+	if err := os.MkdirAll(worktreePath, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	binDir := filepath.Join(tempDir, "bin")
+	os.MkdirAll(binDir, 0755)
+	// In print-path mode no path file can be created, so the function asks
+	// gw shell-integration --print-path; in no-cd mode gw leaves the file
+	// empty (as with new_window_cmd) and the shell must stay where it is.
+	stub := `#!/bin/sh
+case "$GW_TEST_MODE:$1" in
+cd-file:start) echo "Creating worktree..."; printf '%s' "$GW_TEST_WORKTREE" > "$GW_CD_FILE" ;;
+print-path:shell-integration|no-cd:shell-integration) printf '%s\n' "$GW_TEST_WORKTREE" ;;
+esac
+`
+	if err := os.WriteFile(filepath.Join(binDir, "gw"), []byte(stub), 0755); err != nil {
+		t.Fatal(err)
+	}
+	configPath := filepath.Join(tempDir, ".gwrc")
+	os.WriteFile(configPath, []byte("auto_cd = true\n"), 0644)
+
+	for _, shell := range []string{"bash", "fish"} {
+		for _, mode := range []string{"cd-file", "print-path", "no-cd"} {
+			t.Run(shell+"/"+mode, func(t *testing.T) {
+				shellPath, err := exec.LookPath(shell)
+				if err != nil {
+					t.Skipf("%s not installed", shell)
+				}
+				script, err := shellIntegrationScript(shell)
+				if err != nil {
+					t.Fatal(err)
+				}
+				scriptPath := filepath.Join(tempDir, "gw."+shell)
+				os.WriteFile(scriptPath, []byte(script), 0644)
+
+				command := `source "$GW_TEST_SCRIPT" && gw start 123 >/dev/null && pwd`
+				if shell == "fish" {
+					command = `source $GW_TEST_SCRIPT; and gw start 123 >/dev/null; and pwd`
+				}
+				cmd := exec.Command(shellPath, "-c", command)
+				cmd.Dir = tempDir
+				cmd.Env = append(os.Environ(),
+					"PATH="+binDir+string(os.PathListSeparator)+os.Getenv("PATH"),
+					"GW_CONFIG="+configPath,
+					"GW_TEST_MODE="+mode,
+					"GW_TEST_WORKTREE="+worktreePath,
+					"GW_TEST_SCRIPT="+scriptPath,
+				)
+				want := worktreePath
+				switch mode {
+				case "print-path":
+					cmd.Env = append(cmd.Env, "TMPDIR="+filepath.Join(tempDir, "missing"))
+				case "no-cd":
+					want = tempDir
+				}
+				out, err := cmd.CombinedOutput()
+				if err != nil {
+					t.Fatalf("%s failed: %v\n%s", shell, err, out)
+				}
+				if got := strings.TrimSpace(string(out)); canonicalDir(got) != canonicalDir(want) {
+					t.Errorf("pwd = %q, want %q", got, want)
+				}
+			})
+		}
+	}
+}
+
+func TestShellIntegrationCommand_PrintOnly(t *testing.T) {
+	t.Run("prints the script with a header naming the shell", func(t *testing.T) {
+		stdout := &bytes.Buffer{}
+		cmd := NewShellIntegrationCommand(nil, stdout, &bytes.Buffer{})
+		cmd.printOnly = true
+		cmd.shell = "zsh"
+
+		if err := cmd.Execute(); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		script, _ := shellIntegrationScript("zsh")
+		header, body, _ := strings.Cut(stdout.String(), "#!/bin/zsh")
+		if !strings.HasPrefix(header, "# gw shell integration for zsh (--shell)\n") {
+			t.Errorf("unexpected header: %q", header)
+		}
+		if "#!/bin/zsh"+body != script {
+			t.Error("expected the generated script verbatim after the header")
+		}
+	})
+
+	t.Run("reports an auto-detected shell", func(t *testing.T) {
+		t.Setenv("SHELL", "/usr/bin/fish")
+		stdout := &bytes.Buffer{}
+		cmd := NewShellIntegrationCommand(nil, stdout, &bytes.Buffer{})
+		cmd.printOnly = true
+
+		if err := cmd.Execute(); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if !strings.HasPrefix(stdout.String(), "# gw shell integration for fish (auto-detected from $SHELL)") {
+			t.Errorf("unexpected output: %q", stdout.String())
+		}
+	})
+
+	t.Run("cannot be combined with --print-path", func(t *testing.T) {
+		cmd := NewShellIntegrationCommand(nil, &bytes.Buffer{}, &bytes.Buffer{})
+		cmd.printOnly = true
+		cmd.printPath = "123"
+
+		err := cmd.Execute()
+		if err == nil || !strings.Contains(err.Error(), "cannot use both --print-only and --print-path") {
+			t.Errorf("expected conflict error, got %v", err)
+		}
+	})
+}
+
 func TestNewShellIntegrationCommand(t *testing.T) {
 	stdout := &bytes.Buffer{}
 	stderr := &bytes.Buffer{}
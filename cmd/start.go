@@ -1,13 +1,31 @@
 package cmd
 
 import (
+	"fmt"
+
+	"github.com/sotarok/gw/internal/stats"
 	"github.com/spf13/cobra"
 )
 
 var (
-	startCopyEnvs       bool
-	startNoFetch        bool
-	startNoProjectHooks bool
+	startCopyEnvs        bool
+	startNoFetch         bool
+	startNoProjectHooks  bool
+	startStash           bool
+	startPatch           string
+	startTemplate        string
+	startCopyFrom        string
+	startOverwriteEnvs   bool
+	startOpen            bool
+	startTrack           string
+	startBaseFromDefault bool
+	startPrintPath       bool
+	startOrphan          bool
+	startPreview         bool
+	startLock            bool
+	startLockReason      string
+	startCopyFromCurrent bool
+	startNoCD            bool
 )
 
 var startCmd = &cobra.Command{
@@ -23,18 +41,79 @@ If a branch name with "/" is provided (e.g., "476/impl-migration-script"), it cr
   - Branch: Exactly as provided
   - Directory: ../{repository-name}-{sanitized-branch-name}
 
-Examples:
-  gw start 123              # Creates branch "123/impl"
+A template is defined in ~/.gwrc and sets the base branch and a branch prefix:
+  template.feature.base = develop
+  template.feature.prefix = feature/
+With that template, "gw start --template feature login" creates branch
+"feature/login" from develop. A base branch given as the second argument
+still takes precedence.
+
+With --base-from-default, or always_branch_from_remote_default = true in the
+config, the branch starts at the remote's default branch (e.g. origin/main)
+right after a fetch instead of at a possibly stale local base branch. The
+config key does not apply when a base branch, template base or --track is
+given.
+
+With --orphan, the branch is created exactly as named, as an orphan branch
+with no history and an empty worktree, e.g. for gh-pages or a docs branch.
+
+With --preview, gw start only prints the branch, worktree directory and base
+it would use, to check naming and templates; nothing is fetched or created.
+--dry-run prints the same before the git commands it would run.
+
+With --lock, the worktree is created locked (git worktree add --lock), so
+gw clean and git worktree prune leave it alone; --lock-reason records why.
+
+With --no-cd, the shell stays in the current directory even when auto_cd is
+enabled, e.g. when creating several worktrees in a row; the new worktree's
+path is still printed.`,
+	Example: `  gw start 123                        # Creates branch "123/impl"
+  gw start 123 develop                # Creates "123/impl" from develop instead of main
   gw start 476/impl-migration-script  # Creates branch "476/impl-migration-script"
-  gw start feature/new-feature        # Creates branch "feature/new-feature"`,
+  gw start feature/new-feature        # Creates branch "feature/new-feature"
+  gw start 123 --stash                # Also applies the latest stash in the new worktree
+  gw start 123 --patch fix.patch      # Also applies fix.patch in the new worktree
+  gw start 123 --copy-from ../repo-456  # Also copies untracked/ignored files (e.g. .idea/) from another worktree
+  gw start 124 --copy-from-current    # Same, from the worktree you are in
+  gw start --template feature login   # Uses the "feature" template from ~/.gwrc
+  gw start feature --track origin/foo # Creates "feature" from origin/foo, tracking it
+  gw start 123 --base-from-default    # Creates "123/impl" from freshly fetched origin/HEAD
+  gw start gh-pages --orphan          # Creates "gh-pages" with no history, in an empty worktree
+  cd "$(gw start 123 --print-path)"   # Prints only the worktree path, for scripts
+  gw start --template feature login --preview  # Shows the branch and directory without creating them
+  gw start 123 --lock-reason "long-lived"      # Creates the worktree locked, with a reason
+  gw start 124 --no-cd                # Creates the worktree but stays in the current directory`,
 	Args: cobra.RangeArgs(1, 2), //nolint:mnd // min=1 (issue), max=2 (issue + base-branch) — obvious in context
 	RunE: runStart,
 }
 
 func init() {
 	startCmd.Flags().BoolVar(&startCopyEnvs, "copy-envs", false, "Copy untracked .env files to the new worktree")
+	startCmd.Flags().BoolVar(&startOverwriteEnvs, "overwrite-envs", false, "Overwrite env files that already exist in the worktree with different content")
 	startCmd.Flags().BoolVar(&startNoFetch, "no-fetch", false, "Skip git fetch before running the command")
 	startCmd.Flags().BoolVar(&startNoProjectHooks, "no-project-hooks", false, "Skip project-local .gwrc hook overrides for this run")
+	startCmd.Flags().BoolVar(&startStash, "stash", false, "Apply the latest stash entry in the new worktree")
+	startCmd.Flags().StringVar(&startPatch, "patch", "", "Apply a patch file in the new worktree")
+	startCmd.Flags().StringVar(&startCopyFrom, "copy-from", "", "Copy untracked and ignored files from another worktree into the new one")
+	startCmd.Flags().BoolVar(&startCopyFromCurrent, "copy-from-current", false, "Copy untracked and ignored files from the current worktree into the new one")
+	startCmd.MarkFlagsMutuallyExclusive("copy-from", "copy-from-current")
+	startCmd.Flags().StringVar(&startTemplate, "template", "", "Apply a named template (base branch and branch prefix) from the config")
+	startCmd.Flags().StringVar(&startTrack, "track", "", "Start the new branch at this remote branch (e.g. origin/foo) and set it as upstream")
+	startCmd.Flags().BoolVar(&startOpen, "open", false, "Open the new worktree in the editor (editor key or $EDITOR)")
+	startCmd.Flags().BoolVar(&startBaseFromDefault, "base-from-default", false, "Start the new branch at the remote's default branch (e.g. origin/main), fetched first")
+	startCmd.MarkFlagsMutuallyExclusive("stash", "patch")
+	startCmd.Flags().BoolVar(&startPrintPath, "print-path", false, "Print only the worktree path on stdout (status goes to stderr) and never prompt")
+	startCmd.MarkFlagsMutuallyExclusive("track", "base-from-default")
+	startCmd.MarkFlagsMutuallyExclusive("open", "print-path")
+	startCmd.Flags().BoolVar(&startPreview, "preview", false, "Print the branch and worktree path that would be created, without creating anything")
+	startCmd.MarkFlagsMutuallyExclusive("preview", "print-path")
+	startCmd.Flags().BoolVar(&startLock, "lock", false, "Create the worktree locked, so gw clean and git worktree prune leave it alone")
+	startCmd.Flags().StringVar(&startLockReason, "lock-reason", "", "Why the worktree is locked (implies --lock)")
+	startCmd.Flags().BoolVar(&startNoCD, "no-cd", false, "Stay in the current directory even when auto_cd is enabled")
+	startCmd.Flags().BoolVar(&startOrphan, "orphan", false, "Create the branch as named, with no history, in an empty worktree (e.g. gh-pages)")
+	for _, flag := range []string{"track", "base-from-default", "template", "stash", "patch"} {
+		startCmd.MarkFlagsMutuallyExclusive("orphan", flag)
+	}
 	rootCmd.AddCommand(startCmd)
 }
 
@@ -46,8 +125,48 @@ func runStart(cmd *cobra.Command, args []string) error {
 		baseBranch = args[1]
 	}
 
+	if startTrack != "" && len(args) > 1 {
+		return fmt.Errorf("--track cannot be combined with a base branch: the tracked branch is the starting point")
+	}
+	if startOrphan && len(args) > 1 {
+		return fmt.Errorf("--orphan cannot be combined with a base branch: an orphan branch has no history to start from")
+	}
+	if startBaseFromDefault && len(args) > 1 {
+		return fmt.Errorf("--base-from-default cannot be combined with a base branch: the remote's default branch is the starting point")
+	}
+
 	// Use the new command structure
 	deps := DefaultDependencies()
+	if startPrintPath {
+		defer redirectOSStdout(deps)()
+	}
+
+	issueNumber, baseBranch, err := applyStartTemplate(deps.Config, startTemplate, issueNumber, baseBranch, len(args) > 1)
+	if err != nil {
+		return err
+	}
+
 	startCmd := NewStartCommand(deps, startCopyEnvs, startNoFetch, startNoProjectHooks)
-	return startCmd.Execute(issueNumber, baseBranch)
+	startCmd.applyStash = startStash
+	startCmd.patchFile = startPatch
+	startCmd.copyFrom = startCopyFrom
+	startCmd.copyFromCurrent = startCopyFromCurrent
+	startCmd.overwriteEnvs = startOverwriteEnvs
+	startCmd.openEditor = startOpen
+	startCmd.trackBranch = startTrack
+	startCmd.printPath = startPrintPath
+	startCmd.orphan = startOrphan
+	startCmd.preview = startPreview
+	startCmd.lock = startLock
+	startCmd.lockReason = startLockReason
+	startCmd.noCD = startNoCD
+	// The config key only stands in for a base branch nobody chose: an
+	// argument, a template base or --track wins over it.
+	startCmd.baseFromDefault = startBaseFromDefault ||
+		(deps.Config.AlwaysBranchFromRemoteDefault && len(args) == 1 && baseBranch == defaultBaseBranch && startTrack == "" && !startOrphan)
+	if err := startCmd.Execute(issueNumber, baseBranch); err != nil {
+		return err
+	}
+	recordUsage(deps, stats.CommandStart)
+	return nil
 }
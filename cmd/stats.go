@@ -0,0 +1,38 @@
+package cmd
+
+import (
+	"github.com/sotarok/gw/internal/stats"
+	"github.com/spf13/cobra"
+)
+
+var statsReset bool
+
+var statsCmd = &cobra.Command{
+	Use:   "stats",
+	Short: "Show local usage counts of start, end and clean",
+	Long: `Shows how many times gw start, gw end and gw clean completed.
+
+Tracking is off by default; enable it with track_stats = true in ~/.gwrc.
+The counts are stored only in ~/.gw/stats.json and are never sent anywhere.
+Use --reset to clear them.`,
+	Example: `  gw stats
+
+  gw stats --reset`,
+	Args: cobra.NoArgs,
+	RunE: runStats,
+}
+
+func init() {
+	rootCmd.AddCommand(statsCmd)
+	statsCmd.Flags().BoolVar(&statsReset, "reset", false, "Clear the recorded counts")
+}
+
+func runStats(cmd *cobra.Command, args []string) error {
+	path, err := stats.DefaultPath()
+	if err != nil {
+		return err
+	}
+	deps := DefaultDependencies()
+	statsCmd := NewStatsCommand(deps, path)
+	return statsCmd.Execute(statsReset)
+}
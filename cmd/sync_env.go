@@ -0,0 +1,41 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var syncEnvOverwrite bool
+
+var syncEnvCmd = &cobra.Command{
+	Use:   "sync-env [issue-number-or-branch]",
+	Short: "Copy env files added to the main worktree into an existing worktree",
+	Long: `Copies the untracked environment files (.env, .env.local, ...) of the main
+worktree that are missing from a worktree into it, e.g. after adding a new
+.env file once the worktree was created.
+If no issue number is provided, an interactive selector will be shown.
+
+Files that already exist in the worktree are left alone; those whose content
+differs are reported, and replaced only with --overwrite.`,
+	Example: `  # Copy env files that changed in the main worktree into the current one
+  gw sync-env
+
+  gw sync-env 123 --overwrite`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runSyncEnv,
+}
+
+func init() {
+	rootCmd.AddCommand(syncEnvCmd)
+	syncEnvCmd.Flags().BoolVar(&syncEnvOverwrite, "overwrite", false, "Replace env files that differ in the worktree")
+}
+
+func runSyncEnv(cmd *cobra.Command, args []string) error {
+	var issueNumber string
+	if len(args) > 0 {
+		issueNumber = args[0]
+	}
+
+	deps := DefaultDependencies()
+	syncEnvCmd := NewSyncEnvCommand(deps, syncEnvOverwrite)
+	return syncEnvCmd.Execute(issueNumber)
+}
@@ -0,0 +1,129 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/sotarok/gw/internal/config"
+	"github.com/sotarok/gw/internal/glyph"
+)
+
+// outputTheme is the set of glyphs and colors commands decorate their output
+// with. The active theme is chosen from the config by applyTheme.
+type outputTheme struct {
+	success, error, warning, arrow string
+	ready, hint                    string
+	bullet, dash                   string // list items and "name — reason" separators
+
+	// Styles for the colored glyphs (lipgloss handles NO_COLOR automatically)
+	successStyle, errorStyle, warningStyle, arrowStyle lipgloss.Style
+}
+
+var defaultTheme = outputTheme{
+	success: glyph.Success.Unicode,
+	error:   glyph.Error.Unicode,
+	warning: glyph.Warning.Unicode,
+	arrow:   glyph.Arrow.Unicode,
+	ready:   glyph.Ready.Unicode,
+	hint:    glyph.Hint.Unicode,
+	bullet:  glyph.Bullet.Unicode,
+	dash:    glyph.Dash.Unicode,
+
+	successStyle: lipgloss.NewStyle().Foreground(lipgloss.Color("2")), // Green
+	errorStyle:   lipgloss.NewStyle().Foreground(lipgloss.Color("1")), // Red
+	warningStyle: lipgloss.NewStyle().Foreground(lipgloss.Color("3")), // Yellow
+	arrowStyle:   lipgloss.NewStyle().Foreground(lipgloss.Color("4")), // Blue
+}
+
+// asciiTheme is the "ascii" preset for terminals that cannot show emoji or
+// other non-ASCII glyphs.
+var asciiTheme = func() outputTheme {
+	t := defaultTheme
+	t.success, t.error, t.warning, t.arrow = glyph.Success.ASCII, glyph.Error.ASCII, glyph.Warning.ASCII, glyph.Arrow.ASCII
+	t.ready, t.hint = glyph.Ready.ASCII, glyph.Hint.ASCII
+	t.bullet, t.dash = glyph.Bullet.ASCII, glyph.Dash.ASCII
+	return t
+}()
+
+// activeTheme is the theme used by the colored* helpers.
+var activeTheme = defaultTheme
+
+// Colored symbol functions return symbols with appropriate colors
+func coloredSuccess() string { return activeTheme.successStyle.Render(activeTheme.success) }
+func coloredError() string   { return activeTheme.errorStyle.Render(activeTheme.error) }
+func coloredWarning() string { return activeTheme.warningStyle.Render(activeTheme.warning) }
+func coloredArrow() string   { return activeTheme.arrowStyle.Render(activeTheme.arrow) }
+
+// colorNames maps the color names accepted in theme.*_color to ANSI colors.
+// Any other value (an ANSI number or "#rrggbb") is passed to lipgloss as is.
+var colorNames = map[string]string{
+	"black":   "0",
+	"red":     "1",
+	"green":   "2",
+	"yellow":  "3",
+	"blue":    "4",
+	"magenta": "5",
+	"cyan":    "6",
+	"white":   "7",
+}
+
+// resolveTheme builds the output theme for the config's theme settings: the
+// preset first, then every glyph or color set explicitly.
+func resolveTheme(cfg config.Theme) (outputTheme, error) {
+	var t outputTheme
+	switch cfg.Preset {
+	case "", config.ThemePresetDefault:
+		t = defaultTheme
+	case config.ThemePresetASCII:
+		t = asciiTheme
+	default:
+		return defaultTheme, fmt.Errorf("unknown theme %q (available: %s, %s), using the default", cfg.Preset, config.ThemePresetDefault, config.ThemePresetASCII)
+	}
+
+	overrideString(&t.success, cfg.Success)
+	overrideString(&t.error, cfg.Error)
+	overrideString(&t.warning, cfg.Warning)
+	overrideString(&t.arrow, cfg.Arrow)
+	overrideString(&t.ready, cfg.Ready)
+	overrideString(&t.hint, cfg.Hint)
+	overrideColor(&t.successStyle, cfg.SuccessColor)
+	overrideColor(&t.errorStyle, cfg.ErrorColor)
+	overrideColor(&t.warningStyle, cfg.WarningColor)
+	overrideColor(&t.arrowStyle, cfg.ArrowColor)
+	return t, nil
+}
+
+func overrideString(dst *string, value string) {
+	if value != "" {
+		*dst = value
+	}
+}
+
+func overrideColor(style *lipgloss.Style, color string) {
+	if color == "" {
+		return
+	}
+	if ansi, ok := colorNames[color]; ok {
+		color = ansi
+	}
+	*style = style.Foreground(lipgloss.Color(color))
+}
+
+// applyTheme makes the theme configured in cfg the active one. An unknown
+// preset is returned as an error after falling back to the default theme.
+// ASCII mode ($GW_ASCII or --ascii) wins over the config: the ascii preset is
+// used and glyph overrides are dropped, keeping only the colors.
+func applyTheme(cfg config.Theme) error {
+	if glyph.ASCIIMode() {
+		cfg = config.Theme{
+			Preset:       config.ThemePresetASCII,
+			SuccessColor: cfg.SuccessColor,
+			ErrorColor:   cfg.ErrorColor,
+			WarningColor: cfg.WarningColor,
+			ArrowColor:   cfg.ArrowColor,
+		}
+	}
+	t, err := resolveTheme(cfg)
+	activeTheme = t
+	return err
+}
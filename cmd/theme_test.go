@@ -0,0 +1,195 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"unicode"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/sotarok/gw/internal/config"
+	"github.com/sotarok/gw/internal/git"
+	"github.com/sotarok/gw/internal/glyph"
+	"github.com/sotarok/gw/internal/ui"
+)
+
+// useTheme activates the theme for cfg for the rest of the test.
+func useTheme(t *testing.T, cfg config.Theme) {
+	t.Helper()
+	previous := activeTheme
+	t.Cleanup(func() { activeTheme = previous })
+	if err := applyTheme(cfg); err != nil {
+		t.Fatalf("applyTheme failed: %v", err)
+	}
+}
+
+func isASCII(s string) bool {
+	for _, r := range s {
+		if r > unicode.MaxASCII {
+			return false
+		}
+	}
+	return true
+}
+
+func TestApplyTheme_ASCIIPreset(t *testing.T) {
+	useTheme(t, config.Theme{Preset: config.ThemePresetASCII})
+
+	for _, glyph := range []string{coloredSuccess(), coloredError(), coloredWarning(), coloredArrow()} {
+		if !isASCII(glyph) {
+			t.Errorf("Expected an ASCII glyph, got %q", glyph)
+		}
+	}
+
+	originalDir, _ := os.Getwd()
+	defer os.Chdir(originalDir)
+	os.Chdir(t.TempDir())
+
+	stdout := &bytes.Buffer{}
+	deps := &Dependencies{
+		Git:    &mockGit{isGitRepo: true, worktreePath: t.TempDir()},
+		UI:     &mockUI{},
+		Detect: &mockDetect{},
+		Config: &config.Config{AutoCD: true},
+		Stdout: stdout,
+		Stderr: &bytes.Buffer{},
+	}
+	if err := NewStartCommand(deps, false, true, false).Execute("123", "main"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !isASCII(stdout.String()) {
+		t.Errorf("Expected emoji-free output, got:\n%s", stdout.String())
+	}
+	if !strings.Contains(stdout.String(), "* Worktree ready at:") {
+		t.Errorf("Expected the ascii ready prefix, got:\n%s", stdout.String())
+	}
+}
+
+func TestApplyTheme_ASCIIPreset_WarningLists(t *testing.T) {
+	useTheme(t, config.Theme{Preset: config.ThemePresetASCII})
+
+	t.Run("gw end safety warnings", func(t *testing.T) {
+		mg := &mockGit{
+			GetWorktreeForIssueFn: func(string) (*git.WorktreeInfo, error) {
+				return &git.WorktreeInfo{Path: "/repo-123", Branch: testBranch123}, nil
+			},
+			HasUncommittedChangesFn: func() (bool, error) { return true, nil },
+		}
+		deps, stdout, stderr := newListTestDeps(mg)
+		if err := NewEndCommand(deps, false, true, false).Execute("123"); err == nil {
+			t.Fatal("Expected the declined removal to abort")
+		}
+		output := stdout.String() + stderr.String()
+		if !isASCII(output) {
+			t.Errorf("Expected ASCII-only output, got:\n%s", output)
+		}
+		if !strings.Contains(stderr.String(), "  - ") {
+			t.Errorf("Expected ASCII list items, got:\n%s", stderr.String())
+		}
+	})
+
+	t.Run("gw clean --interactive", func(t *testing.T) {
+		var offered []ui.SelectorItem
+		mg := &mockGit{
+			ListWorktreesFn: func() ([]git.WorktreeInfo, error) {
+				return []git.WorktreeInfo{
+					{Path: "/repo", Branch: "main"},
+					{Path: "/repo-123", Branch: testBranch123},
+				}, nil
+			},
+			HasUncommittedChangesAtFn: func(string) (bool, error) { return true, nil },
+		}
+		mu := &mockUI{ShowMultiSelectorFn: func(_ string, items []ui.SelectorItem) ([]ui.SelectorItem, error) {
+			offered = items
+			return items, nil
+		}}
+		stdout := &bytes.Buffer{}
+		stderr := &bytes.Buffer{}
+		deps := &Dependencies{Config: &config.Config{}, Git: mg, UI: mu, Stdout: stdout, Stderr: stderr}
+		cmd := NewCleanCommand(deps, false, false, true, false)
+		cmd.interactive = true
+		if err := cmd.Execute(); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if len(offered) != 1 || offered[0].Name != "repo-123 ("+testBranch123+") - uncommitted changes" {
+			t.Errorf("Expected an ASCII separator in the selector item, got %+v", offered)
+		}
+		if !strings.Contains(stderr.String(), "  - repo-123 ("+testBranch123+"): uncommitted changes") {
+			t.Errorf("Expected ASCII list items, got:\n%s", stderr.String())
+		}
+		if output := stdout.String() + stderr.String(); !isASCII(output) {
+			t.Errorf("Expected ASCII-only output, got:\n%s", output)
+		}
+	})
+}
+
+func TestApplyTheme_Overrides(t *testing.T) {
+	useTheme(t, config.Theme{Success: "✔", ErrorColor: "red"})
+
+	if !strings.Contains(coloredSuccess(), "✔") {
+		t.Errorf("Expected the custom success symbol, got %q", coloredSuccess())
+	}
+	if !strings.Contains(coloredWarning(), defaultTheme.warning) {
+		t.Errorf("Expected unset glyphs to keep the default, got %q", coloredWarning())
+	}
+	if got := activeTheme.errorStyle.GetForeground(); got != lipgloss.Color("1") {
+		t.Errorf("error color = %v, want ANSI 1 (red)", got)
+	}
+}
+
+func TestApplyTheme_UnknownPreset(t *testing.T) {
+	previous := activeTheme
+	t.Cleanup(func() { activeTheme = previous })
+
+	err := applyTheme(config.Theme{Preset: "neon", Success: "✔"})
+	if err == nil || !strings.Contains(err.Error(), `unknown theme "neon"`) {
+		t.Errorf("Expected unknown theme error, got: %v", err)
+	}
+	if coloredSuccess() != defaultTheme.successStyle.Render(defaultTheme.success) {
+		t.Errorf("Expected the default theme after an unknown preset, got %q", coloredSuccess())
+	}
+}
+
+func TestASCIIFlag_OverridesConfiguredGlyphs(t *testing.T) {
+	t.Setenv(glyph.EnvASCII, "") // restored after the flag sets it
+	configPath := filepath.Join(t.TempDir(), ".gwrc")
+	os.WriteFile(configPath, []byte("auto_cd = true\ntheme.success = ✔\n"), 0600)
+	t.Setenv(config.EnvConfigPath, configPath)
+
+	previous := activeTheme
+	t.Cleanup(func() {
+		activeTheme = previous
+		asciiOutput = false
+	})
+
+	if err := rootCmd.PersistentFlags().Set("ascii", "true"); err != nil {
+		t.Fatalf("Failed to set --ascii: %v", err)
+	}
+	rootCmd.PersistentPreRun(rootCmd, nil)
+	if !glyph.ASCIIMode() {
+		t.Fatalf("Expected --ascii to export %s", glyph.EnvASCII)
+	}
+
+	deps := DefaultDependencies()
+	stdout := &bytes.Buffer{}
+	deps.Git = &mockGit{isGitRepo: true, worktreePath: t.TempDir()}
+	deps.UI = &mockUI{}
+	deps.Detect = &mockDetect{}
+	deps.Stdout = stdout
+	deps.Stderr = stdout
+
+	originalDir, _ := os.Getwd()
+	defer os.Chdir(originalDir)
+	os.Chdir(t.TempDir())
+
+	if err := NewStartCommand(deps, false, true, false).Execute("123", "main"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	output := stdout.String() + coloredSuccess() + glyph.Warning.String()
+	if !isASCII(output) {
+		t.Errorf("Expected no multibyte glyphs with --ascii, got:\n%s", output)
+	}
+}
@@ -0,0 +1,155 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/sotarok/gw/internal/config"
+	"github.com/spf13/cobra"
+)
+
+var uninstallRemoveConfig bool
+
+var uninstallCmd = &cobra.Command{
+	Use:   "uninstall",
+	Short: "Remove gw shell integration",
+	Long: `Remove the shell integration that 'gw init' added to your shell rc files.
+
+The ~/.zshrc, ~/.bashrc and ~/.config/fish/config.fish files are checked.
+Only the block (or line) written by gw is removed; the rest of each file is
+left as it was. An integration line you added by hand is reported so you can
+remove it yourself.
+
+Use --remove-config to also delete ~/.gwrc (you will be asked to confirm).`,
+	Example: `  # Remove the shell integration gw init added
+  gw uninstall
+
+  # Also delete ~/.gwrc
+  gw uninstall --remove-config`,
+	Args: cobra.NoArgs,
+	RunE: runUninstall,
+}
+
+func init() {
+	rootCmd.AddCommand(uninstallCmd)
+	uninstallCmd.Flags().BoolVar(&uninstallRemoveConfig, "remove-config", false, "Also delete ~/.gwrc after confirmation")
+}
+
+func runUninstall(cmd *cobra.Command, args []string) error {
+	uninstallCmd := NewUninstallCommand(os.Stdin, os.Stdout, os.Stderr, config.GetConfigPath())
+	uninstallCmd.removeConfig = uninstallRemoveConfig
+	return uninstallCmd.Execute()
+}
+
+// UninstallCommand handles the uninstall command logic
+type UninstallCommand struct {
+	stdin        io.Reader
+	stdout       io.Writer
+	stderr       io.Writer
+	configPath   string
+	rcPath       string // For testing shell integration
+	removeConfig bool   // --remove-config: also delete the config file
+}
+
+// NewUninstallCommand creates a new uninstall command handler
+func NewUninstallCommand(stdin io.Reader, stdout, stderr io.Writer, configPath string) *UninstallCommand {
+	return &UninstallCommand{
+		stdin:      stdin,
+		stdout:     stdout,
+		stderr:     stderr,
+		configPath: configPath,
+	}
+}
+
+// NewUninstallCommandWithShell creates a new uninstall command handler with custom shell rc path (for testing)
+func NewUninstallCommandWithShell(stdin io.Reader, stdout, stderr io.Writer, configPath, rcPath string) *UninstallCommand {
+	return &UninstallCommand{
+		stdin:      stdin,
+		stdout:     stdout,
+		stderr:     stderr,
+		configPath: configPath,
+		rcPath:     rcPath,
+	}
+}
+
+// Execute runs the uninstall command
+func (c *UninstallCommand) Execute() error {
+	// The rc helpers live on InitCommand; they only need the output writers.
+	installer := &InitCommand{stdout: c.stdout, stderr: c.stderr}
+
+	removedAny := false
+	for _, rcPath := range c.rcPaths(installer) {
+		removed, err := installer.removeShellIntegration(rcPath)
+		if err != nil {
+			fmt.Fprintf(c.stderr, "%s Failed to remove shell integration: %v\n", coloredWarning(), err)
+			continue
+		}
+		if removed {
+			removedAny = true
+			fmt.Fprintf(c.stdout, "%s Removed shell integration from %s\n", coloredSuccess(), rcPath)
+		}
+
+		// Anything left was written by hand, so leave it to the user
+		if installer.hasShellIntegration(rcPath, showScriptCommand) {
+			fmt.Fprintf(c.stderr, "%s %s still loads gw shell integration that gw did not add.\n", coloredWarning(), rcPath)
+			fmt.Fprintf(c.stderr, "  Remove the line containing '%s' manually.\n", showScriptCommand)
+		}
+	}
+
+	if removedAny {
+		fmt.Fprintln(c.stdout, "Restart your shell for the change to take effect.")
+	} else {
+		fmt.Fprintln(c.stdout, "No gw shell integration found.")
+	}
+
+	if c.removeConfig {
+		return c.deleteConfig(bufio.NewReader(c.stdin))
+	}
+
+	return nil
+}
+
+// rcPaths returns the rc files to clean: the test override if set, otherwise
+// every rc file gw init knows how to write to.
+func (c *UninstallCommand) rcPaths(installer *InitCommand) []string {
+	if c.rcPath != "" {
+		return []string{c.rcPath}
+	}
+
+	var paths []string
+	for _, shell := range []string{shellZsh, shellBash, shellFish} {
+		if rcPath := installer.detectRCPath(shell); rcPath != "" {
+			paths = append(paths, rcPath)
+		}
+	}
+	return paths
+}
+
+func (c *UninstallCommand) deleteConfig(reader *bufio.Reader) error {
+	if _, err := os.Stat(c.configPath); err != nil {
+		fmt.Fprintf(c.stdout, "No configuration file at %s\n", c.configPath)
+		return nil
+	}
+
+	fmt.Fprintf(c.stdout, "Delete configuration file %s? (y/N): ", c.configPath)
+	response, err := reader.ReadString('\n')
+	if err != nil {
+		return fmt.Errorf("failed to read input: %w", err)
+	}
+
+	response = strings.TrimSpace(strings.ToLower(response))
+	if response != "y" && response != yes {
+		fmt.Fprintln(c.stdout, "Configuration file kept.")
+		return nil
+	}
+
+	if err := os.Remove(c.configPath); err != nil {
+		return fmt.Errorf("failed to delete configuration: %w", err)
+	}
+	fmt.Fprintf(c.stdout, "%s Deleted %s\n", coloredSuccess(), c.configPath)
+
+	return nil
+}
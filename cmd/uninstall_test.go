@@ -0,0 +1,200 @@
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestUninstallCommand_RestoresRCFile(t *testing.T) {
+	tempDir := t.TempDir()
+	rcPath := filepath.Join(tempDir, ".bashrc")
+	configPath := filepath.Join(tempDir, ".gwrc")
+	original := "# my aliases\nalias ll='ls -l'\n"
+	os.WriteFile(rcPath, []byte(original), 0644)
+
+	oldShell := os.Getenv("SHELL")
+	os.Setenv("SHELL", "/bin/bash")
+	defer os.Setenv("SHELL", oldShell)
+
+	// Install through gw init
+	initCmd := NewInitCommandWithShell(strings.NewReader(""), &bytes.Buffer{}, &bytes.Buffer{}, configPath, rcPath)
+	if err := initCmd.offerShellIntegration(bufio.NewReader(strings.NewReader("y\n"))); err != nil {
+		t.Fatalf("Unexpected error installing: %v", err)
+	}
+	installed, _ := os.ReadFile(rcPath)
+	if !strings.Contains(string(installed), shellBlockStart) {
+		t.Fatalf("Expected integration block after install, got: %s", installed)
+	}
+
+	stdout := &bytes.Buffer{}
+	stderr := &bytes.Buffer{}
+	cmd := NewUninstallCommandWithShell(strings.NewReader(""), stdout, stderr, configPath, rcPath)
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	content, _ := os.ReadFile(rcPath)
+	if string(content) != original {
+		t.Errorf("rc content = %q, want %q", content, original)
+	}
+	if !strings.Contains(stdout.String(), "Removed shell integration from "+rcPath) {
+		t.Errorf("Expected removal message, got: %s", stdout.String())
+	}
+	if stderr.Len() != 0 {
+		t.Errorf("Expected no warnings, got: %s", stderr.String())
+	}
+}
+
+func TestUninstallCommand_RestoresRCFileFromLegacyInstall(t *testing.T) {
+	const original = "# my aliases\nalias ll='ls -l'\n"
+
+	tests := []struct {
+		name  string
+		shell string
+		rc    string
+		after string // user content written after gw init
+	}{
+		{name: "bash", shell: shellBash, rc: ".bashrc"},
+		{name: "zsh with lines added later", shell: shellZsh, rc: ".zshrc", after: "export EDITOR=vim\n"},
+		{name: "fish", shell: shellFish, rc: "config.fish"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tempDir := t.TempDir()
+			rcPath := filepath.Join(tempDir, tt.rc)
+			// What gw init appended before the managed block existed
+			legacy := "\n# gw shell integration\n" + shellIntegrationLine(tt.shell) + "\n"
+			os.WriteFile(rcPath, []byte(original+legacy+tt.after), 0644)
+
+			stdout := &bytes.Buffer{}
+			stderr := &bytes.Buffer{}
+			cmd := NewUninstallCommandWithShell(strings.NewReader(""), stdout, stderr, filepath.Join(tempDir, ".gwrc"), rcPath)
+			if err := cmd.Execute(); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			content, _ := os.ReadFile(rcPath)
+			if want := original + tt.after; string(content) != want {
+				t.Errorf("rc content = %q, want %q", content, want)
+			}
+			if !strings.Contains(stdout.String(), "Removed shell integration from "+rcPath) {
+				t.Errorf("Expected removal message, got: %s", stdout.String())
+			}
+			if stderr.Len() != 0 {
+				t.Errorf("Expected no warnings, got: %s", stderr.String())
+			}
+		})
+	}
+}
+
+func TestUninstallCommand_RCFileVariants(t *testing.T) {
+	const before = "# config\n"
+
+	tests := []struct {
+		name        string
+		content     string
+		wantContent string
+		wantStdout  string
+		wantStderr  string
+	}{
+		{
+			name:        "removes snippet written by older gw",
+			content:     before + legacyShellIntegration(shellZsh),
+			wantContent: before,
+			wantStdout:  "Removed shell integration from",
+		},
+		{
+			name:        "removes both the managed block and an older snippet",
+			content:     before + legacyShellIntegration(shellZsh) + "\n" + shellIntegrationBlock(shellZsh),
+			wantContent: before,
+			wantStdout:  "Removed shell integration from",
+		},
+		{
+			name:        "leaves hand-written line and warns",
+			content:     before + shellIntegrationLine(shellBash) + "\n",
+			wantContent: before + shellIntegrationLine(shellBash) + "\n",
+			wantStdout:  "No gw shell integration found.",
+			wantStderr:  "did not add",
+		},
+		{
+			name:        "nothing to remove",
+			content:     before,
+			wantContent: before,
+			wantStdout:  "No gw shell integration found.",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tempDir := t.TempDir()
+			rcPath := filepath.Join(tempDir, ".zshrc")
+			os.WriteFile(rcPath, []byte(tt.content), 0644)
+
+			stdout := &bytes.Buffer{}
+			stderr := &bytes.Buffer{}
+			cmd := NewUninstallCommandWithShell(strings.NewReader(""), stdout, stderr, filepath.Join(tempDir, ".gwrc"), rcPath)
+			if err := cmd.Execute(); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			content, _ := os.ReadFile(rcPath)
+			if string(content) != tt.wantContent {
+				t.Errorf("rc content = %q, want %q", content, tt.wantContent)
+			}
+			if !strings.Contains(stdout.String(), tt.wantStdout) {
+				t.Errorf("Expected stdout to contain %q, got: %s", tt.wantStdout, stdout.String())
+			}
+			if tt.wantStderr == "" && stderr.Len() != 0 {
+				t.Errorf("Expected no warnings, got: %s", stderr.String())
+			}
+			if !strings.Contains(stderr.String(), tt.wantStderr) {
+				t.Errorf("Expected stderr to contain %q, got: %s", tt.wantStderr, stderr.String())
+			}
+		})
+	}
+}
+
+func TestUninstallCommand_RemoveConfig(t *testing.T) {
+	tests := []struct {
+		name       string
+		input      string
+		createFile bool
+		wantExists bool
+		wantStdout string
+	}{
+		{name: "confirmed", input: "y\n", createFile: true, wantExists: false, wantStdout: "Deleted"},
+		{name: "declined", input: "n\n", createFile: true, wantExists: true, wantStdout: "Configuration file kept."},
+		{name: "no config file", input: "", createFile: false, wantExists: false, wantStdout: "No configuration file at"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tempDir := t.TempDir()
+			configPath := filepath.Join(tempDir, ".gwrc")
+			if tt.createFile {
+				os.WriteFile(configPath, []byte("auto_cd = true\n"), 0600)
+			}
+
+			stdout := &bytes.Buffer{}
+			cmd := NewUninstallCommandWithShell(strings.NewReader(tt.input), stdout, &bytes.Buffer{},
+				configPath, filepath.Join(tempDir, ".bashrc"))
+			cmd.removeConfig = true
+			if err := cmd.Execute(); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			_, err := os.Stat(configPath)
+			if exists := err == nil; exists != tt.wantExists {
+				t.Errorf("config exists = %v, want %v", exists, tt.wantExists)
+			}
+			if !strings.Contains(stdout.String(), tt.wantStdout) {
+				t.Errorf("Expected stdout to contain %q, got: %s", tt.wantStdout, stdout.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,35 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var whereCmd = &cobra.Command{
+	Use:   "where [issue-number-or-branch]",
+	Short: "Print the path of a worktree",
+	Long: `Prints the absolute path of the worktree for the given issue number or
+branch on a single line, and nothing else, so scripts can use it:
+
+  cd "$(gw where 123)"
+
+Without an argument, prints the path of the worktree you are in. Exits with
+an error when no worktree exists for the issue.`,
+	Example: `  cd "$(gw where 123)"`,
+	Args:    cobra.MaximumNArgs(1),
+	RunE:    runWhere,
+}
+
+func init() {
+	rootCmd.AddCommand(whereCmd)
+}
+
+func runWhere(cmd *cobra.Command, args []string) error {
+	var issueNumber string
+	if len(args) > 0 {
+		issueNumber = args[0]
+	}
+
+	deps := DefaultDependencies()
+	whereCmd := NewWhereCommand(deps)
+	return whereCmd.Execute(issueNumber)
+}
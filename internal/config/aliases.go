@@ -0,0 +1,78 @@
+package config
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// aliasKeyPrefix starts every alias key. Like git aliases, the value is the
+// command line the alias stands for, flags included:
+//
+//	alias.s = start
+//	alias.clean-dry = clean --dry-run
+const aliasKeyPrefix = "alias."
+
+// parseAliasKey returns the alias name configured by key. ok is false for any
+// other key, including "alias." with an empty name.
+func parseAliasKey(key string) (name string, ok bool) {
+	name, ok = strings.CutPrefix(key, aliasKeyPrefix)
+	if !ok || name == "" || strings.ContainsAny(name, ". \t") {
+		return "", false
+	}
+	return name, true
+}
+
+// IsAliasKey reports whether key defines a command alias.
+func IsAliasKey(key string) bool {
+	_, ok := parseAliasKey(key)
+	return ok
+}
+
+// loadAliasKey applies an alias key to c. It reports whether key was an alias
+// key. An alias with an empty value is dropped.
+func (c *Config) loadAliasKey(key, value string) bool {
+	name, ok := parseAliasKey(key)
+	if !ok {
+		return false
+	}
+	if value == "" {
+		delete(c.Aliases, name)
+		return true
+	}
+	if c.Aliases == nil {
+		c.Aliases = map[string]string{}
+	}
+	c.Aliases[name] = value
+	return true
+}
+
+// AliasNames returns the configured alias names in sorted order.
+func (c *Config) AliasNames() []string {
+	names := make([]string, 0, len(c.Aliases))
+	for name := range c.Aliases {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ExpandAlias returns the arguments the alias called name stands for, or none
+// when there is no such alias.
+func (c *Config) ExpandAlias(name string) []string {
+	return strings.Fields(c.Aliases[name])
+}
+
+// saveAliasLines renders the configured aliases for Save, or an empty string
+// when there are none.
+func (c *Config) saveAliasLines() string {
+	if len(c.Aliases) == 0 {
+		return ""
+	}
+
+	lines := "\n# Command aliases: gw <alias> runs gw <command line>\n"
+	for _, name := range c.AliasNames() {
+		lines += fmt.Sprintf("%s%s = %s\n", aliasKeyPrefix, name, c.Aliases[name])
+	}
+	return lines
+}
@@ -0,0 +1,58 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestLoadAliases(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), ".gwrc")
+	content := `alias.s = start
+alias.clean-dry = clean --dry-run
+alias. = ignored
+alias.a.b = ignored
+`
+	if err := os.WriteFile(configPath, []byte(content), 0600); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	cfg, err := Load(configPath)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	want := map[string]string{"s": "start", "clean-dry": "clean --dry-run"}
+	if !reflect.DeepEqual(cfg.Aliases, want) {
+		t.Errorf("Aliases = %v, want %v", cfg.Aliases, want)
+	}
+	if got := cfg.ExpandAlias("clean-dry"); !reflect.DeepEqual(got, []string{"clean", "--dry-run"}) {
+		t.Errorf("ExpandAlias(clean-dry) = %q", got)
+	}
+	if got := cfg.ExpandAlias("missing"); len(got) != 0 {
+		t.Errorf("ExpandAlias(missing) = %q, want no arguments", got)
+	}
+}
+
+func TestSaveAliases(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), ".gwrc")
+	cfg := New()
+	cfg.Aliases = map[string]string{"s": "start", "co": "checkout --copy-envs"}
+	if err := cfg.Save(configPath); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	content, _ := os.ReadFile(configPath)
+	if !strings.Contains(string(content), "alias.co = checkout --copy-envs\nalias.s = start\n") {
+		t.Errorf("Expected sorted alias lines, got:\n%s", content)
+	}
+	loaded, err := Load(configPath)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if !reflect.DeepEqual(loaded.Aliases, cfg.Aliases) {
+		t.Errorf("Aliases after round trip = %v, want %v", loaded.Aliases, cfg.Aliases)
+	}
+}
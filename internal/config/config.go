@@ -4,20 +4,37 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strconv"
+	"strings"
 )
 
+// EnvConfigPath names the environment variable that overrides the config file
+// location, e.g. to point CI or tests at a throwaway file.
+const EnvConfigPath = "GW_CONFIG"
+
 const (
 	trueValue = "true"
 
 	// Config keys
-	autoCDKey             = "auto_cd"
-	updateITerm2TabKey    = "update_iterm2_tab"
-	autoRemoveBranchKey   = "auto_remove_branch"
-	copyEnvsKey           = "copy_envs"
-	fetchBeforeCommandKey = "fetch_before_command"
-	postStartHookKey      = "post_start_hook"
-	postCheckoutHookKey   = "post_checkout_hook"
-	preEndHookKey         = "pre_end_hook"
+	autoCDKey                        = "auto_cd"
+	updateITerm2TabKey               = "update_iterm2_tab"
+	autoRemoveBranchKey              = "auto_remove_branch"
+	copyEnvsKey                      = "copy_envs"
+	fetchBeforeCommandKey            = "fetch_before_command"
+	deleteRemoteBranchKey            = "delete_remote_branch"
+	trackStatsKey                    = "track_stats"
+	alwaysBranchFromRemoteDefaultKey = "always_branch_from_remote_default"
+	alwaysCopyKey                    = "always_copy"
+	languageKey                      = "language"
+	editorKey                        = "editor"
+	defaultRemoteKey                 = "default_remote"
+	worktreeRootKey                  = "worktree_root"
+	maxParallelChecksKey             = "max_parallel_checks"
+	newWindowCmdKey                  = "new_window_cmd"
+	startSuccessTemplateKey          = "start_success_template"
+	postStartHookKey                 = "post_start_hook"
+	postCheckoutHookKey              = "post_checkout_hook"
+	preEndHookKey                    = "pre_end_hook"
 
 	// File permission bits
 	permConfigDir  = 0o755 // directories: rwxr-xr-x
@@ -34,6 +51,8 @@ const (
 	kindBool         fieldKind = iota // plain bool toggle
 	kindOptionalBool                  // copy_envs: nil = unset (prompt the user)
 	kindString                        // hook commands
+	kindList                          // always_copy: comma-separated values
+	kindValue                         // language, editor, default_remote, worktree_root, max_parallel_checks, new_window_cmd, start_success_template: a single plain value
 )
 
 // fieldSpec is the single source of truth for one configuration key. Load,
@@ -60,6 +79,41 @@ type fieldSpec struct {
 	setString func(c *Config, value string)
 }
 
+// parseList splits a comma-separated kindList value, dropping empty entries.
+func parseList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// expandValue expands a leading ~ to the home directory and $VAR or ${VAR}
+// to the environment variable's value, so that path values can be written as
+// ~/worktrees or $HOME/worktrees. Hooks and new_window_cmd are not expanded:
+// they run through a shell, which expands them itself when they run.
+func expandValue(value string) string {
+	if value == "~" || strings.HasPrefix(value, "~/") {
+		if home, err := os.UserHomeDir(); err == nil {
+			value = home + value[1:]
+		}
+	}
+	return os.ExpandEnv(value)
+}
+
+// unexpandedValue returns what Save writes for a value expandValue was applied
+// to at load: raw, the value as written in the file, while it still expands
+// to value, so that ~ and $VARS are not replaced by what they expanded to. A
+// value changed since load is written as is.
+func unexpandedValue(value, raw string) string {
+	if raw != "" && expandValue(raw) == value {
+		return raw
+	}
+	return value
+}
+
 // fieldSpecs is the ordered single source of truth for all configuration keys.
 var fieldSpecs = []fieldSpec{
 	{
@@ -110,6 +164,78 @@ var fieldSpecs = []fieldSpec{
 		setBool:     func(c *Config, v bool) { c.FetchBeforeCommand = v },
 		getBool:     func(c *Config) bool { return c.FetchBeforeCommand },
 	},
+	{
+		key:         deleteRemoteBranchKey,
+		kind:        kindBool,
+		description: "Delete the remote branch on the default remote after the local branch is removed",
+		defaultBool: false,
+		load:        func(c *Config, v string) { c.DeleteRemoteBranch = v == trueValue },
+		setBool:     func(c *Config, v bool) { c.DeleteRemoteBranch = v },
+		getBool:     func(c *Config) bool { return c.DeleteRemoteBranch },
+	},
+	{
+		key:         trackStatsKey,
+		kind:        kindBool,
+		description: "Count start/end/clean runs locally for gw stats (never sent anywhere)",
+		defaultBool: false,
+		load:        func(c *Config, v string) { c.TrackStats = v == trueValue },
+		setBool:     func(c *Config, v bool) { c.TrackStats = v },
+		getBool:     func(c *Config) bool { return c.TrackStats },
+	},
+	{
+		key:         alwaysBranchFromRemoteDefaultKey,
+		kind:        kindBool,
+		description: "Start new worktrees from the remote's default branch (e.g. origin/main), fetched first",
+		defaultBool: false,
+		load:        func(c *Config, v string) { c.AlwaysBranchFromRemoteDefault = v == trueValue },
+		setBool:     func(c *Config, v bool) { c.AlwaysBranchFromRemoteDefault = v },
+		getBool:     func(c *Config) bool { return c.AlwaysBranchFromRemoteDefault },
+	},
+	{
+		key:  alwaysCopyKey,
+		kind: kindList,
+		load: func(c *Config, v string) { c.AlwaysCopy = parseList(v) },
+	},
+	{
+		key:  languageKey,
+		kind: kindValue,
+		load: func(c *Config, v string) { c.Language = v },
+	},
+	{
+		key:  editorKey,
+		kind: kindValue,
+		load: func(c *Config, v string) { c.rawEditor, c.Editor = v, expandValue(v) },
+	},
+	{
+		key:  defaultRemoteKey,
+		kind: kindValue,
+		load: func(c *Config, v string) { c.DefaultRemote = v },
+	},
+	{
+		key:  worktreeRootKey,
+		kind: kindValue,
+		load: func(c *Config, v string) { c.rawWorktreeRoot, c.WorktreeRoot = v, expandValue(v) },
+	},
+	{
+		key:  maxParallelChecksKey,
+		kind: kindValue,
+		load: func(c *Config, v string) {
+			// Anything but a positive number keeps the default.
+			if n, err := strconv.Atoi(v); err == nil && n > 0 {
+				c.MaxParallelChecks = n
+			}
+		},
+	},
+	{
+		key:  newWindowCmdKey,
+		kind: kindValue,
+		load: func(c *Config, v string) { c.NewWindowCmd = v },
+	},
+	{
+		key:  startSuccessTemplateKey,
+		kind: kindValue,
+		load: func(c *Config, v string) { c.StartSuccessTemplate = v },
+	},
 	{
 		key:       postStartHookKey,
 		kind:      kindString,
@@ -153,14 +279,54 @@ type Item struct {
 
 // Config represents the gw configuration
 type Config struct {
-	AutoCD             bool   `toml:"auto_cd"`
-	UpdateITerm2Tab    bool   `toml:"update_iterm2_tab"`
-	AutoRemoveBranch   bool   `toml:"auto_remove_branch"`
-	CopyEnvs           *bool  `toml:"copy_envs"` // Pointer to distinguish between unset and false
-	FetchBeforeCommand bool   `toml:"fetch_before_command"`
-	PostStartHook      string `toml:"post_start_hook"`
-	PostCheckoutHook   string `toml:"post_checkout_hook"`
-	PreEndHook         string `toml:"pre_end_hook"`
+	AutoCD             bool  `toml:"auto_cd"`
+	UpdateITerm2Tab    bool  `toml:"update_iterm2_tab"`
+	AutoRemoveBranch   bool  `toml:"auto_remove_branch"`
+	CopyEnvs           *bool `toml:"copy_envs"` // Pointer to distinguish between unset and false
+	FetchBeforeCommand bool  `toml:"fetch_before_command"`
+	DeleteRemoteBranch bool  `toml:"delete_remote_branch"`
+	TrackStats         bool  `toml:"track_stats"`
+	// AlwaysBranchFromRemoteDefault makes gw start branch from the remote's
+	// default branch instead of the local base branch, like --base-from-default.
+	AlwaysBranchFromRemoteDefault bool `toml:"always_branch_from_remote_default"`
+	// AlwaysCopy lists repo-relative files copied into every new worktree.
+	AlwaysCopy []string `toml:"always_copy"`
+	// Language selects the message catalog; empty follows $LANG.
+	Language string `toml:"language"`
+	// Editor is the command --open launches; empty falls back to $EDITOR.
+	Editor string `toml:"editor"`
+	// DefaultRemote is the remote used for merge checks, remote branches and
+	// pushes; empty means origin.
+	DefaultRemote string `toml:"default_remote"`
+	// WorktreeRoot is the directory gw list --all-repos scans for worktrees.
+	WorktreeRoot string `toml:"worktree_root"`
+	// MaxParallelChecks caps how many worktrees gw clean checks at once; 0
+	// means one per CPU.
+	MaxParallelChecks int `toml:"max_parallel_checks"`
+	// NewWindowCmd opens a new terminal window or tab for a worktree created
+	// by gw start or gw checkout, instead of changing directory; {path} is
+	// replaced with the worktree's path.
+	NewWindowCmd string `toml:"new_window_cmd"`
+	// StartSuccessTemplate replaces the message gw start prints once the
+	// worktree is ready; {path}, {branch} and {issue} are filled in.
+	StartSuccessTemplate string `toml:"start_success_template"`
+	PostStartHook        string `toml:"post_start_hook"`
+	PostCheckoutHook     string `toml:"post_checkout_hook"`
+	PreEndHook           string `toml:"pre_end_hook"`
+
+	// Templates holds the named worktree templates (template.<name>.* keys).
+	Templates map[string]Template `toml:"templates"`
+
+	// Theme holds the output glyph and color settings (theme and theme.* keys).
+	Theme Theme `toml:"theme"`
+
+	// Aliases maps alias names to the command line they expand to (alias.* keys).
+	Aliases map[string]string `toml:"aliases"`
+
+	// rawEditor and rawWorktreeRoot hold editor and worktree_root as written
+	// in the file, before expansion, so that Save keeps ~ and $VARS.
+	rawEditor       string
+	rawWorktreeRoot string
 }
 
 // New creates a new Config with default values
@@ -171,6 +337,10 @@ func New() *Config {
 		AutoRemoveBranch:   false, // Default to false to avoid unexpected behavior
 		CopyEnvs:           nil,   // nil means not configured, will prompt user
 		FetchBeforeCommand: true,  // Default to true to ensure remote info is up-to-date
+		DeleteRemoteBranch: false, // Default to false: deleting shared refs must be opt-in
+		TrackStats:         false, // Default to false: usage stats are strictly opt-in
+
+		AlwaysBranchFromRemoteDefault: false, // Default to false: the local base branch is used as given
 	}
 }
 
@@ -207,6 +377,44 @@ func (c *Config) Save(path string) error {
 		copyEnvsStr = fmt.Sprintf("# %s = false  # Uncomment to set default behavior\n", copyEnvsKey)
 	}
 
+	alwaysCopyStr := fmt.Sprintf("# %s =  # Comma-separated repo-relative files to copy into new worktrees\n", alwaysCopyKey)
+	if len(c.AlwaysCopy) > 0 {
+		alwaysCopyStr = fmt.Sprintf("%s = %s\n", alwaysCopyKey, strings.Join(c.AlwaysCopy, ", "))
+	}
+
+	var languageStr string
+	if c.Language != "" {
+		languageStr = fmt.Sprintf("%s = %s\n", languageKey, c.Language)
+	}
+	var editorStr string
+	if c.Editor != "" {
+		editorStr = fmt.Sprintf("%s = %s\n", editorKey, unexpandedValue(c.Editor, c.rawEditor))
+	}
+	var defaultRemoteStr string
+	if c.DefaultRemote != "" {
+		defaultRemoteStr = fmt.Sprintf("%s = %s\n", defaultRemoteKey, c.DefaultRemote)
+	}
+
+	var worktreeRootStr string
+	if c.WorktreeRoot != "" {
+		worktreeRootStr = fmt.Sprintf("%s = %s\n", worktreeRootKey, unexpandedValue(c.WorktreeRoot, c.rawWorktreeRoot))
+	}
+
+	var maxParallelChecksStr string
+	if c.MaxParallelChecks > 0 {
+		maxParallelChecksStr = fmt.Sprintf("%s = %d\n", maxParallelChecksKey, c.MaxParallelChecks)
+	}
+
+	var newWindowCmdStr string
+	if c.NewWindowCmd != "" {
+		newWindowCmdStr = fmt.Sprintf("%s = %s\n", newWindowCmdKey, c.NewWindowCmd)
+	}
+
+	var startSuccessTemplateStr string
+	if c.StartSuccessTemplate != "" {
+		startSuccessTemplateStr = fmt.Sprintf("%s = %s\n", startSuccessTemplateKey, c.StartSuccessTemplate)
+	}
+
 	var postHookLines string
 	postHookLines += saveHookLine(postStartHookKey, c.PostStartHook)
 	postHookLines += saveHookLine(postCheckoutHookKey, c.PostCheckoutHook)
@@ -214,13 +422,13 @@ func (c *Config) Save(path string) error {
 	preHookLines := saveHookLine(preEndHookKey, c.PreEndHook)
 
 	content := fmt.Sprintf(`# gw configuration file
-%s%s
+%s%s%s%s%s%s%s%s%s%s
 # Hook commands executed after successful worktree operations
 # Available env vars: GW_WORKTREE_PATH, GW_BRANCH_NAME, GW_REPO_NAME, GW_COMMAND
 %s
 # Hook commands executed before a worktree is removed (from end/clean)
 # Runs with cwd set to the worktree. Same env vars as above; GW_COMMAND is "end" or "clean"
-%s`, boolLines, copyEnvsStr, postHookLines, preHookLines)
+%s%s%s%s`, boolLines, copyEnvsStr, alwaysCopyStr, languageStr, editorStr, defaultRemoteStr, worktreeRootStr, maxParallelChecksStr, newWindowCmdStr, startSuccessTemplateStr, postHookLines, preHookLines, c.saveTemplateLines(), c.saveThemeLines(), c.saveAliasLines())
 
 	if err := os.WriteFile(path, []byte(content), permConfigFile); err != nil {
 		return fmt.Errorf("failed to write config file: %w", err)
@@ -238,8 +446,13 @@ func saveHookLine(key, value string) string {
 	return fmt.Sprintf("# %s =\n", key)
 }
 
-// GetConfigPath returns the default configuration file path
+// GetConfigPath returns the configuration file path: $GW_CONFIG when set,
+// otherwise ~/.gwrc.
 func GetConfigPath() string {
+	if path := os.Getenv(EnvConfigPath); path != "" {
+		return path
+	}
+
 	home, err := os.UserHomeDir()
 	if err != nil {
 		// Fallback to HOME environment variable
@@ -249,13 +462,13 @@ func GetConfigPath() string {
 }
 
 // GetConfigItems returns all configuration items with their descriptions.
-// Only bool-valued items are returned; hook (string) keys are intentionally
-// excluded because the interactive `gw config` UI is a bool toggle.
+// Only bool-valued items are returned; hook (string) and list keys are
+// intentionally excluded because the interactive `gw config` UI is a bool toggle.
 func (c *Config) GetConfigItems() []Item {
 	items := make([]Item, 0, len(fieldSpecs))
 	for i := range fieldSpecs {
 		spec := &fieldSpecs[i]
-		if spec.kind == kindString {
+		if spec.kind != kindBool && spec.kind != kindOptionalBool {
 			continue
 		}
 		items = append(items, Item{
@@ -3,6 +3,8 @@ package config
 import (
 	"os"
 	"path/filepath"
+	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -129,6 +131,8 @@ func TestSaveConfig(t *testing.T) {
 }
 
 func TestGetConfigPath(t *testing.T) {
+	t.Setenv(EnvConfigPath, "")
+
 	// Test with HOME environment variable
 	originalHome := os.Getenv("HOME")
 	defer os.Setenv("HOME", originalHome)
@@ -144,6 +148,16 @@ func TestGetConfigPath(t *testing.T) {
 	}
 }
 
+func TestGetConfigPath_EnvOverride(t *testing.T) {
+	t.Setenv("HOME", "/test/home")
+	custom := filepath.Join(t.TempDir(), "custom.gwrc")
+	t.Setenv(EnvConfigPath, custom)
+
+	if path := GetConfigPath(); path != custom {
+		t.Errorf("Expected %s to override the config path, got %s", EnvConfigPath, path)
+	}
+}
+
 func TestLoadConfig_InvalidFormat(t *testing.T) {
 	// Create a temp directory and config file
 	tempDir := t.TempDir()
@@ -269,7 +283,11 @@ func TestSaveConfig_DirectoryCreation(t *testing.T) {
 		"update_iterm2_tab = false\n" +
 		"auto_remove_branch = false\n" +
 		"fetch_before_command = false\n" +
+		"delete_remote_branch = false\n" +
+		"track_stats = false\n" +
+		"always_branch_from_remote_default = false\n" +
 		"# copy_envs = false  # Uncomment to set default behavior\n" +
+		"# always_copy =  # Comma-separated repo-relative files to copy into new worktrees\n" +
 		"\n" +
 		"# Hook commands executed after successful worktree operations\n" +
 		"# Available env vars: GW_WORKTREE_PATH, GW_BRANCH_NAME, GW_REPO_NAME, GW_COMMAND\n" +
@@ -294,9 +312,9 @@ func TestGetConfigItems(t *testing.T) {
 
 	items := config.GetConfigItems()
 
-	// Should return 5 items (added fetch_before_command)
-	if len(items) != 5 {
-		t.Fatalf("Expected 5 config items, got %d", len(items))
+	// Should return 8 items (added always_branch_from_remote_default)
+	if len(items) != 8 {
+		t.Fatalf("Expected 8 config items, got %d", len(items))
 	}
 
 	// Check auto_cd item
@@ -409,6 +427,247 @@ auto_cd = true
 	}
 }
 
+func TestLoadConfig_AlwaysCopy(t *testing.T) {
+	tempDir := t.TempDir()
+	configPath := filepath.Join(tempDir, ".gwrc")
+	content := "always_copy = config/master.key, .tool-versions ,, certs/dev.pem\n"
+	if err := os.WriteFile(configPath, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write test config: %v", err)
+	}
+
+	config, err := Load(configPath)
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+
+	want := []string{"config/master.key", ".tool-versions", "certs/dev.pem"}
+	if !reflect.DeepEqual(config.AlwaysCopy, want) {
+		t.Errorf("AlwaysCopy = %q, want %q", config.AlwaysCopy, want)
+	}
+
+	if err := config.Save(configPath); err != nil {
+		t.Fatalf("Failed to save config: %v", err)
+	}
+	reloaded, err := Load(configPath)
+	if err != nil {
+		t.Fatalf("Failed to reload config: %v", err)
+	}
+	if !reflect.DeepEqual(reloaded.AlwaysCopy, want) {
+		t.Errorf("AlwaysCopy after round trip = %q, want %q", reloaded.AlwaysCopy, want)
+	}
+}
+
+func TestLoadConfig_MaxParallelChecks(t *testing.T) {
+	tests := []struct {
+		value string
+		want  int
+	}{
+		{value: "4", want: 4},
+		{value: "0", want: 0},
+		{value: "-2", want: 0},
+		{value: "many", want: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			configPath := filepath.Join(t.TempDir(), ".gwrc")
+			if err := os.WriteFile(configPath, []byte("max_parallel_checks = "+tt.value+"\n"), 0644); err != nil {
+				t.Fatalf("Failed to write test config: %v", err)
+			}
+
+			config, err := Load(configPath)
+			if err != nil {
+				t.Fatalf("Failed to load config: %v", err)
+			}
+			if config.MaxParallelChecks != tt.want {
+				t.Errorf("MaxParallelChecks = %d, want %d", config.MaxParallelChecks, tt.want)
+			}
+
+			if err := config.Save(configPath); err != nil {
+				t.Fatalf("Failed to save config: %v", err)
+			}
+			reloaded, err := Load(configPath)
+			if err != nil {
+				t.Fatalf("Failed to reload config: %v", err)
+			}
+			if reloaded.MaxParallelChecks != tt.want {
+				t.Errorf("MaxParallelChecks after round trip = %d, want %d", reloaded.MaxParallelChecks, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadConfig_ExpandsPaths(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("GW_TEST_EDITOR", "code")
+
+	tests := []struct {
+		name         string
+		worktreeRoot string
+		editor       string
+		wantRoot     string
+		wantEditor   string
+	}{
+		{"tilde", "~/worktrees", "~/bin/edit", filepath.Join(home, "worktrees"), filepath.Join(home, "bin", "edit")},
+		{"bare tilde", "~", "vim", home, "vim"},
+		{"variables", "$HOME/worktrees", "${GW_TEST_EDITOR} --wait", filepath.Join(home, "worktrees"), "code --wait"},
+		{"literal", "/opt/worktrees", "code --wait", "/opt/worktrees", "code --wait"},
+		{"tilde inside a value", "/opt/~worktrees", "vim", "/opt/~worktrees", "vim"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			configPath := filepath.Join(t.TempDir(), ".gwrc")
+			content := "worktree_root = " + tt.worktreeRoot + "\neditor = " + tt.editor + "\n"
+			if err := os.WriteFile(configPath, []byte(content), 0644); err != nil {
+				t.Fatalf("Failed to write test config: %v", err)
+			}
+
+			config, err := Load(configPath)
+			if err != nil {
+				t.Fatalf("Failed to load config: %v", err)
+			}
+			if config.WorktreeRoot != tt.wantRoot {
+				t.Errorf("WorktreeRoot = %q, want %q", config.WorktreeRoot, tt.wantRoot)
+			}
+			if config.Editor != tt.wantEditor {
+				t.Errorf("Editor = %q, want %q", config.Editor, tt.wantEditor)
+			}
+		})
+	}
+}
+
+func TestSaveConfig_KeepsUnexpandedPaths(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("EDITOR", "vim")
+
+	tests := []struct {
+		name         string
+		worktreeRoot string
+		editor       string
+	}{
+		{"tilde", "~/worktrees", "~/bin/edit"},
+		{"variables", "$HOME/worktrees", "$EDITOR"},
+		{"braced variables", "${HOME}/worktrees", "${EDITOR} --wait"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			configPath := filepath.Join(t.TempDir(), ".gwrc")
+			content := "worktree_root = " + tt.worktreeRoot + "\neditor = " + tt.editor + "\n"
+			if err := os.WriteFile(configPath, []byte(content), 0644); err != nil {
+				t.Fatalf("Failed to write test config: %v", err)
+			}
+
+			config, err := Load(configPath)
+			if err != nil {
+				t.Fatalf("Failed to load config: %v", err)
+			}
+			if err := config.Save(configPath); err != nil {
+				t.Fatalf("Failed to save config: %v", err)
+			}
+
+			saved, err := os.ReadFile(configPath)
+			if err != nil {
+				t.Fatalf("Failed to read saved config: %v", err)
+			}
+			for _, want := range []string{"worktree_root = " + tt.worktreeRoot + "\n", "editor = " + tt.editor + "\n"} {
+				if !strings.Contains(string(saved), want) {
+					t.Errorf("Expected saved config to contain %q, got:\n%s", want, saved)
+				}
+			}
+		})
+	}
+
+	t.Run("changed value is written as is", func(t *testing.T) {
+		configPath := filepath.Join(t.TempDir(), ".gwrc")
+		if err := os.WriteFile(configPath, []byte("worktree_root = ~/worktrees\n"), 0644); err != nil {
+			t.Fatalf("Failed to write test config: %v", err)
+		}
+		config, err := Load(configPath)
+		if err != nil {
+			t.Fatalf("Failed to load config: %v", err)
+		}
+		config.WorktreeRoot = "/opt/worktrees"
+		if err := config.Save(configPath); err != nil {
+			t.Fatalf("Failed to save config: %v", err)
+		}
+		saved, _ := os.ReadFile(configPath)
+		if !strings.Contains(string(saved), "worktree_root = /opt/worktrees\n") {
+			t.Errorf("Expected the new worktree_root to be saved, got:\n%s", saved)
+		}
+	})
+}
+
+func TestLoadConfig_HooksNotExpanded(t *testing.T) {
+	const hook = "echo $GW_WORKTREE_PATH > ~/last-worktree"
+	configPath := filepath.Join(t.TempDir(), ".gwrc")
+	if err := os.WriteFile(configPath, []byte("post_start_hook = "+hook+"\n"), 0644); err != nil {
+		t.Fatalf("Failed to write test config: %v", err)
+	}
+
+	config, err := Load(configPath)
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+	if config.PostStartHook != hook {
+		t.Errorf("PostStartHook = %q, want it untouched: %q", config.PostStartHook, hook)
+	}
+}
+
+func TestLoadConfig_NewWindowCmd(t *testing.T) {
+	const cmd = "gnome-terminal --working-directory={path}"
+	configPath := filepath.Join(t.TempDir(), ".gwrc")
+	if err := os.WriteFile(configPath, []byte("new_window_cmd = "+cmd+"\n"), 0644); err != nil {
+		t.Fatalf("Failed to write test config: %v", err)
+	}
+
+	config, err := Load(configPath)
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+	if config.NewWindowCmd != cmd {
+		t.Errorf("NewWindowCmd = %q, want %q", config.NewWindowCmd, cmd)
+	}
+
+	if err := config.Save(configPath); err != nil {
+		t.Fatalf("Failed to save config: %v", err)
+	}
+	reloaded, err := Load(configPath)
+	if err != nil {
+		t.Fatalf("Failed to reload config: %v", err)
+	}
+	if reloaded.NewWindowCmd != cmd {
+		t.Errorf("NewWindowCmd after round trip = %q, want %q", reloaded.NewWindowCmd, cmd)
+	}
+}
+
+func TestLoadConfig_StartSuccessTemplate(t *testing.T) {
+	const template = "Ready: {branch} at {path} (#{issue})"
+	configPath := filepath.Join(t.TempDir(), ".gwrc")
+	if err := os.WriteFile(configPath, []byte("start_success_template = "+template+"\n"), 0644); err != nil {
+		t.Fatalf("Failed to write test config: %v", err)
+	}
+
+	config, err := Load(configPath)
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+	if config.StartSuccessTemplate != template {
+		t.Errorf("StartSuccessTemplate = %q, want %q", config.StartSuccessTemplate, template)
+	}
+
+	if err := config.Save(configPath); err != nil {
+		t.Fatalf("Failed to save config: %v", err)
+	}
+	reloaded, err := Load(configPath)
+	if err != nil {
+		t.Fatalf("Failed to reload config: %v", err)
+	}
+	if reloaded.StartSuccessTemplate != template {
+		t.Errorf("StartSuccessTemplate after round trip = %q, want %q", reloaded.StartSuccessTemplate, template)
+	}
+}
+
 func TestSaveConfig_CopyEnvs(t *testing.T) {
 	tests := []struct {
 		name             string
@@ -550,6 +809,15 @@ func TestSetConfigItem(t *testing.T) {
 		t.Errorf("Expected FetchBeforeCommand to be false after setting, got %v", config.FetchBeforeCommand)
 	}
 
+	// Test setting delete_remote_branch
+	err = config.SetConfigItem("delete_remote_branch", true)
+	if err != nil {
+		t.Errorf("Failed to set delete_remote_branch: %v", err)
+	}
+	if config.DeleteRemoteBranch != true {
+		t.Errorf("Expected DeleteRemoteBranch to be true after setting, got %v", config.DeleteRemoteBranch)
+	}
+
 	// Test setting post_start_hook (not supported via SetConfigItem, it's bool-only)
 
 	// Test setting unknown key
@@ -26,7 +26,7 @@ func IsHookKey(key string) bool {
 // (silently ignored, same as the global config always has) from a known
 // non-hook key that a project .gwrc declared but v1.1 does not apply.
 func IsKnownKey(key string) bool {
-	return fieldSpecByKey(key) != nil
+	return fieldSpecByKey(key) != nil || IsTemplateKey(key) || IsThemeKey(key) || IsAliasKey(key)
 }
 
 // SetHookValue sets the value of one of the three hook keys by name. It
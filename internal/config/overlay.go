@@ -59,6 +59,8 @@ func LoadWithPresence(path string) (cfg *Config, content []byte, presentKeys map
 
 		if spec := fieldSpecByKey(key); spec != nil {
 			spec.load(cfg, value)
+		} else if !cfg.loadTemplateKey(key, value) && !cfg.loadThemeKey(key, value) {
+			cfg.loadAliasKey(key, value)
 		}
 	}
 
@@ -0,0 +1,121 @@
+package config
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// templateKeyPrefix starts every template key. Templates are written as
+// dotted keys so they fit the flat "key = value" format:
+//
+//	template.feature.base = develop
+//	template.feature.prefix = feature/
+const templateKeyPrefix = "template."
+
+const (
+	templateFieldBase   = "base"
+	templateFieldPrefix = "prefix"
+
+	// templateKeyParts is the number of dot-separated parts in a template key
+	// ("template", name, field).
+	templateKeyParts = 3
+)
+
+// Template is a named preset for gw start: the branch to base the worktree on
+// and a prefix prepended to the branch name.
+type Template struct {
+	Base   string
+	Prefix string
+}
+
+// parseTemplateKey splits "template.<name>.<field>" into name and field. ok is
+// false for any other key, including template keys with an unknown field.
+func parseTemplateKey(key string) (name, field string, ok bool) {
+	if !strings.HasPrefix(key, templateKeyPrefix) {
+		return "", "", false
+	}
+	parts := strings.Split(key, ".")
+	if len(parts) != templateKeyParts || parts[1] == "" {
+		return "", "", false
+	}
+	switch parts[2] {
+	case templateFieldBase, templateFieldPrefix:
+		return parts[1], parts[2], true
+	default:
+		return "", "", false
+	}
+}
+
+// IsTemplateKey reports whether key configures a worktree template.
+func IsTemplateKey(key string) bool {
+	_, _, ok := parseTemplateKey(key)
+	return ok
+}
+
+// loadTemplateKey applies a template key to c. It reports whether key was a
+// template key.
+func (c *Config) loadTemplateKey(key, value string) bool {
+	name, field, ok := parseTemplateKey(key)
+	if !ok {
+		return false
+	}
+	if c.Templates == nil {
+		c.Templates = map[string]Template{}
+	}
+	tmpl := c.Templates[name]
+	if field == templateFieldBase {
+		tmpl.Base = value
+	} else {
+		tmpl.Prefix = value
+	}
+	c.Templates[name] = tmpl
+	return true
+}
+
+// TemplateNames returns the configured template names in sorted order.
+func (c *Config) TemplateNames() []string {
+	names := make([]string, 0, len(c.Templates))
+	for name := range c.Templates {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ResolveTemplate returns the template called name. An empty name means no
+// template was requested and yields the zero Template, which leaves the base
+// branch and branch name unchanged.
+func (c *Config) ResolveTemplate(name string) (Template, error) {
+	if name == "" {
+		return Template{}, nil
+	}
+	tmpl, ok := c.Templates[name]
+	if !ok {
+		if len(c.Templates) == 0 {
+			return Template{}, fmt.Errorf("unknown template %q: no templates are configured", name)
+		}
+		return Template{}, fmt.Errorf("unknown template %q (available: %s)", name, strings.Join(c.TemplateNames(), ", "))
+	}
+	return tmpl, nil
+}
+
+// saveTemplateLines renders the configured templates for Save, or an empty
+// string when there are none.
+func (c *Config) saveTemplateLines() string {
+	if len(c.Templates) == 0 {
+		return ""
+	}
+
+	lines := "\n# Worktree templates for gw start --template <name>\n"
+	for _, name := range c.TemplateNames() {
+		tmpl := c.Templates[name]
+		if tmpl.Base != "" {
+			lines += fmt.Sprintf("%s%s.%s = %s\n", templateKeyPrefix, name, templateFieldBase, tmpl.Base)
+		}
+		if tmpl.Prefix != "" {
+			lines += fmt.Sprintf("%s%s.%s = %s\n", templateKeyPrefix, name, templateFieldPrefix, tmpl.Prefix)
+		}
+	}
+	return lines
+}
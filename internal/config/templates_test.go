@@ -0,0 +1,121 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLoadTemplates(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), ".gwrc")
+	content := `auto_cd = true
+template.feature.base = develop
+template.feature.prefix = feature/
+template.hotfix.prefix = hotfix/
+template.bad.unknown = ignored
+template..base = ignored
+`
+	if err := os.WriteFile(configPath, []byte(content), 0600); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	cfg, err := Load(configPath)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	want := map[string]Template{
+		"feature": {Base: "develop", Prefix: "feature/"},
+		"hotfix":  {Prefix: "hotfix/"},
+	}
+	if len(cfg.Templates) != len(want) {
+		t.Fatalf("Expected %d templates, got %v", len(want), cfg.Templates)
+	}
+	for name, tmpl := range want {
+		if cfg.Templates[name] != tmpl {
+			t.Errorf("Template %s = %+v, want %+v", name, cfg.Templates[name], tmpl)
+		}
+	}
+}
+
+func TestResolveTemplate(t *testing.T) {
+	cfg := New()
+	cfg.Templates = map[string]Template{
+		"feature": {Base: "develop", Prefix: "feature/"},
+		"bugfix":  {Prefix: "bugfix/"},
+	}
+
+	tests := []struct {
+		name    string
+		tmpl    string
+		want    Template
+		wantErr string
+	}{
+		{name: "no template named returns the default", tmpl: "", want: Template{}},
+		{name: "known template", tmpl: "feature", want: Template{Base: "develop", Prefix: "feature/"}},
+		{name: "template without base", tmpl: "bugfix", want: Template{Prefix: "bugfix/"}},
+		{name: "unknown template lists available", tmpl: "hotfix", wantErr: "available: bugfix, feature"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := cfg.ResolveTemplate(tt.tmpl)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Expected error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("ResolveTemplate(%q) = %+v, want %+v", tt.tmpl, got, tt.want)
+			}
+		})
+	}
+
+	if _, err := New().ResolveTemplate("feature"); err == nil || !strings.Contains(err.Error(), "no templates are configured") {
+		t.Errorf("Expected 'no templates are configured' error, got %v", err)
+	}
+}
+
+func TestSaveTemplates_RoundTrip(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), ".gwrc")
+	cfg := New()
+	cfg.Templates = map[string]Template{
+		"hotfix":  {Base: "main", Prefix: "hotfix/"},
+		"feature": {Base: "develop", Prefix: "feature/"},
+	}
+	if err := cfg.Save(configPath); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	content, _ := os.ReadFile(configPath)
+	if !strings.Contains(string(content), "template.feature.base = develop\ntemplate.feature.prefix = feature/\ntemplate.hotfix.base = main\n") {
+		t.Errorf("Expected sorted template lines, got:\n%s", content)
+	}
+
+	loaded, err := Load(configPath)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	for name, tmpl := range cfg.Templates {
+		if loaded.Templates[name] != tmpl {
+			t.Errorf("Template %s = %+v after round trip, want %+v", name, loaded.Templates[name], tmpl)
+		}
+	}
+}
+
+func TestIsKnownKey_TemplateKeys(t *testing.T) {
+	if !IsKnownKey("template.feature.base") {
+		t.Error("Expected template.feature.base to be a known key")
+	}
+	if IsKnownKey("template.feature.colour") {
+		t.Error("Expected template key with unknown field to be unknown")
+	}
+	if IsHookKey("template.feature.prefix") {
+		t.Error("Template keys must not be hook keys")
+	}
+}
@@ -0,0 +1,110 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+)
+
+// themeKey selects an output theme preset; themeKeyPrefix starts the keys that
+// override single glyphs or colors on top of it:
+//
+//	theme = ascii
+//	theme.success = ✔
+//	theme.error_color = red
+const (
+	themeKey       = "theme"
+	themeKeyPrefix = "theme."
+)
+
+// Theme presets understood by the theme key.
+const (
+	ThemePresetDefault = "default"
+	ThemePresetASCII   = "ascii" // plain ASCII glyphs for terminals without emoji
+)
+
+// Theme holds the output theme settings. Empty fields keep the preset's value.
+type Theme struct {
+	Preset string
+
+	Success string
+	Error   string
+	Warning string
+	Arrow   string
+	Ready   string // prefix of the "Worktree ready" message
+	Hint    string // prefix of the shell integration hint
+
+	SuccessColor string
+	ErrorColor   string
+	WarningColor string
+	ArrowColor   string
+}
+
+// themeFields maps each theme.<field> key suffix to its Theme field, in the
+// order Save writes them.
+var themeFields = []struct {
+	name  string
+	field func(t *Theme) *string
+}{
+	{"success", func(t *Theme) *string { return &t.Success }},
+	{"error", func(t *Theme) *string { return &t.Error }},
+	{"warning", func(t *Theme) *string { return &t.Warning }},
+	{"arrow", func(t *Theme) *string { return &t.Arrow }},
+	{"ready", func(t *Theme) *string { return &t.Ready }},
+	{"hint", func(t *Theme) *string { return &t.Hint }},
+	{"success_color", func(t *Theme) *string { return &t.SuccessColor }},
+	{"error_color", func(t *Theme) *string { return &t.ErrorColor }},
+	{"warning_color", func(t *Theme) *string { return &t.WarningColor }},
+	{"arrow_color", func(t *Theme) *string { return &t.ArrowColor }},
+}
+
+// themeField returns the Theme field configured by key, or nil when key is not
+// a theme key. The preset key itself maps to Preset.
+func (t *Theme) themeField(key string) *string {
+	if key == themeKey {
+		return &t.Preset
+	}
+	name, ok := strings.CutPrefix(key, themeKeyPrefix)
+	if !ok {
+		return nil
+	}
+	for _, f := range themeFields {
+		if f.name == name {
+			return f.field(t)
+		}
+	}
+	return nil
+}
+
+// IsThemeKey reports whether key configures the output theme.
+func IsThemeKey(key string) bool {
+	return (&Theme{}).themeField(key) != nil
+}
+
+// loadThemeKey applies a theme key to c. It reports whether key was a theme
+// key.
+func (c *Config) loadThemeKey(key, value string) bool {
+	field := c.Theme.themeField(key)
+	if field == nil {
+		return false
+	}
+	*field = value
+	return true
+}
+
+// saveThemeLines renders the configured theme for Save, or an empty string
+// when nothing is set.
+func (c *Config) saveThemeLines() string {
+	var lines string
+	if c.Theme.Preset != "" {
+		lines += fmt.Sprintf("%s = %s\n", themeKey, c.Theme.Preset)
+	}
+	for _, f := range themeFields {
+		if value := *f.field(&c.Theme); value != "" {
+			lines += fmt.Sprintf("%s%s = %s\n", themeKeyPrefix, f.name, value)
+		}
+	}
+	if lines == "" {
+		return ""
+	}
+	return "\n# Output theme (preset: default or ascii) and glyph/color overrides\n" + lines
+}
@@ -0,0 +1,60 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLoadTheme(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), ".gwrc")
+	content := `theme = ascii
+theme.success = ✔
+theme.error_color = red
+theme.unknown = ignored
+`
+	if err := os.WriteFile(configPath, []byte(content), 0600); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	cfg, err := Load(configPath)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	want := Theme{Preset: ThemePresetASCII, Success: "✔", ErrorColor: "red"}
+	if cfg.Theme != want {
+		t.Errorf("Theme = %+v, want %+v", cfg.Theme, want)
+	}
+	if !IsKnownKey("theme.arrow_color") || IsKnownKey("theme.unknown") {
+		t.Error("Expected theme.arrow_color to be known and theme.unknown not")
+	}
+}
+
+func TestSaveTheme_RoundTrip(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), ".gwrc")
+	cfg := New()
+	cfg.Theme = Theme{Preset: ThemePresetASCII, Warning: "!!", ArrowColor: "#00ffff"}
+
+	if err := cfg.Save(configPath); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	loaded, err := Load(configPath)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if loaded.Theme != cfg.Theme {
+		t.Errorf("Theme after round trip = %+v, want %+v", loaded.Theme, cfg.Theme)
+	}
+
+	// No theme settings means no theme section
+	plain := filepath.Join(t.TempDir(), ".gwrc")
+	if err := New().Save(plain); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	data, _ := os.ReadFile(plain)
+	if strings.Contains(string(data), "theme") {
+		t.Errorf("Expected no theme section, got:\n%s", data)
+	}
+}
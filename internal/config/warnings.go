@@ -0,0 +1,56 @@
+package config
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Warnings returns one explanation per setting that conflicts with another or
+// has no effect as configured, for gw doctor and gw config doctor. None of
+// them stops gw from running; an empty result means nothing looks off.
+func (c *Config) Warnings() []string {
+	var warnings []string
+
+	if strings.TrimSpace(c.NewWindowCmd) != "" {
+		if c.AutoCD {
+			warnings = append(warnings, fmt.Sprintf(
+				"%s has no effect while %s is set: gw start and gw checkout open new worktrees in a new window instead of changing directory",
+				autoCDKey, newWindowCmdKey))
+		}
+		if c.UpdateITerm2Tab {
+			warnings = append(warnings, fmt.Sprintf(
+				"%s renames the tab gw runs in, but %s opens the worktree in a new window or tab, so the name ends up on the wrong tab",
+				updateITerm2TabKey, newWindowCmdKey))
+		}
+	}
+
+	if c.WorktreeRoot != "" && !filepath.IsAbs(c.WorktreeRoot) {
+		warnings = append(warnings, fmt.Sprintf(
+			"%s %q is relative, so worktrees land somewhere else depending on where gw runs; use an absolute path or one starting with ~",
+			worktreeRootKey, c.WorktreeRoot))
+	}
+
+	for _, p := range c.AlwaysCopy {
+		rel := filepath.Clean(p)
+		if filepath.IsAbs(rel) || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			warnings = append(warnings, fmt.Sprintf(
+				"%s entry %q is not a repository-relative path, so it is never copied", alwaysCopyKey, p))
+		}
+	}
+
+	names := make([]string, 0, len(c.Templates))
+	for name := range c.Templates {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if tmpl := c.Templates[name]; tmpl.Base == "" && tmpl.Prefix == "" {
+			warnings = append(warnings, fmt.Sprintf(
+				"%s%s sets neither a base nor a prefix, so gw start --template %s changes nothing", templateKeyPrefix, name, name))
+		}
+	}
+
+	return warnings
+}
@@ -0,0 +1,109 @@
+package config
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestConfig_Warnings(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(c *Config)
+		want   []string // substrings, one per expected warning, in order
+	}{
+		{
+			name:   "defaults",
+			modify: func(c *Config) {},
+		},
+		{
+			name:   "new_window_cmd with auto_cd",
+			modify: func(c *Config) { c.NewWindowCmd = "wezterm start --cwd {path}" },
+			want:   []string{"auto_cd has no effect while new_window_cmd is set"},
+		},
+		{
+			name: "new_window_cmd without auto_cd",
+			modify: func(c *Config) {
+				c.NewWindowCmd = "wezterm start --cwd {path}"
+				c.AutoCD = false
+			},
+		},
+		{
+			name: "new_window_cmd with update_iterm2_tab",
+			modify: func(c *Config) {
+				c.NewWindowCmd = "open -a iTerm {path}"
+				c.AutoCD = false
+				c.UpdateITerm2Tab = true
+			},
+			want: []string{"update_iterm2_tab renames the tab gw runs in"},
+		},
+		{
+			name:   "update_iterm2_tab alone",
+			modify: func(c *Config) { c.UpdateITerm2Tab = true },
+		},
+		{
+			name:   "relative worktree_root",
+			modify: func(c *Config) { c.WorktreeRoot = "worktrees" },
+			want:   []string{`worktree_root "worktrees" is relative`},
+		},
+		{
+			name:   "absolute worktree_root",
+			modify: func(c *Config) { c.WorktreeRoot = "/home/me/worktrees" },
+		},
+		{
+			name: "always_copy outside the repository",
+			modify: func(c *Config) {
+				c.AlwaysCopy = []string{".env", "/etc/hosts", "../shared/.env", "config/../.env.local"}
+			},
+			want: []string{
+				`always_copy entry "/etc/hosts" is not a repository-relative path`,
+				`always_copy entry "../shared/.env" is not a repository-relative path`,
+			},
+		},
+		{
+			name: "template without base or prefix",
+			modify: func(c *Config) {
+				c.Templates = map[string]Template{
+					"feature": {Prefix: "feature/"},
+					"empty":   {},
+					"blank":   {},
+				}
+			},
+			want: []string{
+				"template.blank sets neither a base nor a prefix",
+				"template.empty sets neither a base nor a prefix",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := New()
+			tt.modify(c)
+			got := c.Warnings()
+			if len(got) != len(tt.want) {
+				t.Fatalf("Warnings() = %q, want %d warnings", got, len(tt.want))
+			}
+			for i, want := range tt.want {
+				if !strings.Contains(got[i], want) {
+					t.Errorf("Warnings()[%d] = %q, want it to contain %q", i, got[i], want)
+				}
+			}
+		})
+	}
+}
+
+func TestConfig_Warnings_Combined(t *testing.T) {
+	c := New()
+	c.NewWindowCmd = "open -a iTerm {path}"
+	c.UpdateITerm2Tab = true
+	c.WorktreeRoot = "wt"
+
+	var keys []string
+	for _, warning := range c.Warnings() {
+		keys = append(keys, strings.Fields(warning)[0])
+	}
+	if want := []string{"auto_cd", "update_iterm2_tab", "worktree_root"}; !reflect.DeepEqual(keys, want) {
+		t.Errorf("Warnings() start with %v, want %v", keys, want)
+	}
+}
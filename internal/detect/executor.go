@@ -15,6 +15,7 @@ type CommandExecutor interface {
 
 // DefaultExecutor implements CommandExecutor using os/exec
 type DefaultExecutor struct {
+	Stdin  io.Reader // nil leaves the command without input
 	Stdout io.Writer
 	Stderr io.Writer
 }
@@ -31,6 +32,7 @@ func NewDefaultExecutor() *DefaultExecutor {
 func (e *DefaultExecutor) Execute(dir, command string, args []string) error {
 	cmd := exec.Command(command, args...)
 	cmd.Dir = dir
+	cmd.Stdin = e.Stdin
 	cmd.Stdout = e.Stdout
 	cmd.Stderr = e.Stderr
 
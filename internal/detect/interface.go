@@ -6,6 +6,7 @@ import (
 	"path/filepath"
 
 	"github.com/charmbracelet/lipgloss"
+	"github.com/sotarok/gw/internal/glyph"
 )
 
 // Interface defines the package detection operations
@@ -80,6 +81,6 @@ func (d *DefaultDetector) RunSetup(path string) error {
 	}
 
 	successStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("2"))
-	fmt.Printf("%s %s setup completed\n", successStyle.Render("✓"), pm.Name)
+	fmt.Printf("%s %s setup completed\n", successStyle.Render(glyph.Success.String()), pm.Name)
 	return nil
 }
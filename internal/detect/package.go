@@ -6,6 +6,7 @@ import (
 	"path/filepath"
 
 	"github.com/charmbracelet/lipgloss"
+	"github.com/sotarok/gw/internal/glyph"
 )
 
 const (
@@ -129,6 +130,6 @@ func RunSetupWithExecutor(dir string, executor CommandExecutor) error {
 	}
 
 	successStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("2"))
-	fmt.Printf("%s %s setup completed\n", successStyle.Render("✓"), pm.Name)
+	fmt.Printf("%s %s setup completed\n", successStyle.Render(glyph.Success.String()), pm.Name)
 	return nil
 }
@@ -0,0 +1,57 @@
+package git
+
+import "sync"
+
+// readCache memoizes the branch and worktree lists for the lifetime of a
+// Client. Commands build their Client once per invocation (see
+// cmd.DefaultDependencies), so the cache never outlives a single command;
+// within one, repeated lookups such as GetWorktreeForIssue after
+// ListWorktrees reuse the first result instead of forking git again.
+//
+// Every Client method that creates or removes branches or worktrees, or
+// fetches, calls invalidate so later reads see the change.
+type readCache struct {
+	mu        sync.Mutex
+	branches  []string
+	worktrees []WorktreeInfo
+	hasBranch bool
+	hasTrees  bool
+}
+
+// cachedBranches returns a copy of the cached branch list, loading it with
+// load on a miss. Errors are not cached.
+func (rc *readCache) cachedBranches(load func() ([]string, error)) ([]string, error) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	if !rc.hasBranch {
+		branches, err := load()
+		if err != nil {
+			return nil, err
+		}
+		rc.branches, rc.hasBranch = branches, true
+	}
+	return append([]string(nil), rc.branches...), nil
+}
+
+// cachedWorktrees returns a copy of the cached worktree list, loading it with
+// load on a miss. Errors are not cached.
+func (rc *readCache) cachedWorktrees(load func() ([]WorktreeInfo, error)) ([]WorktreeInfo, error) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	if !rc.hasTrees {
+		worktrees, err := load()
+		if err != nil {
+			return nil, err
+		}
+		rc.worktrees, rc.hasTrees = worktrees, true
+	}
+	return append([]WorktreeInfo(nil), rc.worktrees...), nil
+}
+
+// invalidate drops everything cached so the next read goes to git.
+func (rc *readCache) invalidate() {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	rc.branches, rc.hasBranch = nil, false
+	rc.worktrees, rc.hasTrees = nil, false
+}
@@ -0,0 +1,96 @@
+package git
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// countingRunner runs real git and counts run calls by their first two
+// arguments (e.g. "worktree list").
+type countingRunner struct {
+	execRunner
+	calls map[string]int
+}
+
+func (r *countingRunner) run(dir string, args ...string) (string, error) {
+	r.calls[strings.Join(args[:min(2, len(args))], " ")]++
+	return r.execRunner.run(dir, args...)
+}
+
+func TestClient_ReadCache(t *testing.T) {
+	originalDir, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Failed to get current dir: %v", err)
+	}
+	defer os.Chdir(originalDir)
+
+	repoDir := filepath.Join(t.TempDir(), "test-repo")
+	os.MkdirAll(repoDir, 0755)
+	runGitCommand(t, repoDir, "init", "-b", "main")
+	runGitCommand(t, repoDir, "config", "user.email", "test@example.com")
+	runGitCommand(t, repoDir, "config", "user.name", "Test User")
+	runGitCommand(t, repoDir, "commit", "--allow-empty", "-m", "initial commit")
+	if err := os.Chdir(repoDir); err != nil {
+		t.Fatalf("Failed to change to repo dir: %v", err)
+	}
+
+	r := &countingRunner{calls: map[string]int{}}
+	c := &Client{r: r}
+
+	t.Run("repeated reads run git once", func(t *testing.T) {
+		for i := 0; i < 3; i++ {
+			if _, err := c.ListAllBranches(); err != nil {
+				t.Fatalf("ListAllBranches failed: %v", err)
+			}
+			if _, err := c.ListWorktrees(); err != nil {
+				t.Fatalf("ListWorktrees failed: %v", err)
+			}
+		}
+		// Not created yet; the lookup still goes through ListWorktrees.
+		_, _ = c.GetWorktreeForIssue("123")
+		if got := r.calls["branch -a"]; got != 1 {
+			t.Errorf("git branch -a ran %d times, want 1", got)
+		}
+		if got := r.calls["worktree list"]; got != 1 {
+			t.Errorf("git worktree list ran %d times, want 1", got)
+		}
+	})
+
+	t.Run("callers cannot modify the cache", func(t *testing.T) {
+		worktrees, _ := c.ListWorktrees()
+		worktrees[0].Branch = "changed"
+		again, _ := c.ListWorktrees()
+		if again[0].Branch != "main" {
+			t.Errorf("cached worktree branch = %q, want main", again[0].Branch)
+		}
+	})
+
+	t.Run("mutation invalidates the cache", func(t *testing.T) {
+		if _, err := c.CreateWorktree("123", "main"); err != nil {
+			t.Fatalf("CreateWorktree failed: %v", err)
+		}
+
+		worktrees, err := c.ListWorktrees()
+		if err != nil {
+			t.Fatalf("ListWorktrees failed: %v", err)
+		}
+		if len(worktrees) != 2 {
+			t.Errorf("Expected the new worktree to be listed, got %+v", worktrees)
+		}
+		branches, err := c.ListAllBranches()
+		if err != nil {
+			t.Fatalf("ListAllBranches failed: %v", err)
+		}
+		if !strings.Contains(strings.Join(branches, " "), "123/impl") {
+			t.Errorf("Expected 123/impl in branches, got %v", branches)
+		}
+		if got := r.calls["branch -a"]; got != 2 {
+			t.Errorf("git branch -a ran %d times, want 2", got)
+		}
+		if got := r.calls["worktree list"]; got != 2 {
+			t.Errorf("git worktree list ran %d times, want 2", got)
+		}
+	})
+}
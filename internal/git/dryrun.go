@@ -0,0 +1,25 @@
+package git
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// SetDryRun switches c into dry-run mode: every mutating git command
+// (worktree add/remove/repair, branch deletion, push, fetch, stash and patch
+// application) is printed to w instead of being run, while read-only commands
+// still run. A nil w turns dry-run mode off again.
+func (c *Client) SetDryRun(w io.Writer) {
+	c.dryRun = w
+}
+
+// skipMutation reports whether c is in dry-run mode. When it is, the git
+// command the caller was about to run is printed first.
+func (c *Client) skipMutation(dir string, args ...string) bool {
+	if c.dryRun == nil {
+		return false
+	}
+	fmt.Fprintf(c.dryRun, "[dry-run] git %s\n", strings.Join(gitArgs(dir, args), " "))
+	return true
+}
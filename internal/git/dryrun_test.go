@@ -0,0 +1,99 @@
+package git
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// mutationRecorder runs read-only git commands for real and records, without
+// running them, the combined and streaming commands mutations go through.
+type mutationRecorder struct {
+	execRunner
+	mutations []string
+}
+
+func (r *mutationRecorder) runCombined(dir string, args ...string) (string, error) {
+	r.mutations = append(r.mutations, strings.Join(args, " "))
+	return "", nil
+}
+
+func (r *mutationRecorder) runStreaming(dir string, args ...string) error {
+	r.mutations = append(r.mutations, strings.Join(args, " "))
+	return nil
+}
+
+func TestClient_DryRun(t *testing.T) {
+	originalDir, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Failed to get current dir: %v", err)
+	}
+	defer os.Chdir(originalDir)
+
+	repoDir := filepath.Join(t.TempDir(), "test-repo")
+	os.MkdirAll(repoDir, 0755)
+	runGitCommand(t, repoDir, "init", "-b", "main")
+	runGitCommand(t, repoDir, "config", "user.email", "test@example.com")
+	runGitCommand(t, repoDir, "config", "user.name", "Test User")
+	runGitCommand(t, repoDir, "commit", "--allow-empty", "-m", "initial commit")
+	if err := os.Chdir(repoDir); err != nil {
+		t.Fatalf("Failed to change to repo dir: %v", err)
+	}
+
+	r := &mutationRecorder{}
+	out := &bytes.Buffer{}
+	c := &Client{r: r}
+	c.SetDryRun(out)
+
+	path, err := c.CreateWorktree("123", "main")
+	if err != nil {
+		t.Fatalf("CreateWorktree failed: %v", err)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("Expected %s not to be created, stat error: %v", path, err)
+	}
+	steps := []struct {
+		name string
+		call func() error
+	}{
+		{"FetchAll", c.FetchAll},
+		{"CreateWorktreeFromBranch", func() error { return c.CreateWorktreeFromBranch(path, "origin/feature", "feature") }},
+		{"CreateUntrackedWorktreeFromBranch", func() error { return c.CreateUntrackedWorktreeFromBranch(path, "origin/feature", "feature") }},
+		{"RemoveWorktreeByPath", func() error { return c.RemoveWorktreeByPath(path) }},
+		{"CreateWorktreeAt", func() error { return c.CreateWorktreeAt(path, "HEAD", "") }},
+		{"CreateOrphanWorktree", func() error { _, err := c.CreateOrphanWorktree("gh-pages"); return err }},
+		{"MoveWorktree", func() error { return c.MoveWorktree(path, path+"-moved") }},
+		{"RepairWorktrees", func() error { return c.RepairWorktrees() }},
+		{"PruneWorktrees", func() error { return c.PruneWorktrees() }},
+		{"DeleteBranch", func() error { return c.DeleteBranch("123/impl", false) }},
+		{"DeleteRemoteBranch", func() error { return c.DeleteRemoteBranch("123/impl") }},
+		{"ApplyStash", func() error { return c.ApplyStash(path) }},
+		{"FormatPatch", func() error { _, err := c.FormatPatch(path, "main", path+"-archive"); return err }},
+		{"AdoptClone", func() error { _, err := c.AdoptClone(path+"-clone", "feature"); return err }},
+	}
+	for _, step := range steps {
+		if err := step.call(); err != nil {
+			t.Errorf("%s failed in dry-run mode: %v", step.name, err)
+		}
+	}
+
+	if len(r.mutations) != 0 {
+		t.Errorf("Expected no mutating git commands, ran: %v", r.mutations)
+	}
+	for _, want := range []string{
+		"[dry-run] git worktree add " + path,
+		"[dry-run] git fetch --all --prune",
+		"[dry-run] git worktree remove " + path,
+		"[dry-run] git worktree move " + path + " " + path + "-moved",
+		"[dry-run] git worktree repair",
+		"[dry-run] git branch -d 123/impl",
+		"[dry-run] git push origin --delete 123/impl",
+		"[dry-run] git -C " + path + " stash apply",
+	} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("Expected %q in the dry-run output, got:\n%s", want, out.String())
+		}
+	}
+}
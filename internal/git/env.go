@@ -1,16 +1,14 @@
 package git
 
 import (
+	"bytes"
 	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
 )
 
-const (
-	permEnvDir  = 0o755 // directories: rwxr-xr-x
-	permEnvFile = 0o600 // env files: rw------- (owner-only, contains secrets)
-)
+const permEnvDir = 0o755 // directories: rwxr-xr-x
 
 // EnvFile represents an environment file found in the repository
 type EnvFile struct {
@@ -91,6 +89,128 @@ func (c *Client) FindUntrackedEnvFiles(repoPath string) ([]EnvFile, error) {
 	return untrackedEnvFiles, nil
 }
 
+// FindUntrackedFiles lists every untracked file in repoPath, including ignored
+// ones such as .idea/ or local certificates, so they can seed a new worktree.
+// Files inside .git, nested repositories and the directories in skipDirs are
+// left out.
+func (c *Client) FindUntrackedFiles(repoPath string) ([]EnvFile, error) {
+	// Without --exclude-standard, --others includes ignored files too.
+	out, err := c.r.run(repoPath, "ls-files", "--others", "-z")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list untracked files: %w", err)
+	}
+
+	var files []EnvFile
+	for _, rel := range strings.Split(out, "\x00") {
+		// A trailing slash marks a nested repository, which git does not descend into.
+		if rel == "" || strings.HasSuffix(rel, "/") || inSkippedDir(rel) || hasGitComponent(rel) {
+			continue
+		}
+		files = append(files, EnvFile{
+			Path:         filepath.FromSlash(rel),
+			AbsolutePath: filepath.Join(repoPath, rel),
+		})
+	}
+	return files, nil
+}
+
+// inSkippedDir reports whether any directory in the relative path rel is one
+// of skipDirs.
+func inSkippedDir(rel string) bool {
+	parts := strings.Split(filepath.ToSlash(rel), "/")
+	for _, dir := range parts[:len(parts)-1] {
+		if skipDirs[dir] {
+			return true
+		}
+	}
+	return false
+}
+
+// CopyFiles copies files from sourceRoot to the same relative paths under
+// destRoot, keeping each file's permissions and recreating symlinks as
+// symlinks. Files that already exist in destRoot are left alone, and nothing
+// under a .git directory is ever copied. It returns how many files were copied.
+func (c *Client) CopyFiles(files []EnvFile, sourceRoot, destRoot string) (int, error) {
+	copied := 0
+	for _, file := range files {
+		if hasGitComponent(file.Path) {
+			continue
+		}
+
+		srcPath := filepath.Join(sourceRoot, file.Path)
+		destPath := filepath.Join(destRoot, file.Path)
+		if _, err := os.Lstat(destPath); err == nil {
+			continue
+		}
+
+		info, err := os.Lstat(srcPath)
+		if err != nil {
+			return copied, fmt.Errorf("failed to stat file %s: %w", srcPath, err)
+		}
+
+		destDir := filepath.Dir(destPath)
+		if err := os.MkdirAll(destDir, permEnvDir); err != nil {
+			return copied, fmt.Errorf("failed to create directory %s: %w", destDir, err)
+		}
+
+		if info.Mode()&os.ModeSymlink != 0 {
+			target, err := os.Readlink(srcPath)
+			if err != nil {
+				return copied, fmt.Errorf("failed to read link %s: %w", srcPath, err)
+			}
+			if err := os.Symlink(target, destPath); err != nil {
+				return copied, fmt.Errorf("failed to create link %s: %w", destPath, err)
+			}
+			copied++
+			continue
+		}
+
+		data, err := os.ReadFile(srcPath)
+		if err != nil {
+			return copied, fmt.Errorf("failed to read file %s: %w", srcPath, err)
+		}
+		if err := os.WriteFile(destPath, data, info.Mode().Perm()); err != nil {
+			return copied, fmt.Errorf("failed to write file %s: %w", destPath, err)
+		}
+		copied++
+	}
+
+	return copied, nil
+}
+
+// hasGitComponent reports whether any element of the relative path rel is .git.
+func hasGitComponent(rel string) bool {
+	for _, part := range strings.Split(filepath.ToSlash(rel), "/") {
+		if part == gitDir {
+			return true
+		}
+	}
+	return false
+}
+
+// FindEnvFileConflicts returns the env files whose destination under destRoot
+// already exists with content different from the source, i.e. the files that
+// CopyEnvFiles would silently overwrite. Identical files are not conflicts.
+func FindEnvFileConflicts(envFiles []EnvFile, sourceRoot, destRoot string) []EnvFile {
+	var conflicts []EnvFile
+	for _, envFile := range envFiles {
+		destData, err := os.ReadFile(filepath.Join(destRoot, envFile.Path))
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			// An unreadable destination is treated as different content.
+			conflicts = append(conflicts, envFile)
+			continue
+		}
+		srcData, err := os.ReadFile(filepath.Join(sourceRoot, envFile.Path))
+		if err != nil || !bytes.Equal(srcData, destData) {
+			conflicts = append(conflicts, envFile)
+		}
+	}
+	return conflicts
+}
+
 // CopyEnvFiles copies environment files from source to destination
 func (c *Client) CopyEnvFiles(envFiles []EnvFile, sourceRoot, destRoot string) error {
 	for _, envFile := range envFiles {
@@ -103,16 +223,26 @@ func (c *Client) CopyEnvFiles(envFiles []EnvFile, sourceRoot, destRoot string) e
 			return fmt.Errorf("failed to create directory %s: %w", destDir, err)
 		}
 
-		// Read source file
+		// Read source file along with its permissions
+		info, err := os.Stat(srcPath)
+		if err != nil {
+			return fmt.Errorf("failed to stat file %s: %w", srcPath, err)
+		}
 		data, err := os.ReadFile(srcPath)
 		if err != nil {
 			return fmt.Errorf("failed to read file %s: %w", srcPath, err)
 		}
 
-		// Write to destination with secure permissions
-		if err := os.WriteFile(destPath, data, permEnvFile); err != nil {
+		// Write to destination with the source's permissions. WriteFile only
+		// applies the mode (minus umask) to new files, so Chmod explicitly to
+		// keep e.g. a 0600 key 0600 when the destination already existed.
+		perm := info.Mode().Perm()
+		if err := os.WriteFile(destPath, data, perm); err != nil {
 			return fmt.Errorf("failed to write file %s: %w", destPath, err)
 		}
+		if err := os.Chmod(destPath, perm); err != nil {
+			return fmt.Errorf("failed to set permissions on %s: %w", destPath, err)
+		}
 
 		fmt.Printf("Copied: %s\n", envFile.Path)
 	}
@@ -282,3 +282,151 @@ func TestCopyEnvFiles(t *testing.T) {
 		}
 	}
 }
+
+func TestFindUntrackedFilesAndCopyFiles(t *testing.T) {
+	repoDir, cleanup := createTestRepo(t)
+	defer cleanup()
+
+	write := func(root, rel, content string, perm os.FileMode) {
+		t.Helper()
+		path := filepath.Join(root, rel)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("failed to create dir: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), perm); err != nil {
+			t.Fatalf("failed to write %s: %v", rel, err)
+		}
+	}
+
+	write(repoDir, ".gitignore", ".idea/\ncerts/\nnode_modules/\n", 0644)
+	write(repoDir, "tracked.txt", "tracked\n", 0644)
+	runGitCommand(t, repoDir, "add", ".")
+	runGitCommand(t, repoDir, "commit", "-m", "initial")
+
+	// Two worktrees of the same repository: the source holds local-only files.
+	sourceDir := filepath.Join(t.TempDir(), "repo-456")
+	destDir := filepath.Join(t.TempDir(), "repo-123")
+	runGitCommand(t, repoDir, "worktree", "add", "-b", "source", sourceDir)
+	runGitCommand(t, repoDir, "worktree", "add", "-b", "dest", destDir)
+
+	write(sourceDir, ".idea/workspace/settings.xml", "<settings/>\n", 0644)
+	write(sourceDir, "certs/local.pem", "secret\n", 0600)
+	write(sourceDir, "notes.txt", "from source\n", 0644)
+	write(sourceDir, "node_modules/pkg/index.js", "skip me\n", 0644)
+	write(sourceDir, "tracked.txt", "modified, but tracked\n", 0644)
+	write(destDir, "notes.txt", "already here\n", 0644)
+
+	files, err := FindUntrackedFiles(sourceDir)
+	if err != nil {
+		t.Fatalf("FindUntrackedFiles failed: %v", err)
+	}
+	found := map[string]bool{}
+	for _, f := range files {
+		found[filepath.ToSlash(f.Path)] = true
+	}
+	for _, want := range []string{".idea/workspace/settings.xml", "certs/local.pem", "notes.txt"} {
+		if !found[want] {
+			t.Errorf("expected %s to be listed, got %v", want, found)
+		}
+	}
+	for _, unwanted := range []string{"tracked.txt", "node_modules/pkg/index.js", ".git"} {
+		if found[unwanted] {
+			t.Errorf("did not expect %s to be listed", unwanted)
+		}
+	}
+
+	// A .git path is never copied, even if a caller passes one in.
+	files = append(files, EnvFile{Path: filepath.Join(".git", "config")})
+	copied, err := CopyFiles(files, sourceDir, destDir)
+	if err != nil {
+		t.Fatalf("CopyFiles failed: %v", err)
+	}
+	if copied != 2 {
+		t.Errorf("expected 2 files copied (notes.txt already existed), got %d", copied)
+	}
+
+	content, err := os.ReadFile(filepath.Join(destDir, ".idea", "workspace", "settings.xml"))
+	if err != nil || string(content) != "<settings/>\n" {
+		t.Errorf("nested ignored file not copied: %q, %v", content, err)
+	}
+	info, err := os.Stat(filepath.Join(destDir, "certs", "local.pem"))
+	if err != nil {
+		t.Fatalf("certs/local.pem not copied: %v", err)
+	}
+	if info.Mode().Perm() != 0600 {
+		t.Errorf("expected permissions 0600 to be kept, got %v", info.Mode().Perm())
+	}
+	if content, _ := os.ReadFile(filepath.Join(destDir, "notes.txt")); string(content) != "already here\n" {
+		t.Errorf("existing file was overwritten: %q", content)
+	}
+	if info, err := os.Stat(filepath.Join(destDir, ".git")); err != nil || info.IsDir() {
+		t.Errorf("destination .git file should be untouched, stat: %v, %v", info, err)
+	}
+}
+
+func TestFindEnvFileConflicts(t *testing.T) {
+	srcDir := t.TempDir()
+	destDir := t.TempDir()
+
+	write := func(dir, path, content string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(dir, path), []byte(content), 0600); err != nil {
+			t.Fatalf("Failed to write %s: %v", path, err)
+		}
+	}
+	write(srcDir, ".env", "A=1")
+	write(srcDir, ".env.local", "B=1")
+	write(srcDir, ".env.test", "C=1")
+	write(destDir, ".env", "A=2")       // different content: conflict
+	write(destDir, ".env.local", "B=1") // identical: not a conflict
+	// .env.test does not exist in destDir: not a conflict
+
+	envFiles := []EnvFile{{Path: ".env"}, {Path: ".env.local"}, {Path: ".env.test"}}
+	conflicts := FindEnvFileConflicts(envFiles, srcDir, destDir)
+
+	if len(conflicts) != 1 || conflicts[0].Path != ".env" {
+		t.Errorf("Expected only .env to conflict, got %v", conflicts)
+	}
+}
+
+func TestCopyEnvFiles_PreservesPermissions(t *testing.T) {
+	srcDir := t.TempDir()
+	destDir := t.TempDir()
+
+	files := []struct {
+		path string
+		mode os.FileMode
+	}{
+		{".env.key", 0600},
+		{".envrc", 0755},
+		{".env", 0644},
+	}
+	var envFiles []EnvFile
+	for _, f := range files {
+		if err := os.WriteFile(filepath.Join(srcDir, f.path), []byte("x"), f.mode); err != nil {
+			t.Fatalf("Failed to write %s: %v", f.path, err)
+		}
+		if err := os.Chmod(filepath.Join(srcDir, f.path), f.mode); err != nil {
+			t.Fatalf("Failed to chmod %s: %v", f.path, err)
+		}
+		envFiles = append(envFiles, EnvFile{Path: f.path})
+	}
+	// An existing, more permissive destination must end up with the source mode too.
+	if err := os.WriteFile(filepath.Join(destDir, ".env.key"), []byte("old"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := CopyEnvFiles(envFiles, srcDir, destDir); err != nil {
+		t.Fatalf("CopyEnvFiles failed: %v", err)
+	}
+
+	for _, f := range files {
+		info, err := os.Stat(filepath.Join(destDir, f.path))
+		if err != nil {
+			t.Fatalf("Failed to stat copied %s: %v", f.path, err)
+		}
+		if info.Mode().Perm() != f.mode {
+			t.Errorf("%s: mode = %v, want %v", f.path, info.Mode().Perm(), f.mode)
+		}
+	}
+}
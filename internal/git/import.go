@@ -0,0 +1,193 @@
+package git
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// CheckoutKind tells what kind of git checkout a directory is.
+type CheckoutKind int
+
+const (
+	// NotACheckout is a directory without a .git entry of its own.
+	NotACheckout CheckoutKind = iota
+	// LinkedCheckout is a linked worktree: its .git file names the
+	// administrative directory inside the repository it belongs to.
+	LinkedCheckout
+	// CloneCheckout is a repository of its own, with a .git directory.
+	CloneCheckout
+)
+
+// Checkout describes a directory as found by InspectCheckout.
+type Checkout struct {
+	Path   string // absolute path of the directory
+	Kind   CheckoutKind
+	Branch string // the checked-out branch; empty for a detached HEAD
+	// SameRepository reports whether the checkout belongs to the current
+	// repository: a worktree sharing its git directory, or a clone sharing a
+	// root commit with it.
+	SameRepository bool
+}
+
+// InspectCheckout reports what kind of checkout the directory at path is and
+// whether it belongs to the current repository. path must be the top of the
+// checkout; a subdirectory is NotACheckout. Like ScanWorktrees it reads the
+// .git entry from the filesystem, so a worktree whose link git lost after a
+// move is still recognised.
+func (c *Client) InspectCheckout(path string) (*Checkout, error) {
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve %s: %w", path, err)
+	}
+	checkout := &Checkout{Path: absPath}
+	gitDir, commonDir, ok := resolveGitDirs(absPath)
+	if !ok {
+		return checkout, nil
+	}
+	checkout.Kind = LinkedCheckout
+	if info, err := os.Stat(filepath.Join(absPath, ".git")); err == nil && info.IsDir() {
+		checkout.Kind = CloneCheckout
+	}
+	checkout.Branch = readHeadBranch(gitDir)
+
+	ours, err := c.r.run("", "rev-parse", "--git-common-dir")
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrNotGitRepository, err)
+	}
+	if ours, err = filepath.Abs(ours); err != nil {
+		return nil, fmt.Errorf("failed to resolve git common dir: %w", err)
+	}
+	if canonicalPath(ours) == commonDir {
+		checkout.SameRepository = true
+		return checkout, nil
+	}
+	if checkout.Kind == CloneCheckout {
+		if checkout.SameRepository, err = c.sharesRootCommit(absPath); err != nil {
+			return nil, err
+		}
+	}
+	return checkout, nil
+}
+
+// sharesRootCommit reports whether the repository at dir and the current one
+// have a root commit in common, i.e. one is a clone of the other.
+func (c *Client) sharesRootCommit(dir string) (bool, error) {
+	roots := func(dir string) (map[string]bool, error) {
+		out, err := c.r.run(dir, "rev-list", "--max-parents=0", "--all")
+		if err != nil {
+			return nil, fmt.Errorf("failed to list root commits: %w", err)
+		}
+		set := map[string]bool{}
+		for _, commit := range strings.Fields(out) {
+			set[commit] = true
+		}
+		return set, nil
+	}
+	theirs, err := roots(dir)
+	if err != nil {
+		return false, err
+	}
+	ours, err := roots("")
+	if err != nil {
+		return false, err
+	}
+	for commit := range theirs {
+		if ours[commit] {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// AdoptClone turns the clone at clonePath into a linked worktree of the
+// current repository with branch checked out, keeping its files, including
+// uncommitted changes, where they are. branch is fetched from the clone
+// first, which fails when the repository already has a branch of that name
+// the clone's is not a fast-forward of; a failed import puts that branch
+// back where it was. The clone's own .git directory is moved aside rather
+// than deleted; its path is returned so its stashes and other branches stay
+// recoverable.
+func (c *Client) AdoptClone(clonePath, branch string) (backup string, err error) {
+	if err := c.requireFeature(FeatureWorktreeRepair); err != nil {
+		return "", err
+	}
+	defer c.cache.invalidate()
+	refspec := "refs/heads/" + branch + ":refs/heads/" + branch
+	if c.skipMutation("", "fetch", clonePath, refspec) {
+		c.skipMutation("", "worktree", "add", "--no-checkout", clonePath, branch)
+		return "", nil
+	}
+
+	backup = clonePath + ".git-before-import"
+	if _, err := os.Lstat(backup); err == nil {
+		return "", fmt.Errorf("%s already exists; move it out of the way first", backup)
+	}
+	// The branch may already exist here; remember where it pointed so a failed
+	// import can put it back instead of leaving the fetched commits behind.
+	previous, _ := c.r.run("", "rev-parse", "--verify", "--quiet", "refs/heads/"+branch)
+	if _, err := c.r.runCombined("", "fetch", clonePath, refspec); err != nil {
+		return "", fmt.Errorf("failed to fetch %s from %s: %w", branch, clonePath, err)
+	}
+	restoreBranch := func() {
+		if previous == "" {
+			_, _ = c.r.runCombined("", "update-ref", "-d", "refs/heads/"+branch)
+		} else {
+			_, _ = c.r.runCombined("", "update-ref", "refs/heads/"+branch, previous)
+		}
+	}
+
+	// git worktree add only creates new directories, so the worktree is added
+	// in a staging directory named like the clone and its .git file moved in.
+	// The staging directory sits next to the clone so that move never crosses
+	// filesystems, as it would from a tmpfs $TMPDIR.
+	staging, err := os.MkdirTemp(filepath.Dir(clonePath), ".gw-import-")
+	if err != nil {
+		restoreBranch()
+		return "", fmt.Errorf("failed to create staging directory: %w", err)
+	}
+	defer os.RemoveAll(staging)
+	stagedPath := filepath.Join(staging, filepath.Base(clonePath))
+	if _, err := c.r.runCombined("", "worktree", "add", "--no-checkout", stagedPath, branch); err != nil {
+		restoreBranch()
+		return "", fmt.Errorf("failed to add worktree for %s: %w", branch, err)
+	}
+
+	dotGit := filepath.Join(clonePath, ".git")
+	if err := os.Rename(dotGit, backup); err != nil {
+		c.discardStagedWorktree(stagedPath)
+		restoreBranch()
+		return "", fmt.Errorf("failed to move %s aside: %w", dotGit, err)
+	}
+	rollback := func() {
+		_ = os.Rename(dotGit, filepath.Join(stagedPath, ".git"))
+		_ = os.Rename(backup, dotGit)
+		c.discardStagedWorktree(stagedPath)
+		restoreBranch()
+	}
+	if err := os.Rename(filepath.Join(stagedPath, ".git"), dotGit); err != nil {
+		_ = os.Rename(backup, dotGit)
+		c.discardStagedWorktree(stagedPath)
+		restoreBranch()
+		return "", fmt.Errorf("failed to link %s: %w", clonePath, err)
+	}
+	if _, err := c.r.runCombined("", "worktree", "repair", clonePath); err != nil {
+		rollback()
+		return "", fmt.Errorf("failed to repair worktree %s: %w", clonePath, err)
+	}
+	// --no-checkout leaves the index empty; fill it from HEAD without touching
+	// the files, so only the clone's uncommitted changes show as modified.
+	if _, err := c.r.runCombined(clonePath, "reset", "--quiet"); err != nil {
+		rollback()
+		return "", fmt.Errorf("failed to reset the index of %s: %w", clonePath, err)
+	}
+	return backup, nil
+}
+
+// discardStagedWorktree drops the registration of a worktree AdoptClone
+// added in its staging directory.
+func (c *Client) discardStagedWorktree(stagedPath string) {
+	_, _ = c.r.runCombined("", "worktree", "remove", "--force", stagedPath)
+	_, _ = c.r.runCombined("", "worktree", "prune")
+}
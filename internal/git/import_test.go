@@ -0,0 +1,200 @@
+package git
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// setupImportRepo creates a repository with one commit, changes into it and
+// returns its path together with a directory for siblings.
+func setupImportRepo(t *testing.T) (repoDir, siblings string) {
+	t.Helper()
+	tempDir, cleanup := createTestRepo(t)
+	t.Cleanup(cleanup)
+	runGitCommand(t, tempDir, "commit", "--allow-empty", "-m", "initial")
+
+	originalDir, _ := os.Getwd()
+	t.Cleanup(func() { _ = os.Chdir(originalDir) })
+	if err := os.Chdir(tempDir); err != nil {
+		t.Fatalf("chdir: %v", err)
+	}
+	return tempDir, t.TempDir()
+}
+
+func TestInspectCheckout(t *testing.T) {
+	repoDir, siblings := setupImportRepo(t)
+
+	worktreePath := filepath.Join(siblings, "linked")
+	runGitCommand(t, repoDir, "worktree", "add", "-b", "linked-branch", worktreePath)
+	clonePath := filepath.Join(siblings, "clone")
+	runGitCommand(t, repoDir, "clone", "--quiet", repoDir, clonePath)
+	otherPath := filepath.Join(siblings, "other")
+	runGitCommand(t, siblings, "init", "--quiet", otherPath)
+	runGitCommand(t, otherPath, "-c", "user.email=test@example.com", "-c", "user.name=Test User", "commit", "--allow-empty", "-m", "unrelated")
+	plainPath := filepath.Join(siblings, "plain")
+	if err := os.Mkdir(plainPath, 0755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+
+	tests := []struct {
+		path     string
+		wantKind CheckoutKind
+		wantSame bool
+		wantRef  string
+	}{
+		{worktreePath, LinkedCheckout, true, "linked-branch"},
+		{clonePath, CloneCheckout, true, getDefaultBranchName(t, repoDir)},
+		{otherPath, CloneCheckout, false, getDefaultBranchName(t, otherPath)},
+		{plainPath, NotACheckout, false, ""},
+		{filepath.Join(repoDir, ".git"), NotACheckout, false, ""},
+	}
+	for _, tt := range tests {
+		t.Run(filepath.Base(tt.path), func(t *testing.T) {
+			got, err := InspectCheckout(tt.path)
+			if err != nil {
+				t.Fatalf("InspectCheckout failed: %v", err)
+			}
+			if got.Kind != tt.wantKind || got.SameRepository != tt.wantSame || got.Branch != tt.wantRef {
+				t.Errorf("InspectCheckout(%s) = kind %d, same %v, branch %q; want kind %d, same %v, branch %q",
+					tt.path, got.Kind, got.SameRepository, got.Branch, tt.wantKind, tt.wantSame, tt.wantRef)
+			}
+		})
+	}
+}
+
+func TestAdoptClone(t *testing.T) {
+	repoDir, siblings := setupImportRepo(t)
+
+	clonePath := filepath.Join(siblings, "clone")
+	runGitCommand(t, repoDir, "clone", "--quiet", repoDir, clonePath)
+	runGitCommand(t, clonePath, "switch", "--quiet", "-c", "feature")
+	if err := os.WriteFile(filepath.Join(clonePath, "feature.txt"), []byte("committed\n"), 0644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	runGitCommand(t, clonePath, "add", "feature.txt")
+	runGitCommand(t, clonePath, "-c", "user.email=test@example.com", "-c", "user.name=Test User", "commit", "-m", "feature")
+	if err := os.WriteFile(filepath.Join(clonePath, "feature.txt"), []byte("uncommitted\n"), 0644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+
+	backup, err := AdoptClone(clonePath, "feature")
+	if err != nil {
+		t.Fatalf("AdoptClone failed: %v", err)
+	}
+
+	worktrees, err := ListWorktrees()
+	if err != nil {
+		t.Fatalf("ListWorktrees failed: %v", err)
+	}
+	found := false
+	for _, wt := range worktrees {
+		if canonicalPath(wt.Path) == canonicalPath(clonePath) {
+			found = wt.Branch == "feature"
+		}
+	}
+	if !found {
+		t.Errorf("Expected %s to be listed on branch feature, got %+v", clonePath, worktrees)
+	}
+
+	status, err := exec.Command("git", "-C", clonePath, "status", "--porcelain").Output()
+	if err != nil {
+		t.Fatalf("git status failed: %v", err)
+	}
+	if got := strings.TrimSpace(string(status)); got != "M feature.txt" {
+		t.Errorf("Expected only the uncommitted change to remain, got %q", got)
+	}
+	if info, err := os.Stat(backup); err != nil || !info.IsDir() {
+		t.Errorf("Expected the clone's .git directory to be kept at %s: %v", backup, err)
+	}
+}
+
+func TestAdoptClone_BranchCheckedOutElsewhere(t *testing.T) {
+	repoDir, siblings := setupImportRepo(t)
+
+	clonePath := filepath.Join(siblings, "clone")
+	runGitCommand(t, repoDir, "clone", "--quiet", repoDir, clonePath)
+
+	_, err := AdoptClone(clonePath, getDefaultBranchName(t, repoDir))
+	if err == nil {
+		t.Fatal("Expected an error for a branch checked out in the main worktree")
+	}
+	if info, statErr := os.Stat(filepath.Join(clonePath, ".git")); statErr != nil || !info.IsDir() {
+		t.Errorf("Expected the clone to be left alone, stat error: %v", statErr)
+	}
+}
+
+func TestAdoptClone_StagingOnAnotherFilesystem(t *testing.T) {
+	repoDir, siblings := setupImportRepo(t)
+
+	clonePath := filepath.Join(siblings, "clone")
+	runGitCommand(t, repoDir, "clone", "--quiet", repoDir, clonePath)
+	runGitCommand(t, clonePath, "switch", "--quiet", "-c", "feature")
+
+	// A tmpfs $TMPDIR is usually on another filesystem than the clone; the
+	// staged .git file must still be moved in.
+	tmpRoot := "/dev/shm"
+	if info, err := os.Stat(tmpRoot); err != nil || !info.IsDir() {
+		tmpRoot = ""
+	}
+	tmpDir, err := os.MkdirTemp(tmpRoot, "gw-import-test-")
+	if err != nil {
+		t.Fatalf("MkdirTemp: %v", err)
+	}
+	t.Cleanup(func() { _ = os.RemoveAll(tmpDir) })
+	t.Setenv("TMPDIR", tmpDir)
+
+	if _, err := AdoptClone(clonePath, "feature"); err != nil {
+		t.Fatalf("AdoptClone failed: %v", err)
+	}
+	entries, err := os.ReadDir(siblings)
+	if err != nil {
+		t.Fatalf("ReadDir: %v", err)
+	}
+	for _, entry := range entries {
+		if strings.HasPrefix(entry.Name(), ".gw-import-") {
+			t.Errorf("Expected the staging directory to be removed, found %s", entry.Name())
+		}
+	}
+}
+
+// failingRunner delegates to runner but fails git commands whose arguments
+// start with failArgs.
+type failingRunner struct {
+	runner
+	failArgs []string
+}
+
+func (r failingRunner) runCombined(dir string, args ...string) (string, error) {
+	if len(args) >= len(r.failArgs) && slices.Equal(args[:len(r.failArgs)], r.failArgs) {
+		return "", &GitError{Args: args, ExitCode: 1, Stderr: "injected failure"}
+	}
+	return r.runner.runCombined(dir, args...)
+}
+
+func TestAdoptClone_RollbackRestoresBranch(t *testing.T) {
+	repoDir, siblings := setupImportRepo(t)
+
+	clonePath := filepath.Join(siblings, "clone")
+	runGitCommand(t, repoDir, "clone", "--quiet", repoDir, clonePath)
+	runGitCommand(t, clonePath, "switch", "--quiet", "-c", "feature")
+
+	c := NewClient()
+	c.r = failingRunner{runner: c.r, failArgs: []string{"worktree", "repair"}}
+	if _, err := c.AdoptClone(clonePath, "feature"); err == nil {
+		t.Fatal("Expected the injected repair failure to be returned")
+	}
+
+	if err := exec.Command("git", "-C", repoDir, "rev-parse", "--verify", "--quiet", "refs/heads/feature").Run(); err == nil {
+		t.Error("Expected the fetched branch to be deleted on rollback")
+	}
+	if info, err := os.Stat(filepath.Join(clonePath, ".git")); err != nil || !info.IsDir() {
+		t.Errorf("Expected the clone's .git directory to be restored, stat error: %v", err)
+	}
+	if _, err := os.Lstat(clonePath + ".git-before-import"); !os.IsNotExist(err) {
+		t.Errorf("Expected no backup to be left behind, got: %v", err)
+	}
+}
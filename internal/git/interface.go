@@ -1,5 +1,7 @@
 package git
 
+import "io"
+
 // RepositoryReader exposes read-only repository introspection and remote sync.
 type RepositoryReader interface {
 	IsGitRepository() bool
@@ -9,36 +11,76 @@ type RepositoryReader interface {
 	GetMainRepositoryRoot() (string, error)
 	GetCurrentBranch() (string, error)
 	FetchAll() error
+	Remote() string
+	HasRemote() (bool, error)
+	GitVersion() (Version, error)
 }
 
-// WorktreeManager exposes worktree lifecycle operations.
+// WorktreeManager exposes worktree lifecycle operations, including carrying
+// local changes (a stash or a patch) into a new worktree.
 type WorktreeManager interface {
-	CreateWorktree(issueNumber, baseBranch string) (string, error)
+	CreateWorktree(issueNumber, baseBranch string, opts ...AddOption) (string, error)
+	CreateTrackingWorktree(issueNumber, remoteBranch string, opts ...AddOption) (string, error)
 	CreateWorktreeFromBranch(worktreePath, sourceBranch, targetBranch string) error
+	CreateUntrackedWorktreeFromBranch(worktreePath, sourceBranch, targetBranch string) error
+	CreateWorktreeAt(worktreePath, commit, newBranch string) error
+	CreateOrphanWorktree(branch string, opts ...AddOption) (string, error)
 	RemoveWorktree(issueNumber string) error
 	RemoveWorktreeByPath(worktreePath string) error
+	MoveWorktree(worktreePath, newPath string) error
+	RepairWorktrees(worktreePaths ...string) error
+	PruneWorktrees() error
 	ListWorktrees() ([]WorktreeInfo, error)
 	GetWorktreeForIssue(issueNumber string) (*WorktreeInfo, error)
+	ApplyStash(worktreePath string) error
+	ApplyPatch(worktreePath, patchFile string) error
+	FormatPatch(worktreePath, base, outDir string) ([]string, error)
+	InspectCheckout(path string) (*Checkout, error)
+	AdoptClone(clonePath, branch string) (backup string, err error)
+	WorktreeRoot() string
 }
 
-// BranchManager exposes branch inspection and deletion.
+// BranchManager exposes branch and ref inspection, deletion, fast-forwarding
+// and merging.
 type BranchManager interface {
 	BranchExists(branch string) (bool, error)
+	IsUpstreamGone(branch string) (bool, error)
 	ListAllBranches() ([]string, error)
-	DeleteBranch(branch string) error
+	ListLocalBranches() ([]string, error)
+	ResolveBaseBranch(baseBranch string) (string, bool)
+	DeleteBranch(branch string, force bool) error
+	DeleteRemoteBranch(branch string) error
+	UpstreamStatus(branch string) (*TrackingStatus, error)
+	FastForwardBranch(branch string) error
+	MergeBranch(worktreePath, baseBranch, branch string, opts MergeOptions) error
+	ResolveRefDescription(commit string) (string, error)
+	MergeBase(a, b string) (string, error)
+	RemoteDefaultBranch() (string, error)
+	FetchPullRequest(number, branch string) (RemoteRepo, error)
+	RemoteBranchCandidates(branch string) ([]string, error)
+	CutRemoteBranch(branch string) (string, bool)
 }
 
-// StatusChecker exposes the safety checks performed before destructive ops.
+// StatusChecker exposes the safety checks performed before destructive ops,
+// plus the detailed worktree state shown by gw info.
 type StatusChecker interface {
 	HasUncommittedChanges(worktreePath string) (bool, error)
 	HasUnpushedCommits(worktreePath, currentBranch string) (bool, error)
 	IsMergedToBaseBranch(worktreePath, currentBranch, targetBranch string) (bool, error)
+	IsInProgressOperation(worktreePath string) (inProgress bool, operation string, err error)
+	GetWorktreeDetails(worktreePath string) (*WorktreeDetails, error)
+	CommitsBehind(worktreePath, baseBranch string) (int, error)
+	CommitsSince(worktreePath, rev string) (int, error)
+	AheadBehind(worktreePath, baseBranch string) (ahead, behind int, err error)
 }
 
-// EnvFileHandler exposes untracked env file discovery and copying.
+// EnvFileHandler exposes untracked env file discovery and copying, plus the
+// general untracked-file variant used to seed a worktree from another one.
 type EnvFileHandler interface {
 	FindUntrackedEnvFiles(repoPath string) ([]EnvFile, error)
 	CopyEnvFiles(envFiles []EnvFile, sourceRoot, destRoot string) error
+	FindUntrackedFiles(repoPath string) ([]EnvFile, error)
+	CopyFiles(files []EnvFile, sourceRoot, destRoot string) (int, error)
 }
 
 // Interface is the composed surface used by cmd.Dependencies. It aggregates the
@@ -58,7 +100,12 @@ type Interface interface {
 
 // Client implements git operations by invoking the git CLI through a runner.
 type Client struct {
-	r runner
+	r      runner
+	cache  readCache
+	dryRun io.Writer // set by SetDryRun; nil runs mutating commands
+	remote string    // set by SetRemote; empty means DefaultRemote
+
+	worktreeRoot string // set by SetWorktreeRoot; empty places worktrees as siblings
 }
 
 // Ensure Client implements Interface
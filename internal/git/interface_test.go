@@ -207,7 +207,7 @@ func TestClient_BranchOperations(t *testing.T) {
 	}
 
 	// Test DeleteBranch
-	err = client.DeleteBranch("test-branch")
+	err = client.DeleteBranch("test-branch", false)
 	if err != nil {
 		t.Fatalf("DeleteBranch() failed: %v", err)
 	}
@@ -283,7 +283,11 @@ func TestClient_StatusOperations(t *testing.T) {
 	os.Remove("test.txt")
 
 	// Create a feature branch with its own commit so it has work that is
-	// neither pushed nor merged into the base branch.
+	// neither pushed nor merged into the base branch, in a repository that
+	// has a remote to push it to.
+	if err := RunCommand("git remote add origin " + t.TempDir()); err != nil {
+		t.Fatalf("failed to add remote: %v", err)
+	}
 	if err := RunCommand("git checkout -b feature-no-upstream"); err != nil {
 		t.Fatalf("failed to create branch: %v", err)
 	}
@@ -1,6 +1,7 @@
 package git
 
 import (
+	"errors"
 	"os"
 	"path/filepath"
 	"testing"
@@ -136,8 +137,11 @@ func TestGetMainRepositoryRoot(t *testing.T) {
 			t.Fatalf("failed to change dir: %v", err)
 		}
 
-		if _, err := GetMainRepositoryRoot(); err == nil {
-			t.Error("expected error when not in a git repository")
+		if _, err := GetMainRepositoryRoot(); !errors.Is(err, ErrNotGitRepository) {
+			t.Errorf("expected ErrNotGitRepository, got: %v", err)
+		}
+		if _, err := GetRepositoryRoot(); !errors.Is(err, ErrNotGitRepository) {
+			t.Errorf("expected ErrNotGitRepository from GetRepositoryRoot, got: %v", err)
 		}
 	})
 }
@@ -0,0 +1,62 @@
+package git
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ValidateRef checks input against git's branch naming rules (see
+// git-check-ref-format(1), as applied by `git check-ref-format --branch`) so
+// that obviously invalid issue numbers or branch names are rejected with a
+// clear message before git is ever invoked.
+func ValidateRef(input string) error {
+	if reason := invalidRefReason(input); reason != "" {
+		return fmt.Errorf("invalid branch name %q: %s", input, reason)
+	}
+	return nil
+}
+
+// invalidRefReason returns why name is not a valid branch name, or "" when it
+// is valid.
+func invalidRefReason(name string) string {
+	switch {
+	case name == "":
+		return "name is empty"
+	case name == "@":
+		return `"@" is not allowed as a name`
+	case strings.HasPrefix(name, "-"):
+		return "cannot start with '-'"
+	case strings.HasPrefix(name, "/") || strings.HasSuffix(name, "/"):
+		return "cannot start or end with '/'"
+	case strings.HasSuffix(name, "."):
+		return "cannot end with '.'"
+	case strings.Contains(name, ".."):
+		return "cannot contain '..'"
+	case strings.Contains(name, "//"):
+		return "cannot contain '//'"
+	case strings.Contains(name, "@{"):
+		return "cannot contain '@{'"
+	}
+
+	for _, r := range name {
+		switch {
+		case r == ' ' || r == '\t':
+			return "cannot contain whitespace"
+		case r < 0x20 || r == 0x7f:
+			return "cannot contain control characters"
+		case strings.ContainsRune(`~^:?*[\`, r):
+			return fmt.Sprintf("cannot contain '%c'", r)
+		}
+	}
+
+	for _, component := range strings.Split(name, "/") {
+		if strings.HasPrefix(component, ".") {
+			return "path components cannot start with '.'"
+		}
+		if strings.HasSuffix(component, ".lock") {
+			return "path components cannot end with '.lock'"
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,66 @@
+package git
+
+import (
+	"os/exec"
+	"strings"
+	"testing"
+)
+
+func TestValidateRef(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		wantErr string // empty means valid
+	}{
+		{name: "issue number", input: "123"},
+		{name: "branch with slash", input: "476/impl-migration-script"},
+		{name: "remote branch", input: "origin/feature/api"},
+		{name: "dots inside a component", input: "release/v1.2.3"},
+		{name: "leading space", input: " 123", wantErr: "whitespace"},
+		{name: "inner space", input: "my branch", wantErr: "whitespace"},
+		{name: "leading dash", input: "--oops", wantErr: "cannot start with '-'"},
+		{name: "double dot", input: "foo..bar", wantErr: "cannot contain '..'"},
+		{name: "empty", input: "", wantErr: "empty"},
+		{name: "at sign alone", input: "@", wantErr: `"@"`},
+		{name: "reflog syntax", input: "main@{1}", wantErr: "'@{'"},
+		{name: "trailing slash", input: "feature/", wantErr: "'/'"},
+		{name: "double slash", input: "feature//x", wantErr: "'//'"},
+		{name: "trailing dot", input: "feature.", wantErr: "'.'"},
+		{name: "hidden component", input: "feature/.x", wantErr: "start with '.'"},
+		{name: "lock suffix", input: "feature.lock", wantErr: ".lock"},
+		{name: "colon", input: "a:b", wantErr: "':'"},
+		{name: "glob", input: "feat*", wantErr: "'*'"},
+		{name: "backslash", input: `a\b`, wantErr: `'\'`},
+		{name: "control character", input: "a\x01b", wantErr: "control"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateRef(tt.input)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("ValidateRef(%q) unexpected error: %v", tt.input, err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("ValidateRef(%q) expected error containing %q", tt.input, tt.wantErr)
+			}
+			if !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("ValidateRef(%q) error = %v, want it to contain %q", tt.input, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// TestValidateRef_MatchesGit cross-checks the rules against git itself.
+func TestValidateRef_MatchesGit(t *testing.T) {
+	inputs := []string{"123", "feature/x", " 123", "foo..bar", "a:b", "x.lock", "feature/.x", "a@{b", "ok-name_1"}
+	for _, input := range inputs {
+		gitErr := exec.Command("git", "check-ref-format", "--branch", input).Run()
+		ourErr := ValidateRef(input)
+		if (gitErr == nil) != (ourErr == nil) {
+			t.Errorf("ValidateRef(%q) = %v, but git check-ref-format --branch error = %v", input, ourErr, gitErr)
+		}
+	}
+}
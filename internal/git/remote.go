@@ -0,0 +1,196 @@
+package git
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// DefaultRemote is the remote gw compares against, checks out from and pushes
+// to unless SetRemote selects another one.
+const DefaultRemote = "origin"
+
+// SetRemote selects the remote used for merge checks, remote branch lookups and
+// pushes, e.g. "upstream" in a fork workflow. An empty name restores
+// DefaultRemote.
+func (c *Client) SetRemote(name string) {
+	c.remote = name
+}
+
+// Remote returns the remote selected with SetRemote.
+func (c *Client) Remote() string {
+	if c.remote == "" {
+		return DefaultRemote
+	}
+	return c.remote
+}
+
+// noSuchRemoteExitCode is what git remote get-url exits with for a remote
+// that is not configured.
+const noSuchRemoteExitCode = 2
+
+// HasRemote reports whether the selected remote is configured, e.g. false in
+// a repository that was never pushed anywhere.
+func (c *Client) HasRemote() (bool, error) {
+	_, err := c.r.run("", "remote", "get-url", c.Remote())
+	if err == nil {
+		return true, nil
+	}
+	var gitErr *GitError
+	if errors.As(err, &gitErr) && gitErr.ExitCode == noSuchRemoteExitCode {
+		return false, nil
+	}
+	return false, fmt.Errorf("failed to look up remote %s: %w", c.Remote(), err)
+}
+
+// RemoteDefaultBranch returns the default branch of the selected remote as a
+// remote-tracking branch, e.g. "origin/main", as recorded by <remote>/HEAD.
+// git clone sets that ref; for a remote added later it is set by git remote
+// set-head.
+func (c *Client) RemoteDefaultBranch() (string, error) {
+	remote := c.Remote()
+	out, err := c.r.run("", "symbolic-ref", "--short", "refs/remotes/"+remote+"/HEAD")
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve the default branch of %s (run git remote set-head %s --auto): %w", remote, remote, err)
+	}
+	return out, nil
+}
+
+// CutRemotePrefix removes the "<remote>/" prefix from branch and reports
+// whether it was there, e.g. ("origin/foo", "origin") -> ("foo", true).
+func CutRemotePrefix(branch, remote string) (string, bool) {
+	return strings.CutPrefix(branch, remote+"/")
+}
+
+// remotes returns the names of the configured remotes, in the order git
+// remote lists them.
+func (c *Client) remotes() ([]string, error) {
+	out, err := c.r.run("", "remote")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list remotes: %w", err)
+	}
+	return strings.Fields(out), nil
+}
+
+// RemoteBranchCandidates returns the remote-tracking branches a bare branch
+// name could mean, e.g. [origin/feature upstream/feature] for feature. It
+// returns none when a local branch has that name, since that is what git
+// checks out.
+func (c *Client) RemoteBranchCandidates(branch string) ([]string, error) {
+	if branch == "" || c.localBranchExists(branch) {
+		return nil, nil
+	}
+	remotes, err := c.remotes()
+	if err != nil {
+		return nil, err
+	}
+	var candidates []string
+	for _, remote := range remotes {
+		if c.refExists("refs/remotes/" + remote + "/" + branch) {
+			candidates = append(candidates, remote+"/"+branch)
+		}
+	}
+	return candidates, nil
+}
+
+// CutRemoteBranch is CutRemotePrefix for the selected remote that also
+// recognises a remote-tracking branch of any other remote, e.g.
+// upstream/feature -> ("feature", true), unless a local branch has that name.
+func (c *Client) CutRemoteBranch(branch string) (string, bool) {
+	if name, ok := CutRemotePrefix(branch, c.Remote()); ok {
+		return name, true
+	}
+	if c.localBranchExists(branch) || !c.refExists("refs/remotes/"+branch) {
+		return branch, false
+	}
+	remotes, err := c.remotes()
+	if err != nil {
+		return branch, false
+	}
+	for _, remote := range remotes {
+		if name, ok := CutRemotePrefix(branch, remote); ok {
+			return name, true
+		}
+	}
+	return branch, false
+}
+
+// RemoteRepo identifies a hosted repository parsed from a remote URL.
+type RemoteRepo struct {
+	Host  string // e.g. "github.com"
+	Owner string // the user or organization; GitLab subgroups keep their slashes
+	Name  string // the repository name, without ".git"
+}
+
+// String returns the repository as "owner/name".
+func (r RemoteRepo) String() string {
+	return r.Owner + "/" + r.Name
+}
+
+// IsGitLab reports whether the repository is hosted on a GitLab instance,
+// which publishes merge requests under refs/merge-requests rather than
+// refs/pull.
+func (r RemoteRepo) IsGitLab() bool {
+	return strings.Contains(r.Host, "gitlab")
+}
+
+// ParseRemote parses a remote URL in the scp-like form
+// ("git@github.com:owner/repo.git") or the URL form
+// ("https://github.com/owner/repo", "ssh://git@host:22/owner/repo.git").
+// Local paths and file:// URLs name no host and are rejected.
+func ParseRemote(url string) (RemoteRepo, error) {
+	var host, path string
+	if scheme, rest, ok := strings.Cut(url, "://"); ok {
+		if scheme == "file" {
+			return RemoteRepo{}, fmt.Errorf("remote %q is a local path", url)
+		}
+		host, path, _ = strings.Cut(rest, "/")
+		if _, after, found := strings.Cut(host, "@"); found {
+			host = after
+		}
+		if name, _, found := strings.Cut(host, ":"); found {
+			host = name
+		}
+	} else if userHost, rest, found := strings.Cut(url, ":"); found && !strings.Contains(userHost, "/") {
+		host, path = userHost, rest
+		if _, after, found := strings.Cut(host, "@"); found {
+			host = after
+		}
+	}
+
+	path = strings.TrimSuffix(strings.Trim(path, "/"), ".git")
+	slash := strings.LastIndex(path, "/")
+	if host == "" || slash <= 0 || slash == len(path)-1 {
+		return RemoteRepo{}, fmt.Errorf("cannot parse owner/repo from remote %q", url)
+	}
+	return RemoteRepo{Host: host, Owner: path[:slash], Name: path[slash+1:]}, nil
+}
+
+// FetchPullRequest fetches the head of pull request number from the selected
+// remote into the local branch, creating it or fast-forwarding it. GitLab
+// remotes are fetched from refs/merge-requests/<number>/head, everything else
+// (GitHub, or a remote whose URL cannot be parsed) from refs/pull/<number>/head.
+// The returned RemoteRepo is zero when the remote URL could not be parsed.
+func (c *Client) FetchPullRequest(number, branch string) (RemoteRepo, error) {
+	defer c.cache.invalidate()
+
+	remote := c.Remote()
+	url, err := c.r.run("", "remote", "get-url", remote)
+	if err != nil {
+		return RemoteRepo{}, fmt.Errorf("failed to get the URL of %s: %w", remote, err)
+	}
+	repo, _ := ParseRemote(url) // zero for a local path: fetched like GitHub
+
+	ref := "pull/" + number + "/head"
+	if repo.IsGitLab() {
+		ref = "merge-requests/" + number + "/head"
+	}
+	args := []string{"fetch", remote, ref + ":refs/heads/" + branch}
+	if c.skipMutation("", args...) {
+		return repo, nil
+	}
+	if _, err := c.r.runCombined("", args...); err != nil {
+		return repo, fmt.Errorf("failed to fetch %s from %s (if %s was rewritten, delete it with git branch -D %s and retry): %w", ref, remote, branch, branch, err)
+	}
+	return repo, nil
+}
@@ -0,0 +1,265 @@
+package git
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// setupRepoWithTwoRemotes creates a repository with two bare remotes, origin
+// and upstream, both holding the default branch, and chdirs into it. feature
+// is merged into upstream's default branch only; dev and topic exist only on
+// upstream.
+func setupRepoWithTwoRemotes(t *testing.T) (originDir, upstreamDir, defaultBranch string) {
+	t.Helper()
+	tmpDir := t.TempDir()
+	originDir = t.TempDir()
+	upstreamDir = t.TempDir()
+
+	runGitCommand(t, originDir, "init", "--bare")
+	runGitCommand(t, upstreamDir, "init", "--bare")
+	runGitCommand(t, tmpDir, "init")
+	runGitCommand(t, tmpDir, "config", "user.email", "test@example.com")
+	runGitCommand(t, tmpDir, "config", "user.name", "Test User")
+	if err := os.WriteFile(filepath.Join(tmpDir, "test.txt"), []byte("test"), 0644); err != nil {
+		t.Fatalf("failed to create file: %v", err)
+	}
+	runGitCommand(t, tmpDir, "add", "test.txt")
+	runGitCommand(t, tmpDir, "commit", "-m", "Initial commit")
+	defaultBranch = getDefaultBranchName(t, tmpDir)
+	runGitCommand(t, tmpDir, "remote", "add", "origin", originDir)
+	runGitCommand(t, tmpDir, "remote", "add", "upstream", upstreamDir)
+	runGitCommand(t, tmpDir, "push", "origin", defaultBranch)
+
+	runGitCommand(t, tmpDir, "checkout", "-b", "feature")
+	runGitCommand(t, tmpDir, "commit", "--allow-empty", "-m", "Feature commit")
+	runGitCommand(t, tmpDir, "checkout", defaultBranch)
+	runGitCommand(t, tmpDir, "push", "upstream", "feature:"+defaultBranch, defaultBranch+":dev", defaultBranch+":topic")
+	runGitCommand(t, tmpDir, "fetch", "--all")
+
+	originalDir, _ := os.Getwd()
+	t.Cleanup(func() { _ = os.Chdir(originalDir) })
+	if err := os.Chdir(tmpDir); err != nil {
+		t.Fatalf("failed to change dir: %v", err)
+	}
+	return originDir, upstreamDir, defaultBranch
+}
+
+func TestClient_SetRemote(t *testing.T) {
+	originDir, upstreamDir, defaultBranch := setupRepoWithTwoRemotes(t)
+
+	remoteRefExists := func(remoteDir, branch string) bool {
+		cmd := exec.Command("git", "show-ref", "--verify", "--quiet", "refs/heads/"+branch)
+		cmd.Dir = remoteDir
+		return cmd.Run() == nil
+	}
+
+	t.Run("defaults to origin", func(t *testing.T) {
+		c := NewClient()
+		if got := c.Remote(); got != DefaultRemote {
+			t.Errorf("Remote() = %q, want %q", got, DefaultRemote)
+		}
+		merged, err := c.IsMergedToBaseBranch("", "feature", defaultBranch)
+		if err != nil {
+			t.Fatalf("IsMergedToBaseBranch failed: %v", err)
+		}
+		if merged {
+			t.Error("feature is not merged into origin or the local base branch")
+		}
+		if resolved, isRemote := c.ResolveBaseBranch("dev"); resolved != "dev" || isRemote {
+			t.Errorf("ResolveBaseBranch(dev) = %q, %v; dev does not exist on origin", resolved, isRemote)
+		}
+	})
+
+	t.Run("merge status against the configured remote", func(t *testing.T) {
+		c := NewClient()
+		c.SetRemote("upstream")
+		merged, err := c.IsMergedToBaseBranch("", "feature", defaultBranch)
+		if err != nil {
+			t.Fatalf("IsMergedToBaseBranch failed: %v", err)
+		}
+		if !merged {
+			t.Errorf("feature is merged into upstream/%s", defaultBranch)
+		}
+	})
+
+	t.Run("base branch resolution uses the configured remote", func(t *testing.T) {
+		c := NewClient()
+		c.SetRemote("upstream")
+		if resolved, isRemote := c.ResolveBaseBranch("dev"); resolved != "upstream/dev" || !isRemote {
+			t.Errorf("ResolveBaseBranch(dev) = %q, %v; want upstream/dev, true", resolved, isRemote)
+		}
+	})
+
+	t.Run("remote branch deletion pushes to the configured remote", func(t *testing.T) {
+		runGitCommand(t, ".", "push", "origin", defaultBranch+":topic")
+		c := NewClient()
+		c.SetRemote("upstream")
+		if err := c.DeleteRemoteBranch("topic"); err != nil {
+			t.Fatalf("DeleteRemoteBranch failed: %v", err)
+		}
+		if remoteRefExists(upstreamDir, "topic") {
+			t.Error("topic should be deleted from upstream")
+		}
+		if !remoteRefExists(originDir, "topic") {
+			t.Error("topic should be left untouched on origin")
+		}
+	})
+
+	t.Run("empty name restores origin", func(t *testing.T) {
+		c := NewClient()
+		c.SetRemote("upstream")
+		c.SetRemote("")
+		if got := c.Remote(); got != DefaultRemote {
+			t.Errorf("Remote() = %q, want %q", got, DefaultRemote)
+		}
+	})
+}
+
+func TestClient_RemoteDefaultBranch(t *testing.T) {
+	_, _, defaultBranch := setupRepoWithTwoRemotes(t)
+	runGitCommand(t, ".", "remote", "set-head", "origin", "--auto")
+
+	c := NewClient()
+	got, err := c.RemoteDefaultBranch()
+	if err != nil {
+		t.Fatalf("RemoteDefaultBranch failed: %v", err)
+	}
+	if want := "origin/" + defaultBranch; got != want {
+		t.Errorf("RemoteDefaultBranch() = %q, want %q", got, want)
+	}
+
+	// upstream was added with git remote add, which leaves upstream/HEAD unset.
+	c.SetRemote("upstream")
+	if _, err := c.RemoteDefaultBranch(); err == nil || !strings.Contains(err.Error(), "git remote set-head upstream --auto") {
+		t.Errorf("expected an error suggesting git remote set-head, got %v", err)
+	}
+}
+
+func TestClient_HasRemote(t *testing.T) {
+	setupRepoWithTwoRemotes(t)
+
+	c := NewClient()
+	for _, tt := range []struct {
+		remote string
+		want   bool
+	}{
+		{"", true},
+		{"upstream", true},
+		{"fork", false},
+	} {
+		c.SetRemote(tt.remote)
+		got, err := c.HasRemote()
+		if err != nil {
+			t.Fatalf("HasRemote(%q) failed: %v", tt.remote, err)
+		}
+		if got != tt.want {
+			t.Errorf("HasRemote(%q) = %v, want %v", tt.remote, got, tt.want)
+		}
+	}
+}
+
+func TestClient_RemoteBranchCandidates(t *testing.T) {
+	_, _, defaultBranch := setupRepoWithTwoRemotes(t)
+	runGitCommand(t, ".", "push", "origin", "feature")
+	runGitCommand(t, ".", "push", "upstream", "feature")
+	runGitCommand(t, ".", "fetch", "--all")
+	runGitCommand(t, ".", "branch", "-m", "feature", "renamed")
+
+	for _, tt := range []struct {
+		branch string
+		want   []string
+	}{
+		{"feature", []string{"origin/feature", "upstream/feature"}},
+		{"dev", []string{"upstream/dev"}},
+		{defaultBranch, nil},
+		{"missing", nil},
+	} {
+		got, err := RemoteBranchCandidates(tt.branch)
+		if err != nil {
+			t.Fatalf("RemoteBranchCandidates(%q) failed: %v", tt.branch, err)
+		}
+		if strings.Join(got, " ") != strings.Join(tt.want, " ") {
+			t.Errorf("RemoteBranchCandidates(%q) = %v, want %v", tt.branch, got, tt.want)
+		}
+	}
+}
+
+func TestClient_CutRemoteBranch(t *testing.T) {
+	setupRepoWithTwoRemotes(t)
+
+	for _, tt := range []struct {
+		branch   string
+		want     string
+		isRemote bool
+	}{
+		{"origin/anything", "anything", true},
+		{"upstream/dev", "dev", true},
+		{"upstream/missing", "upstream/missing", false},
+		{"feature", "feature", false},
+	} {
+		got, isRemote := CutRemoteBranch(tt.branch)
+		if got != tt.want || isRemote != tt.isRemote {
+			t.Errorf("CutRemoteBranch(%q) = (%q, %v), want (%q, %v)", tt.branch, got, isRemote, tt.want, tt.isRemote)
+		}
+	}
+}
+
+func TestParseRemote(t *testing.T) {
+	tests := []struct {
+		url     string
+		want    RemoteRepo
+		wantErr bool
+	}{
+		{url: "git@github.com:sotarok/gw.git", want: RemoteRepo{Host: "github.com", Owner: "sotarok", Name: "gw"}},
+		{url: "https://github.com/sotarok/gw", want: RemoteRepo{Host: "github.com", Owner: "sotarok", Name: "gw"}},
+		{url: "https://user@github.com/sotarok/gw.git/", want: RemoteRepo{Host: "github.com", Owner: "sotarok", Name: "gw"}},
+		{url: "ssh://git@gitlab.example.com:2222/group/sub/project.git", want: RemoteRepo{Host: "gitlab.example.com", Owner: "group/sub", Name: "project"}},
+		{url: "/srv/git/project.git", wantErr: true},
+		{url: "file:///srv/git/project.git", wantErr: true},
+		{url: "https://github.com/gw", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.url, func(t *testing.T) {
+			got, err := ParseRemote(tt.url)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("ParseRemote(%q) = %+v, want an error", tt.url, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseRemote(%q) failed: %v", tt.url, err)
+			}
+			if got != tt.want {
+				t.Errorf("ParseRemote(%q) = %+v, want %+v", tt.url, got, tt.want)
+			}
+		})
+	}
+
+	if !(RemoteRepo{Host: "gitlab.example.com"}).IsGitLab() || (RemoteRepo{Host: "github.com"}).IsGitLab() {
+		t.Error("IsGitLab should report only GitLab hosts")
+	}
+}
+
+func TestCutRemotePrefix(t *testing.T) {
+	tests := []struct {
+		branch, remote string
+		want           string
+		wantOK         bool
+	}{
+		{"origin/foo", "origin", "foo", true},
+		{"upstream/feature/x", "upstream", "feature/x", true},
+		{"origin/foo", "upstream", "origin/foo", false},
+		{"originals/foo", "origin", "originals/foo", false},
+		{"foo", "origin", "foo", false},
+	}
+	for _, tt := range tests {
+		got, ok := CutRemotePrefix(tt.branch, tt.remote)
+		if got != tt.want || ok != tt.wantOK {
+			t.Errorf("CutRemotePrefix(%q, %q) = %q, %v; want %q, %v", tt.branch, tt.remote, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
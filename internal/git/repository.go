@@ -14,7 +14,7 @@ const gitDir = ".git"
 func (c *Client) showTopLevel() (string, error) {
 	out, err := c.r.run("", "rev-parse", "--show-toplevel")
 	if err != nil {
-		return "", fmt.Errorf("not in a git repository: %w", err)
+		return "", fmt.Errorf("%w: %w", ErrNotGitRepository, err)
 	}
 	return out, nil
 }
@@ -68,11 +68,11 @@ func (c *Client) GetOriginalRepositoryName() (string, error) {
 func (c *Client) GetMainRepositoryRoot() (string, error) {
 	gitDir, err := c.r.run("", "rev-parse", "--git-dir")
 	if err != nil {
-		return "", fmt.Errorf("not in a git repository: %w", err)
+		return "", fmt.Errorf("%w: %w", ErrNotGitRepository, err)
 	}
 	gitCommonDir, err := c.r.run("", "rev-parse", "--git-common-dir")
 	if err != nil {
-		return "", fmt.Errorf("not in a git repository: %w", err)
+		return "", fmt.Errorf("%w: %w", ErrNotGitRepository, err)
 	}
 
 	// Both may be cwd-relative (".git", "../.git", ...) when called from the
@@ -107,6 +107,10 @@ func (c *Client) IsGitRepository() bool {
 
 // FetchAll fetches from all remotes and prunes deleted remote-tracking branches
 func (c *Client) FetchAll() error {
+	defer c.cache.invalidate()
+	if c.skipMutation("", "fetch", "--all", "--prune") {
+		return nil
+	}
 	if _, err := c.r.runCombined("", "fetch", "--all", "--prune"); err != nil {
 		return fmt.Errorf("failed to fetch from remotes: %w", err)
 	}
@@ -122,12 +126,33 @@ func (c *Client) GetCurrentBranch() (string, error) {
 	return out, nil
 }
 
-// ListAllBranches returns all local and remote branches
+// ListLocalBranches returns the local branches (refs/heads), without
+// fetching first.
+func (c *Client) ListLocalBranches() ([]string, error) {
+	out, err := c.r.run("", "for-each-ref", "--format=%(refname)", "refs/heads")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list branches: %w", err)
+	}
+	var branches []string
+	for _, ref := range strings.Fields(out) {
+		branches = append(branches, strings.TrimPrefix(ref, "refs/heads/"))
+	}
+	return branches, nil
+}
+
+// ListAllBranches returns all local and remote branches. The list is cached
+// until the next mutating call on c.
 func (c *Client) ListAllBranches() ([]string, error) {
+	return c.cache.cachedBranches(c.listAllBranches)
+}
+
+func (c *Client) listAllBranches() ([]string, error) {
 	// First, fetch to ensure we have latest remote branches
-	if _, err := c.r.run("", "fetch", "--prune"); err != nil {
-		// Continue even if fetch fails
-		fmt.Printf("Warning: failed to fetch latest branches: %v\n", err)
+	if !c.skipMutation("", "fetch", "--prune") {
+		if _, err := c.r.run("", "fetch", "--prune"); err != nil {
+			// Continue even if fetch fails
+			fmt.Printf("Warning: failed to fetch latest branches: %v\n", err)
+		}
 	}
 
 	// Get all branches (local and remote)
@@ -148,24 +173,33 @@ func (c *Client) ListAllBranches() ([]string, error) {
 	return branches, nil
 }
 
+// refExists reports whether the fully qualified ref exists. show-ref --verify
+// matches the exact name only, so inputs that rev-parse would interpret as
+// revisions ("HEAD~1", "main@{1}") or patterns ("feat*") never match.
+func (c *Client) refExists(ref string) bool {
+	_, err := c.r.run("", "show-ref", "--verify", "--quiet", ref)
+	return err == nil
+}
+
 // localBranchExists checks if a local branch exists
 func (c *Client) localBranchExists(branch string) bool {
-	_, err := c.r.run("", "rev-parse", "--verify", "--quiet", "refs/heads/"+branch)
-	return err == nil
+	return c.refExists("refs/heads/" + branch)
 }
 
-// remoteBranchExists checks if a remote branch exists (origin/<branch>)
+// remoteBranchExists checks if a remote branch exists (<remote>/<branch>)
 func (c *Client) remoteBranchExists(branch string) bool {
-	remoteRef := branch
-	if !strings.HasPrefix(branch, "origin/") {
-		remoteRef = "origin/" + branch
-	}
-	_, err := c.r.run("", "rev-parse", "--verify", "--quiet", remoteRef)
-	return err == nil
+	name, _ := CutRemotePrefix(branch, c.Remote())
+	return c.refExists("refs/remotes/" + c.Remote() + "/" + name)
 }
 
-// BranchExists checks if a branch exists (local or remote)
+// BranchExists checks if a branch exists (local or remote). Each check is a
+// single ref lookup rather than a scan of every branch; an empty name never
+// exists.
 func (c *Client) BranchExists(branch string) (bool, error) {
+	if branch == "" {
+		return false, nil
+	}
+
 	// Check if it's a local branch
 	if c.localBranchExists(branch) {
 		return true, nil
@@ -176,14 +210,222 @@ func (c *Client) BranchExists(branch string) (bool, error) {
 		return true, nil
 	}
 
+	// A remote-tracking branch of another remote, named in full
+	if _, ok := c.CutRemoteBranch(branch); ok && c.refExists("refs/remotes/"+branch) {
+		return true, nil
+	}
+
 	return false, nil
 }
 
-// DeleteBranch deletes a local git branch
-func (c *Client) DeleteBranch(branch string) error {
-	// Use -D flag to force delete even if not merged
-	if _, err := c.r.runCombined("", "branch", "-D", branch); err != nil {
+// DeleteBranch deletes a local git branch. Without force it uses `git branch -d`,
+// which refuses to delete a branch that is not fully merged so its commits are
+// never lost; force switches to `-D`.
+func (c *Client) DeleteBranch(branch string, force bool) error {
+	defer c.cache.invalidate()
+	flag := "-d"
+	if force {
+		flag = "-D"
+	}
+	if c.skipMutation("", "branch", flag, branch) {
+		return nil
+	}
+	if _, err := c.r.runCombined("", "branch", flag, branch); err != nil {
 		return fmt.Errorf("failed to delete branch %s: %w", branch, err)
 	}
 	return nil
 }
+
+// DeleteRemoteBranch deletes branch from the remote (origin unless SetRemote
+// selected another one) via `git push <remote> --delete`.
+func (c *Client) DeleteRemoteBranch(branch string) error {
+	defer c.cache.invalidate()
+	if c.skipMutation("", "push", c.Remote(), "--delete", branch) {
+		return nil
+	}
+	if _, err := c.r.runCombined("", "push", c.Remote(), "--delete", branch); err != nil {
+		return fmt.Errorf("failed to delete remote branch %s: %w", branch, err)
+	}
+	return nil
+}
+
+// IsUpstreamGone reports whether branch tracks an upstream that no longer
+// exists, typically a remote branch deleted after its pull request merged.
+// Git marks such branches "[gone]" once a pruning fetch has dropped the
+// remote-tracking ref. A branch without an upstream is never gone.
+func (c *Client) IsUpstreamGone(branch string) (bool, error) {
+	ref := "refs/heads/" + branch
+	// for-each-ref patterns also match refs below ref (e.g. "foo" matches
+	// "foo/bar"), so the output is filtered to the exact ref.
+	out, err := c.r.run("", "for-each-ref", "--format=%(refname) %(upstream:track)", ref)
+	if err != nil {
+		return false, fmt.Errorf("failed to read upstream of %s: %w", branch, err)
+	}
+	for _, line := range strings.Split(out, "\n") {
+		name, track, _ := strings.Cut(line, " ")
+		if name == ref {
+			return track == "[gone]", nil
+		}
+	}
+	return false, nil
+}
+
+// ResolveRefDescription names commit after the nearest tag it descends from,
+// as `git describe --tags` does: "v1.2.3" when the tag points at commit, or
+// "v1.2.3-2-gabc1234" two commits past it. It returns "" when no tag is
+// reachable from commit.
+func (c *Client) ResolveRefDescription(commit string) (string, error) {
+	// --always falls back to the abbreviated hash instead of failing, so an
+	// untagged history can be told apart from a real error.
+	out, err := c.r.run("", "describe", "--tags", "--always", commit)
+	if err != nil {
+		return "", fmt.Errorf("failed to describe %s: %w", commit, err)
+	}
+	if strings.HasPrefix(commit, out) {
+		return "", nil
+	}
+	return out, nil
+}
+
+// MergeBase returns the commit hash of the best common ancestor of a and b,
+// as found by `git merge-base`.
+func (c *Client) MergeBase(a, b string) (string, error) {
+	out, err := c.r.run("", "merge-base", a, b)
+	if err != nil {
+		return "", fmt.Errorf("failed to find the merge-base of %s and %s: %w", a, b, err)
+	}
+	return out, nil
+}
+
+// TrackingStatus is a local branch's position relative to its upstream.
+type TrackingStatus struct {
+	Upstream string // e.g. "origin/main"; empty when none is configured
+	Ahead    int    // commits on the branch that are not on Upstream
+	Behind   int    // commits on Upstream that are not on the branch
+}
+
+// UpstreamStatus reports how far the local branch has diverged from its
+// upstream, as of the last fetch. A branch without an upstream (or that does
+// not exist locally) reports an empty status.
+func (c *Client) UpstreamStatus(branch string) (*TrackingStatus, error) {
+	status := &TrackingStatus{}
+	upstream, err := c.r.run("", "rev-parse", "--abbrev-ref", "--symbolic-full-name", branch+"@{upstream}")
+	if err != nil {
+		return status, nil
+	}
+	status.Upstream = upstream
+	status.Ahead, status.Behind, err = c.aheadBehind("", branch)
+	if err != nil {
+		return nil, err
+	}
+	return status, nil
+}
+
+// MergeOptions controls how MergeBranch merges.
+type MergeOptions struct {
+	NoFF   bool // always create a merge commit, even when a fast-forward is possible
+	Squash bool // commit the branch's changes as a single new commit on the base branch
+}
+
+// MergeBranch switches the worktree at worktreePath to baseBranch and merges
+// branch into it. When the merge fails, typically on conflicts, it is aborted
+// so baseBranch and the worktree are left as they were before the merge.
+//
+// With Squash the changes are committed as one commit whose message names the
+// branch and lists the subjects of its commits. A squashed branch is not an
+// ancestor of baseBranch afterwards, so deleting it needs force.
+func (c *Client) MergeBranch(worktreePath, baseBranch, branch string, opts MergeOptions) error {
+	defer c.cache.invalidate()
+	checkout := []string{"checkout", baseBranch}
+	merge := []string{"merge", "--no-edit", "--ff", branch}
+	switch {
+	case opts.Squash:
+		merge[2] = "--squash"
+	case opts.NoFF:
+		merge[2] = "--no-ff"
+	}
+	if c.skipMutation(worktreePath, checkout...) {
+		c.skipMutation(worktreePath, merge...)
+		if opts.Squash {
+			c.skipMutation(worktreePath, "commit", "-m", fmt.Sprintf("Squashed commit of %s", branch))
+		}
+		return nil
+	}
+
+	if _, err := c.r.runCombined(worktreePath, checkout...); err != nil {
+		return fmt.Errorf("failed to switch to %s: %w", baseBranch, err)
+	}
+	if opts.Squash {
+		return c.squashMerge(worktreePath, baseBranch, branch, merge)
+	}
+	if _, err := c.r.runCombined(worktreePath, merge...); err != nil {
+		_, _ = c.r.runCombined(worktreePath, "merge", "--abort")
+		return fmt.Errorf("failed to merge %s into %s, the merge was aborted: %w", branch, baseBranch, err)
+	}
+	return nil
+}
+
+// squashMerge runs the `git merge --squash` in merge and commits the result.
+// A squash merge records no merge state, so `merge --abort` cannot undo it;
+// `reset --merge` is used instead, which only touches what the merge changed.
+func (c *Client) squashMerge(worktreePath, baseBranch, branch string, merge []string) error {
+	subjects, err := c.r.run(worktreePath, "log", "--reverse", "--format=%s", baseBranch+".."+branch)
+	if err != nil {
+		return fmt.Errorf("failed to read the commits of %s: %w", branch, err)
+	}
+	if subjects == "" {
+		return fmt.Errorf("%s has no commits that are not on %s", branch, baseBranch)
+	}
+	message := fmt.Sprintf("Squashed commit of %s\n", branch)
+	for _, subject := range strings.Split(subjects, "\n") {
+		message += "\n* " + subject
+	}
+
+	if _, err := c.r.runCombined(worktreePath, merge...); err != nil {
+		_, _ = c.r.runCombined(worktreePath, "reset", "--merge")
+		return fmt.Errorf("failed to merge %s into %s, the merge was aborted: %w", branch, baseBranch, err)
+	}
+	if _, err := c.r.runCombined(worktreePath, "commit", "-m", message); err != nil {
+		_, _ = c.r.runCombined(worktreePath, "reset", "--merge")
+		return fmt.Errorf("failed to commit the squashed changes of %s, the merge was aborted: %w", branch, err)
+	}
+	return nil
+}
+
+// FastForwardBranch moves the local branch to its upstream, refusing anything
+// but a fast-forward. When the branch is checked out in a worktree it is
+// merged there so the working tree follows; otherwise only the ref moves.
+func (c *Client) FastForwardBranch(branch string) error {
+	defer c.cache.invalidate()
+	worktrees, err := c.ListWorktrees()
+	if err != nil {
+		return err
+	}
+	for _, wt := range worktrees {
+		if wt.Branch != branch {
+			continue
+		}
+		if c.skipMutation(wt.Path, "merge", "--ff-only", "@{upstream}") {
+			return nil
+		}
+		if _, err := c.r.runCombined(wt.Path, "merge", "--ff-only", "@{upstream}"); err != nil {
+			return fmt.Errorf("failed to fast-forward %s: %w", branch, err)
+		}
+		return nil
+	}
+
+	upstream, err := c.r.run("", "rev-parse", "--symbolic-full-name", branch+"@{upstream}")
+	if err != nil {
+		return fmt.Errorf("branch %s has no upstream: %w", branch, err)
+	}
+	// Fetching from the repository itself updates the ref only when the update
+	// is a fast-forward.
+	refspec := upstream + ":refs/heads/" + branch
+	if c.skipMutation("", "fetch", ".", refspec) {
+		return nil
+	}
+	if _, err := c.r.runCombined("", "fetch", ".", refspec); err != nil {
+		return fmt.Errorf("failed to fast-forward %s: %w", branch, err)
+	}
+	return nil
+}
@@ -3,6 +3,7 @@ package git
 import (
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 	"testing"
 )
@@ -391,4 +392,68 @@ func TestBranchExists_EdgeCases(t *testing.T) {
 			t.Error("Expected current-branch to exist")
 		}
 	})
+	t.Run("matches exact ref names only", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		runGitCommand(t, tmpDir, "init", "-b", "main")
+		runGitCommand(t, tmpDir, "config", "user.email", "test@example.com")
+		runGitCommand(t, tmpDir, "config", "user.name", "Test User")
+		runGitCommand(t, tmpDir, "commit", "--allow-empty", "-m", "Initial commit")
+		runGitCommand(t, tmpDir, "branch", "feature")
+
+		originalDir, _ := os.Getwd()
+		defer os.Chdir(originalDir)
+		os.Chdir(tmpDir)
+
+		// Patterns and revision syntax resolve to commits but are not branches
+		for _, name := range []string{"feat*", "*", "HEAD", "main~0", "main@{0}", "origin/feature"} {
+			exists, err := BranchExists(name)
+			if err != nil {
+				t.Fatalf("BranchExists(%q) failed: %v", name, err)
+			}
+			if exists {
+				t.Errorf("Expected %q not to exist as a branch", name)
+			}
+		}
+	})
+
+	t.Run("runs a single ref lookup per check", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		runGitCommand(t, tmpDir, "init", "-b", "main")
+		runGitCommand(t, tmpDir, "config", "user.email", "test@example.com")
+		runGitCommand(t, tmpDir, "config", "user.name", "Test User")
+		runGitCommand(t, tmpDir, "commit", "--allow-empty", "-m", "Initial commit")
+
+		originalDir, _ := os.Getwd()
+		defer os.Chdir(originalDir)
+		os.Chdir(tmpDir)
+
+		r := &countingRunner{calls: map[string]int{}}
+		c := &Client{r: r}
+		if exists, _ := c.BranchExists("main"); !exists {
+			t.Error("Expected main to exist")
+		}
+		if got := r.calls["show-ref --verify"]; got != 1 {
+			t.Errorf("git show-ref ran %d times for a local branch, want 1", got)
+		}
+		if got := r.calls["branch -a"]; got != 0 {
+			t.Errorf("git branch -a ran %d times, want 0", got)
+		}
+	})
+}
+
+func TestListLocalBranches(t *testing.T) {
+	setupRepoWithTwoRemotes(t)
+
+	branches, err := ListLocalBranches()
+	if err != nil {
+		t.Fatalf("ListLocalBranches failed: %v", err)
+	}
+	for _, branch := range branches {
+		if strings.HasPrefix(branch, "origin/") || strings.HasPrefix(branch, "upstream/") {
+			t.Errorf("Expected only local branches, got %q", branch)
+		}
+	}
+	if !slices.Contains(branches, "feature") {
+		t.Errorf("Expected the local feature branch, got %v", branches)
+	}
 }
@@ -2,6 +2,8 @@ package git
 
 import (
 	"os"
+	"os/exec"
+	"path/filepath"
 	"strings"
 	"testing"
 )
@@ -424,8 +426,8 @@ func TestDeleteBranch(t *testing.T) {
 			t.Fatal("test-branch should exist before deletion")
 		}
 
-		// Delete the branch
-		err = DeleteBranch("test-branch")
+		// Delete the branch (merged, so the safe delete succeeds)
+		err = DeleteBranch("test-branch", false)
 		if err != nil {
 			t.Fatalf("failed to delete branch: %v", err)
 		}
@@ -481,7 +483,7 @@ func TestDeleteBranch(t *testing.T) {
 		}
 
 		// Try to delete non-existent branch
-		err = DeleteBranch("non-existent-branch")
+		err = DeleteBranch("non-existent-branch", false)
 		if err == nil {
 			t.Error("expected error when deleting non-existent branch")
 		}
@@ -549,7 +551,7 @@ func TestDeleteBranch(t *testing.T) {
 		}
 
 		// Delete the unmerged branch (should work with -D flag)
-		err = DeleteBranch("unmerged-branch")
+		err = DeleteBranch("unmerged-branch", true)
 		if err != nil {
 			t.Fatalf("failed to force delete unmerged branch: %v", err)
 		}
@@ -563,4 +565,482 @@ func TestDeleteBranch(t *testing.T) {
 			t.Error("unmerged-branch should not exist after force deletion")
 		}
 	})
+
+	t.Run("safe delete refuses unmerged branch", func(t *testing.T) {
+		tempDir, err := os.MkdirTemp("", "test-delete-unmerged-safe")
+		if err != nil {
+			t.Fatalf("failed to create temp dir: %v", err)
+		}
+		defer os.RemoveAll(tempDir)
+
+		originalDir, err := os.Getwd()
+		if err != nil {
+			t.Fatalf("failed to get current dir: %v", err)
+		}
+		defer func() {
+			_ = os.Chdir(originalDir)
+		}()
+
+		if err := os.Chdir(tempDir); err != nil {
+			t.Fatalf("failed to change dir: %v", err)
+		}
+		if err := RunCommand("git init"); err != nil {
+			t.Fatalf("failed to init git repo: %v", err)
+		}
+		if err := RunCommand("git config user.email 'test@example.com' && git config user.name 'Test User'"); err != nil {
+			t.Fatalf("failed to configure git: %v", err)
+		}
+		if err := os.WriteFile("README.md", []byte("test"), 0644); err != nil {
+			t.Fatalf("failed to create file: %v", err)
+		}
+		if err := RunCommand("git add . && git commit -m 'initial commit'"); err != nil {
+			t.Fatalf("failed to create commit: %v", err)
+		}
+
+		// Create a branch carrying a commit that exists nowhere else
+		if err := RunCommand("git checkout -b unmerged-branch"); err != nil {
+			t.Fatalf("failed to create branch: %v", err)
+		}
+		if err := os.WriteFile("test.txt", []byte("unmerged changes"), 0644); err != nil {
+			t.Fatalf("failed to create file: %v", err)
+		}
+		if err := RunCommand("git add . && git commit -m 'unmerged changes'"); err != nil {
+			t.Fatalf("failed to create commit: %v", err)
+		}
+		if err := RunCommand("git checkout -"); err != nil {
+			t.Fatalf("failed to switch back to main: %v", err)
+		}
+
+		err = DeleteBranch("unmerged-branch", false)
+		if err == nil {
+			t.Fatal("expected safe delete of unmerged branch to fail")
+		}
+		if !strings.Contains(err.Error(), "not fully merged") {
+			t.Errorf("expected error to mention 'not fully merged', got: %v", err)
+		}
+
+		// The branch and its commit must survive
+		exists, err := BranchExists("unmerged-branch")
+		if err != nil {
+			t.Fatalf("failed to check branch existence: %v", err)
+		}
+		if !exists {
+			t.Error("unmerged-branch should still exist after refused safe delete")
+		}
+	})
+}
+
+func TestDeleteRemoteBranch(t *testing.T) {
+	// setupRepoWithRemote creates a repo whose origin is a bare repository
+	// holding the default branch and feature-1, and chdirs into the repo.
+	setupRepoWithRemote := func(t *testing.T) (remoteDir, defaultBranch string) {
+		t.Helper()
+		tmpDir := t.TempDir()
+		remoteDir = t.TempDir()
+
+		runGitCommand(t, remoteDir, "init", "--bare")
+		runGitCommand(t, tmpDir, "init")
+		runGitCommand(t, tmpDir, "config", "user.email", "test@example.com")
+		runGitCommand(t, tmpDir, "config", "user.name", "Test User")
+
+		testFile := filepath.Join(tmpDir, "test.txt")
+		if err := os.WriteFile(testFile, []byte("test"), 0644); err != nil {
+			t.Fatalf("failed to create file: %v", err)
+		}
+		runGitCommand(t, tmpDir, "add", "test.txt")
+		runGitCommand(t, tmpDir, "commit", "-m", "Initial commit")
+
+		defaultBranch = getDefaultBranchName(t, tmpDir)
+		runGitCommand(t, tmpDir, "remote", "add", "origin", remoteDir)
+		runGitCommand(t, tmpDir, "push", "-u", "origin", defaultBranch)
+		runGitCommand(t, tmpDir, "push", "origin", defaultBranch+":feature-1")
+
+		originalDir, _ := os.Getwd()
+		t.Cleanup(func() { _ = os.Chdir(originalDir) })
+		if err := os.Chdir(tmpDir); err != nil {
+			t.Fatalf("failed to change dir: %v", err)
+		}
+		return remoteDir, defaultBranch
+	}
+
+	remoteRefExists := func(remoteDir, branch string) bool {
+		cmd := exec.Command("git", "show-ref", "--verify", "--quiet", "refs/heads/"+branch)
+		cmd.Dir = remoteDir
+		return cmd.Run() == nil
+	}
+
+	t.Run("removes the branch from origin", func(t *testing.T) {
+		remoteDir, defaultBranch := setupRepoWithRemote(t)
+
+		if !remoteRefExists(remoteDir, "feature-1") {
+			t.Fatal("feature-1 should exist on the remote before deletion")
+		}
+
+		if err := DeleteRemoteBranch("feature-1"); err != nil {
+			t.Fatalf("DeleteRemoteBranch failed: %v", err)
+		}
+
+		if remoteRefExists(remoteDir, "feature-1") {
+			t.Error("feature-1 should not exist on the remote after deletion")
+		}
+		if !remoteRefExists(remoteDir, defaultBranch) {
+			t.Errorf("%s should be left untouched on the remote", defaultBranch)
+		}
+	})
+
+	t.Run("returns error when the remote branch does not exist", func(t *testing.T) {
+		setupRepoWithRemote(t)
+
+		err := DeleteRemoteBranch("no-such-branch")
+		if err == nil {
+			t.Fatal("expected error when deleting a missing remote branch")
+		}
+		if !strings.Contains(err.Error(), "failed to delete remote branch") {
+			t.Errorf("expected error to contain 'failed to delete remote branch', got: %v", err)
+		}
+	})
+}
+
+func TestIsUpstreamGone(t *testing.T) {
+	tmpDir := t.TempDir()
+	remoteDir := t.TempDir()
+
+	runGitCommand(t, remoteDir, "init", "--bare")
+	runGitCommand(t, tmpDir, "init")
+	runGitCommand(t, tmpDir, "config", "user.email", "test@example.com")
+	runGitCommand(t, tmpDir, "config", "user.name", "Test User")
+	if err := os.WriteFile(filepath.Join(tmpDir, "test.txt"), []byte("test"), 0644); err != nil {
+		t.Fatalf("failed to create file: %v", err)
+	}
+	runGitCommand(t, tmpDir, "add", "test.txt")
+	runGitCommand(t, tmpDir, "commit", "-m", "Initial commit")
+	defaultBranch := getDefaultBranchName(t, tmpDir)
+	runGitCommand(t, tmpDir, "remote", "add", "origin", remoteDir)
+	runGitCommand(t, tmpDir, "push", "-u", "origin", defaultBranch)
+
+	// merged/impl tracks a remote branch that is deleted afterwards (as after
+	// a merged PR); kept/impl stays on the remote.
+	runGitCommand(t, tmpDir, "branch", "merged/impl")
+	runGitCommand(t, tmpDir, "push", "-u", "origin", "merged/impl")
+	runGitCommand(t, tmpDir, "branch", "kept/impl")
+	runGitCommand(t, tmpDir, "push", "-u", "origin", "kept/impl")
+	runGitCommand(t, tmpDir, "branch", "local-only")
+	runGitCommand(t, remoteDir, "branch", "-D", "merged/impl")
+	runGitCommand(t, tmpDir, "fetch", "--prune", "origin")
+
+	originalDir, _ := os.Getwd()
+	t.Cleanup(func() { _ = os.Chdir(originalDir) })
+	if err := os.Chdir(tmpDir); err != nil {
+		t.Fatalf("failed to change dir: %v", err)
+	}
+
+	tests := []struct {
+		branch string
+		want   bool
+	}{
+		{branch: "merged/impl", want: true},
+		{branch: "kept/impl", want: false},
+		{branch: "merged", want: false}, // only a prefix of the gone branch
+		{branch: defaultBranch, want: false},
+		{branch: "local-only", want: false},
+		{branch: "no-such-branch", want: false},
+	}
+	for _, tt := range tests {
+		got, err := IsUpstreamGone(tt.branch)
+		if err != nil {
+			t.Fatalf("IsUpstreamGone(%q) failed: %v", tt.branch, err)
+		}
+		if got != tt.want {
+			t.Errorf("IsUpstreamGone(%q) = %v, want %v", tt.branch, got, tt.want)
+		}
+	}
+}
+
+func TestUpstreamStatusAndFastForwardBranch(t *testing.T) {
+	tmpDir := t.TempDir()
+	remoteDir := t.TempDir()
+	otherDir := t.TempDir()
+
+	runGitCommand(t, remoteDir, "init", "--bare")
+	runGitCommand(t, tmpDir, "init")
+	runGitCommand(t, tmpDir, "config", "user.email", "test@example.com")
+	runGitCommand(t, tmpDir, "config", "user.name", "Test User")
+	if err := os.WriteFile(filepath.Join(tmpDir, "test.txt"), []byte("test"), 0644); err != nil {
+		t.Fatalf("failed to create file: %v", err)
+	}
+	runGitCommand(t, tmpDir, "add", "test.txt")
+	runGitCommand(t, tmpDir, "commit", "-m", "Initial commit")
+	defaultBranch := getDefaultBranchName(t, tmpDir)
+	runGitCommand(t, tmpDir, "remote", "add", "origin", remoteDir)
+	runGitCommand(t, tmpDir, "push", "-u", "origin", defaultBranch)
+	runGitCommand(t, tmpDir, "branch", "side")
+	runGitCommand(t, tmpDir, "push", "-u", "origin", "side")
+	runGitCommand(t, tmpDir, "branch", "local-only")
+
+	// Someone else pushes a new commit to both branches.
+	runGitCommand(t, otherDir, "clone", remoteDir, ".")
+	runGitCommand(t, otherDir, "config", "user.email", "other@example.com")
+	runGitCommand(t, otherDir, "config", "user.name", "Other User")
+	if err := os.WriteFile(filepath.Join(otherDir, "new.txt"), []byte("new"), 0644); err != nil {
+		t.Fatalf("failed to create file: %v", err)
+	}
+	runGitCommand(t, otherDir, "add", "new.txt")
+	runGitCommand(t, otherDir, "commit", "-m", "Upstream commit")
+	runGitCommand(t, otherDir, "push", "origin", "HEAD:"+defaultBranch, "HEAD:side")
+	runGitCommand(t, tmpDir, "fetch", "origin")
+
+	originalDir, _ := os.Getwd()
+	t.Cleanup(func() { _ = os.Chdir(originalDir) })
+	if err := os.Chdir(tmpDir); err != nil {
+		t.Fatalf("failed to change dir: %v", err)
+	}
+
+	status, err := UpstreamStatus(defaultBranch)
+	if err != nil {
+		t.Fatalf("UpstreamStatus failed: %v", err)
+	}
+	want := TrackingStatus{Upstream: "origin/" + defaultBranch, Ahead: 0, Behind: 1}
+	if *status != want {
+		t.Errorf("UpstreamStatus(%q) = %+v, want %+v", defaultBranch, *status, want)
+	}
+	if status, err := UpstreamStatus("local-only"); err != nil || *status != (TrackingStatus{}) {
+		t.Errorf("UpstreamStatus(local-only) = %+v, %v; want an empty status", status, err)
+	}
+
+	// defaultBranch is checked out: the working tree must follow the branch.
+	if err := FastForwardBranch(defaultBranch); err != nil {
+		t.Fatalf("FastForwardBranch(%q) failed: %v", defaultBranch, err)
+	}
+	if _, err := os.Stat(filepath.Join(tmpDir, "new.txt")); err != nil {
+		t.Errorf("expected new.txt in the worktree after fast-forward: %v", err)
+	}
+
+	// side is not checked out anywhere: only the ref moves.
+	if err := FastForwardBranch("side"); err != nil {
+		t.Fatalf("FastForwardBranch(side) failed: %v", err)
+	}
+	for _, branch := range []string{defaultBranch, "side"} {
+		if status, _ := UpstreamStatus(branch); status.Behind != 0 {
+			t.Errorf("%s is still %d behind after fast-forward", branch, status.Behind)
+		}
+	}
+
+	if err := FastForwardBranch("local-only"); err == nil {
+		t.Error("expected error fast-forwarding a branch without upstream")
+	}
+}
+
+func TestMergeBranch(t *testing.T) {
+	// setupMergeRepo creates a repository whose default branch and feature
+	// both descend from one commit; feature changes test.txt. With
+	// conflicting, the default branch changes test.txt differently too. The
+	// default branch is checked out somewhere else first so MergeBranch has
+	// to switch to it.
+	setupMergeRepo := func(t *testing.T, conflicting bool) (repoDir, defaultBranch string) {
+		t.Helper()
+		repoDir = t.TempDir()
+		runGitCommand(t, repoDir, "init")
+		runGitCommand(t, repoDir, "config", "user.email", "test@example.com")
+		runGitCommand(t, repoDir, "config", "user.name", "Test User")
+		writeFile := func(content string) {
+			if err := os.WriteFile(filepath.Join(repoDir, "test.txt"), []byte(content), 0644); err != nil {
+				t.Fatalf("failed to write file: %v", err)
+			}
+		}
+		writeFile("base\n")
+		runGitCommand(t, repoDir, "add", "test.txt")
+		runGitCommand(t, repoDir, "commit", "-m", "Initial commit")
+		defaultBranch = getDefaultBranchName(t, repoDir)
+
+		runGitCommand(t, repoDir, "checkout", "-b", "feature")
+		writeFile("feature\n")
+		runGitCommand(t, repoDir, "commit", "-am", "Feature change")
+		if conflicting {
+			runGitCommand(t, repoDir, "checkout", defaultBranch)
+			writeFile("main\n")
+			runGitCommand(t, repoDir, "commit", "-am", "Conflicting change")
+		}
+		runGitCommand(t, repoDir, "checkout", "-b", "elsewhere")
+		return repoDir, defaultBranch
+	}
+
+	revParse := func(t *testing.T, dir, rev string) string {
+		t.Helper()
+		cmd := exec.Command("git", "rev-parse", rev)
+		cmd.Dir = dir
+		out, err := cmd.Output()
+		if err != nil {
+			t.Fatalf("git rev-parse %s failed: %v", rev, err)
+		}
+		return strings.TrimSpace(string(out))
+	}
+
+	t.Run("fast-forwards", func(t *testing.T) {
+		repoDir, defaultBranch := setupMergeRepo(t, false)
+
+		if err := NewClient().MergeBranch(repoDir, defaultBranch, "feature", MergeOptions{}); err != nil {
+			t.Fatalf("MergeBranch failed: %v", err)
+		}
+		if head, feature := revParse(t, repoDir, "HEAD"), revParse(t, repoDir, "feature"); head != feature {
+			t.Errorf("expected %s to be fast-forwarded to feature (%s), got %s", defaultBranch, feature, head)
+		}
+		if branch := getDefaultBranchName(t, repoDir); branch != defaultBranch {
+			t.Errorf("expected the worktree to be on %s, got %s", defaultBranch, branch)
+		}
+	})
+
+	t.Run("creates a merge commit with NoFF", func(t *testing.T) {
+		repoDir, defaultBranch := setupMergeRepo(t, false)
+
+		if err := NewClient().MergeBranch(repoDir, defaultBranch, "feature", MergeOptions{NoFF: true}); err != nil {
+			t.Fatalf("MergeBranch failed: %v", err)
+		}
+		if parent2 := revParse(t, repoDir, "HEAD^2"); parent2 != revParse(t, repoDir, "feature") {
+			t.Errorf("expected a merge commit with feature as second parent, got %s", parent2)
+		}
+	})
+
+	t.Run("squashes into a single commit", func(t *testing.T) {
+		repoDir, defaultBranch := setupMergeRepo(t, false)
+		runGitCommand(t, repoDir, "checkout", "feature")
+		if err := os.WriteFile(filepath.Join(repoDir, "second.txt"), []byte("second\n"), 0644); err != nil {
+			t.Fatalf("failed to write file: %v", err)
+		}
+		runGitCommand(t, repoDir, "add", "second.txt")
+		runGitCommand(t, repoDir, "commit", "-m", "Second feature change")
+		runGitCommand(t, repoDir, "checkout", "elsewhere")
+		before := revParse(t, repoDir, defaultBranch)
+
+		if err := NewClient().MergeBranch(repoDir, defaultBranch, "feature", MergeOptions{Squash: true}); err != nil {
+			t.Fatalf("MergeBranch failed: %v", err)
+		}
+
+		if parent := revParse(t, repoDir, "HEAD^"); parent != before {
+			t.Errorf("expected exactly one new commit on top of %s, HEAD^ is %s", before, parent)
+		}
+		cmd := exec.Command("git", "log", "-1", "--format=%P%n%B")
+		cmd.Dir = repoDir
+		out, err := cmd.Output()
+		if err != nil {
+			t.Fatalf("git log failed: %v", err)
+		}
+		parents, message, _ := strings.Cut(strings.TrimSpace(string(out)), "\n")
+		if strings.Contains(parents, " ") {
+			t.Errorf("expected a single-parent commit, got parents %q", parents)
+		}
+		wantMessage := "Squashed commit of feature\n\n* Feature change\n* Second feature change"
+		if message != wantMessage {
+			t.Errorf("commit message = %q, want %q", message, wantMessage)
+		}
+		for file, want := range map[string]string{"test.txt": "feature\n", "second.txt": "second\n"} {
+			if got, _ := os.ReadFile(filepath.Join(repoDir, file)); string(got) != want {
+				t.Errorf("%s = %q, want %q", file, got, want)
+			}
+		}
+	})
+
+	t.Run("squash aborts on conflicts without committing", func(t *testing.T) {
+		repoDir, defaultBranch := setupMergeRepo(t, true)
+		before := revParse(t, repoDir, defaultBranch)
+
+		err := NewClient().MergeBranch(repoDir, defaultBranch, "feature", MergeOptions{Squash: true})
+		if err == nil || !strings.Contains(err.Error(), "the merge was aborted") {
+			t.Fatalf("expected an aborted merge error, got: %v", err)
+		}
+		if after := revParse(t, repoDir, defaultBranch); after != before {
+			t.Errorf("expected %s to stay at %s, got %s", defaultBranch, before, after)
+		}
+		if dirty, _ := NewClient().HasUncommittedChanges(repoDir); dirty {
+			t.Error("expected a clean working tree after the abort")
+		}
+	})
+
+	t.Run("aborts on conflicts", func(t *testing.T) {
+		repoDir, defaultBranch := setupMergeRepo(t, true)
+		before := revParse(t, repoDir, defaultBranch)
+
+		err := NewClient().MergeBranch(repoDir, defaultBranch, "feature", MergeOptions{})
+		if err == nil || !strings.Contains(err.Error(), "the merge was aborted") {
+			t.Fatalf("expected an aborted merge error, got: %v", err)
+		}
+		if after := revParse(t, repoDir, defaultBranch); after != before {
+			t.Errorf("expected %s to stay at %s, got %s", defaultBranch, before, after)
+		}
+		if _, err := os.Stat(filepath.Join(repoDir, ".git", "MERGE_HEAD")); !os.IsNotExist(err) {
+			t.Error("expected no merge in progress after the abort")
+		}
+		if dirty, _ := NewClient().HasUncommittedChanges(repoDir); dirty {
+			t.Error("expected a clean working tree after the abort")
+		}
+	})
+}
+
+func TestResolveRefDescription(t *testing.T) {
+	repoDir := t.TempDir()
+	runGitCommand(t, repoDir, "init")
+	runGitCommand(t, repoDir, "config", "user.email", "test@example.com")
+	runGitCommand(t, repoDir, "config", "user.name", "Test User")
+	runGitCommand(t, repoDir, "commit", "--allow-empty", "-m", "Initial commit")
+
+	originalDir, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("failed to get current dir: %v", err)
+	}
+	defer func() {
+		_ = os.Chdir(originalDir)
+	}()
+	if err := os.Chdir(repoDir); err != nil {
+		t.Fatalf("failed to change dir: %v", err)
+	}
+
+	headCommit := func() string {
+		t.Helper()
+		cmd := exec.Command("git", "rev-parse", "HEAD")
+		cmd.Dir = repoDir
+		out, err := cmd.Output()
+		if err != nil {
+			t.Fatalf("git rev-parse HEAD failed: %v", err)
+		}
+		return strings.TrimSpace(string(out))
+	}
+
+	client := NewClient()
+	untagged := headCommit()
+	if description, err := client.ResolveRefDescription(untagged); err != nil || description != "" {
+		t.Errorf("expected no description without tags, got %q (err: %v)", description, err)
+	}
+
+	runGitCommand(t, repoDir, "tag", "v1.2.3")
+	worktreeDir := filepath.Join(t.TempDir(), "detached")
+	runGitCommand(t, repoDir, "worktree", "add", "--detach", worktreeDir, "v1.2.3")
+
+	worktrees, err := client.ListWorktrees()
+	if err != nil {
+		t.Fatalf("ListWorktrees failed: %v", err)
+	}
+	var detached *WorktreeInfo
+	for i := range worktrees {
+		if worktrees[i].IsDetached {
+			detached = &worktrees[i]
+		}
+	}
+	if detached == nil {
+		t.Fatalf("expected a detached worktree, got %+v", worktrees)
+	}
+	description, err := client.ResolveRefDescription(detached.Commit)
+	if err != nil {
+		t.Fatalf("ResolveRefDescription failed: %v", err)
+	}
+	if description != "v1.2.3" {
+		t.Errorf("expected v1.2.3 for the tagged commit, got %q", description)
+	}
+
+	runGitCommand(t, repoDir, "commit", "--allow-empty", "-m", "After the tag")
+	description, err = client.ResolveRefDescription(headCommit())
+	if err != nil {
+		t.Fatalf("ResolveRefDescription failed: %v", err)
+	}
+	if !strings.HasPrefix(description, "v1.2.3-1-g") {
+		t.Errorf("expected a description relative to v1.2.3, got %q", description)
+	}
 }
@@ -0,0 +1,144 @@
+package git
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// scanMaxDepth bounds how far below the root ScanWorktrees looks for
+// worktrees; <root>/<repo>/<worktree> is at depth 2.
+const scanMaxDepth = 3
+
+// RepoWorktrees is one repository's worktrees as found by ScanWorktrees.
+type RepoWorktrees struct {
+	Repo      string         // the repository's main worktree (or bare repository) path
+	Worktrees []WorktreeInfo // only Path, Branch and IsDetached are set; sorted by path
+}
+
+// Name returns the repository's name: the base name of its directory, without
+// the .git suffix of a bare repository.
+func (r RepoWorktrees) Name() string {
+	return strings.TrimSuffix(filepath.Base(r.Repo), ".git")
+}
+
+// ScanWorktrees walks root for git worktrees and groups them by the
+// repository they belong to. A directory with a .git directory is a main
+// worktree; one with a .git file ("gitdir: ...") is a linked worktree whose
+// administrative directory names the shared git directory in its commondir
+// file. Worktrees are not descended into, and only the filesystem is read,
+// so no git process is started per directory. Repositories are sorted by
+// path.
+func ScanWorktrees(root string) ([]RepoWorktrees, error) {
+	root, err := filepath.Abs(root)
+	if err != nil {
+		return nil, err
+	}
+
+	groups := map[string]*RepoWorktrees{}
+	walkErr := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if path == root {
+				return err
+			}
+			return nil // an unreadable directory is skipped, not fatal
+		}
+		if !d.IsDir() {
+			return nil
+		}
+
+		if gitDir, commonDir, ok := resolveGitDirs(path); ok {
+			repo := commonDir
+			if filepath.Base(commonDir) == ".git" {
+				repo = filepath.Dir(commonDir)
+			}
+			group, found := groups[repo]
+			if !found {
+				group = &RepoWorktrees{Repo: repo}
+				groups[repo] = group
+			}
+			branch := readHeadBranch(gitDir)
+			group.Worktrees = append(group.Worktrees, WorktreeInfo{Path: path, Branch: branch, IsDetached: branch == ""})
+			return filepath.SkipDir
+		}
+
+		if rel, _ := filepath.Rel(root, path); rel != "." && strings.Count(rel, string(filepath.Separator))+1 >= scanMaxDepth {
+			return filepath.SkipDir
+		}
+		return nil
+	})
+	if walkErr != nil {
+		return nil, walkErr
+	}
+
+	repos := make([]RepoWorktrees, 0, len(groups))
+	for _, group := range groups {
+		sort.Slice(group.Worktrees, func(i, j int) bool { return group.Worktrees[i].Path < group.Worktrees[j].Path })
+		repos = append(repos, *group)
+	}
+	sort.Slice(repos, func(i, j int) bool { return repos[i].Repo < repos[j].Repo })
+	return repos, nil
+}
+
+// resolveGitDirs returns the git directory of the worktree at dir and the
+// common git directory it shares with the other worktrees of its repository.
+// ok is false when dir is not a worktree.
+func resolveGitDirs(dir string) (gitDir, commonDir string, ok bool) {
+	dotGit := filepath.Join(dir, ".git")
+	info, err := os.Stat(dotGit)
+	if err != nil {
+		return "", "", false
+	}
+	if info.IsDir() {
+		return dotGit, canonicalPath(dotGit), true
+	}
+
+	content, err := os.ReadFile(dotGit)
+	if err != nil {
+		return "", "", false
+	}
+	gitDir, found := strings.CutPrefix(strings.TrimSpace(string(content)), "gitdir:")
+	if !found {
+		return "", "", false
+	}
+	gitDir = resolveRelative(dir, strings.TrimSpace(gitDir))
+
+	commonDir = gitDir
+	if common, err := os.ReadFile(filepath.Join(gitDir, "commondir")); err == nil {
+		commonDir = resolveRelative(gitDir, strings.TrimSpace(string(common)))
+	}
+	return gitDir, canonicalPath(commonDir), true
+}
+
+// resolveRelative resolves path against base unless it is already absolute.
+func resolveRelative(base, path string) string {
+	if filepath.IsAbs(path) {
+		return filepath.Clean(path)
+	}
+	return filepath.Join(base, path)
+}
+
+// canonicalPath resolves symlinks in path so that worktrees reaching the
+// same git directory through different links are grouped together.
+func canonicalPath(path string) string {
+	if resolved, err := filepath.EvalSymlinks(path); err == nil {
+		return resolved
+	}
+	return path
+}
+
+// readHeadBranch returns the branch checked out according to gitDir's HEAD,
+// or "" for a detached HEAD or an unreadable file.
+func readHeadBranch(gitDir string) string {
+	head, err := os.ReadFile(filepath.Join(gitDir, "HEAD"))
+	if err != nil {
+		return ""
+	}
+	branch, ok := strings.CutPrefix(strings.TrimSpace(string(head)), "ref: refs/heads/")
+	if !ok {
+		return ""
+	}
+	return branch
+}
@@ -0,0 +1,74 @@
+package git
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestScanWorktrees(t *testing.T) {
+	root := t.TempDir()
+
+	// newRepo creates a repository outside root with one commit.
+	newRepo := func(name string) string {
+		dir := filepath.Join(t.TempDir(), name)
+		if err := os.Mkdir(dir, 0755); err != nil {
+			t.Fatalf("failed to create repo dir: %v", err)
+		}
+		runGitCommand(t, dir, "init")
+		runGitCommand(t, dir, "config", "user.email", "test@example.com")
+		runGitCommand(t, dir, "config", "user.name", "Test User")
+		runGitCommand(t, dir, "commit", "--allow-empty", "-m", "Initial commit")
+		return dir
+	}
+	app := newRepo("app")
+	lib := newRepo("lib")
+	runGitCommand(t, app, "worktree", "add", "-b", "1/impl", filepath.Join(root, "app", "app-1"))
+	runGitCommand(t, app, "worktree", "add", "--detach", filepath.Join(root, "app", "app-detached"))
+	runGitCommand(t, lib, "worktree", "add", "-b", "feature/x", filepath.Join(root, "lib", "lib-feature-x"))
+	if err := os.MkdirAll(filepath.Join(root, "notes", "drafts"), 0755); err != nil {
+		t.Fatalf("failed to create plain dir: %v", err)
+	}
+
+	repos, err := ScanWorktrees(root)
+	if err != nil {
+		t.Fatalf("ScanWorktrees failed: %v", err)
+	}
+
+	if len(repos) != 2 {
+		t.Fatalf("expected 2 repositories, got %d: %+v", len(repos), repos)
+	}
+	want := []struct {
+		repo      string
+		worktrees []WorktreeInfo
+	}{
+		{app, []WorktreeInfo{
+			{Path: filepath.Join(root, "app", "app-1"), Branch: "1/impl"},
+			{Path: filepath.Join(root, "app", "app-detached"), IsDetached: true},
+		}},
+		{lib, []WorktreeInfo{
+			{Path: filepath.Join(root, "lib", "lib-feature-x"), Branch: "feature/x"},
+		}},
+	}
+	for i, w := range want {
+		wantRepo, _ := filepath.EvalSymlinks(w.repo)
+		if repos[i].Repo != wantRepo {
+			t.Errorf("repos[%d].Repo = %s, want %s", i, repos[i].Repo, wantRepo)
+		}
+		if len(repos[i].Worktrees) != len(w.worktrees) {
+			t.Errorf("repos[%d] has %d worktrees, want %d: %+v", i, len(repos[i].Worktrees), len(w.worktrees), repos[i].Worktrees)
+			continue
+		}
+		for j, wt := range w.worktrees {
+			if repos[i].Worktrees[j] != wt {
+				t.Errorf("repos[%d].Worktrees[%d] = %+v, want %+v", i, j, repos[i].Worktrees[j], wt)
+			}
+		}
+	}
+}
+
+func TestScanWorktrees_MissingRoot(t *testing.T) {
+	if _, err := ScanWorktrees(filepath.Join(t.TempDir(), "missing")); err == nil {
+		t.Error("expected an error for a missing root")
+	}
+}
@@ -2,9 +2,118 @@ package git
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
 	"strings"
+	"time"
 )
 
+// inProgressMarkers maps the state files git leaves in a worktree's git dir
+// while an operation is stopped half-way to the operation's name. Rebase is
+// checked first so that when several markers exist, the outermost operation
+// is reported.
+var inProgressMarkers = []struct {
+	file      string
+	operation string
+}{
+	{"rebase-merge", "rebase"},
+	{"rebase-apply", "rebase"},
+	{"MERGE_HEAD", "merge"},
+	{"CHERRY_PICK_HEAD", "cherry-pick"},
+	{"REVERT_HEAD", "revert"},
+}
+
+// CommitInfo summarizes a single commit.
+type CommitInfo struct {
+	Hash    string
+	Subject string
+	Author  string
+	Date    time.Time
+}
+
+// WorktreeDetails describes a worktree's state beyond what `git worktree list`
+// reports: its upstream tracking status, pending changes and latest commit.
+type WorktreeDetails struct {
+	Upstream         string // e.g. "origin/123/impl"; empty when none is configured
+	Ahead            int    // commits on HEAD that are not on Upstream
+	Behind           int    // commits on Upstream that are not on HEAD
+	UncommittedFiles int    // entries reported by `git status --porcelain`
+	LastCommit       CommitInfo
+}
+
+// logFieldSep separates the fields of the `git log --format` used for
+// LastCommit; NUL cannot appear in subjects or author names.
+const logFieldSep = "\x00"
+
+// lastCommitFields is the number of fields in the LastCommit log format.
+const lastCommitFields = 4
+
+// GetWorktreeDetails gathers upstream, ahead/behind, uncommitted-file and
+// last-commit information for the worktree at worktreePath.
+func (c *Client) GetWorktreeDetails(worktreePath string) (*WorktreeDetails, error) {
+	details := &WorktreeDetails{}
+
+	status, err := c.r.run(worktreePath, "status", "--porcelain")
+	if err != nil {
+		return nil, fmt.Errorf("failed to check git status: %w", err)
+	}
+	if status != "" {
+		details.UncommittedFiles = len(strings.Split(status, "\n"))
+	}
+
+	// No upstream is a normal state (e.g. a branch that was never pushed).
+	if upstream, err := c.r.run(worktreePath, "rev-parse", "--abbrev-ref", "--symbolic-full-name", "@{upstream}"); err == nil {
+		details.Upstream = upstream
+		details.Ahead, details.Behind, err = c.aheadBehind(worktreePath, "HEAD")
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	out, err := c.r.run(worktreePath, "log", "-1", "--format=%H%x00%s%x00%an%x00%cI")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read last commit: %w", err)
+	}
+	if fields := strings.SplitN(out, logFieldSep, lastCommitFields); len(fields) == lastCommitFields {
+		details.LastCommit = CommitInfo{Hash: fields[0], Subject: fields[1], Author: fields[2]}
+		details.LastCommit.Date, _ = time.Parse(time.RFC3339, fields[3])
+	}
+
+	return details, nil
+}
+
+// aheadBehind counts the commits on rev that are not on its upstream and the
+// commits on the upstream that are not on rev, evaluated in dir.
+func (c *Client) aheadBehind(dir, rev string) (ahead, behind int, err error) {
+	ahead, behind, err = c.countLeftRight(dir, rev, rev+"@{upstream}")
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to count commits ahead/behind: %w", err)
+	}
+	return ahead, behind, nil
+}
+
+// countLeftRight counts the commits on left that are not on right and those
+// on right that are not on left, with `git rev-list --left-right --count`
+// run in dir.
+func (c *Client) countLeftRight(dir, left, right string) (onlyLeft, onlyRight int, err error) {
+	out, err := c.r.run(dir, "rev-list", "--left-right", "--count", left+"..."+right)
+	if err != nil {
+		return 0, 0, err
+	}
+	fields := strings.Fields(out)
+	if len(fields) != 2 {
+		return 0, 0, fmt.Errorf("unexpected rev-list output %q", out)
+	}
+	if onlyLeft, err = strconv.Atoi(fields[0]); err == nil {
+		onlyRight, err = strconv.Atoi(fields[1])
+	}
+	if err != nil {
+		return 0, 0, fmt.Errorf("unexpected rev-list output %q: %w", out, err)
+	}
+	return onlyLeft, onlyRight, nil
+}
+
 // HasUncommittedChanges checks if the worktree at worktreePath has any
 // uncommitted changes.
 func (c *Client) HasUncommittedChanges(worktreePath string) (bool, error) {
@@ -16,12 +125,60 @@ func (c *Client) HasUncommittedChanges(worktreePath string) (bool, error) {
 	return out != "", nil
 }
 
+// IsInProgressOperation reports whether the worktree at worktreePath is in the
+// middle of a rebase, merge, cherry-pick or revert, and which one. Removing
+// such a worktree throws away the half-finished operation. The state lives in
+// the worktree's own git dir (.git/worktrees/<id>/ for linked worktrees).
+func (c *Client) IsInProgressOperation(worktreePath string) (inProgress bool, operation string, err error) {
+	gitDir, err := c.gitDir(worktreePath)
+	if err != nil {
+		return false, "", err
+	}
+
+	for _, marker := range inProgressMarkers {
+		if _, statErr := os.Stat(filepath.Join(gitDir, marker.file)); statErr == nil {
+			return true, marker.operation, nil
+		}
+	}
+	return false, "", nil
+}
+
+// rebasingBranch returns the branch being rebased in the worktree at
+// worktreePath, or "" when no rebase is in progress. git detaches HEAD for the
+// duration of a rebase, so `worktree list` reports no branch; the original
+// branch is recorded in the rebase state's head-name file.
+func (c *Client) rebasingBranch(worktreePath string) string {
+	gitDir, err := c.gitDir(worktreePath)
+	if err != nil {
+		return ""
+	}
+	for _, stateDir := range []string{"rebase-merge", "rebase-apply"} {
+		headName, err := os.ReadFile(filepath.Join(gitDir, stateDir, "head-name"))
+		if err == nil {
+			return strings.TrimPrefix(strings.TrimSpace(string(headName)), "refs/heads/")
+		}
+	}
+	return ""
+}
+
+// gitDir returns the absolute git dir of the worktree at worktreePath
+// (.git/worktrees/<id> for linked worktrees).
+func (c *Client) gitDir(worktreePath string) (string, error) {
+	gitDir, err := c.r.run(worktreePath, "rev-parse", "--absolute-git-dir")
+	if err != nil {
+		return "", fmt.Errorf("failed to locate git dir: %w", err)
+	}
+	return gitDir, nil
+}
+
 // HasUnpushedCommits checks whether currentBranch in the worktree at
 // worktreePath has commits that haven't been pushed to its upstream. When the
 // branch has no upstream configured, the function falls back to checking
 // whether it is already merged into the base branch (local main or
 // origin/main), covering both the "PR merged then remote branch auto-deleted"
-// case and the "merged into local main before pushing" case.
+// case and the "merged into local main before pushing" case. In a repository
+// without the selected remote there is nowhere to push, so a branch without
+// an upstream never counts as unpushed; the merge check still guards it.
 func (c *Client) HasUnpushedCommits(worktreePath, currentBranch string) (bool, error) {
 	// Check if the branch has an upstream
 	if _, err := c.r.run(worktreePath, "rev-parse", "--abbrev-ref", currentBranch+"@{upstream}"); err != nil {
@@ -33,6 +190,9 @@ func (c *Client) HasUnpushedCommits(worktreePath, currentBranch string) (bool, e
 			// Branch is merged, so no unpushed commits
 			return false, nil
 		}
+		if hasRemote, remoteErr := c.HasRemote(); remoteErr == nil && !hasRemote {
+			return false, nil
+		}
 
 		// If we can't determine merge status or branch is not merged,
 		// assume there are unpushed commits for safety
@@ -48,16 +208,57 @@ func (c *Client) HasUnpushedCommits(worktreePath, currentBranch string) (bool, e
 	return out != "0", nil
 }
 
+// CommitsBehind counts the commits on baseBranch that the worktree at
+// worktreePath does not have yet.
+func (c *Client) CommitsBehind(worktreePath, baseBranch string) (int, error) {
+	out, err := c.r.run(worktreePath, "rev-list", "--count", "HEAD.."+baseBranch)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count commits behind %s: %w", baseBranch, err)
+	}
+	n, err := strconv.Atoi(out)
+	if err != nil {
+		return 0, fmt.Errorf("unexpected rev-list output %q: %w", out, err)
+	}
+	return n, nil
+}
+
+// CommitsSince counts the commits HEAD of the worktree at worktreePath has
+// that rev does not, with `git rev-list --count <rev>..HEAD`. rev can be any
+// revision, such as a tag.
+func (c *Client) CommitsSince(worktreePath, rev string) (int, error) {
+	out, err := c.r.run(worktreePath, "rev-list", "--count", rev+"..HEAD")
+	if err != nil {
+		return 0, fmt.Errorf("failed to count commits since %s: %w", rev, err)
+	}
+	n, err := strconv.Atoi(out)
+	if err != nil {
+		return 0, fmt.Errorf("unexpected rev-list output %q: %w", out, err)
+	}
+	return n, nil
+}
+
+// AheadBehind counts the commits HEAD of the worktree at worktreePath has that
+// baseBranch does not (ahead) and those baseBranch has that HEAD does not
+// (behind). git runs in the worktree itself, so no chdir is needed.
+func (c *Client) AheadBehind(worktreePath, baseBranch string) (ahead, behind int, err error) {
+	ahead, behind, err = c.countLeftRight(worktreePath, "HEAD", baseBranch)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to count commits against %s: %w", baseBranch, err)
+	}
+	return ahead, behind, nil
+}
+
 // IsMergedToBaseBranch reports whether currentBranch in the worktree at
 // worktreePath is already merged into the base branch, considering both the
-// local <targetBranch> and origin/<targetBranch>. A branch merged into the
+// local <targetBranch> and <remote>/<targetBranch>. A branch merged into the
 // local base branch is treated as merged even when that merge hasn't been
 // pushed yet, since the work is preserved in the local base branch's history
-// and is therefore safe to remove. Callers must refresh remote-tracking refs
+// and is therefore safe to remove. Without the selected remote, only the
+// local base branch is compared. Callers must refresh remote-tracking refs
 // themselves (e.g. via fetchIfConfigured) — this function does not fetch.
 func (c *Client) IsMergedToBaseBranch(worktreePath, currentBranch, targetBranch string) (bool, error) {
-	// Merged into the remote base branch (origin/<targetBranch>).
-	remoteMerged, err := c.branchListContains(worktreePath, currentBranch, true, "origin/"+targetBranch)
+	// Merged into the remote base branch (<remote>/<targetBranch>).
+	remoteMerged, err := c.branchListContains(worktreePath, currentBranch, true, c.Remote()+"/"+targetBranch)
 	if err != nil {
 		return false, err
 	}
@@ -432,6 +432,9 @@ func TestHasUnpushedCommits(t *testing.T) {
 			t.Fatalf("failed to commit: %v", err)
 		}
 
+		// The remote exists, the branch was just never pushed to it.
+		runGitCommand(t, tempDir, "remote", "add", "origin", t.TempDir())
+
 		// Create a new branch without upstream, with its own commit so it has
 		// work that is neither pushed nor merged into the base branch.
 		cmd = exec.Command("git", "checkout", "-b", "no-upstream-branch")
@@ -461,6 +464,35 @@ func TestHasUnpushedCommits(t *testing.T) {
 		}
 	})
 
+	t.Run("returns false without a remote to push to", func(t *testing.T) {
+		tempDir, cleanup := createTestRepo(t)
+		defer cleanup()
+
+		originalDir, _ := os.Getwd()
+		defer os.Chdir(originalDir)
+		os.Chdir(tempDir)
+
+		runGitCommand(t, tempDir, "commit", "--allow-empty", "-m", "initial")
+		defaultBranch := getDefaultBranchName(t, tempDir)
+		runGitCommand(t, tempDir, "checkout", "-b", "local-only")
+		runGitCommand(t, tempDir, "commit", "--allow-empty", "-m", "local work")
+
+		hasUnpushed, err := HasUnpushedCommits(tempDir, "local-only")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if hasUnpushed {
+			t.Error("expected no unpushed commits in a repository without a remote")
+		}
+		merged, err := IsMergedToBaseBranch(tempDir, "local-only", defaultBranch)
+		if err != nil {
+			t.Fatalf("unexpected error from the merge check: %v", err)
+		}
+		if merged {
+			t.Error("expected the unmerged branch to still count as not merged")
+		}
+	})
+
 	t.Run("returns false when branch is merged and remote deleted", func(t *testing.T) {
 		tempDir, cleanup := createTestRepo(t)
 		defer cleanup()
@@ -874,3 +906,221 @@ func TestIsMergedToBaseBranch(t *testing.T) {
 		}
 	})
 }
+
+func TestIsInProgressOperation(t *testing.T) {
+	tempDir, cleanup := createTestRepo(t)
+	defer cleanup()
+
+	writeAndCommit := func(dir, content, message string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(dir, "test.txt"), []byte(content), 0644); err != nil {
+			t.Fatalf("failed to write file: %v", err)
+		}
+		runGitCommand(t, dir, "add", "test.txt")
+		runGitCommand(t, dir, "commit", "-m", message)
+	}
+	// startConflicting runs a git operation that is expected to stop on a
+	// conflict, leaving its in-progress state behind.
+	startConflicting := func(dir string, args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		if err := cmd.Run(); err == nil {
+			t.Fatalf("expected git %v to stop on a conflict", args)
+		}
+	}
+
+	writeAndCommit(tempDir, "base\n", "initial")
+	baseBranch := getDefaultBranchName(t, tempDir)
+
+	// A linked worktree whose branch conflicts with the base branch, so its
+	// operation state lives under .git/worktrees/<id>/.
+	worktreePath := filepath.Join(t.TempDir(), "wt")
+	runGitCommand(t, tempDir, "worktree", "add", "-b", "feature", worktreePath)
+	writeAndCommit(worktreePath, "feature\n", "feature change")
+	writeAndCommit(tempDir, "base change\n", "base change")
+
+	assertState := func(wantInProgress bool, wantOperation string) {
+		t.Helper()
+		inProgress, operation, err := IsInProgressOperation(worktreePath)
+		if err != nil {
+			t.Fatalf("IsInProgressOperation failed: %v", err)
+		}
+		if inProgress != wantInProgress || operation != wantOperation {
+			t.Errorf("expected (%v, %q), got (%v, %q)", wantInProgress, wantOperation, inProgress, operation)
+		}
+	}
+
+	t.Run("reports nothing for an idle worktree", func(t *testing.T) {
+		assertState(false, "")
+	})
+
+	t.Run("detects a rebase", func(t *testing.T) {
+		startConflicting(worktreePath, "rebase", baseBranch)
+		defer runGitCommand(t, worktreePath, "rebase", "--abort")
+		assertState(true, "rebase")
+	})
+
+	t.Run("ListWorktrees reports the branch being rebased", func(t *testing.T) {
+		startConflicting(worktreePath, "rebase", baseBranch)
+		defer runGitCommand(t, worktreePath, "rebase", "--abort")
+
+		originalDir, _ := os.Getwd()
+		defer os.Chdir(originalDir)
+		if err := os.Chdir(tempDir); err != nil {
+			t.Fatalf("failed to change dir: %v", err)
+		}
+
+		worktrees, err := ListWorktrees()
+		if err != nil {
+			t.Fatalf("ListWorktrees failed: %v", err)
+		}
+		for _, wt := range worktrees {
+			if filepath.Base(wt.Path) != filepath.Base(worktreePath) {
+				continue
+			}
+			if !wt.IsDetached || wt.Branch != "feature" {
+				t.Errorf("expected detached worktree on branch feature, got detached=%v branch=%q", wt.IsDetached, wt.Branch)
+			}
+			return
+		}
+		t.Errorf("worktree %s not listed", worktreePath)
+	})
+
+	t.Run("detects a merge", func(t *testing.T) {
+		startConflicting(worktreePath, "merge", baseBranch)
+		defer runGitCommand(t, worktreePath, "merge", "--abort")
+		assertState(true, "merge")
+	})
+
+	t.Run("detects a cherry-pick", func(t *testing.T) {
+		startConflicting(worktreePath, "cherry-pick", baseBranch)
+		defer runGitCommand(t, worktreePath, "cherry-pick", "--abort")
+		assertState(true, "cherry-pick")
+	})
+
+	t.Run("does not leak state from the main worktree", func(t *testing.T) {
+		startConflicting(tempDir, "merge", "feature")
+		defer runGitCommand(t, tempDir, "merge", "--abort")
+		assertState(false, "")
+	})
+
+	t.Run("returns error for a missing worktree", func(t *testing.T) {
+		if _, _, err := IsInProgressOperation(filepath.Join(t.TempDir(), "missing")); err == nil {
+			t.Error("expected error for a missing worktree directory")
+		}
+	})
+}
+
+func TestGetWorktreeDetails(t *testing.T) {
+	tempDir, cleanup := createTestRepo(t)
+	defer cleanup()
+	remoteDir := t.TempDir()
+	runGitCommand(t, remoteDir, "init", "--bare")
+
+	commit := func(content, message string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(tempDir, "test.txt"), []byte(content), 0644); err != nil {
+			t.Fatalf("failed to write file: %v", err)
+		}
+		runGitCommand(t, tempDir, "add", "test.txt")
+		runGitCommand(t, tempDir, "commit", "-m", message)
+	}
+
+	commit("a\n", "first")
+	branch := getDefaultBranchName(t, tempDir)
+
+	t.Run("no upstream", func(t *testing.T) {
+		details, err := GetWorktreeDetails(tempDir)
+		if err != nil {
+			t.Fatalf("GetWorktreeDetails failed: %v", err)
+		}
+		if details.Upstream != "" || details.Ahead != 0 || details.Behind != 0 {
+			t.Errorf("expected no upstream, got %+v", details)
+		}
+		if details.LastCommit.Subject != "first" || details.LastCommit.Author != "Test User" {
+			t.Errorf("unexpected last commit: %+v", details.LastCommit)
+		}
+		if details.LastCommit.Date.IsZero() || len(details.LastCommit.Hash) != 40 {
+			t.Errorf("expected full hash and date, got %+v", details.LastCommit)
+		}
+	})
+
+	// Diverge from the upstream: one commit only on origin, one only local.
+	runGitCommand(t, tempDir, "remote", "add", "origin", remoteDir)
+	commit("b\n", "pushed")
+	runGitCommand(t, tempDir, "push", "-u", "origin", branch)
+	runGitCommand(t, tempDir, "reset", "--hard", "HEAD~1")
+	commit("c\n", "local only")
+
+	// One modified tracked file and one untracked file.
+	os.WriteFile(filepath.Join(tempDir, "test.txt"), []byte("dirty\n"), 0644)
+	os.WriteFile(filepath.Join(tempDir, "new.txt"), []byte("new\n"), 0644)
+
+	t.Run("diverged upstream with local changes", func(t *testing.T) {
+		details, err := GetWorktreeDetails(tempDir)
+		if err != nil {
+			t.Fatalf("GetWorktreeDetails failed: %v", err)
+		}
+		if details.Upstream != "origin/"+branch {
+			t.Errorf("expected upstream origin/%s, got %q", branch, details.Upstream)
+		}
+		if details.Ahead != 1 || details.Behind != 1 {
+			t.Errorf("expected ahead 1, behind 1, got ahead %d, behind %d", details.Ahead, details.Behind)
+		}
+		if details.UncommittedFiles != 2 {
+			t.Errorf("expected 2 uncommitted files, got %d", details.UncommittedFiles)
+		}
+		if details.LastCommit.Subject != "local only" {
+			t.Errorf("expected last commit 'local only', got %q", details.LastCommit.Subject)
+		}
+	})
+}
+
+func TestCommitsSince(t *testing.T) {
+	tempDir, cleanup := createTestRepo(t)
+	defer cleanup()
+	runGitCommand(t, tempDir, "commit", "--allow-empty", "-m", "initial")
+	runGitCommand(t, tempDir, "tag", "v1.0.0")
+
+	wt := filepath.Join(t.TempDir(), "wt")
+	runGitCommand(t, tempDir, "worktree", "add", "-b", "feature", wt)
+	runGitCommand(t, wt, "commit", "--allow-empty", "-m", "first")
+	runGitCommand(t, wt, "commit", "--allow-empty", "-m", "second")
+
+	if n, err := NewClient().CommitsSince(wt, "v1.0.0"); err != nil || n != 2 {
+		t.Errorf("CommitsSince(wt) = %d, %v; want 2", n, err)
+	}
+	if n, err := NewClient().CommitsSince(tempDir, "v1.0.0"); err != nil || n != 0 {
+		t.Errorf("CommitsSince(main) = %d, %v; want 0", n, err)
+	}
+	if _, err := NewClient().CommitsSince(wt, "v9.9.9"); err == nil {
+		t.Error("expected an error for an unknown revision")
+	}
+}
+
+func TestAheadBehind(t *testing.T) {
+	tempDir, cleanup := createTestRepo(t)
+	defer cleanup()
+	runGitCommand(t, tempDir, "commit", "--allow-empty", "-m", "initial")
+	base := getDefaultBranchName(t, tempDir)
+
+	wt := filepath.Join(t.TempDir(), "wt")
+	runGitCommand(t, tempDir, "worktree", "add", "-b", "feature", wt)
+	runGitCommand(t, wt, "commit", "--allow-empty", "-m", "first")
+	runGitCommand(t, wt, "commit", "--allow-empty", "-m", "second")
+	runGitCommand(t, tempDir, "commit", "--allow-empty", "-m", "on base")
+
+	// The counts are taken in the given worktree, not the current directory.
+	ahead, behind, err := NewClient().AheadBehind(wt, base)
+	if err != nil {
+		t.Fatalf("AheadBehind failed: %v", err)
+	}
+	if ahead != 2 || behind != 1 {
+		t.Errorf("expected ahead 2, behind 1, got ahead %d, behind %d", ahead, behind)
+	}
+
+	if _, _, err := NewClient().AheadBehind(wt, "no-such-branch"); err == nil {
+		t.Error("expected an error for an unknown base branch")
+	}
+}
@@ -12,55 +12,107 @@ const (
 	defaultMasterBranch = "master"
 )
 
-// testClient is the shared git.Client used by the package-level test helpers
+// testClient returns the git.Client used by the package-level test helpers
 // below. The helpers exist so the large body of existing integration tests can
 // keep calling git operations by short names while every call routes through a
 // real *Client method (the production entry point after the runner/Client
-// refactor).
-var testClient = NewClient()
+// refactor). Each call gets a fresh Client: the tests switch repositories and
+// run git directly between calls, which a shared Client's read cache would not
+// notice.
+func testClient() *Client { return NewClient() }
 
-func RunCommand(command string) error            { return testClient.RunCommand(command) }
-func IsGitRepository() bool                      { return testClient.IsGitRepository() }
-func GetRepositoryName() (string, error)         { return testClient.GetRepositoryName() }
-func GetOriginalRepositoryName() (string, error) { return testClient.GetOriginalRepositoryName() }
-func GetRepositoryRoot() (string, error)         { return testClient.GetRepositoryRoot() }
-func GetMainRepositoryRoot() (string, error)     { return testClient.GetMainRepositoryRoot() }
-func GetCurrentBranch() (string, error)          { return testClient.GetCurrentBranch() }
-func FetchAll() error                            { return testClient.FetchAll() }
-func ListAllBranches() ([]string, error)         { return testClient.ListAllBranches() }
-func BranchExists(branch string) (bool, error)   { return testClient.BranchExists(branch) }
-func DeleteBranch(branch string) error           { return testClient.DeleteBranch(branch) }
-func ListWorktrees() ([]WorktreeInfo, error)     { return testClient.ListWorktrees() }
-func RemoveWorktree(issueNumber string) error    { return testClient.RemoveWorktree(issueNumber) }
+func RunCommand(command string) error              { return testClient().RunCommand(command) }
+func IsGitRepository() bool                        { return testClient().IsGitRepository() }
+func GetRepositoryName() (string, error)           { return testClient().GetRepositoryName() }
+func GetOriginalRepositoryName() (string, error)   { return testClient().GetOriginalRepositoryName() }
+func GetRepositoryRoot() (string, error)           { return testClient().GetRepositoryRoot() }
+func GetMainRepositoryRoot() (string, error)       { return testClient().GetMainRepositoryRoot() }
+func GetCurrentBranch() (string, error)            { return testClient().GetCurrentBranch() }
+func FetchAll() error                              { return testClient().FetchAll() }
+func ListAllBranches() ([]string, error)           { return testClient().ListAllBranches() }
+func ListLocalBranches() ([]string, error)         { return testClient().ListLocalBranches() }
+func BranchExists(branch string) (bool, error)     { return testClient().BranchExists(branch) }
+func DeleteBranch(branch string, force bool) error { return testClient().DeleteBranch(branch, force) }
+func DeleteRemoteBranch(branch string) error       { return testClient().DeleteRemoteBranch(branch) }
+func RemoteDefaultBranch() (string, error)         { return testClient().RemoteDefaultBranch() }
+func IsUpstreamGone(branch string) (bool, error)   { return testClient().IsUpstreamGone(branch) }
+func ListWorktrees() ([]WorktreeInfo, error)       { return testClient().ListWorktrees() }
+func RemoveWorktree(issueNumber string) error      { return testClient().RemoveWorktree(issueNumber) }
+func RepairWorktrees(worktreePaths ...string) error {
+	return testClient().RepairWorktrees(worktreePaths...)
+}
+func PruneWorktrees() error {
+	return testClient().PruneWorktrees()
+}
 func RemoveWorktreeByPath(worktreePath string) error {
-	return testClient.RemoveWorktreeByPath(worktreePath)
+	return testClient().RemoveWorktreeByPath(worktreePath)
 }
-func CreateWorktree(issueNumberOrBranch, baseBranch string) (string, error) {
-	return testClient.CreateWorktree(issueNumberOrBranch, baseBranch)
+func CreateWorktree(issueNumberOrBranch, baseBranch string, opts ...AddOption) (string, error) {
+	return testClient().CreateWorktree(issueNumberOrBranch, baseBranch, opts...)
+}
+func CreateTrackingWorktree(issueNumberOrBranch, remoteBranch string) (string, error) {
+	return testClient().CreateTrackingWorktree(issueNumberOrBranch, remoteBranch)
 }
 func CreateWorktreeFromBranch(worktreePath, sourceBranch, targetBranch string) error {
-	return testClient.CreateWorktreeFromBranch(worktreePath, sourceBranch, targetBranch)
+	return testClient().CreateWorktreeFromBranch(worktreePath, sourceBranch, targetBranch)
+}
+func ApplyStash(worktreePath string) error { return testClient().ApplyStash(worktreePath) }
+func ApplyPatch(worktreePath, patchFile string) error {
+	return testClient().ApplyPatch(worktreePath, patchFile)
+}
+func FormatPatch(worktreePath, base, outDir string) ([]string, error) {
+	return testClient().FormatPatch(worktreePath, base, outDir)
+}
+func InspectCheckout(path string) (*Checkout, error) { return testClient().InspectCheckout(path) }
+func AdoptClone(clonePath, branch string) (string, error) {
+	return testClient().AdoptClone(clonePath, branch)
 }
 func GetWorktreeForIssue(issueNumberOrBranch string) (*WorktreeInfo, error) {
-	return testClient.GetWorktreeForIssue(issueNumberOrBranch)
+	return testClient().GetWorktreeForIssue(issueNumberOrBranch)
+}
+func RemoteBranchCandidates(branch string) ([]string, error) {
+	return testClient().RemoteBranchCandidates(branch)
 }
+func CutRemoteBranch(branch string) (string, bool) { return testClient().CutRemoteBranch(branch) }
 func ResolveBaseBranch(baseBranch string) (string, bool) {
-	return testClient.ResolveBaseBranch(baseBranch)
+	return testClient().ResolveBaseBranch(baseBranch)
+}
+func UpstreamStatus(branch string) (*TrackingStatus, error) {
+	return testClient().UpstreamStatus(branch)
+}
+func FastForwardBranch(branch string) error { return testClient().FastForwardBranch(branch) }
+func MergeBranch(worktreePath, baseBranch, branch string, opts MergeOptions) error {
+	return testClient().MergeBranch(worktreePath, baseBranch, branch, opts)
 }
 func HasUncommittedChanges(worktreePath string) (bool, error) {
-	return testClient.HasUncommittedChanges(worktreePath)
+	return testClient().HasUncommittedChanges(worktreePath)
 }
 func HasUnpushedCommits(worktreePath, currentBranch string) (bool, error) {
-	return testClient.HasUnpushedCommits(worktreePath, currentBranch)
+	return testClient().HasUnpushedCommits(worktreePath, currentBranch)
+}
+func IsInProgressOperation(worktreePath string) (bool, string, error) {
+	return testClient().IsInProgressOperation(worktreePath)
+}
+func GetWorktreeDetails(worktreePath string) (*WorktreeDetails, error) {
+	return testClient().GetWorktreeDetails(worktreePath)
+}
+func CommitsBehind(worktreePath, baseBranch string) (int, error) {
+	return testClient().CommitsBehind(worktreePath, baseBranch)
 }
 func IsMergedToBaseBranch(worktreePath, currentBranch, targetBranch string) (bool, error) {
-	return testClient.IsMergedToBaseBranch(worktreePath, currentBranch, targetBranch)
+	return testClient().IsMergedToBaseBranch(worktreePath, currentBranch, targetBranch)
 }
 func FindUntrackedEnvFiles(repoPath string) ([]EnvFile, error) {
-	return testClient.FindUntrackedEnvFiles(repoPath)
+	return testClient().FindUntrackedEnvFiles(repoPath)
+}
+func FindUntrackedFiles(repoPath string) ([]EnvFile, error) {
+	return testClient().FindUntrackedFiles(repoPath)
+}
+func CopyFiles(files []EnvFile, sourceRoot, destRoot string) (int, error) {
+	return testClient().CopyFiles(files, sourceRoot, destRoot)
 }
 func CopyEnvFiles(envFiles []EnvFile, sourceRoot, destRoot string) error {
-	return testClient.CopyEnvFiles(envFiles, sourceRoot, destRoot)
+	return testClient().CopyEnvFiles(envFiles, sourceRoot, destRoot)
 }
 
 // Helper function to run git commands in tests
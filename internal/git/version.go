@@ -0,0 +1,144 @@
+package git
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Version is a git release version, e.g. 2.39.5.
+type Version struct {
+	Major, Minor, Patch int
+}
+
+// String returns the version as "major.minor.patch".
+func (v Version) String() string {
+	return fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+}
+
+// AtLeast reports whether v is the same release as want or a later one.
+func (v Version) AtLeast(want Version) bool {
+	if v.Major != want.Major {
+		return v.Major > want.Major
+	}
+	if v.Minor != want.Minor {
+		return v.Minor > want.Minor
+	}
+	return v.Patch >= want.Patch
+}
+
+// ParseVersion parses the output of `git version`, such as
+// "git version 2.39.5" or "git version 2.39.3 (Apple Git-146)". A missing
+// patch number, or one with a suffix like "2.45.0.rc1", is read as far as it
+// is numeric.
+func ParseVersion(out string) (Version, error) {
+	fields := strings.Fields(strings.TrimPrefix(strings.TrimSpace(out), "git version "))
+	if len(fields) == 0 {
+		return Version{}, fmt.Errorf("unexpected git version output %q", out)
+	}
+	parts := strings.SplitN(fields[0], ".", 4)
+	if len(parts) < 2 {
+		return Version{}, fmt.Errorf("unexpected git version output %q", out)
+	}
+	var numbers [3]int
+	for i := 0; i < len(parts) && i < len(numbers); i++ {
+		n, err := strconv.Atoi(parts[i])
+		if err != nil {
+			if i < 2 {
+				return Version{}, fmt.Errorf("unexpected git version output %q", out)
+			}
+			break
+		}
+		numbers[i] = n
+	}
+	return Version{Major: numbers[0], Minor: numbers[1], Patch: numbers[2]}, nil
+}
+
+// Feature is a git capability gw relies on that older git versions lack.
+type Feature struct {
+	Name     string  // the git command or option, e.g. "git worktree move"
+	Requires Version // the first git version that has it
+	Impact   string  // what does not work without it, for gw doctor
+}
+
+// The git features gw checks the version for before using them.
+var (
+	FeatureWorktreeMove = Feature{
+		Name:     "git worktree move",
+		Requires: Version{2, 17, 0},
+		Impact:   "gw relocate cannot move worktrees",
+	}
+	FeatureSwitchOrphan = Feature{
+		Name:     "git switch --orphan",
+		Requires: Version{2, 23, 0},
+		Impact:   "gw start --orphan cannot create orphan branches",
+	}
+	FeatureWorktreeRepair = Feature{
+		Name:     "git worktree repair",
+		Requires: Version{2, 29, 0},
+		Impact:   "gw reattach and gw import cannot re-link worktrees or import clones",
+	}
+	FeatureWorktreeListAnnotations = Feature{
+		Name:     "git worktree list --porcelain reporting prunable and locked worktrees",
+		Requires: Version{2, 31, 0},
+		Impact:   "gw list, gw clean and gw relocate do not see missing or locked worktrees",
+	}
+	FeatureWorktreeAddLockReason = Feature{
+		Name:     "git worktree add --reason",
+		Requires: Version{2, 33, 0},
+		Impact:   "gw start --lock-reason cannot record why a worktree is locked",
+	}
+	FeatureWorktreeAddOrphan = Feature{
+		Name:     "git worktree add --orphan",
+		Requires: Version{2, 42, 0},
+		Impact:   "gw start --orphan uses git switch --orphan instead",
+	}
+)
+
+// Features lists every Feature, oldest first.
+var Features = []Feature{
+	FeatureWorktreeMove,
+	FeatureSwitchOrphan,
+	FeatureWorktreeRepair,
+	FeatureWorktreeListAnnotations,
+	FeatureWorktreeAddLockReason,
+	FeatureWorktreeAddOrphan,
+}
+
+// VersionError reports that the installed git is too old for a Feature.
+type VersionError struct {
+	Feature   Feature
+	Installed Version
+}
+
+func (e *VersionError) Error() string {
+	return fmt.Sprintf("%s requires git >= %d.%d (found %s); please upgrade git",
+		e.Feature.Name, e.Feature.Requires.Major, e.Feature.Requires.Minor, e.Installed)
+}
+
+// GitVersion returns the version of the installed git.
+func (c *Client) GitVersion() (Version, error) {
+	out, err := c.r.run("", "version")
+	if err != nil {
+		return Version{}, fmt.Errorf("failed to get git version: %w", err)
+	}
+	return ParseVersion(out)
+}
+
+// supports reports whether the installed git has feature. A version that
+// cannot be read counts as new enough, so an unusual git build is never
+// locked out; git itself reports anything it does not support.
+func (c *Client) supports(feature Feature) bool {
+	v, err := c.GitVersion()
+	return err != nil || v.AtLeast(feature.Requires)
+}
+
+// requireFeature returns a *VersionError when the installed git is known to
+// be too old for feature.
+func (c *Client) requireFeature(feature Feature) error {
+	v, err := c.GitVersion()
+	if err != nil || v.AtLeast(feature.Requires) {
+		return nil
+	}
+	return &VersionError{Feature: feature, Installed: v}
+}
@@ -0,0 +1,102 @@
+package git
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestParseVersion(t *testing.T) {
+	tests := []struct {
+		out     string
+		want    Version
+		wantErr bool
+	}{
+		{out: "git version 2.39.5", want: Version{2, 39, 5}},
+		{out: "git version 2.39.3 (Apple Git-146)", want: Version{2, 39, 3}},
+		{out: "git version 2.45.0.rc1", want: Version{2, 45, 0}},
+		{out: "git version 2.44.0.windows.1", want: Version{2, 44, 0}},
+		{out: "git version 2.17", want: Version{2, 17, 0}},
+		{out: "git version", wantErr: true},
+		{out: "not git", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := ParseVersion(tt.out)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseVersion(%q) error = %v, wantErr %v", tt.out, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseVersion(%q) = %v, want %v", tt.out, got, tt.want)
+		}
+	}
+}
+
+func TestVersion_AtLeast(t *testing.T) {
+	v := Version{2, 29, 1}
+	for _, want := range []Version{{1, 99, 0}, {2, 17, 0}, {2, 29, 0}, {2, 29, 1}} {
+		if !v.AtLeast(want) {
+			t.Errorf("Expected %s to be at least %s", v, want)
+		}
+	}
+	for _, want := range []Version{{2, 29, 2}, {2, 31, 0}, {3, 0, 0}} {
+		if v.AtLeast(want) {
+			t.Errorf("Expected %s to be older than %s", v, want)
+		}
+	}
+}
+
+// versionStub reports a fixed git version and records, without running them,
+// the commands mutations go through.
+type versionStub struct {
+	mutationRecorder
+	version string
+}
+
+func (r *versionStub) run(dir string, args ...string) (string, error) {
+	if len(args) == 1 && args[0] == "version" {
+		return r.version, nil
+	}
+	return r.mutationRecorder.run(dir, args...)
+}
+
+func TestClient_FeatureGating(t *testing.T) {
+	t.Run("too old", func(t *testing.T) {
+		r := &versionStub{version: "git version 2.16.4"}
+		c := &Client{r: r}
+
+		err := c.MoveWorktree("/repo-123", "/worktrees/repo-123")
+		var versionErr *VersionError
+		if !errors.As(err, &versionErr) || versionErr.Feature != FeatureWorktreeMove {
+			t.Fatalf("Expected a VersionError for git worktree move, got: %v", err)
+		}
+		if !strings.Contains(err.Error(), "git worktree move requires git >= 2.17 (found 2.16.4)") {
+			t.Errorf("Unexpected message: %v", err)
+		}
+		if err := c.RepairWorktrees(); !errors.As(err, &versionErr) {
+			t.Errorf("Expected a VersionError for git worktree repair, got: %v", err)
+		}
+		if len(r.mutations) != 0 {
+			t.Errorf("Expected no git command to run, got %v", r.mutations)
+		}
+	})
+
+	t.Run("new enough", func(t *testing.T) {
+		r := &versionStub{version: "git version 2.29.0"}
+		c := &Client{r: r}
+
+		if err := c.RepairWorktrees("/repo-123"); err != nil {
+			t.Fatalf("RepairWorktrees failed: %v", err)
+		}
+		if want := []string{"worktree repair /repo-123"}; len(r.mutations) != 1 || r.mutations[0] != want[0] {
+			t.Errorf("Expected %v to run, got %v", want, r.mutations)
+		}
+	})
+
+	t.Run("unreadable version", func(t *testing.T) {
+		c := &Client{r: &versionStub{version: "something else"}}
+		if !c.supports(FeatureWorktreeAddOrphan) {
+			t.Error("Expected an unreadable version not to lock features out")
+		}
+	})
+}
@@ -1,12 +1,74 @@
 package git
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
 )
 
+// ErrNotGitRepository is returned when an operation needs a git repository but
+// the current directory is not inside one.
+var ErrNotGitRepository = errors.New("not in a git repository")
+
+// ErrWorktreeNotFound matches (via errors.Is) the error returned when no
+// worktree corresponds to an issue number or branch name.
+var ErrWorktreeNotFound = errors.New("worktree not found")
+
+// WorktreeNotFoundError reports that no worktree matches Identifier, an issue
+// number or branch name.
+type WorktreeNotFoundError struct {
+	Identifier string
+}
+
+func (e *WorktreeNotFoundError) Error() string {
+	return fmt.Sprintf("worktree for %s not found", e.Identifier)
+}
+
+// Is makes errors.Is(err, ErrWorktreeNotFound) report true.
+func (e *WorktreeNotFoundError) Is(target error) bool {
+	return target == ErrWorktreeNotFound
+}
+
+// ErrWorktreeExists matches (via errors.Is) the error returned when a worktree
+// already exists for the issue gw was asked to start.
+var ErrWorktreeExists = errors.New("worktree already exists")
+
+// WorktreeExistsError reports that Issue already has a worktree at Path.
+type WorktreeExistsError struct {
+	Issue string
+	Path  string
+}
+
+func (e *WorktreeExistsError) Error() string {
+	return fmt.Sprintf("worktree for issue %s already exists at %s", e.Issue, e.Path)
+}
+
+// Is makes errors.Is(err, ErrWorktreeExists) report true.
+func (e *WorktreeExistsError) Is(target error) bool {
+	return target == ErrWorktreeExists
+}
+
+// ErrBranchNotFound matches (via errors.Is) the error returned when a branch
+// exists neither locally nor on the remote.
+var ErrBranchNotFound = errors.New("branch not found")
+
+// BranchNotFoundError reports that Branch exists neither locally nor on the
+// remote.
+type BranchNotFoundError struct {
+	Branch string
+}
+
+func (e *BranchNotFoundError) Error() string {
+	return fmt.Sprintf("branch '%s' does not exist in the repository", e.Branch)
+}
+
+// Is makes errors.Is(err, ErrBranchNotFound) report true.
+func (e *BranchNotFoundError) Is(target error) bool {
+	return target == ErrBranchNotFound
+}
+
 // WorktreeInfo represents information about a git worktree
 type WorktreeInfo struct {
 	Path       string
@@ -14,6 +76,18 @@ type WorktreeInfo struct {
 	Commit     string
 	IsDetached bool
 	IsCurrent  bool
+	IsLocked   bool // set by `git worktree lock`; git refuses to remove it
+	LockReason string
+	IsBare     bool // the bare repository itself, without a working tree
+	// IsPrunable is set when the worktree's directory is gone and
+	// `git worktree prune` would remove its administrative files.
+	IsPrunable     bool
+	PrunableReason string
+	// Ahead and Behind count the commits the worktree's HEAD has that the
+	// default branch lacks, and the reverse. ListWorktrees leaves them zero;
+	// gw list fills them in with AheadBehind.
+	Ahead  int
+	Behind int
 }
 
 // DetermineWorktreeNames determines the branch name and directory suffix based on input
@@ -33,12 +107,53 @@ func DetermineWorktreeNames(input string) (branchName, dirSuffix string) {
 	return branchName, dirSuffix
 }
 
+// ExtractIssueFromBranch returns the issue number or identifier a gw branch
+// was created for: the part before "/impl" for branches named by
+// DetermineWorktreeNames ("123/impl" -> "123", "hotfix/impl" -> "hotfix"), or
+// a numeric first component ("476/impl-migration-script" -> "476"). Other
+// branches, such as "main" or "feature/login", yield "".
+func ExtractIssueFromBranch(branch string) string {
+	if issue, ok := strings.CutSuffix(branch, "/impl"); ok && issue != "" && !strings.Contains(issue, "/") {
+		return issue
+	}
+	first, _, found := strings.Cut(branch, "/")
+	if !found || first == "" || strings.Trim(first, "0123456789") != "" {
+		return ""
+	}
+	return first
+}
+
 // ResolveWorktreePath derives the worktree directory path for a given
-// repository name and suffix, anchored as a sibling of the repository root:
-// `<repoRoot>/../<repoName>-<suffix>`. This is the single source of truth for
-// the worktree naming convention shared by creation, checkout and lookup.
-func ResolveWorktreePath(repoRoot, repoName, suffix string) string {
-	return filepath.Join(repoRoot, "..", fmt.Sprintf("%s-%s", repoName, suffix))
+// repository name and suffix. By default it is anchored as a sibling of the
+// repository root, `<repoRoot>/../<repoName>-<suffix>`; with a worktreeRoot
+// it goes into a per-repository directory below it,
+// `<worktreeRoot>/<repoName>/<repoName>-<suffix>`. This is the single source
+// of truth for the worktree naming convention shared by creation, checkout
+// and lookup.
+func ResolveWorktreePath(worktreeRoot, repoRoot, repoName, suffix string) string {
+	dirName := worktreeDirName(repoName, suffix)
+	if worktreeRoot != "" {
+		return filepath.Join(worktreeRoot, repoName, dirName)
+	}
+	return filepath.Join(repoRoot, "..", dirName)
+}
+
+// worktreeDirName is the directory name of a worktree, the same in both
+// placements.
+func worktreeDirName(repoName, suffix string) string {
+	return fmt.Sprintf("%s-%s", repoName, suffix)
+}
+
+// SetWorktreeRoot makes new worktrees go under dir, in a subdirectory per
+// repository, instead of next to the repository. An empty dir restores the
+// sibling placement.
+func (c *Client) SetWorktreeRoot(dir string) {
+	c.worktreeRoot = dir
+}
+
+// WorktreeRoot returns the directory set with SetWorktreeRoot.
+func (c *Client) WorktreeRoot() string {
+	return c.worktreeRoot
 }
 
 // ResolveBaseBranch resolves the base branch, checking local first, then remote
@@ -49,8 +164,8 @@ func (c *Client) ResolveBaseBranch(baseBranch string) (string, bool) {
 		return baseBranch, false
 	}
 
-	// If it already starts with origin/, check if it exists
-	if strings.HasPrefix(baseBranch, "origin/") {
+	// If it already starts with the remote name, check if it exists
+	if _, ok := CutRemotePrefix(baseBranch, c.Remote()); ok {
 		if c.remoteBranchExists(baseBranch) {
 			return baseBranch, true
 		}
@@ -58,7 +173,7 @@ func (c *Client) ResolveBaseBranch(baseBranch string) (string, bool) {
 	}
 
 	// Check if it exists as a remote branch
-	remoteBranch := "origin/" + baseBranch
+	remoteBranch := c.Remote() + "/" + baseBranch
 	if c.remoteBranchExists(remoteBranch) {
 		return remoteBranch, true
 	}
@@ -67,10 +182,65 @@ func (c *Client) ResolveBaseBranch(baseBranch string) (string, bool) {
 	return baseBranch, false
 }
 
+// AddOption changes how CreateWorktree, CreateTrackingWorktree and
+// CreateOrphanWorktree run git worktree add.
+type AddOption func(*addOptions)
+
+type addOptions struct {
+	lock       bool
+	lockReason string
+}
+
+// WithLock creates the worktree locked (git worktree add --lock), recording
+// reason unless it is empty, so git worktree prune, gw clean and gw relocate
+// leave it alone until it is unlocked.
+func WithLock(reason string) AddOption {
+	return func(o *addOptions) {
+		o.lock = true
+		o.lockReason = reason
+	}
+}
+
+// addFlags returns the git worktree add flags for opts.
+func (c *Client) addFlags(opts []AddOption) ([]string, error) {
+	var o addOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if !o.lock {
+		return nil, nil
+	}
+	if o.lockReason == "" {
+		return []string{"--lock"}, nil
+	}
+	if err := c.requireFeature(FeatureWorktreeAddLockReason); err != nil {
+		return nil, err
+	}
+	return []string{"--lock", "--reason", o.lockReason}, nil
+}
+
 // CreateWorktree creates a new git worktree
-func (c *Client) CreateWorktree(issueNumberOrBranch, baseBranch string) (string, error) {
+func (c *Client) CreateWorktree(issueNumberOrBranch, baseBranch string, opts ...AddOption) (string, error) {
+	return c.createBranchWorktree(issueNumberOrBranch, baseBranch, false, opts)
+}
+
+// CreateTrackingWorktree creates a new git worktree like CreateWorktree, with
+// remoteBranch (e.g. "origin/foo") as both the start point and the upstream
+// of the new branch.
+func (c *Client) CreateTrackingWorktree(issueNumberOrBranch, remoteBranch string, opts ...AddOption) (string, error) {
+	return c.createBranchWorktree(issueNumberOrBranch, remoteBranch, true, opts)
+}
+
+// createBranchWorktree creates the worktree and branch for issueNumberOrBranch
+// starting at startPoint. With track, startPoint is used as given and becomes
+// the branch's upstream; otherwise it is resolved as a base branch.
+func (c *Client) createBranchWorktree(issueNumberOrBranch, startPoint string, track bool, opts []AddOption) (string, error) {
 	if !c.IsGitRepository() {
-		return "", fmt.Errorf("not in a git repository")
+		return "", ErrNotGitRepository
+	}
+	addFlags, err := c.addFlags(opts)
+	if err != nil {
+		return "", err
 	}
 
 	repoName, err := c.GetOriginalRepositoryName()
@@ -87,15 +257,26 @@ func (c *Client) CreateWorktree(issueNumberOrBranch, baseBranch string) (string,
 	// Determine branch name and directory suffix
 	branchName, dirSuffix := DetermineWorktreeNames(issueNumberOrBranch)
 
-	// Create worktree directory path relative to repository root
-	worktreeDir := ResolveWorktreePath(repoRoot, repoName, dirSuffix)
+	worktreeDir := ResolveWorktreePath(c.worktreeRoot, repoRoot, repoName, dirSuffix)
 
-	// Resolve base branch (check local first, then remote)
-	resolvedBaseBranch, _ := c.ResolveBaseBranch(baseBranch)
+	args := append([]string{"worktree", "add"}, addFlags...)
+	if track {
+		args = append(args, "--track", "-b", branchName, worktreeDir, startPoint)
+	} else {
+		// Resolve base branch (check local first, then remote)
+		resolvedBaseBranch, _ := c.ResolveBaseBranch(startPoint)
+		args = append(args, worktreeDir, "-b", branchName, resolvedBaseBranch)
+	}
 
 	// Create the worktree
-	if err := c.r.runStreaming("", "worktree", "add", worktreeDir, "-b", branchName, resolvedBaseBranch); err != nil {
-		return "", fmt.Errorf("failed to create worktree: %w", err)
+	defer c.cache.invalidate()
+	if !c.skipMutation("", args...) {
+		if err := c.ensureWorktreeParent(worktreeDir); err != nil {
+			return "", err
+		}
+		if err := c.r.runStreaming("", args...); err != nil {
+			return "", fmt.Errorf("failed to create worktree: %w", err)
+		}
 	}
 
 	// Get absolute path
@@ -110,7 +291,7 @@ func (c *Client) CreateWorktree(issueNumberOrBranch, baseBranch string) (string,
 // RemoveWorktree removes a git worktree by issue number or branch name
 func (c *Client) RemoveWorktree(issueNumberOrBranch string) error {
 	if !c.IsGitRepository() {
-		return fmt.Errorf("not in a git repository")
+		return ErrNotGitRepository
 	}
 
 	repoName, err := c.GetOriginalRepositoryName()
@@ -127,18 +308,21 @@ func (c *Client) RemoveWorktree(issueNumberOrBranch string) error {
 	// Determine directory suffix
 	_, dirSuffix := DetermineWorktreeNames(issueNumberOrBranch)
 
-	// Create worktree directory path relative to repository root
-	worktreeDir := ResolveWorktreePath(repoRoot, repoName, dirSuffix)
+	worktreeDir := ResolveWorktreePath(c.worktreeRoot, repoRoot, repoName, dirSuffix)
 	return c.RemoveWorktreeByPath(worktreeDir)
 }
 
 // RemoveWorktreeByPath removes a git worktree by its path
 func (c *Client) RemoveWorktreeByPath(worktreePath string) error {
 	if !c.IsGitRepository() {
-		return fmt.Errorf("not in a git repository")
+		return ErrNotGitRepository
 	}
 
 	// Remove the worktree
+	defer c.cache.invalidate()
+	if c.skipMutation("", "worktree", "remove", worktreePath) {
+		return nil
+	}
 	if err := c.r.runStreaming("", "worktree", "remove", worktreePath); err != nil {
 		return fmt.Errorf("failed to remove worktree: %w", err)
 	}
@@ -146,44 +330,83 @@ func (c *Client) RemoveWorktreeByPath(worktreePath string) error {
 	return nil
 }
 
-// ListWorktrees returns a list of all worktrees
+// MoveWorktree moves the worktree at worktreePath to newPath with `git
+// worktree move`, creating newPath's parent directory first. git refuses to
+// move the main worktree or a locked one.
+func (c *Client) MoveWorktree(worktreePath, newPath string) error {
+	if err := c.requireFeature(FeatureWorktreeMove); err != nil {
+		return err
+	}
+	defer c.cache.invalidate()
+	if c.skipMutation("", "worktree", "move", worktreePath, newPath) {
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(newPath), 0o755); err != nil {
+		return fmt.Errorf("failed to create worktree directory: %w", err)
+	}
+	if _, err := c.r.runCombined("", "worktree", "move", worktreePath, newPath); err != nil {
+		return fmt.Errorf("failed to move worktree: %w", err)
+	}
+	return nil
+}
+
+// RepairWorktrees runs `git worktree repair` for worktreePaths, or for every
+// registered worktree when none are given. Passing the new location of a
+// worktree directory that was moved by hand re-links it with the repository.
+func (c *Client) RepairWorktrees(worktreePaths ...string) error {
+	if err := c.requireFeature(FeatureWorktreeRepair); err != nil {
+		return err
+	}
+	defer c.cache.invalidate()
+	args := append([]string{"worktree", "repair"}, worktreePaths...)
+	if c.skipMutation("", args...) {
+		return nil
+	}
+	if _, err := c.r.runCombined("", args...); err != nil {
+		return fmt.Errorf("failed to repair worktrees: %w", err)
+	}
+	return nil
+}
+
+// PruneWorktrees runs `git worktree prune`, dropping the registration of every
+// worktree whose directory no longer exists.
+func (c *Client) PruneWorktrees() error {
+	defer c.cache.invalidate()
+	args := []string{"worktree", "prune"}
+	if c.skipMutation("", args...) {
+		return nil
+	}
+	if _, err := c.r.runCombined("", args...); err != nil {
+		return fmt.Errorf("failed to prune worktrees: %w", err)
+	}
+	return nil
+}
+
+// ListWorktrees returns a list of all worktrees. The list is cached until the
+// next mutating call on c.
 func (c *Client) ListWorktrees() ([]WorktreeInfo, error) {
+	return c.cache.cachedWorktrees(c.listWorktrees)
+}
+
+func (c *Client) listWorktrees() ([]WorktreeInfo, error) {
 	output, err := c.r.run("", "worktree", "list", "--porcelain")
 	if err != nil {
+		if !c.IsGitRepository() {
+			return nil, ErrNotGitRepository
+		}
 		return nil, fmt.Errorf("failed to list worktrees: %w", err)
 	}
 
-	var worktrees []WorktreeInfo
-	lines := strings.Split(output, "\n")
-	var current WorktreeInfo
+	worktrees := parseWorktreeList(output)
 
-	for _, line := range lines {
-		if strings.HasPrefix(line, "worktree ") {
-			if current.Path != "" {
-				worktrees = append(worktrees, current)
-			}
-			current = WorktreeInfo{
-				Path: strings.TrimPrefix(line, "worktree "),
-			}
-		} else if strings.HasPrefix(line, "HEAD ") {
-			current.Commit = strings.TrimPrefix(line, "HEAD ")
-		} else if strings.HasPrefix(line, "branch ") {
-			branch := strings.TrimPrefix(line, "branch ")
-			// Remove refs/heads/ prefix if present
-			branch = strings.TrimPrefix(branch, "refs/heads/")
-			current.Branch = branch
-		} else if line == "detached" {
-			current.IsDetached = true
-		} else if line == "" && current.Path != "" {
-			worktrees = append(worktrees, current)
-			current = WorktreeInfo{}
+	// A worktree in the middle of a rebase is detached; report the branch
+	// being rebased so callers still see whose worktree it is.
+	for i := range worktrees {
+		if worktrees[i].IsDetached {
+			worktrees[i].Branch = c.rebasingBranch(worktrees[i].Path)
 		}
 	}
 
-	if current.Path != "" {
-		worktrees = append(worktrees, current)
-	}
-
 	// Mark current worktree
 	cwd, err := os.Getwd()
 	if err == nil {
@@ -200,61 +423,354 @@ func (c *Client) ListWorktrees() ([]WorktreeInfo, error) {
 	return worktrees, nil
 }
 
+// parseWorktreeList parses the output of `git worktree list --porcelain`.
+// Each worktree is a record of "label[ value]" lines ended by a blank line;
+// the record's first line is always "worktree <path>". Labels gw does not know
+// (added by newer git) are skipped, and a record without a worktree line is
+// dropped.
+func parseWorktreeList(output string) []WorktreeInfo {
+	var worktrees []WorktreeInfo
+	var current WorktreeInfo
+	flush := func() {
+		if current.Path != "" {
+			worktrees = append(worktrees, current)
+		}
+		current = WorktreeInfo{}
+	}
+
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimSuffix(line, "\r")
+		if line == "" {
+			flush()
+			continue
+		}
+
+		label, value, _ := strings.Cut(line, " ")
+		switch label {
+		case "worktree":
+			// Tolerate a missing blank line between records.
+			flush()
+			current.Path = value
+		case "HEAD":
+			current.Commit = value
+		case "branch":
+			current.Branch = strings.TrimPrefix(value, "refs/heads/")
+		case "detached":
+			current.IsDetached = true
+		case "bare":
+			current.IsBare = true
+		case "locked":
+			current.IsLocked = true
+			current.LockReason = value
+		case "prunable":
+			current.IsPrunable = true
+			current.PrunableReason = value
+		}
+	}
+	flush()
+	return worktrees
+}
+
+// BranchConflict is a branch that more than one worktree has checked out.
+type BranchConflict struct {
+	Branch string
+	Paths  []string
+}
+
+// FindBranchConflicts returns the branches that appear on more than one of
+// worktrees, in the order they first appear. Git refuses to check out a
+// branch twice, but a worktree repaired or edited by hand can still end up
+// sharing its branch with another one.
+func FindBranchConflicts(worktrees []WorktreeInfo) []BranchConflict {
+	paths := map[string][]string{}
+	var order []string
+	for _, wt := range worktrees {
+		if wt.Branch == "" {
+			continue
+		}
+		if _, seen := paths[wt.Branch]; !seen {
+			order = append(order, wt.Branch)
+		}
+		paths[wt.Branch] = append(paths[wt.Branch], wt.Path)
+	}
+
+	var conflicts []BranchConflict
+	for _, branch := range order {
+		if len(paths[branch]) > 1 {
+			conflicts = append(conflicts, BranchConflict{Branch: branch, Paths: paths[branch]})
+		}
+	}
+	return conflicts
+}
+
 // GetWorktreeForIssue finds a worktree for a specific issue number or branch name
 func (c *Client) GetWorktreeForIssue(issueNumberOrBranch string) (*WorktreeInfo, error) {
 	repoName, err := c.GetOriginalRepositoryName()
 	if err != nil {
+		// Only check on failure so the common path costs no extra git call.
+		if !c.IsGitRepository() {
+			return nil, ErrNotGitRepository
+		}
 		return nil, err
 	}
 
 	// Determine the branch name and directory suffix
 	branchName, dirSuffix := DetermineWorktreeNames(issueNumberOrBranch)
 
-	targetPath := fmt.Sprintf("%s-%s", repoName, dirSuffix)
+	targetDir := worktreeDirName(repoName, dirSuffix)
 
 	worktrees, err := c.ListWorktrees()
 	if err != nil {
 		return nil, err
 	}
 
-	// Match by branch name first, then fall back to the computed directory path.
-	// Matching by branch name lets the same worktree be found whether the user
-	// passes the issue number ("527") or the full branch name ("527/impl"),
-	// which is what shell completion suggests.
+	// Match by branch name first. That lets the same worktree be found whether
+	// the user passes the issue number ("527") or the full branch name
+	// ("527/impl"), which is what shell completion suggests.
 	for _, wt := range worktrees {
-		if wt.Branch == branchName || strings.Contains(wt.Path, targetPath) {
+		if wt.Branch == branchName {
 			return &wt, nil
 		}
 	}
 
-	return nil, fmt.Errorf("worktree for %s not found", issueNumberOrBranch)
+	// Then by the issue the branch was created for ("476" finds
+	// "476/impl-migration-script") or by the directory name, which is the same
+	// whether the worktree sits next to the repository or under worktree_root.
+	// Both are compared exactly so that issue 12 never finds the worktree of
+	// issue 120.
+	issue := ""
+	if !strings.Contains(issueNumberOrBranch, "/") {
+		issue = issueNumberOrBranch
+	}
+	for _, wt := range worktrees {
+		if (issue != "" && ExtractIssueFromBranch(wt.Branch) == issue) || filepath.Base(wt.Path) == targetDir {
+			return &wt, nil
+		}
+	}
+
+	return nil, &WorktreeNotFoundError{Identifier: issueNumberOrBranch}
 }
 
-// CreateWorktreeFromBranch creates a new git worktree from an existing branch
+// CreateWorktreeFromBranch creates a new git worktree from an existing branch.
+// A remote branch gets a new local branch, targetBranch, tracking it.
 func (c *Client) CreateWorktreeFromBranch(worktreePath, sourceBranch, targetBranch string) error {
+	return c.createWorktreeFromBranch(worktreePath, sourceBranch, targetBranch, true)
+}
+
+// CreateUntrackedWorktreeFromBranch is CreateWorktreeFromBranch without an
+// upstream: the local branch created for a remote branch does not track it.
+func (c *Client) CreateUntrackedWorktreeFromBranch(worktreePath, sourceBranch, targetBranch string) error {
+	return c.createWorktreeFromBranch(worktreePath, sourceBranch, targetBranch, false)
+}
+
+func (c *Client) createWorktreeFromBranch(worktreePath, sourceBranch, targetBranch string, track bool) error {
 	if !c.IsGitRepository() {
-		return fmt.Errorf("not in a git repository")
+		return ErrNotGitRepository
 	}
 
-	// Check if source branch starts with origin/
-	isRemoteBranch := strings.HasPrefix(sourceBranch, "origin/")
+	defer c.cache.invalidate()
+
+	// Check if source branch is a remote-tracking branch
+	_, isRemoteBranch := c.CutRemoteBranch(sourceBranch)
 
-	var err error
+	// For local branches, just check it out
+	args := []string{"worktree", "add", worktreePath, sourceBranch}
 	if isRemoteBranch {
 		// For remote branches, create a new local branch tracking the remote
-		err = c.r.runStreaming("", "worktree", "add", worktreePath, "-b", targetBranch, sourceBranch)
-	} else {
-		// For local branches, just check it out
-		err = c.r.runStreaming("", "worktree", "add", worktreePath, sourceBranch)
+		args = []string{"worktree", "add", worktreePath, "-b", targetBranch, sourceBranch}
+		if !track {
+			args = []string{"worktree", "add", "--no-track", worktreePath, "-b", targetBranch, sourceBranch}
+		}
+	}
+	if c.skipMutation("", args...) {
+		return nil
 	}
 
+	if err := c.ensureWorktreeParent(worktreePath); err != nil {
+		return err
+	}
+	if err := c.r.runStreaming("", args...); err != nil {
+		return fmt.Errorf("failed to create worktree: %w", err)
+	}
+
+	return nil
+}
+
+// CreateWorktreeAt creates a new git worktree at commit. With a newBranch it
+// is created there and checked out; otherwise the worktree's HEAD is
+// detached at commit.
+func (c *Client) CreateWorktreeAt(worktreePath, commit, newBranch string) error {
+	if !c.IsGitRepository() {
+		return ErrNotGitRepository
+	}
+
+	defer c.cache.invalidate()
+
+	args := []string{"worktree", "add", "--detach", worktreePath, commit}
+	if newBranch != "" {
+		args = []string{"worktree", "add", worktreePath, "-b", newBranch, commit}
+	}
+	if c.skipMutation("", args...) {
+		return nil
+	}
+
+	if err := c.ensureWorktreeParent(worktreePath); err != nil {
+		return err
+	}
+	if err := c.r.runStreaming("", args...); err != nil {
+		return fmt.Errorf("failed to create worktree: %w", err)
+	}
+
+	return nil
+}
+
+// CreateOrphanWorktree creates a new git worktree on branch, a new orphan
+// branch with no history and an empty tree, e.g. for gh-pages or a docs
+// branch. The directory is derived from the branch name as for a branch given
+// to CreateWorktree. Git versions without git worktree add --orphan get an
+// empty worktree switched to the orphan branch instead.
+func (c *Client) CreateOrphanWorktree(branch string, opts ...AddOption) (string, error) {
+	if !c.IsGitRepository() {
+		return "", ErrNotGitRepository
+	}
+	addFlags, err := c.addFlags(opts)
 	if err != nil {
+		return "", err
+	}
+	if c.localBranchExists(branch) {
+		return "", fmt.Errorf("branch %s already exists", branch)
+	}
+
+	repoName, err := c.GetOriginalRepositoryName()
+	if err != nil {
+		return "", err
+	}
+	repoRoot, err := c.r.run("", "rev-parse", "--show-toplevel")
+	if err != nil {
+		return "", fmt.Errorf("failed to get repository root: %w", err)
+	}
+	worktreeDir := ResolveWorktreePath(c.worktreeRoot, repoRoot, repoName, SanitizeBranchNameForDirectory(branch))
+
+	defer c.cache.invalidate()
+	if c.supports(FeatureWorktreeAddOrphan) {
+		args := append([]string{"worktree", "add"}, addFlags...)
+		args = append(args, "--orphan", "-b", branch, worktreeDir)
+		if !c.skipMutation("", args...) {
+			if err := c.ensureWorktreeParent(worktreeDir); err != nil {
+				return "", err
+			}
+			if err := c.r.runStreaming("", args...); err != nil {
+				return "", fmt.Errorf("failed to create worktree: %w", err)
+			}
+		}
+	} else if err := c.emulateOrphanWorktree(worktreeDir, branch, addFlags); err != nil {
+		return "", err
+	}
+
+	absPath, err := filepath.Abs(worktreeDir)
+	if err != nil {
+		return worktreeDir, nil
+	}
+	return absPath, nil
+}
+
+// emulateOrphanWorktree creates worktreeDir on the orphan branch without
+// git worktree add --orphan: an unpopulated detached worktree is added and
+// switched to the new branch, which leaves its index and tree empty. A
+// worktree that cannot be switched is removed again.
+func (c *Client) emulateOrphanWorktree(worktreeDir, branch string, addFlags []string) error {
+	if err := c.requireFeature(FeatureSwitchOrphan); err != nil {
+		return err
+	}
+	addArgs := append([]string{"worktree", "add"}, addFlags...)
+	addArgs = append(addArgs, "--detach", "--no-checkout", worktreeDir)
+	switchArgs := []string{"switch", "--orphan", branch}
+	if c.skipMutation("", addArgs...) {
+		c.skipMutation(worktreeDir, switchArgs...)
+		return nil
+	}
+
+	if err := c.ensureWorktreeParent(worktreeDir); err != nil {
+		return err
+	}
+	if err := c.r.runStreaming("", addArgs...); err != nil {
 		return fmt.Errorf("failed to create worktree: %w", err)
 	}
+	if _, err := c.r.runCombined(worktreeDir, switchArgs...); err != nil {
+		_, _ = c.r.run("", "worktree", "remove", "--force", worktreeDir)
+		return fmt.Errorf("failed to create orphan branch %s: %w", branch, err)
+	}
+	return nil
+}
+
+// ensureWorktreeParent creates the per-repository directory under the
+// worktree root that worktreeDir goes into. Sibling placement needs nothing.
+func (c *Client) ensureWorktreeParent(worktreeDir string) error {
+	if c.worktreeRoot == "" {
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(worktreeDir), 0o755); err != nil {
+		return fmt.Errorf("failed to create worktree directory: %w", err)
+	}
+	return nil
+}
+
+// ApplyStash applies the most recent stash entry to the worktree at
+// worktreePath. Stashes are shared by every worktree of a repository, so a
+// stash made in the main checkout can be applied inside a fresh worktree. The
+// entry is kept (apply, not pop) so a conflicting apply never loses it.
+func (c *Client) ApplyStash(worktreePath string) error {
+	if c.skipMutation(worktreePath, "stash", "apply") {
+		return nil
+	}
+	if _, err := c.r.runCombined(worktreePath, "stash", "apply"); err != nil {
+		return fmt.Errorf("failed to apply stash: %w", err)
+	}
+	return nil
+}
 
+// ApplyPatch applies patchFile to the working tree at worktreePath with
+// `git apply`. A relative patchFile is resolved against the current directory,
+// not the worktree.
+func (c *Client) ApplyPatch(worktreePath, patchFile string) error {
+	absPatchFile, err := filepath.Abs(patchFile)
+	if err != nil {
+		return fmt.Errorf("failed to resolve patch file %s: %w", patchFile, err)
+	}
+	if c.skipMutation(worktreePath, "apply", absPatchFile) {
+		return nil
+	}
+	if _, err := c.r.runCombined(worktreePath, "apply", absPatchFile); err != nil {
+		return fmt.Errorf("failed to apply patch %s: %w", patchFile, err)
+	}
 	return nil
 }
 
+// FormatPatch writes the commits HEAD of the worktree at worktreePath has
+// that base does not into outDir, one mbox patch per commit, with
+// `git format-patch -o <outDir> <base>..HEAD`. It returns the paths of the
+// files written, oldest commit first; none when there is nothing to export.
+func (c *Client) FormatPatch(worktreePath, base, outDir string) ([]string, error) {
+	absOutDir, err := filepath.Abs(outDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve %s: %w", outDir, err)
+	}
+	if c.skipMutation(worktreePath, "format-patch", "-o", absOutDir, base+"..HEAD") {
+		return nil, nil
+	}
+	out, err := c.r.run(worktreePath, "format-patch", "-o", absOutDir, base+"..HEAD")
+	if err != nil {
+		return nil, fmt.Errorf("failed to format patches since %s: %w", base, err)
+	}
+	var files []string
+	for _, line := range strings.Split(out, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			files = append(files, line)
+		}
+	}
+	return files, nil
+}
+
 // RunCommand executes a command in the current directory
 func (c *Client) RunCommand(command string) error {
 	return c.r.runShell(command)
@@ -1,9 +1,12 @@
 package git
 
 import (
+	"errors"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"reflect"
+	"slices"
 	"strings"
 	"testing"
 )
@@ -115,6 +118,201 @@ func TestCreateWorktree(t *testing.T) {
 	}
 }
 
+func TestCreateWorktree_Placement(t *testing.T) {
+	// setupPlacementRepo creates <tempDir>/myrepo with one commit and chdirs
+	// into it.
+	setupPlacementRepo := func(t *testing.T) (tempDir, repoDir string) {
+		t.Helper()
+		tempDir, err := filepath.EvalSymlinks(t.TempDir())
+		if err != nil {
+			t.Fatalf("EvalSymlinks failed: %v", err)
+		}
+		repoDir = filepath.Join(tempDir, "myrepo")
+		if err := os.Mkdir(repoDir, 0755); err != nil {
+			t.Fatalf("failed to create repo dir: %v", err)
+		}
+		runGitCommand(t, repoDir, "init")
+		runGitCommand(t, repoDir, "config", "user.email", "test@example.com")
+		runGitCommand(t, repoDir, "config", "user.name", "Test User")
+		runGitCommand(t, repoDir, "commit", "--allow-empty", "-m", "Initial commit")
+
+		originalDir, _ := os.Getwd()
+		t.Cleanup(func() { _ = os.Chdir(originalDir) })
+		if err := os.Chdir(repoDir); err != nil {
+			t.Fatalf("failed to change dir: %v", err)
+		}
+		return tempDir, repoDir
+	}
+
+	tests := []struct {
+		name     string
+		rootDir  string // relative to the temp dir; empty keeps sibling placement
+		expected string // relative to the temp dir
+	}{
+		{name: "sibling by default", expected: "myrepo-123"},
+		{name: "under the worktree root", rootDir: "worktrees", expected: "worktrees/myrepo/myrepo-123"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tempDir, repoDir := setupPlacementRepo(t)
+			c := NewClient()
+			if tt.rootDir != "" {
+				c.SetWorktreeRoot(filepath.Join(tempDir, tt.rootDir))
+			}
+			expected := filepath.Join(tempDir, tt.expected)
+
+			path, err := c.CreateWorktree("123", getDefaultBranchName(t, repoDir))
+			if err != nil {
+				t.Fatalf("CreateWorktree failed: %v", err)
+			}
+			if path != expected {
+				t.Errorf("CreateWorktree path = %s, want %s", path, expected)
+			}
+			if _, err := os.Stat(filepath.Join(expected, ".git")); err != nil {
+				t.Errorf("expected a worktree at %s: %v", expected, err)
+			}
+
+			wt, err := c.GetWorktreeForIssue("123")
+			if err != nil {
+				t.Fatalf("GetWorktreeForIssue failed: %v", err)
+			}
+			if wt.Path != expected {
+				t.Errorf("GetWorktreeForIssue path = %s, want %s", wt.Path, expected)
+			}
+
+			if err := c.RemoveWorktree("123"); err != nil {
+				t.Fatalf("RemoveWorktree failed: %v", err)
+			}
+			if _, err := os.Stat(expected); !os.IsNotExist(err) {
+				t.Errorf("expected %s to be removed, stat error: %v", expected, err)
+			}
+		})
+	}
+
+	t.Run("finds sibling worktrees after the root is set", func(t *testing.T) {
+		tempDir, repoDir := setupPlacementRepo(t)
+		sibling := filepath.Join(tempDir, "myrepo-456")
+		runGitCommand(t, repoDir, "worktree", "add", "--detach", sibling)
+
+		c := NewClient()
+		c.SetWorktreeRoot(filepath.Join(tempDir, "worktrees"))
+		wt, err := c.GetWorktreeForIssue("456")
+		if err != nil {
+			t.Fatalf("GetWorktreeForIssue failed: %v", err)
+		}
+		if wt.Path != sibling {
+			t.Errorf("GetWorktreeForIssue path = %s, want %s", wt.Path, sibling)
+		}
+	})
+}
+
+func TestCreateTrackingWorktree(t *testing.T) {
+	// origin is a bare repository holding the default branch and foo; the
+	// worktree directory is created next to repoDir, inside the temp dir.
+	tempDir := t.TempDir()
+	remoteDir := filepath.Join(tempDir, "remote.git")
+	repoDir := filepath.Join(tempDir, "test-repo")
+	for _, dir := range []string{remoteDir, repoDir} {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatalf("failed to create %s: %v", dir, err)
+		}
+	}
+
+	runGitCommand(t, remoteDir, "init", "--bare")
+	runGitCommand(t, repoDir, "init")
+	runGitCommand(t, repoDir, "config", "user.email", "test@example.com")
+	runGitCommand(t, repoDir, "config", "user.name", "Test User")
+	if err := os.WriteFile(filepath.Join(repoDir, "test.txt"), []byte("test"), 0644); err != nil {
+		t.Fatalf("failed to create file: %v", err)
+	}
+	runGitCommand(t, repoDir, "add", "test.txt")
+	runGitCommand(t, repoDir, "commit", "-m", "Initial commit")
+	defaultBranch := getDefaultBranchName(t, repoDir)
+	runGitCommand(t, repoDir, "remote", "add", "origin", remoteDir)
+	runGitCommand(t, repoDir, "push", "origin", defaultBranch, defaultBranch+":foo")
+	runGitCommand(t, repoDir, "fetch", "origin")
+
+	originalDir, _ := os.Getwd()
+	t.Cleanup(func() { _ = os.Chdir(originalDir) })
+	if err := os.Chdir(repoDir); err != nil {
+		t.Fatalf("failed to change dir: %v", err)
+	}
+
+	worktreePath, err := CreateTrackingWorktree("feature/foo", "origin/foo")
+	if err != nil {
+		t.Fatalf("CreateTrackingWorktree failed: %v", err)
+	}
+	t.Cleanup(func() { _ = exec.Command("git", "worktree", "remove", "--force", worktreePath).Run() })
+
+	if !strings.HasSuffix(worktreePath, "feature-foo") {
+		t.Errorf("Expected worktree path to end with feature-foo, got %s", worktreePath)
+	}
+
+	cmd := exec.Command("git", "rev-parse", "--abbrev-ref", "feature/foo@{upstream}")
+	cmd.Dir = repoDir
+	output, err := cmd.Output()
+	if err != nil {
+		t.Fatalf("feature/foo has no upstream: %v", err)
+	}
+	if upstream := strings.TrimSpace(string(output)); upstream != "origin/foo" {
+		t.Errorf("Expected upstream origin/foo, got %q", upstream)
+	}
+
+	if _, err := CreateTrackingWorktree("feature/bar", "origin/missing"); err == nil {
+		t.Error("Expected error when tracking a missing remote branch")
+	}
+}
+
+func TestCreateOrphanWorktree(t *testing.T) {
+	repoDir := filepath.Join(t.TempDir(), "test-repo")
+	if err := os.MkdirAll(repoDir, 0755); err != nil {
+		t.Fatalf("failed to create %s: %v", repoDir, err)
+	}
+	runGitCommand(t, repoDir, "init")
+	runGitCommand(t, repoDir, "config", "user.email", "test@example.com")
+	runGitCommand(t, repoDir, "config", "user.name", "Test User")
+	if err := os.WriteFile(filepath.Join(repoDir, "test.txt"), []byte("test"), 0644); err != nil {
+		t.Fatalf("failed to create file: %v", err)
+	}
+	runGitCommand(t, repoDir, "add", "test.txt")
+	runGitCommand(t, repoDir, "commit", "-m", "Initial commit")
+
+	originalDir, _ := os.Getwd()
+	t.Cleanup(func() { _ = os.Chdir(originalDir) })
+	if err := os.Chdir(repoDir); err != nil {
+		t.Fatalf("failed to change dir: %v", err)
+	}
+
+	worktreePath, err := NewClient().CreateOrphanWorktree("gh-pages")
+	if err != nil {
+		t.Fatalf("CreateOrphanWorktree failed: %v", err)
+	}
+	if filepath.Base(worktreePath) != "test-repo-gh-pages" {
+		t.Errorf("Expected worktree directory test-repo-gh-pages, got %s", worktreePath)
+	}
+	if _, err := os.Stat(filepath.Join(worktreePath, "test.txt")); !os.IsNotExist(err) {
+		t.Errorf("Expected an empty worktree, stat error for test.txt: %v", err)
+	}
+	if branch, _ := exec.Command("git", "-C", worktreePath, "branch", "--show-current").Output(); strings.TrimSpace(string(branch)) != "gh-pages" {
+		t.Errorf("Expected the worktree on gh-pages, got %q", branch)
+	}
+
+	// The first commit on the orphan branch is a root commit.
+	runGitCommand(t, worktreePath, "commit", "--allow-empty", "-m", "Start gh-pages")
+	out, err := exec.Command("git", "-C", worktreePath, "rev-list", "--parents", "-n", "1", "HEAD").Output()
+	if err != nil {
+		t.Fatalf("rev-list failed: %v", err)
+	}
+	if fields := strings.Fields(string(out)); len(fields) != 1 {
+		t.Errorf("Expected the orphan branch's commit to have no parent, got %q", out)
+	}
+
+	if _, err := NewClient().CreateOrphanWorktree("gh-pages"); err == nil || !strings.Contains(err.Error(), "already exists") {
+		t.Errorf("Expected an error for an existing branch, got: %v", err)
+	}
+}
+
 func TestDetermineWorktreeNames(t *testing.T) {
 	tests := []struct {
 		name               string
@@ -169,13 +367,36 @@ func TestDetermineWorktreeNames(t *testing.T) {
 	}
 }
 
+func TestExtractIssueFromBranch(t *testing.T) {
+	tests := []struct {
+		branch string
+		want   string
+	}{
+		{branch: "123/impl", want: "123"},
+		{branch: "hotfix/impl", want: "hotfix"},
+		{branch: "476/impl-migration-script", want: "476"},
+		{branch: "main", want: ""},
+		{branch: "feature/new-feature", want: ""},
+		{branch: "bugfix/issue-789/impl", want: ""},
+		{branch: "/impl", want: ""},
+		{branch: "", want: ""},
+	}
+
+	for _, tt := range tests {
+		if got := ExtractIssueFromBranch(tt.branch); got != tt.want {
+			t.Errorf("ExtractIssueFromBranch(%q) = %q, want %q", tt.branch, got, tt.want)
+		}
+	}
+}
+
 func TestResolveWorktreePath(t *testing.T) {
 	tests := []struct {
-		name     string
-		repoRoot string
-		repoName string
-		suffix   string
-		expected string
+		name         string
+		worktreeRoot string
+		repoRoot     string
+		repoName     string
+		suffix       string
+		expected     string
 	}{
 		{
 			name:     "issue suffix",
@@ -198,14 +419,22 @@ func TestResolveWorktreePath(t *testing.T) {
 			suffix:   "123",
 			expected: "/home/user/projects/myrepo-123",
 		},
+		{
+			name:         "under the worktree root",
+			worktreeRoot: "/home/user/worktrees",
+			repoRoot:     "/home/user/projects/myrepo",
+			repoName:     "myrepo",
+			suffix:       "123",
+			expected:     "/home/user/worktrees/myrepo/myrepo-123",
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got := ResolveWorktreePath(tt.repoRoot, tt.repoName, tt.suffix)
+			got := ResolveWorktreePath(tt.worktreeRoot, tt.repoRoot, tt.repoName, tt.suffix)
 			if got != tt.expected {
-				t.Errorf("ResolveWorktreePath(%q, %q, %q) = %q, want %q",
-					tt.repoRoot, tt.repoName, tt.suffix, got, tt.expected)
+				t.Errorf("ResolveWorktreePath(%q, %q, %q, %q) = %q, want %q",
+					tt.worktreeRoot, tt.repoRoot, tt.repoName, tt.suffix, got, tt.expected)
 			}
 		})
 	}
@@ -266,6 +495,102 @@ func TestListWorktrees(t *testing.T) {
 	})
 }
 
+func TestParseWorktreeList(t *testing.T) {
+	tests := []struct {
+		name      string
+		porcelain string
+		want      []WorktreeInfo
+	}{
+		{
+			name: "bare repository with a locked and a prunable worktree",
+			porcelain: `worktree /srv/repo.git
+bare
+
+worktree /srv/repo-123
+HEAD 1111111111111111111111111111111111111111
+branch refs/heads/123/impl
+locked on a USB drive
+
+worktree /srv/repo-456
+HEAD 2222222222222222222222222222222222222222
+branch refs/heads/456/impl
+locked
+
+worktree /tmp/gone
+HEAD 3333333333333333333333333333333333333333
+detached
+prunable gitdir file points to non-existent location
+`,
+			want: []WorktreeInfo{
+				{Path: "/srv/repo.git", IsBare: true},
+				{Path: "/srv/repo-123", Commit: "1111111111111111111111111111111111111111", Branch: "123/impl", IsLocked: true, LockReason: "on a USB drive"},
+				{Path: "/srv/repo-456", Commit: "2222222222222222222222222222222222222222", Branch: "456/impl", IsLocked: true},
+				{Path: "/tmp/gone", Commit: "3333333333333333333333333333333333333333", IsDetached: true, IsPrunable: true, PrunableReason: "gitdir file points to non-existent location"},
+			},
+		},
+		{
+			name: "unknown labels, CRLF, paths with spaces and a missing separator",
+			porcelain: "worktree /home/me/my repo\r\nHEAD abc\r\nbranch refs/heads/main\r\nfuture-field some value\r\n" +
+				"worktree /home/me/my repo-7\nHEAD def\nbranch refs/heads/7/impl\n\n\n",
+			want: []WorktreeInfo{
+				{Path: "/home/me/my repo", Commit: "abc", Branch: "main"},
+				{Path: "/home/me/my repo-7", Commit: "def", Branch: "7/impl"},
+			},
+		},
+		{
+			name:      "record without a worktree line is dropped",
+			porcelain: "HEAD abc\nbranch refs/heads/main\n\nworktree /repo\nHEAD abc\n",
+			want:      []WorktreeInfo{{Path: "/repo", Commit: "abc"}},
+		},
+		{
+			name:      "empty output",
+			porcelain: "",
+			want:      nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parseWorktreeList(tt.porcelain); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseWorktreeList() =\n%+v\nwant\n%+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseWorktreeList_BranchConflicts(t *testing.T) {
+	porcelain := `worktree /repo
+HEAD 1111111111111111111111111111111111111111
+branch refs/heads/main
+
+worktree /repo-123
+HEAD 2222222222222222222222222222222222222222
+branch refs/heads/123/impl
+
+worktree /moved/repo-123
+HEAD 2222222222222222222222222222222222222222
+branch refs/heads/123/impl
+
+worktree /repo-detached
+HEAD 3333333333333333333333333333333333333333
+detached
+`
+	worktrees := parseWorktreeList(porcelain)
+	if len(worktrees) != 4 {
+		t.Fatalf("Expected 4 worktrees, got %d: %+v", len(worktrees), worktrees)
+	}
+
+	conflicts := FindBranchConflicts(worktrees)
+	want := []BranchConflict{{Branch: "123/impl", Paths: []string{"/repo-123", "/moved/repo-123"}}}
+	if !reflect.DeepEqual(conflicts, want) {
+		t.Errorf("FindBranchConflicts = %+v, want %+v", conflicts, want)
+	}
+
+	if conflicts := FindBranchConflicts(worktrees[:2]); len(conflicts) != 0 {
+		t.Errorf("Expected no conflicts without duplicates, got %+v", conflicts)
+	}
+}
+
 func TestRemoveWorktree(t *testing.T) {
 	t.Run("removes worktree successfully", func(t *testing.T) {
 		// Save and restore working directory first
@@ -356,8 +681,8 @@ func TestRemoveWorktree(t *testing.T) {
 		if err == nil {
 			t.Error("expected error when not in git repository")
 		}
-		if !strings.Contains(err.Error(), "not in a git repository") {
-			t.Errorf("expected 'not in a git repository' error, got: %v", err)
+		if !errors.Is(err, ErrNotGitRepository) {
+			t.Errorf("expected ErrNotGitRepository, got: %v", err)
 		}
 	})
 }
@@ -550,6 +875,67 @@ func TestGetWorktreeForIssue(t *testing.T) {
 	})
 }
 
+func TestGetWorktreeForIssue_ExactMatch(t *testing.T) {
+	tempDir, err := filepath.EvalSymlinks(t.TempDir())
+	if err != nil {
+		t.Fatalf("EvalSymlinks failed: %v", err)
+	}
+	repoDir := filepath.Join(tempDir, "myrepo")
+	if err := os.Mkdir(repoDir, 0755); err != nil {
+		t.Fatalf("failed to create repo dir: %v", err)
+	}
+	runGitCommand(t, repoDir, "init")
+	runGitCommand(t, repoDir, "config", "user.email", "test@example.com")
+	runGitCommand(t, repoDir, "config", "user.name", "Test User")
+	runGitCommand(t, repoDir, "commit", "--allow-empty", "-m", "Initial commit")
+
+	// Worktrees for issues 12 and 123 in the order most likely to trip a
+	// substring match, plus one for 120 named after a longer branch and one
+	// detached worktree only identified by its directory.
+	worktrees := map[string]string{
+		"123": filepath.Join(tempDir, "myrepo-123"),
+		"12":  filepath.Join(tempDir, "myrepo-12"),
+		"120": filepath.Join(tempDir, "myrepo-120-impl-cache"),
+		"7":   filepath.Join(tempDir, "myrepo-7"),
+	}
+	runGitCommand(t, repoDir, "worktree", "add", "-b", "123/impl", worktrees["123"])
+	runGitCommand(t, repoDir, "worktree", "add", "-b", "12/impl", worktrees["12"])
+	runGitCommand(t, repoDir, "worktree", "add", "-b", "120/impl-cache", worktrees["120"])
+	runGitCommand(t, repoDir, "worktree", "add", "--detach", worktrees["7"])
+
+	originalDir, _ := os.Getwd()
+	t.Cleanup(func() { _ = os.Chdir(originalDir) })
+	if err := os.Chdir(repoDir); err != nil {
+		t.Fatalf("failed to change dir: %v", err)
+	}
+
+	for _, tt := range []struct{ input, issue string }{
+		{"12", "12"},
+		{"123", "123"},
+		{"12/impl", "12"},
+		{"120", "120"},
+		{"7", "7"},
+	} {
+		t.Run(tt.input, func(t *testing.T) {
+			wt, err := NewClient().GetWorktreeForIssue(tt.input)
+			if err != nil {
+				t.Fatalf("GetWorktreeForIssue failed: %v", err)
+			}
+			if wt.Path != worktrees[tt.issue] {
+				t.Errorf("GetWorktreeForIssue(%q) = %s, want %s", tt.input, wt.Path, worktrees[tt.issue])
+			}
+		})
+	}
+
+	for _, input := range []string{"1", "1234", "23"} {
+		t.Run(input+" is not found", func(t *testing.T) {
+			if wt, err := NewClient().GetWorktreeForIssue(input); !errors.Is(err, ErrWorktreeNotFound) {
+				t.Errorf("expected ErrWorktreeNotFound, got %+v, %v", wt, err)
+			}
+		})
+	}
+}
+
 func TestCreateWorktreeFromBranch(t *testing.T) {
 	t.Run("creates worktree from local branch", func(t *testing.T) {
 		// Save and restore working directory first
@@ -653,8 +1039,8 @@ func TestCreateWorktreeFromBranch(t *testing.T) {
 		if err == nil {
 			t.Error("expected error when not in git repository")
 		}
-		if !strings.Contains(err.Error(), "not in a git repository") {
-			t.Errorf("expected 'not in a git repository' error, got: %v", err)
+		if !errors.Is(err, ErrNotGitRepository) {
+			t.Errorf("expected ErrNotGitRepository, got: %v", err)
 		}
 	})
 }
@@ -1145,8 +1531,8 @@ func TestCreateWorktree_NotInGitRepository(t *testing.T) {
 	if err == nil {
 		t.Error("expected error when not in git repository")
 	}
-	if !strings.Contains(err.Error(), "not in a git repository") {
-		t.Errorf("expected 'not in a git repository' error, got: %v", err)
+	if !errors.Is(err, ErrNotGitRepository) {
+		t.Errorf("expected ErrNotGitRepository, got: %v", err)
 	}
 }
 
@@ -1315,8 +1701,8 @@ func TestRemoveWorktreeByPath_NotInGitRepository(t *testing.T) {
 	if err == nil {
 		t.Error("expected error when not in git repository")
 	}
-	if !strings.Contains(err.Error(), "not in a git repository") {
-		t.Errorf("expected 'not in a git repository' error, got: %v", err)
+	if !errors.Is(err, ErrNotGitRepository) {
+		t.Errorf("expected ErrNotGitRepository, got: %v", err)
 	}
 }
 
@@ -1475,3 +1861,395 @@ func TestRunCommand(t *testing.T) {
 		}
 	})
 }
+
+func TestApplyStashAndPatch(t *testing.T) {
+	// setupRepo creates a repository with one commit, chdirs into it and
+	// returns its path and the default branch name.
+	setupRepo := func(t *testing.T) (repoDir, defaultBranch string) {
+		t.Helper()
+		repoDir = filepath.Join(t.TempDir(), "repo")
+		if err := os.MkdirAll(repoDir, 0755); err != nil {
+			t.Fatalf("failed to create repo dir: %v", err)
+		}
+		runGitCommand(t, repoDir, "init")
+		runGitCommand(t, repoDir, "config", "user.email", "test@example.com")
+		runGitCommand(t, repoDir, "config", "user.name", "Test User")
+		if err := os.WriteFile(filepath.Join(repoDir, "README.md"), []byte("original\n"), 0644); err != nil {
+			t.Fatalf("failed to create file: %v", err)
+		}
+		runGitCommand(t, repoDir, "add", "README.md")
+		runGitCommand(t, repoDir, "commit", "-m", "initial commit")
+
+		originalDir, _ := os.Getwd()
+		t.Cleanup(func() { _ = os.Chdir(originalDir) })
+		if err := os.Chdir(repoDir); err != nil {
+			t.Fatalf("failed to change dir: %v", err)
+		}
+		return repoDir, getDefaultBranchName(t, repoDir)
+	}
+
+	// addWorktree creates a sibling worktree on a new branch off defaultBranch.
+	addWorktree := func(t *testing.T, repoDir, defaultBranch string) string {
+		t.Helper()
+		worktreePath := filepath.Join(filepath.Dir(repoDir), "repo-123")
+		runGitCommand(t, repoDir, "worktree", "add", "-b", "123/impl", worktreePath, defaultBranch)
+		return worktreePath
+	}
+
+	readReadme := func(t *testing.T, dir string) string {
+		t.Helper()
+		content, err := os.ReadFile(filepath.Join(dir, "README.md"))
+		if err != nil {
+			t.Fatalf("failed to read README.md: %v", err)
+		}
+		return string(content)
+	}
+
+	t.Run("applies the latest stash in the worktree", func(t *testing.T) {
+		repoDir, defaultBranch := setupRepo(t)
+		if err := os.WriteFile(filepath.Join(repoDir, "README.md"), []byte("stashed change\n"), 0644); err != nil {
+			t.Fatalf("failed to modify file: %v", err)
+		}
+		runGitCommand(t, repoDir, "stash")
+
+		worktreePath := addWorktree(t, repoDir, defaultBranch)
+		if err := ApplyStash(worktreePath); err != nil {
+			t.Fatalf("ApplyStash failed: %v", err)
+		}
+
+		if got := readReadme(t, worktreePath); got != "stashed change\n" {
+			t.Errorf("expected stashed change in worktree, got %q", got)
+		}
+		if got := readReadme(t, repoDir); got != "original\n" {
+			t.Errorf("expected main checkout to stay clean, got %q", got)
+		}
+	})
+
+	t.Run("fails when there is no stash", func(t *testing.T) {
+		repoDir, defaultBranch := setupRepo(t)
+		worktreePath := addWorktree(t, repoDir, defaultBranch)
+
+		err := ApplyStash(worktreePath)
+		if err == nil {
+			t.Fatal("expected error when no stash entries exist")
+		}
+		if !strings.Contains(err.Error(), "failed to apply stash") {
+			t.Errorf("expected 'failed to apply stash' error, got: %v", err)
+		}
+	})
+
+	t.Run("applies a patch file in the worktree", func(t *testing.T) {
+		repoDir, defaultBranch := setupRepo(t)
+		if err := os.WriteFile(filepath.Join(repoDir, "README.md"), []byte("patched change\n"), 0644); err != nil {
+			t.Fatalf("failed to modify file: %v", err)
+		}
+		patch, err := exec.Command("git", "-C", repoDir, "diff").Output()
+		if err != nil {
+			t.Fatalf("failed to create patch: %v", err)
+		}
+		if err := os.WriteFile(filepath.Join(repoDir, "change.patch"), patch, 0644); err != nil {
+			t.Fatalf("failed to write patch: %v", err)
+		}
+		runGitCommand(t, repoDir, "checkout", "--", "README.md")
+
+		worktreePath := addWorktree(t, repoDir, defaultBranch)
+		// Relative to the current directory (the main checkout), not the worktree
+		if err := ApplyPatch(worktreePath, "change.patch"); err != nil {
+			t.Fatalf("ApplyPatch failed: %v", err)
+		}
+
+		if got := readReadme(t, worktreePath); got != "patched change\n" {
+			t.Errorf("expected patched change in worktree, got %q", got)
+		}
+	})
+
+	t.Run("fails for a missing patch file", func(t *testing.T) {
+		repoDir, defaultBranch := setupRepo(t)
+		worktreePath := addWorktree(t, repoDir, defaultBranch)
+
+		err := ApplyPatch(worktreePath, "missing.patch")
+		if err == nil {
+			t.Fatal("expected error for a missing patch file")
+		}
+		if !strings.Contains(err.Error(), "failed to apply patch missing.patch") {
+			t.Errorf("expected 'failed to apply patch' error, got: %v", err)
+		}
+	})
+}
+
+func TestListWorktrees_Locked(t *testing.T) {
+	tempDir, cleanup := createTestRepo(t)
+	defer cleanup()
+
+	if err := os.WriteFile(filepath.Join(tempDir, "test.txt"), []byte("test"), 0644); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+	runGitCommand(t, tempDir, "add", "test.txt")
+	runGitCommand(t, tempDir, "commit", "-m", "initial")
+
+	lockedPath := filepath.Join(t.TempDir(), "locked")
+	openPath := filepath.Join(t.TempDir(), "open")
+	runGitCommand(t, tempDir, "worktree", "add", "-b", "locked-branch", lockedPath)
+	runGitCommand(t, tempDir, "worktree", "add", "-b", "open-branch", openPath)
+	runGitCommand(t, tempDir, "worktree", "lock", "--reason", "on a USB drive", lockedPath)
+
+	originalDir, _ := os.Getwd()
+	defer os.Chdir(originalDir)
+	os.Chdir(tempDir)
+
+	worktrees, err := ListWorktrees()
+	if err != nil {
+		t.Fatalf("ListWorktrees failed: %v", err)
+	}
+	for _, wt := range worktrees {
+		wantLocked := wt.Branch == "locked-branch"
+		if wt.IsLocked != wantLocked {
+			t.Errorf("worktree %s (%s): IsLocked = %v, want %v", wt.Path, wt.Branch, wt.IsLocked, wantLocked)
+		}
+	}
+}
+
+func TestListWorktrees_Prunable(t *testing.T) {
+	tempDir, cleanup := createTestRepo(t)
+	defer cleanup()
+
+	runGitCommand(t, tempDir, "commit", "--allow-empty", "-m", "initial")
+	gonePath := filepath.Join(t.TempDir(), "gone")
+	runGitCommand(t, tempDir, "worktree", "add", "-b", "gone-branch", gonePath)
+	if err := os.RemoveAll(gonePath); err != nil {
+		t.Fatalf("failed to remove worktree dir: %v", err)
+	}
+
+	originalDir, _ := os.Getwd()
+	defer os.Chdir(originalDir)
+	os.Chdir(tempDir)
+
+	worktrees, err := ListWorktrees()
+	if err != nil {
+		t.Fatalf("ListWorktrees failed: %v", err)
+	}
+	for _, wt := range worktrees {
+		wantPrunable := wt.Branch == "gone-branch"
+		if wt.IsPrunable != wantPrunable {
+			t.Errorf("worktree %s (%s): IsPrunable = %v, want %v", wt.Path, wt.Branch, wt.IsPrunable, wantPrunable)
+		}
+	}
+}
+
+func TestListWorktrees_PathWithSpaces(t *testing.T) {
+	tempDir, cleanup := createTestRepo(t)
+	defer cleanup()
+
+	if err := os.WriteFile(filepath.Join(tempDir, "test.txt"), []byte("test"), 0644); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+	runGitCommand(t, tempDir, "add", "test.txt")
+	runGitCommand(t, tempDir, "commit", "-m", "initial")
+
+	spacedPath := filepath.Join(t.TempDir(), "My Projects", "repo-123")
+	runGitCommand(t, tempDir, "worktree", "add", "-b", "123/impl", spacedPath)
+
+	originalDir, _ := os.Getwd()
+	defer os.Chdir(originalDir)
+	os.Chdir(tempDir)
+
+	worktrees, err := ListWorktrees()
+	if err != nil {
+		t.Fatalf("ListWorktrees failed: %v", err)
+	}
+	want, _ := filepath.EvalSymlinks(spacedPath)
+	for _, wt := range worktrees {
+		if wt.Branch != "123/impl" {
+			continue
+		}
+		if got, _ := filepath.EvalSymlinks(wt.Path); got != want {
+			t.Errorf("Path = %q, want %q", got, want)
+		}
+		return
+	}
+	t.Errorf("worktree for 123/impl not listed: %+v", worktrees)
+}
+
+func TestRepairWorktrees_MovedWorktree(t *testing.T) {
+	tempDir, cleanup := createTestRepo(t)
+	defer cleanup()
+
+	runGitCommand(t, tempDir, "commit", "--allow-empty", "-m", "initial")
+
+	parent := t.TempDir()
+	oldPath := filepath.Join(parent, "repo-123")
+	newPath := filepath.Join(parent, "moved", "repo-123")
+	runGitCommand(t, tempDir, "worktree", "add", "-b", "123/impl", oldPath)
+	os.MkdirAll(filepath.Dir(newPath), 0755)
+	if err := os.Rename(oldPath, newPath); err != nil {
+		t.Fatalf("failed to move worktree: %v", err)
+	}
+
+	originalDir, _ := os.Getwd()
+	defer os.Chdir(originalDir)
+	os.Chdir(tempDir)
+
+	if err := RepairWorktrees(newPath); err != nil {
+		t.Fatalf("RepairWorktrees failed: %v", err)
+	}
+
+	worktrees, err := ListWorktrees()
+	if err != nil {
+		t.Fatalf("ListWorktrees failed: %v", err)
+	}
+	want, _ := filepath.EvalSymlinks(newPath)
+	found := false
+	for _, wt := range worktrees {
+		got, _ := filepath.EvalSymlinks(wt.Path)
+		if wt.Branch == "123/impl" {
+			found = got == want
+		}
+		if strings.HasSuffix(wt.Path, filepath.Join(filepath.Base(parent), "repo-123")) {
+			t.Errorf("old location still listed: %s", wt.Path)
+		}
+	}
+	if !found {
+		t.Errorf("worktree for 123/impl not listed at %s: %+v", want, worktrees)
+	}
+
+	// The worktree's own .git file must point back at the repository too.
+	cmd := exec.Command("git", "rev-parse", "--abbrev-ref", "HEAD")
+	cmd.Dir = newPath
+	out, err := cmd.Output()
+	if err != nil || strings.TrimSpace(string(out)) != "123/impl" {
+		t.Errorf("git in the moved worktree = %q, %v; want 123/impl", out, err)
+	}
+}
+
+func TestPruneWorktrees(t *testing.T) {
+	tempDir, cleanup := createTestRepo(t)
+	defer cleanup()
+
+	runGitCommand(t, tempDir, "commit", "--allow-empty", "-m", "initial")
+
+	parent := t.TempDir()
+	kept := filepath.Join(parent, "repo-123")
+	deleted := filepath.Join(parent, "repo-456")
+	runGitCommand(t, tempDir, "worktree", "add", "-b", "123/impl", kept)
+	runGitCommand(t, tempDir, "worktree", "add", "-b", "456/impl", deleted)
+	if err := os.RemoveAll(deleted); err != nil {
+		t.Fatalf("failed to delete worktree: %v", err)
+	}
+
+	originalDir, _ := os.Getwd()
+	defer os.Chdir(originalDir)
+	os.Chdir(tempDir)
+
+	if err := PruneWorktrees(); err != nil {
+		t.Fatalf("PruneWorktrees failed: %v", err)
+	}
+
+	worktrees, err := ListWorktrees()
+	if err != nil {
+		t.Fatalf("ListWorktrees failed: %v", err)
+	}
+	var branches []string
+	for _, wt := range worktrees {
+		branches = append(branches, wt.Branch)
+	}
+	if !slices.Contains(branches, "123/impl") || slices.Contains(branches, "456/impl") {
+		t.Errorf("Expected only the deleted worktree to be pruned, got branches %v", branches)
+	}
+}
+
+func TestCreateWorktree_WithLock(t *testing.T) {
+	tempDir, cleanup := createTestRepo(t)
+	defer cleanup()
+
+	runGitCommand(t, tempDir, "commit", "--allow-empty", "-m", "initial")
+	base := "HEAD"
+
+	originalDir, _ := os.Getwd()
+	defer os.Chdir(originalDir)
+	os.Chdir(tempDir)
+
+	client := NewClient()
+	client.SetWorktreeRoot(t.TempDir())
+	withReason, err := client.CreateWorktree("123", base, WithLock("on a usb drive"))
+	if err != nil {
+		t.Fatalf("CreateWorktree failed: %v", err)
+	}
+	withoutReason, err := client.CreateWorktree("456", base, WithLock(""))
+	if err != nil {
+		t.Fatalf("CreateWorktree failed: %v", err)
+	}
+	unlocked, err := client.CreateWorktree("789", base)
+	if err != nil {
+		t.Fatalf("CreateWorktree failed: %v", err)
+	}
+
+	worktrees, err := client.ListWorktrees()
+	if err != nil {
+		t.Fatalf("ListWorktrees failed: %v", err)
+	}
+	type lock struct {
+		locked bool
+		reason string
+	}
+	got := map[string]lock{}
+	for _, wt := range worktrees {
+		got[wt.Branch] = lock{wt.IsLocked, wt.LockReason}
+	}
+	want := map[string]lock{
+		"123/impl": {true, "on a usb drive"},
+		"456/impl": {true, "added with --lock"}, // git's own reason when none is given
+		"789/impl": {false, ""},
+	}
+	for branch, w := range want {
+		if got[branch] != w {
+			t.Errorf("%s: locked = %v, reason = %q; want %v, %q", branch, got[branch].locked, got[branch].reason, w.locked, w.reason)
+		}
+	}
+	for _, path := range []string{withReason, withoutReason, unlocked} {
+		if _, err := os.Stat(path); err != nil {
+			t.Errorf("Expected worktree %s to exist: %v", path, err)
+		}
+	}
+}
+
+func TestFormatPatch(t *testing.T) {
+	tempDir, cleanup := createTestRepo(t)
+	defer cleanup()
+
+	runGitCommand(t, tempDir, "commit", "--allow-empty", "-m", "initial")
+	runGitCommand(t, tempDir, "branch", "base")
+	worktreePath := filepath.Join(t.TempDir(), "feature")
+	runGitCommand(t, tempDir, "worktree", "add", "-b", "feature", worktreePath)
+	for _, name := range []string{"one.txt", "two.txt"} {
+		if err := os.WriteFile(filepath.Join(worktreePath, name), []byte(name), 0644); err != nil {
+			t.Fatalf("failed to write file: %v", err)
+		}
+		runGitCommand(t, worktreePath, "add", name)
+		runGitCommand(t, worktreePath, "commit", "-m", "add "+name)
+	}
+
+	outDir := filepath.Join(t.TempDir(), "archive")
+	files, err := FormatPatch(worktreePath, "base", outDir)
+	if err != nil {
+		t.Fatalf("FormatPatch failed: %v", err)
+	}
+	if len(files) != 2 {
+		t.Fatalf("FormatPatch wrote %d files, want 2: %v", len(files), files)
+	}
+	for i, want := range []string{"0001-add-one.txt.patch", "0002-add-two.txt.patch"} {
+		if files[i] != filepath.Join(outDir, want) {
+			t.Errorf("files[%d] = %s, want %s", i, files[i], filepath.Join(outDir, want))
+		}
+		if _, err := os.Stat(files[i]); err != nil {
+			t.Errorf("patch file missing: %v", err)
+		}
+	}
+
+	files, err = FormatPatch(tempDir, "base", outDir)
+	if err != nil {
+		t.Fatalf("FormatPatch without commits failed: %v", err)
+	}
+	if len(files) != 0 {
+		t.Errorf("FormatPatch without commits wrote %v, want nothing", files)
+	}
+}
@@ -0,0 +1,52 @@
+// Package glyph holds the symbols gw decorates its output with and chooses
+// between their Unicode form and a plain ASCII equivalent, for terminals and CI
+// logs that render emoji or box-drawing characters as mojibake.
+package glyph
+
+import (
+	"os"
+	"strings"
+)
+
+// EnvASCII names the environment variable that switches every glyph to its
+// ASCII form. gw --ascii sets it for the rest of the process (and for hooks).
+const EnvASCII = "GW_ASCII"
+
+// Glyph is one output symbol in both forms.
+type Glyph struct {
+	Unicode string
+	ASCII   string
+}
+
+// The glyphs used across gw's output.
+var (
+	Success = Glyph{Unicode: "✓", ASCII: "+"}
+	Error   = Glyph{Unicode: "✗", ASCII: "x"}
+	Warning = Glyph{Unicode: "⚠", ASCII: "!"}
+	Arrow   = Glyph{Unicode: "→", ASCII: "->"}
+	Ready   = Glyph{Unicode: "✨", ASCII: "*"}
+	Hint    = Glyph{Unicode: "💡", ASCII: "Note:"}
+	Ahead   = Glyph{Unicode: "↑", ASCII: "+"}
+	Behind  = Glyph{Unicode: "↓", ASCII: "-"}
+	Bullet  = Glyph{Unicode: "•", ASCII: "-"}
+	Dash    = Glyph{Unicode: "—", ASCII: "-"}
+)
+
+// String returns the glyph in the form selected by $GW_ASCII.
+func (g Glyph) String() string {
+	if ASCIIMode() {
+		return g.ASCII
+	}
+	return g.Unicode
+}
+
+// ASCIIMode reports whether $GW_ASCII asks for ASCII-only output. Any value
+// other than empty, "0" or "false" enables it.
+func ASCIIMode() bool {
+	switch strings.ToLower(os.Getenv(EnvASCII)) {
+	case "", "0", "false":
+		return false
+	default:
+		return true
+	}
+}
@@ -0,0 +1,23 @@
+package glyph
+
+import "testing"
+
+func TestGlyph_String(t *testing.T) {
+	tests := []struct {
+		env  string
+		want string
+	}{
+		{env: "", want: "✓"},
+		{env: "0", want: "✓"},
+		{env: "false", want: "✓"},
+		{env: "1", want: "+"},
+		{env: "true", want: "+"},
+	}
+
+	for _, tt := range tests {
+		t.Setenv(EnvASCII, tt.env)
+		if got := Success.String(); got != tt.want {
+			t.Errorf("GW_ASCII=%q: Success = %q, want %q", tt.env, got, tt.want)
+		}
+	}
+}
@@ -80,6 +80,37 @@ func TestExecute_FailingCommand(t *testing.T) {
 	}
 }
 
+func TestExecute_ShellSyntax(t *testing.T) {
+	if runtime.GOOS == osWindows {
+		t.Skip("Skipping on Windows")
+	}
+
+	tests := []struct {
+		name     string
+		hookCmd  string
+		expected string
+		wantErr  bool
+	}{
+		{name: "and list runs both commands", hookCmd: "echo a && echo b", expected: "a\nb\n"},
+		{name: "failing first command short-circuits", hookCmd: "false && echo b", expected: "", wantErr: true},
+		{name: "pipe", hookCmd: "printf 'x\\ny\\n' | wc -l | tr -d ' '", expected: "2\n"},
+		{name: "variable expansion", hookCmd: "name=$GW_REPO_NAME; echo \"${name}-ok\"", expected: "repo-ok\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			err := Execute(tt.hookCmd, Env{RepoName: "repo"}, &stdout, &stderr)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Execute(%q) error = %v, wantErr %v", tt.hookCmd, err, tt.wantErr)
+			}
+			if stdout.String() != tt.expected {
+				t.Errorf("Execute(%q) stdout = %q, want %q", tt.hookCmd, stdout.String(), tt.expected)
+			}
+		})
+	}
+}
+
 func TestExecute_StderrOutput(t *testing.T) {
 	if runtime.GOOS == osWindows {
 		t.Skip("Skipping on Windows")
@@ -0,0 +1,58 @@
+package i18n
+
+// Message keys. Keys are grouped by the command that prints them; messages
+// shared by several commands have no command prefix.
+const (
+	MsgWorktreeReady   = "worktree.ready"
+	MsgShellIntegCD    = "worktree.shell_integration_cd"
+	MsgAborted         = "aborted"
+	MsgDeletingBranch  = "branch.deleting"
+	MsgSafetyWarnings  = "safety.warnings"
+	MsgContinuePrompt  = "prompt.continue"
+	MsgStartCreated    = "start.created"
+	MsgStartHookFailed = "start.hook_failed"
+
+	MsgCheckoutCreating   = "checkout.creating"
+	MsgCheckoutHookFailed = "checkout.hook_failed"
+
+	MsgEndInteractive   = "end.interactive"
+	MsgEndUsingCurrent  = "end.using_current"
+	MsgEndOfferCurrent  = "end.offer_current"
+	MsgEndBranchDeleted = "end.branch_deleted"
+	MsgEndRemoved       = "end.removed"
+
+	MsgCleanNothing      = "clean.nothing"
+	MsgCleanDryRun       = "clean.dry_run"
+	MsgCleanNoneSelected = "clean.none_selected"
+	MsgCleanRemoved      = "clean.removed"
+	MsgCleanRemovedTotal = "clean.removed_total"
+	MsgCleanFailedTotal  = "clean.failed_total"
+)
+
+// english is the built-in catalog and the fallback for every other language.
+var english = map[string]string{
+	MsgWorktreeReady:   "Worktree ready at:\n   %s",
+	MsgShellIntegCD:    "Shell integration will change to this directory after the command completes.",
+	MsgAborted:         "Aborted.",
+	MsgDeletingBranch:  "Deleting branch %s...",
+	MsgSafetyWarnings:  "Safety check warnings:",
+	MsgContinuePrompt:  "Do you want to continue?",
+	MsgStartCreated:    "Created worktree at %s",
+	MsgStartHookFailed: "Post-start hook failed: %v",
+
+	MsgCheckoutCreating:   "Creating worktree for branch '%s'...",
+	MsgCheckoutHookFailed: "Post-checkout hook failed: %v",
+
+	MsgEndInteractive:   "No issue number provided, entering interactive mode...",
+	MsgEndUsingCurrent:  "Ending the current worktree for issue #%s (%s)",
+	MsgEndOfferCurrent:  "End the current worktree for issue #%s (%s)?",
+	MsgEndBranchDeleted: "Successfully deleted branch %s",
+	MsgEndRemoved:       "Successfully removed worktree for issue #%s",
+
+	MsgCleanNothing:      "No worktrees to remove.",
+	MsgCleanDryRun:       "Dry-run mode: no changes made.",
+	MsgCleanNoneSelected: "No worktrees selected.",
+	MsgCleanRemoved:      "Removed %s",
+	MsgCleanRemovedTotal: "Successfully removed %d worktree(s)",
+	MsgCleanFailedTotal:  "Failed to remove %d worktree(s)",
+}
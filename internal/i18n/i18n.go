@@ -0,0 +1,96 @@
+// Package i18n is a small message catalog for gw's user-facing output. Each
+// message has a key and a format string per language; English is built in and
+// is the fallback for languages or keys without a translation. Translations
+// are added with Register.
+package i18n
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+)
+
+// DefaultLanguage is the language of the built-in catalog.
+const DefaultLanguage = "en"
+
+var (
+	mu       sync.RWMutex
+	catalogs = map[string]map[string]string{DefaultLanguage: english}
+	active   = DefaultLanguage
+)
+
+// Register adds or extends the catalog for lang. Keys missing from messages
+// keep falling back to English.
+func Register(lang string, messages map[string]string) {
+	mu.Lock()
+	defer mu.Unlock()
+	lang = normalize(lang)
+	catalog := catalogs[lang]
+	if catalog == nil {
+		catalog = map[string]string{}
+		catalogs[lang] = catalog
+	}
+	for key, format := range messages {
+		catalog[key] = format
+	}
+}
+
+// SetLanguage selects the language T renders messages in. Languages without a
+// catalog render in English.
+func SetLanguage(lang string) {
+	mu.Lock()
+	defer mu.Unlock()
+	active = normalize(lang)
+}
+
+// Language returns the language set with SetLanguage.
+func Language() string {
+	mu.RLock()
+	defer mu.RUnlock()
+	return active
+}
+
+// DetectLanguage returns configured when it is set, otherwise the language of
+// the first set locale variable among LC_ALL, LC_MESSAGES and LANG, otherwise
+// English.
+func DetectLanguage(configured string) string {
+	if configured != "" {
+		return normalize(configured)
+	}
+	for _, env := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+		if value := os.Getenv(env); value != "" {
+			return normalize(value)
+		}
+	}
+	return DefaultLanguage
+}
+
+// normalize reduces a locale such as "ja_JP.UTF-8" to its language ("ja").
+// The POSIX "C" locale means English.
+func normalize(locale string) string {
+	lang := strings.ToLower(locale)
+	if i := strings.IndexAny(lang, "_.@-"); i >= 0 {
+		lang = lang[:i]
+	}
+	if lang == "" || lang == "c" || lang == "posix" {
+		return DefaultLanguage
+	}
+	return lang
+}
+
+// T renders the message key in the active language with fmt.Sprintf-style
+// args. An unknown key is returned as is so a missing entry is visible rather
+// than silently empty.
+func T(key string, args ...any) string {
+	mu.RLock()
+	format, ok := catalogs[active][key]
+	if !ok {
+		format, ok = english[key]
+	}
+	mu.RUnlock()
+	if !ok {
+		return key
+	}
+	return fmt.Sprintf(format, args...)
+}
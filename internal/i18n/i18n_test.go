@@ -0,0 +1,62 @@
+package i18n
+
+import "testing"
+
+// useLanguage switches the active language for one test.
+func useLanguage(t *testing.T, lang string) {
+	t.Helper()
+	previous := Language()
+	SetLanguage(lang)
+	t.Cleanup(func() { SetLanguage(previous) })
+}
+
+func TestT_StubCatalog(t *testing.T) {
+	Register("xx", map[string]string{
+		MsgCleanRemovedTotal: "xx-removed %d",
+	})
+	useLanguage(t, "xx_XX.UTF-8")
+
+	if got := T(MsgCleanRemovedTotal, 3); got != "xx-removed 3" {
+		t.Errorf("T(MsgCleanRemovedTotal) = %q, want the stub translation", got)
+	}
+	if got := T(MsgAborted); got != "Aborted." {
+		t.Errorf("T(MsgAborted) = %q, want the English fallback", got)
+	}
+}
+
+func TestT_Defaults(t *testing.T) {
+	useLanguage(t, "fr")
+
+	if got := T(MsgCleanRemoved, "repo-123"); got != "Removed repo-123" {
+		t.Errorf("T(MsgCleanRemoved) = %q, want English for a language without a catalog", got)
+	}
+	if got := T("no.such.key"); got != "no.such.key" {
+		t.Errorf("T(unknown) = %q, want the key itself", got)
+	}
+}
+
+func TestDetectLanguage(t *testing.T) {
+	tests := []struct {
+		name       string
+		configured string
+		lcAll      string
+		lang       string
+		want       string
+	}{
+		{name: "configured wins", configured: "ja", lang: "de_DE.UTF-8", want: "ja"},
+		{name: "LANG", lang: "ja_JP.UTF-8", want: "ja"},
+		{name: "LC_ALL before LANG", lcAll: "de_DE", lang: "ja_JP.UTF-8", want: "de"},
+		{name: "C locale", lang: "C", want: DefaultLanguage},
+		{name: "nothing set", want: DefaultLanguage},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("LC_ALL", tt.lcAll)
+			t.Setenv("LC_MESSAGES", "")
+			t.Setenv("LANG", tt.lang)
+			if got := DetectLanguage(tt.configured); got != tt.want {
+				t.Errorf("DetectLanguage(%q) = %q, want %q", tt.configured, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,100 @@
+// Package recent remembers the worktrees gw most recently switched between,
+// so that gw checkout - can return to the previous one like cd -. The list is
+// kept in ~/.gw/recent.json, most recent first.
+package recent
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+)
+
+// maxEntries caps the list; older worktrees fall off the end.
+const maxEntries = 20
+
+const (
+	permRecentDir  = 0o755
+	permRecentFile = 0o600
+)
+
+// DefaultPath returns the recents file location (~/.gw/recent.json),
+// resolving the home directory via os.UserHomeDir().
+func DefaultPath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve home directory: %w", err)
+	}
+	return filepath.Join(home, ".gw", "recent.json"), nil
+}
+
+// Load returns the recorded worktree paths, most recent first. A missing file
+// yields an empty list.
+func Load(path string) ([]string, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read recents file: %w", err)
+	}
+	var paths []string
+	if err := json.Unmarshal(data, &paths); err != nil {
+		return nil, fmt.Errorf("failed to parse recents file %s: %w", path, err)
+	}
+	return paths, nil
+}
+
+// Record moves each of worktrees to the front of the list in turn, so the
+// last one becomes the most recent entry. Empty paths are ignored.
+func Record(path string, worktrees ...string) error {
+	paths, err := Load(path)
+	if err != nil {
+		return err
+	}
+	for _, wt := range worktrees {
+		if wt == "" {
+			continue
+		}
+		paths = slices.DeleteFunc(paths, func(p string) bool { return p == wt })
+		paths = append([]string{wt}, paths...)
+	}
+	if len(paths) > maxEntries {
+		paths = paths[:maxEntries]
+	}
+	return save(path, paths)
+}
+
+// Rename replaces the entry for a worktree that moved from oldWorktree to
+// newWorktree, keeping its place in the list. Nothing is written when
+// oldWorktree was never recorded.
+func Rename(path, oldWorktree, newWorktree string) error {
+	paths, err := Load(path)
+	if err != nil {
+		return err
+	}
+	if !slices.Contains(paths, oldWorktree) {
+		return nil
+	}
+	// The list holds each worktree once; drop a stale entry for newWorktree.
+	paths = slices.DeleteFunc(paths, func(p string) bool { return p == newWorktree })
+	paths[slices.Index(paths, oldWorktree)] = newWorktree
+	return save(path, paths)
+}
+
+// save writes paths to the recents file at path, creating its directory.
+func save(path string, paths []string) error {
+	data, err := json.MarshalIndent(paths, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode recents: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), permRecentDir); err != nil {
+		return fmt.Errorf("failed to create recents directory: %w", err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), permRecentFile); err != nil {
+		return fmt.Errorf("failed to write recents file: %w", err)
+	}
+	return nil
+}
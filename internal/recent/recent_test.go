@@ -0,0 +1,82 @@
+package recent
+
+import (
+	"fmt"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestLoad_MissingFileIsEmpty(t *testing.T) {
+	paths, err := Load(filepath.Join(t.TempDir(), "recent.json"))
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if len(paths) != 0 {
+		t.Errorf("expected no entries, got %v", paths)
+	}
+}
+
+func TestRecord(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".gw", "recent.json")
+
+	if err := Record(path, "/repo", "/repo-1"); err != nil {
+		t.Fatalf("Record failed: %v", err)
+	}
+	if err := Record(path, "/repo-1", "", "/repo-2"); err != nil {
+		t.Fatalf("Record failed: %v", err)
+	}
+	if err := Record(path, "/repo-2", "/repo"); err != nil {
+		t.Fatalf("Record failed: %v", err)
+	}
+
+	paths, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if want := []string{"/repo", "/repo-2", "/repo-1"}; !slices.Equal(paths, want) {
+		t.Errorf("entries = %v, want %v", paths, want)
+	}
+}
+
+func TestRecord_CapsEntries(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "recent.json")
+	for i := range maxEntries + 5 {
+		if err := Record(path, fmt.Sprintf("/repo-%d", i)); err != nil {
+			t.Fatalf("Record failed: %v", err)
+		}
+	}
+
+	paths, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if len(paths) != maxEntries {
+		t.Fatalf("expected %d entries, got %d", maxEntries, len(paths))
+	}
+	if want := fmt.Sprintf("/repo-%d", maxEntries+4); paths[0] != want {
+		t.Errorf("most recent entry = %s, want %s", paths[0], want)
+	}
+}
+
+func TestRename(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "recent.json")
+	if err := Record(path, "/repo-2", "/repo-1", "/repo"); err != nil {
+		t.Fatalf("Record failed: %v", err)
+	}
+
+	if err := Rename(path, "/repo-1", "/wt/repo/repo-1"); err != nil {
+		t.Fatalf("Rename failed: %v", err)
+	}
+	if err := Rename(path, "/repo-9", "/wt/repo/repo-9"); err != nil {
+		t.Fatalf("Rename of an unrecorded worktree failed: %v", err)
+	}
+
+	paths, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if want := []string{"/repo", "/wt/repo/repo-1", "/repo-2"}; !slices.Equal(paths, want) {
+		t.Errorf("entries = %v, want %v", paths, want)
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/briandowns/spinner"
+	"github.com/sotarok/gw/internal/glyph"
 	"golang.org/x/term"
 )
 
@@ -23,20 +24,28 @@ type Spinner struct {
 
 // New creates a new spinner with a message
 func New(message string, w io.Writer) *Spinner {
-	// TTY check + NO_COLOR environment variable support
-	// Only enable spinner for TTY file descriptors
-	enabled := false
-	if f, ok := w.(*os.File); ok {
-		enabled = term.IsTerminal(int(f.Fd())) && os.Getenv("NO_COLOR") == ""
-	}
+	// Only enable spinner for TTY file descriptors (and honor NO_COLOR)
+	enabled := IsTerminal(w)
 
-	// CharSets[14] is a clean dot spinner: ⣾⣽⣻⢿⡿⣟⣯⣷
-	s := spinner.New(spinner.CharSets[14], spinnerInterval, spinner.WithWriter(w))
+	// CharSets[14] is a clean dot spinner: ⣾⣽⣻⢿⡿⣟⣯⣷; CharSets[9] is |/-\ for
+	// ASCII mode
+	charSet := spinner.CharSets[14]
+	if glyph.ASCIIMode() {
+		charSet = spinner.CharSets[9]
+	}
+	s := spinner.New(charSet, spinnerInterval, spinner.WithWriter(w))
 	s.Suffix = " " + message
 
 	return &Spinner{s: s, enabled: enabled, writer: w, message: message}
 }
 
+// IsTerminal reports whether w is a terminal that can show an animated
+// spinner. NO_COLOR counts as "not a terminal".
+func IsTerminal(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	return ok && term.IsTerminal(int(f.Fd())) && os.Getenv("NO_COLOR") == ""
+}
+
 // Start begins the spinner animation
 func (sp *Spinner) Start() {
 	if sp.enabled {
@@ -57,5 +66,8 @@ func (sp *Spinner) Stop() {
 // UpdateMessage changes the spinner message while running
 func (sp *Spinner) UpdateMessage(message string) {
 	sp.message = message
+	// The animation goroutine reads Suffix under the spinner's lock.
+	sp.s.Lock()
 	sp.s.Suffix = " " + message
+	sp.s.Unlock()
 }
@@ -222,3 +222,18 @@ func TestSpinnerIntegration(t *testing.T) {
 		sp.Stop()
 	})
 }
+
+func TestIsTerminal(t *testing.T) {
+	var buf bytes.Buffer
+	if IsTerminal(&buf) {
+		t.Error("expected a buffer not to be a terminal")
+	}
+	if IsTerminal(nil) {
+		t.Error("expected nil writer not to be a terminal")
+	}
+
+	t.Setenv("NO_COLOR", "1")
+	if IsTerminal(os.Stdout) {
+		t.Error("expected NO_COLOR to disable terminal detection")
+	}
+}
@@ -0,0 +1,88 @@
+// Package stats keeps opt-in usage counters for gw commands. The counts are
+// strictly local: they live in a JSON file under ~/.gw and are never sent
+// anywhere.
+package stats
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// Commands whose successful runs are counted, in display order.
+const (
+	CommandStart = "start"
+	CommandEnd   = "end"
+	CommandClean = "clean"
+)
+
+// Commands lists the counted commands in the order gw stats prints them.
+var Commands = []string{CommandStart, CommandEnd, CommandClean}
+
+const (
+	permStatsDir  = 0o755
+	permStatsFile = 0o600
+)
+
+// Counts maps a command name to the number of times it completed.
+type Counts map[string]int
+
+// DefaultPath returns the stats file location (~/.gw/stats.json), resolving
+// the home directory via os.UserHomeDir().
+func DefaultPath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve home directory: %w", err)
+	}
+	return filepath.Join(home, ".gw", "stats.json"), nil
+}
+
+// Load reads the counts stored at path. A missing file means nothing has
+// been recorded yet and yields empty counts.
+func Load(path string) (Counts, error) {
+	counts := Counts{}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return counts, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read stats file: %w", err)
+	}
+	if err := json.Unmarshal(data, &counts); err != nil {
+		return nil, fmt.Errorf("failed to parse stats file %s: %w", path, err)
+	}
+	return counts, nil
+}
+
+// Increment adds one to command's count in the file at path, creating the
+// file (and its directory) on first use.
+func Increment(path, command string) error {
+	counts, err := Load(path)
+	if err != nil {
+		return err
+	}
+	counts[command]++
+
+	data, err := json.MarshalIndent(counts, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode stats: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), permStatsDir); err != nil {
+		return fmt.Errorf("failed to create stats directory: %w", err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), permStatsFile); err != nil {
+		return fmt.Errorf("failed to write stats file: %w", err)
+	}
+	return nil
+}
+
+// Reset discards all recorded counts. Resetting when nothing was recorded is
+// a no-op.
+func Reset(path string) error {
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to remove stats file: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+package stats
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoad_MissingFileIsEmpty(t *testing.T) {
+	counts, err := Load(filepath.Join(t.TempDir(), "stats.json"))
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if len(counts) != 0 {
+		t.Errorf("expected no counts, got %v", counts)
+	}
+}
+
+func TestIncrement(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".gw", "stats.json")
+
+	for _, command := range []string{CommandStart, CommandStart, CommandEnd} {
+		if err := Increment(path, command); err != nil {
+			t.Fatalf("Increment(%s) failed: %v", command, err)
+		}
+	}
+
+	counts, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	want := Counts{CommandStart: 2, CommandEnd: 1}
+	for _, command := range Commands {
+		if counts[command] != want[command] {
+			t.Errorf("%s = %d, want %d", command, counts[command], want[command])
+		}
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("stat failed: %v", err)
+	}
+	if perm := info.Mode().Perm(); perm != permStatsFile {
+		t.Errorf("expected permissions %o, got %o", permStatsFile, perm)
+	}
+}
+
+func TestIncrement_CorruptFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "stats.json")
+	if err := os.WriteFile(path, []byte("not json"), 0o600); err != nil {
+		t.Fatalf("write failed: %v", err)
+	}
+
+	if err := Increment(path, CommandStart); err == nil {
+		t.Error("expected an error for a corrupt stats file")
+	}
+}
+
+func TestReset(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "stats.json")
+	if err := Increment(path, CommandClean); err != nil {
+		t.Fatalf("Increment failed: %v", err)
+	}
+
+	if err := Reset(path); err != nil {
+		t.Fatalf("Reset failed: %v", err)
+	}
+	counts, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if len(counts) != 0 {
+		t.Errorf("expected no counts after reset, got %v", counts)
+	}
+
+	if err := Reset(path); err != nil {
+		t.Errorf("expected resetting twice to be a no-op, got: %v", err)
+	}
+}
@@ -1,19 +1,28 @@
 package ui
 
 import (
+	"errors"
 	"fmt"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 
 	"github.com/sotarok/gw/internal/git"
+	"github.com/sotarok/gw/internal/glyph"
 )
 
+// ErrCanceled is returned (possibly wrapped) when the user leaves a selector
+// without choosing anything.
+var ErrCanceled = errors.New("selection canceled")
+
 // Interface defines the UI operations used by the application
 type Interface interface {
 	// Selection operations
 	SelectWorktree() (*git.WorktreeInfo, error)
 	ShowSelector(title string, items []SelectorItem) (*SelectorItem, error)
+	// ShowMultiSelector lets the user check any number of items. An empty,
+	// non-nil slice means the user confirmed without checking anything.
+	ShowMultiSelector(title string, items []SelectorItem) ([]SelectorItem, error)
 
 	// Prompt operations
 	ConfirmPrompt(message string) (bool, error)
@@ -77,7 +86,7 @@ func (u *DefaultUI) SelectWorktree() (*git.WorktreeInfo, error) {
 
 	model := result.(worktreeSelector)
 	if model.selected == nil {
-		return nil, fmt.Errorf("no worktree selected")
+		return nil, fmt.Errorf("no worktree selected: %w", ErrCanceled)
 	}
 
 	return model.selected, nil
@@ -100,12 +109,28 @@ func (u *DefaultUI) ShowSelector(title string, items []SelectorItem) (*SelectorI
 
 	model := result.(*genericSelector)
 	if model.selected == nil {
-		return nil, fmt.Errorf("no item selected")
+		return nil, fmt.Errorf("no item selected: %w", ErrCanceled)
 	}
 
 	return model.selected, nil
 }
 
+// ShowMultiSelector displays a checkbox list and returns the checked items
+func (u *DefaultUI) ShowMultiSelector(title string, items []SelectorItem) ([]SelectorItem, error) {
+	p := tea.NewProgram(newMultiSelector(title, items), tea.WithAltScreen())
+	result, err := p.Run()
+	if err != nil {
+		return nil, err
+	}
+
+	model := result.(*multiSelector)
+	if !model.confirmed {
+		return nil, ErrCanceled
+	}
+
+	return model.selection(), nil
+}
+
 // ConfirmPrompt shows a yes/no prompt to the user
 func (u *DefaultUI) ConfirmPrompt(message string) (bool, error) {
 	m := confirmModel{
@@ -135,7 +160,7 @@ func (u *DefaultUI) ShowEnvFilesList(files []string) {
 	fileStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("242"))
 
 	for _, file := range files {
-		fmt.Printf("  %s %s\n", headerStyle.Render("→"), fileStyle.Render(file))
+		fmt.Printf("  %s %s\n", headerStyle.Render(glyph.Arrow.String()), fileStyle.Render(file))
 	}
 	fmt.Println()
 }
@@ -0,0 +1,102 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// toggleKey toggles the item under the cursor in the multi selector.
+var toggleKey = key.NewBinding(
+	key.WithKeys(" ", "x"),
+	key.WithHelp("space/x", "toggle"),
+)
+
+// multiSelector is a checkbox list model: items are toggled individually and
+// the whole selection is confirmed with enter.
+type multiSelector struct {
+	items     []SelectorItem
+	checked   []bool
+	cursor    int
+	title     string
+	keyMap    keyMap
+	confirmed bool
+}
+
+func newMultiSelector(title string, items []SelectorItem) *multiSelector {
+	return &multiSelector{
+		items:   items,
+		checked: make([]bool, len(items)),
+		title:   title,
+		keyMap:  keys,
+	}
+}
+
+func (m *multiSelector) Init() tea.Cmd {
+	return nil
+}
+
+func (m *multiSelector) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch {
+		case key.Matches(msg, m.keyMap.Up):
+			if m.cursor > 0 {
+				m.cursor--
+			}
+		case key.Matches(msg, m.keyMap.Down):
+			if m.cursor < len(m.items)-1 {
+				m.cursor++
+			}
+		case key.Matches(msg, toggleKey):
+			if len(m.items) > 0 {
+				m.checked[m.cursor] = !m.checked[m.cursor]
+			}
+		case key.Matches(msg, m.keyMap.Select):
+			m.confirmed = true
+			return m, tea.Quit
+		case key.Matches(msg, m.keyMap.Quit):
+			return m, tea.Quit
+		}
+	}
+	return m, nil
+}
+
+func (m *multiSelector) View() string {
+	s := strings.Builder{}
+	s.WriteString(fmt.Sprintf("%s\n\n", m.title))
+
+	for i, item := range m.items {
+		cursor := " "
+		if m.cursor == i {
+			cursor = ">"
+		}
+		box := "[ ]"
+		if m.checked[i] {
+			box = "[x]"
+		}
+
+		line := fmt.Sprintf("%s %s %s", cursor, box, item.Name)
+		if m.cursor == i {
+			s.WriteString(selectedStyle.Render(line) + "\n")
+		} else {
+			s.WriteString(normalStyle.Render(line) + "\n")
+		}
+	}
+
+	s.WriteString("\n" + dimStyle.Render("↑/k: up • ↓/j: down • space/x: toggle • enter: confirm • q: quit"))
+	return s.String()
+}
+
+// selection returns the checked items in display order.
+func (m *multiSelector) selection() []SelectorItem {
+	selected := []SelectorItem{}
+	for i, item := range m.items {
+		if m.checked[i] {
+			selected = append(selected, item)
+		}
+	}
+	return selected
+}
@@ -0,0 +1,96 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestMultiSelectorUpdate(t *testing.T) {
+	items := []SelectorItem{
+		{ID: "1", Name: "Item 1"},
+		{ID: "2", Name: "Item 2"},
+		{ID: "3", Name: "Item 3"},
+	}
+	space := tea.KeyMsg{Type: tea.KeySpace, Runes: []rune{' '}}
+	down := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'j'}}
+
+	t.Run("space toggles the item under the cursor", func(t *testing.T) {
+		m := newMultiSelector("Pick:", items)
+		m.Update(space)
+		if !m.checked[0] {
+			t.Fatal("expected item 0 to be checked")
+		}
+		m.Update(space)
+		if m.checked[0] {
+			t.Error("expected item 0 to be unchecked after second toggle")
+		}
+	})
+
+	t.Run("enter confirms the checked items in order", func(t *testing.T) {
+		m := newMultiSelector("Pick:", items)
+		m.Update(down)
+		m.Update(down)
+		m.Update(space)
+		m.cursor = 0
+		m.Update(space)
+		_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+
+		if cmd == nil {
+			t.Error("expected quit cmd on enter")
+		}
+		if !m.confirmed {
+			t.Error("expected confirmed=true after enter")
+		}
+		got := m.selection()
+		if len(got) != 2 || got[0].ID != "1" || got[1].ID != "3" {
+			t.Errorf("expected items 1 and 3, got %v", got)
+		}
+	})
+
+	t.Run("enter with nothing checked yields empty selection", func(t *testing.T) {
+		m := newMultiSelector("Pick:", items)
+		m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+		if got := m.selection(); got == nil || len(got) != 0 {
+			t.Errorf("expected empty non-nil selection, got %v", got)
+		}
+	})
+
+	t.Run("q quits without confirming", func(t *testing.T) {
+		m := newMultiSelector("Pick:", items)
+		m.Update(space)
+		_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'q'}})
+		if cmd == nil {
+			t.Error("expected quit cmd on q")
+		}
+		if m.confirmed {
+			t.Error("expected confirmed=false after q")
+		}
+	})
+
+	t.Run("toggle on empty list is a no-op", func(t *testing.T) {
+		m := newMultiSelector("Pick:", nil)
+		m.Update(space)
+	})
+}
+
+func TestMultiSelectorView(t *testing.T) {
+	items := []SelectorItem{
+		{ID: "1", Name: "Item 1"},
+		{ID: "2", Name: "Item 2"},
+	}
+	m := newMultiSelector("Pick some:", items)
+	m.checked[1] = true
+	view := m.View()
+
+	if !strings.Contains(view, "Pick some:") {
+		t.Error("expected title in view")
+	}
+	if !strings.Contains(view, "[ ] Item 1") || !strings.Contains(view, "[x] Item 2") {
+		t.Errorf("expected checkbox states in view, got: %s", view)
+	}
+	if !strings.Contains(view, "space/x: toggle") {
+		t.Error("expected help line in view")
+	}
+}
@@ -6,6 +6,8 @@ import (
 	"os"
 	"strconv"
 	"strings"
+
+	"github.com/sotarok/gw/internal/glyph"
 )
 
 // TrustPrompt asks the user whether to trust and run the hook values shown
@@ -23,7 +25,7 @@ func (u *DefaultUI) TrustPrompt(projectPath string, hookLines []string) (bool, e
 	// last line of defense before a hook value is approved to run. Quote
 	// them so embedded ANSI escapes, carriage returns, or other control
 	// characters can't visually spoof what the user is approving.
-	fmt.Fprintf(os.Stderr, "\n%s Untrusted project configuration at %s\n", symbolWarningPrefix, strconv.Quote(projectPath))
+	fmt.Fprintf(os.Stderr, "\n%s Untrusted project configuration at %s\n", glyph.Warning, strconv.Quote(projectPath))
 	fmt.Fprintln(os.Stderr, "The following hook value(s) require approval before they will run:")
 	for _, line := range hookLines {
 		fmt.Fprintf(os.Stderr, "  %s\n", strconv.Quote(line))
@@ -41,8 +43,3 @@ func (u *DefaultUI) TrustPrompt(projectPath string, hookLines []string) (bool, e
 	answer := strings.ToLower(strings.TrimSpace(line))
 	return answer == "y" || answer == "yes", nil
 }
-
-// symbolWarningPrefix mirrors the "⚠" warning glyph used elsewhere in the
-// CLI's output, without importing the cmd package (which would create an
-// import cycle since cmd imports ui).
-const symbolWarningPrefix = "⚠"
@@ -18,6 +18,6 @@ func main() {
 	cmd.SetVersionInfo(version, commit, date)
 
 	if err := cmd.Execute(); err != nil {
-		os.Exit(1)
+		os.Exit(cmd.ExitCode(err))
 	}
 }